@@ -0,0 +1,41 @@
+package admin
+
+import "time"
+
+// UpdateQuotaTierRequest 修改角色配额档位请求。
+type UpdateQuotaTierRequest struct {
+	ProfileTotal      int32 `json:"profile_total" binding:"min=0,max=1000"`       // 游戏档案总额度
+	SkinsPublicTotal  int32 `json:"skins_public_total" binding:"min=0,max=1000"`  // 公开皮肤总额度
+	SkinsPrivateTotal int32 `json:"skins_private_total" binding:"min=0,max=1000"` // 私有皮肤总额度
+	CapesPublicTotal  int32 `json:"capes_public_total" binding:"min=0,max=1000"`  // 公开披风总额度
+	CapesPrivateTotal int32 `json:"capes_private_total" binding:"min=0,max=1000"` // 私有披风总额度
+	Reapply           bool  `json:"reapply"`                                      // 是否在后台重新应用到该角色的现有用户（仅提升不足档位的额度）
+}
+
+// QuotaTierResponse 角色配额档位响应。
+type QuotaTierResponse struct {
+	RoleName          string    `json:"role_name"`            // 角色名称
+	ProfileTotal      int32     `json:"profile_total"`        // 游戏档案总额度
+	SkinsPublicTotal  int32     `json:"skins_public_total"`   // 公开皮肤总额度
+	SkinsPrivateTotal int32     `json:"skins_private_total"`  // 私有皮肤总额度
+	CapesPublicTotal  int32     `json:"capes_public_total"`   // 公开披风总额度
+	CapesPrivateTotal int32     `json:"capes_private_total"`  // 私有披风总额度
+	UpdatedBy         *string   `json:"updated_by,omitempty"` // 最近修改管理员 ID
+	UpdatedAt         time.Time `json:"updated_at"`           // 最近修改时间
+}
+
+// QuotaTierListResponse 角色配额档位列表响应。
+type QuotaTierListResponse struct {
+	Items []QuotaTierResponse `json:"items"` // 档位列表
+}
+
+// UpdateQuotaTierResponse 修改角色配额档位响应。
+type UpdateQuotaTierResponse struct {
+	Tier           QuotaTierResponse `json:"tier"`            // 修改后的档位
+	ReapplyStarted bool              `json:"reapply_started"` // 是否已开始后台重新应用
+}
+
+// UpdateUserRoleRequest 变更用户角色请求。
+type UpdateUserRoleRequest struct {
+	RoleName string `json:"role_name" binding:"required,max=32"` // 目标角色名称
+}
@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// StartStorageReconcileRequest 发起存储对账请求。
+type StartStorageReconcileRequest struct {
+	Apply bool `json:"apply"` // 是否执行清理（默认 false 为试运行，仅生成报告）
+}
+
+// StorageReconcileReportResponse 存储对账报告响应。
+type StorageReconcileReportResponse struct {
+	ID                xSnowflake.SnowflakeID            `json:"id"`                     // 报告 ID
+	DryRun            bool                              `json:"dry_run"`                // 是否为试运行
+	Status            entityType.StorageReconcileStatus `json:"status"`                 // 任务状态 (1=running, 2=completed, 3=failed)
+	TriggeredBy       *xSnowflake.SnowflakeID           `json:"triggered_by,omitempty"` // 触发管理员 ID（为空表示定时任务）
+	CheckedReferences int64                             `json:"checked_references"`     // 已核对的数据库引用数
+	CheckedFiles      int64                             `json:"checked_files"`          // 已核对的登记文件数
+	OrphanCount       int64                             `json:"orphan_count"`           // 孤儿文件数
+	DanglingCount     int64                             `json:"dangling_count"`         // 悬空引用数
+	MismatchCount     int64                             `json:"mismatch_count"`         // 哈希不一致数
+	UnverifiedCount   int64                             `json:"unverified_count"`       // 缓存态引用数
+	FixedCount        int64                             `json:"fixed_count"`            // 已自动修复数
+	FailedCount       int64                             `json:"failed_count"`           // 自动修复失败数
+	LastError         *string                           `json:"last_error,omitempty"`   // 任务中断原因
+	FinishedAt        *time.Time                        `json:"finished_at,omitempty"`  // 完成时间
+	CreatedAt         time.Time                         `json:"created_at"`             // 创建时间
+	UpdatedAt         time.Time                         `json:"updated_at"`             // 最近更新时间
+}
+
+// StorageReconcileReportListResponse 存储对账报告列表响应。
+type StorageReconcileReportListResponse struct {
+	Total int64                            `json:"total"` // 总数
+	Items []StorageReconcileReportResponse `json:"items"` // 报告列表
+}
+
+// StorageReconcileIssueResponse 存储对账问题响应。
+type StorageReconcileIssueResponse struct {
+	ID           xSnowflake.SnowflakeID        `json:"id"`                      // 问题 ID
+	ReportID     xSnowflake.SnowflakeID        `json:"report_id"`               // 所属报告 ID
+	Type         entityType.StorageIssueType   `json:"type"`                    // 问题类型 (1=orphan_file, 2=dangling_reference, 3=hash_mismatch, 4=unverified_reference)
+	Source       entityType.StorageFileSource  `json:"source"`                  // 文件来源 (1=skin_texture, 2=cape_texture, 3=issue_attachment)
+	FileID       string                        `json:"file_id"`                 // 存储桶文件 ID
+	RefID        *xSnowflake.SnowflakeID       `json:"ref_id,omitempty"`        // 引用该文件的记录 ID（皮肤/披风/附件）
+	ExpectedHash *string                       `json:"expected_hash,omitempty"` // 数据库记录的纹理哈希
+	ActualHash   *string                       `json:"actual_hash,omitempty"`   // 对象存储文件的 SHA256
+	Action       entityType.StorageIssueAction `json:"action"`                  // 处理动作 (1=none, 2=fixed, 3=failed)
+	Message      *string                       `json:"message,omitempty"`       // 处理失败原因
+	CreatedAt    time.Time                     `json:"created_at"`              // 发现时间
+}
+
+// StorageReconcileIssueListResponse 存储对账问题列表响应。
+type StorageReconcileIssueListResponse struct {
+	Total int64                           `json:"total"` // 总数
+	Items []StorageReconcileIssueResponse `json:"items"` // 问题列表
+}
@@ -0,0 +1,41 @@
+package library
+
+import (
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// ArchiveManifest 衣柜压缩包清单（manifest.json）
+//
+// 导入时可选，用于指定每个 PNG 的名称、模型与公开状态；导出时始终写入压缩包根目录。
+type ArchiveManifest struct {
+	Version int                    `json:"version"` // 清单格式版本
+	Entries []ArchiveManifestEntry `json:"entries"` // 条目列表
+}
+
+// ArchiveManifestEntry 衣柜压缩包清单条目
+type ArchiveManifestEntry struct {
+	File           string                    `json:"file"`                      // 压缩包内 PNG 路径
+	Kind           entityType.LibraryKind    `json:"kind"`                      // 资源种类 (1=skin, 2=cape)
+	Name           string                    `json:"name"`                      // 资源名称
+	Model          uint8                     `json:"model,omitempty"`           // 皮肤模型 (1=classic, 2=slim)，披风忽略
+	IsPublic       bool                      `json:"is_public"`                 // 是否公开
+	AssignmentType entityType.AssignmentType `json:"assignment_type,omitempty"` // 关联类型（仅导出时填写）
+}
+
+// ArchiveImportItemResponse 单个导入条目的处理结果
+type ArchiveImportItemResponse struct {
+	File    string                  `json:"file"`              // 压缩包内 PNG 路径
+	Kind    entityType.LibraryKind  `json:"kind"`              // 资源种类 (1=skin, 2=cape)
+	Status  string                  `json:"status"`            // 处理状态 (created/failed)
+	ID      *xSnowflake.SnowflakeID `json:"id,omitempty"`      // 创建成功时的资源 ID
+	Message string                  `json:"message,omitempty"` // 失败原因
+}
+
+// ArchiveImportResponse 衣柜导入结果响应
+type ArchiveImportResponse struct {
+	Total     int                         `json:"total"`     // 处理条目总数
+	Succeeded int                         `json:"succeeded"` // 成功条目数
+	Failed    int                         `json:"failed"`    // 失败条目数
+	Items     []ArchiveImportItemResponse `json:"items"`     // 逐条结果
+}
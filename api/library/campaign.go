@@ -0,0 +1,79 @@
+package library
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// CampaignFilterRequest 发放活动目标用户筛选条件（各条件之间为“且”关系，均为空表示全部用户）
+type CampaignFilterRequest struct {
+	RoleName         *string    `json:"role_name,omitempty"`         // 用户角色名称
+	RegisteredAfter  *time.Time `json:"registered_after,omitempty"`  // 注册时间下限（含，RFC3339）
+	RegisteredBefore *time.Time `json:"registered_before,omitempty"` // 注册时间上限（含，RFC3339）
+	HasProfile       *bool      `json:"has_profile,omitempty"`       // 是否拥有游戏档案
+	UserIDs          []string   `json:"user_ids,omitempty"`          // 指定用户 ID 列表
+}
+
+// CreateCampaignRequest 创建发放活动请求
+type CreateCampaignRequest struct {
+	Name           string                `json:"name" binding:"required"`             // 活动名称
+	Kind           uint8                 `json:"kind" binding:"required,oneof=1 2"`   // 资源种类 (1=skin, 2=cape)
+	LibraryID      string                `json:"library_id" binding:"required"`       // 发放的资源库 ID
+	AssignmentType uint8                 `json:"assignment_type" binding:"omitempty"` // 分配类型 (2=gift, 3=admin)，默认 2
+	Filter         CampaignFilterRequest `json:"filter"`                              // 目标用户筛选条件
+}
+
+// CampaignFilterResponse 发放活动目标用户筛选条件响应
+type CampaignFilterResponse struct {
+	RoleName         *string                  `json:"role_name,omitempty"`         // 用户角色名称
+	RegisteredAfter  *time.Time               `json:"registered_after,omitempty"`  // 注册时间下限
+	RegisteredBefore *time.Time               `json:"registered_before,omitempty"` // 注册时间上限
+	HasProfile       *bool                    `json:"has_profile,omitempty"`       // 是否拥有游戏档案
+	UserIDs          []xSnowflake.SnowflakeID `json:"user_ids,omitempty"`          // 指定用户 ID 列表
+}
+
+// CampaignResponse 发放活动响应 DTO
+type CampaignResponse struct {
+	ID             xSnowflake.SnowflakeID    `json:"id"`                    // 活动 ID
+	Name           string                    `json:"name"`                  // 活动名称
+	Kind           entityType.LibraryKind    `json:"kind"`                  // 资源种类 (1=skin, 2=cape)
+	LibraryID      xSnowflake.SnowflakeID    `json:"library_id"`            // 发放的资源库 ID
+	AssignmentType entityType.AssignmentType `json:"assignment_type"`       // 分配类型 (2=gift, 3=admin)
+	Status         entityType.CampaignStatus `json:"status"`                // 活动状态 (1=pending, 2=running, 3=completed, 4=failed, 5=revoking, 6=revoked)
+	Filter         CampaignFilterResponse    `json:"filter"`                // 目标用户筛选条件
+	MatchedCount   int64                     `json:"matched_count"`         // 开始执行时匹配的用户数
+	GrantedCount   int64                     `json:"granted_count"`         // 已发放数
+	SkippedCount   int64                     `json:"skipped_count"`         // 已持有跳过数
+	FailedCount    int64                     `json:"failed_count"`          // 发放失败数
+	RevokedCount   int64                     `json:"revoked_count"`         // 已收回数
+	LastError      *string                   `json:"last_error,omitempty"`  // 后台任务最近一次中断原因
+	CreatedBy      xSnowflake.SnowflakeID    `json:"created_by"`            // 创建管理员 ID
+	StartedAt      *time.Time                `json:"started_at,omitempty"`  // 开始执行时间
+	FinishedAt     *time.Time                `json:"finished_at,omitempty"` // 发放完成时间
+	RevokedBy      *xSnowflake.SnowflakeID   `json:"revoked_by,omitempty"`  // 撤销管理员 ID
+	RevokedAt      *time.Time                `json:"revoked_at,omitempty"`  // 撤销完成时间
+	CreatedAt      time.Time                 `json:"created_at"`            // 创建时间
+	UpdatedAt      time.Time                 `json:"updated_at"`            // 最近更新时间
+}
+
+// CampaignListResponse 发放活动列表响应
+type CampaignListResponse struct {
+	Total int64              `json:"total"` // 总数
+	Items []CampaignResponse `json:"items"` // 活动列表
+}
+
+// CampaignGrantResponse 发放活动用户处理记录响应
+type CampaignGrantResponse struct {
+	UserID    xSnowflake.SnowflakeID         `json:"user_id"`           // 用户 ID
+	Status    entityType.CampaignGrantStatus `json:"status"`            // 处理结果 (1=granted, 2=skipped, 3=failed, 4=revoked)
+	Message   *string                        `json:"message,omitempty"` // 跳过或失败原因
+	UpdatedAt time.Time                      `json:"updated_at"`        // 最近处理时间
+}
+
+// CampaignGrantListResponse 发放活动用户处理记录列表响应
+type CampaignGrantListResponse struct {
+	Total int64                   `json:"total"` // 总数
+	Items []CampaignGrantResponse `json:"items"` // 处理记录列表
+}
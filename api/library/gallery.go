@@ -0,0 +1,23 @@
+package library
+
+// SetTagsRequest 设置资源标签请求（整体替换）
+type SetTagsRequest struct {
+	Tags []string `json:"tags" binding:"max=10"` // 标签列表（最多 10 个，每个 1-32 字符）
+}
+
+// TagResponse 标签响应
+type TagResponse struct {
+	Name       string `json:"name"`        // 标签名称
+	UsageCount int64  `json:"usage_count"` // 被引用次数
+}
+
+// TagListResponse 标签列表响应
+type TagListResponse struct {
+	Items []TagResponse `json:"items"` // 标签列表
+}
+
+// LikeResponse 点赞操作响应
+type LikeResponse struct {
+	Liked     bool  `json:"liked"`      // 当前用户是否已点赞
+	LikeCount int64 `json:"like_count"` // 最新点赞数
+}
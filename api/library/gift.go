@@ -1,13 +1,17 @@
 package library
 
+import "time"
+
 // GiftSkinRequest 管理员赠送皮肤请求
 type GiftSkinRequest struct {
-	SkinLibraryID  string `json:"skin_library_id" binding:"required"` // 皮肤库 ID
-	AssignmentType uint8  `json:"assignment_type" binding:"required"` // 分配类型 (2=gift, 3=admin)
+	SkinLibraryID  string     `json:"skin_library_id" binding:"required"` // 皮肤库 ID
+	AssignmentType uint8      `json:"assignment_type" binding:"required"` // 分配类型 (2=gift, 3=admin)
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`               // 到期时间（RFC3339，为空表示永久），到期后自动收回
 }
 
 // GiftCapeRequest 管理员赠送披风请求
 type GiftCapeRequest struct {
-	CapeLibraryID  string `json:"cape_library_id" binding:"required"` // 披风库 ID
-	AssignmentType uint8  `json:"assignment_type" binding:"required"` // 分配类型 (2=gift, 3=admin)
+	CapeLibraryID  string     `json:"cape_library_id" binding:"required"` // 披风库 ID
+	AssignmentType uint8      `json:"assignment_type" binding:"required"` // 分配类型 (2=gift, 3=admin)
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`               // 到期时间（RFC3339，为空表示永久），到期后自动收回
 }
@@ -0,0 +1,38 @@
+package library
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// AdjustQuotaRequest 管理员调整用户资源库配额请求
+type AdjustQuotaRequest struct {
+	RequestID string `json:"request_id" binding:"required,max=64"`    // 请求标识（由调用方生成，重复提交同一标识不会重复调整）
+	Bucket    uint8  `json:"bucket" binding:"required,oneof=1 2 3 4"` // 配额分项 (1=skins_public, 2=skins_private, 3=capes_public, 4=capes_private)
+	Delta     int32  `json:"delta" binding:"required"`                // 总额度变化量（正数增加，负数减少）
+	Remark    string `json:"remark" binding:"omitempty,max=200"`      // 备注（可选，最长 200 字符）
+}
+
+// QuotaLogResponse 资源库配额变更日志响应
+type QuotaLogResponse struct {
+	ID           xSnowflake.SnowflakeID        `json:"id"`                       // 日志 ID
+	OpType       entityType.LibraryQuotaOpType `json:"op_type"`                  // 操作类型 (1=create, 2=delete, 3=visibility, 4=recalculate, 5=admin_adjust, 6=redeem_code, 7=role_quota_tier, 8=review_reject, 9=takedown)
+	Bucket       entityType.LibraryQuotaBucket `json:"bucket"`                   // 配额分项 (1=skins_public, 2=skins_private, 3=capes_public, 4=capes_private)
+	Delta        int32                         `json:"delta"`                    // 额度变化值（正增负减）
+	BeforeUsed   int32                         `json:"before_used"`              // 变更前已使用额度
+	AfterUsed    int32                         `json:"after_used"`               // 变更后已使用额度
+	BeforeTotal  int32                         `json:"before_total"`             // 变更前总额度
+	AfterTotal   int32                         `json:"after_total"`              // 变更后总额度
+	RefLibraryID *xSnowflake.SnowflakeID       `json:"ref_library_id,omitempty"` // 关联皮肤或披风 ID
+	OperatorID   *xSnowflake.SnowflakeID       `json:"operator_id,omitempty"`    // 操作管理员 ID
+	Remark       *string                       `json:"remark,omitempty"`         // 备注
+	CreatedAt    time.Time                     `json:"created_at"`               // 变更时间
+}
+
+// QuotaLogListResponse 资源库配额变更日志列表响应
+type QuotaLogListResponse struct {
+	Total int64              `json:"total"` // 总数
+	Items []QuotaLogResponse `json:"items"` // 日志列表
+}
@@ -0,0 +1,81 @@
+package library
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+)
+
+// GenerateRedeemCodesRequest 批量生成兑换码请求（至少包含皮肤、披风或配额增量之一）
+type GenerateRedeemCodesRequest struct {
+	Count             int        `json:"count" binding:"required,min=1,max=1000"`     // 生成数量
+	SkinLibraryID     *string    `json:"skin_library_id,omitempty"`                   // 兑换获得的皮肤 ID
+	CapeLibraryID     *string    `json:"cape_library_id,omitempty"`                   // 兑换获得的披风 ID
+	SkinsPrivateDelta int32      `json:"skins_private_delta" binding:"min=0,max=100"` // 私有皮肤总额度增量
+	SkinsPublicDelta  int32      `json:"skins_public_delta" binding:"min=0,max=100"`  // 公开皮肤总额度增量
+	CapesPrivateDelta int32      `json:"capes_private_delta" binding:"min=0,max=100"` // 私有披风总额度增量
+	CapesPublicDelta  int32      `json:"capes_public_delta" binding:"min=0,max=100"`  // 公开披风总额度增量
+	ProfileQuotaDelta int32      `json:"profile_quota_delta" binding:"min=0,max=100"` // 游戏档案总额度增量
+	MaxUses           int32      `json:"max_uses" binding:"omitempty,min=1"`          // 每个兑换码的最大兑换次数，默认 1
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`                        // 过期时间（RFC3339，为空表示永不过期）
+	AllowedRoles      []string   `json:"allowed_roles,omitempty"`                     // 允许兑换的角色名称列表（为空表示不限）
+	Remark            *string    `json:"remark,omitempty"`                            // 备注
+}
+
+// RedeemCodeResponse 兑换码响应 DTO
+type RedeemCodeResponse struct {
+	ID                xSnowflake.SnowflakeID  `json:"id"`                        // 兑换码记录 ID
+	Code              string                  `json:"code"`                      // 兑换码
+	BatchID           xSnowflake.SnowflakeID  `json:"batch_id"`                  // 生成批次 ID
+	SkinLibraryID     *xSnowflake.SnowflakeID `json:"skin_library_id,omitempty"` // 兑换获得的皮肤 ID
+	CapeLibraryID     *xSnowflake.SnowflakeID `json:"cape_library_id,omitempty"` // 兑换获得的披风 ID
+	SkinsPrivateDelta int32                   `json:"skins_private_delta"`       // 私有皮肤总额度增量
+	SkinsPublicDelta  int32                   `json:"skins_public_delta"`        // 公开皮肤总额度增量
+	CapesPrivateDelta int32                   `json:"capes_private_delta"`       // 私有披风总额度增量
+	CapesPublicDelta  int32                   `json:"capes_public_delta"`        // 公开披风总额度增量
+	ProfileQuotaDelta int32                   `json:"profile_quota_delta"`       // 游戏档案总额度增量
+	MaxUses           int32                   `json:"max_uses"`                  // 最大兑换次数
+	UsedCount         int32                   `json:"used_count"`                // 已兑换次数
+	ExpiresAt         *time.Time              `json:"expires_at,omitempty"`      // 过期时间
+	AllowedRoles      []string                `json:"allowed_roles,omitempty"`   // 允许兑换的角色名称列表
+	IsDisabled        bool                    `json:"is_disabled"`               // 是否已停用
+	CreatedAt         time.Time               `json:"created_at"`                // 创建时间
+}
+
+// RedeemCodeBatchResponse 兑换码生成批次响应
+type RedeemCodeBatchResponse struct {
+	ID        xSnowflake.SnowflakeID `json:"id"`               // 批次 ID
+	Count     int32                  `json:"count"`            // 生成数量
+	Remark    *string                `json:"remark,omitempty"` // 备注
+	CreatedBy xSnowflake.SnowflakeID `json:"created_by"`       // 生成管理员 ID
+	CreatedAt time.Time              `json:"created_at"`       // 生成时间
+	Codes     []RedeemCodeResponse   `json:"codes"`            // 本批次生成的兑换码
+}
+
+// RedeemCodeListResponse 兑换码列表响应
+type RedeemCodeListResponse struct {
+	Total int64                `json:"total"` // 总数
+	Items []RedeemCodeResponse `json:"items"` // 兑换码列表
+}
+
+// DisableRedeemCodeBatchResponse 停用兑换码批次响应
+type DisableRedeemCodeBatchResponse struct {
+	Disabled int64 `json:"disabled"` // 本次停用的兑换码数量
+}
+
+// RedeemRequest 兑换兑换码请求
+type RedeemRequest struct {
+	Code string `json:"code" binding:"required,max=64"` // 兑换码（忽略大小写与 - 分隔符）
+}
+
+// RedeemResultResponse 兑换结果响应
+type RedeemResultResponse struct {
+	Code              string                  `json:"code"`                      // 兑换码
+	SkinLibraryID     *xSnowflake.SnowflakeID `json:"skin_library_id,omitempty"` // 获得的皮肤 ID
+	CapeLibraryID     *xSnowflake.SnowflakeID `json:"cape_library_id,omitempty"` // 获得的披风 ID
+	SkinsPrivateDelta int32                   `json:"skins_private_delta"`       // 私有皮肤总额度增量
+	SkinsPublicDelta  int32                   `json:"skins_public_delta"`        // 公开皮肤总额度增量
+	CapesPrivateDelta int32                   `json:"capes_private_delta"`       // 私有披风总额度增量
+	CapesPublicDelta  int32                   `json:"capes_public_delta"`        // 公开披风总额度增量
+	ProfileQuotaDelta int32                   `json:"profile_quota_delta"`       // 游戏档案总额度增量
+}
@@ -0,0 +1,36 @@
+package library
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// ReportRequest 举报资源请求
+type ReportRequest struct {
+	Reason      uint8  `json:"reason" binding:"required"`     // 举报原因 (1=offensive, 2=copyright, 3=spam, 4=other)
+	Description string `json:"description" binding:"max=255"` // 举报说明（原因为 other 时必填）
+}
+
+// ReportResponse 举报记录响应 DTO
+type ReportResponse struct {
+	ID          xSnowflake.SnowflakeID  `json:"id"`                    // 举报记录 ID
+	ReporterID  xSnowflake.SnowflakeID  `json:"reporter_id"`           // 举报用户 ID
+	Kind        entityType.LibraryKind  `json:"kind"`                  // 资源种类 (1=skin, 2=cape)
+	LibraryID   xSnowflake.SnowflakeID  `json:"library_id"`            // 资源库记录 ID
+	Reason      entityType.ReportReason `json:"reason"`                // 举报原因
+	Description *string                 `json:"description,omitempty"` // 举报说明
+	Status      entityType.ReportStatus `json:"status"`                // 处理状态 (1=pending, 2=dismissed, 3=taken_down)
+	CreatedAt   time.Time               `json:"created_at"`            // 举报时间
+}
+
+// ReportListResponse 举报记录列表响应
+type ReportListResponse struct {
+	Items []ReportResponse `json:"items"` // 举报记录列表
+}
+
+// ReportResultResponse 举报处理结果响应
+type ReportResultResponse struct {
+	Count int64 `json:"count"` // 本次处理的举报条数
+}
@@ -0,0 +1,17 @@
+package library
+
+// ReviewApproveRequest 管理员批量审核通过请求
+type ReviewApproveRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,max=100"` // 资源库 ID 列表
+}
+
+// ReviewRejectRequest 管理员批量审核驳回请求
+type ReviewRejectRequest struct {
+	IDs    []string `json:"ids" binding:"required,min=1,max=100"` // 资源库 ID 列表
+	Reason string   `json:"reason" binding:"required,max=255"`    // 驳回原因
+}
+
+// ReviewResultResponse 批量审核结果响应
+type ReviewResultResponse struct {
+	Count int `json:"count"` // 实际完成审核的数量（非待审核状态的 ID 会被跳过）
+}
@@ -0,0 +1,48 @@
+package library
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// CreateShareRequest 创建私有分享链接请求
+type CreateShareRequest struct {
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=720"` // 有效期（小时），默认 72，最长 720
+}
+
+// ShareResponse 私有分享链接响应（分享者视角）
+type ShareResponse struct {
+	ID         xSnowflake.SnowflakeID `json:"id"`                   // 分享 ID
+	Kind       entityType.LibraryKind `json:"kind"`                 // 资源种类 (1=skin, 2=cape)
+	LibraryID  xSnowflake.SnowflakeID `json:"library_id"`           // 资源库 ID
+	Token      string                 `json:"token"`                // 分享令牌
+	PreviewURL string                 `json:"preview_url"`          // 公开预览页面链接
+	ExpiresAt  time.Time              `json:"expires_at"`           // 到期时间
+	ClaimCount int64                  `json:"claim_count"`          // 领取人数
+	RevokedAt  *time.Time             `json:"revoked_at,omitempty"` // 撤销时间
+	CreatedAt  time.Time              `json:"created_at"`           // 创建时间
+}
+
+// ShareListResponse 私有分享链接列表响应
+type ShareListResponse struct {
+	Items []ShareResponse `json:"items"` // 分享链接列表
+}
+
+// RevokeShareResponse 撤销私有分享链接响应
+type RevokeShareResponse struct {
+	Share         ShareResponse `json:"share"`          // 撤销后的分享链接
+	DetachedCount int64         `json:"detached_count"` // 被移除的未装备接收者关联数
+}
+
+// SharePreviewResponse 私有分享资源预览响应（公开视角）
+type SharePreviewResponse struct {
+	Kind       entityType.LibraryKind `json:"kind"`            // 资源种类 (1=skin, 2=cape)
+	LibraryID  xSnowflake.SnowflakeID `json:"library_id"`      // 资源库 ID
+	Name       string                 `json:"name"`            // 资源名称
+	TextureURL string                 `json:"texture_url"`     // 纹理下载链接
+	Model      *entity.ModelType      `json:"model,omitempty"` // 皮肤模型 (1=classic, 2=slim)，仅皮肤返回
+	ExpiresAt  time.Time              `json:"expires_at"`      // 分享到期时间
+}
@@ -16,6 +16,15 @@ type CreateSkinRequest struct {
 	IsPublic *bool  `json:"is_public,omitempty"`                // 是否公开（可选，默认 false）
 }
 
+// ImportSkinRequest 导入皮肤请求，username 与 url 必须且只能提供一个
+type ImportSkinRequest struct {
+	Username *string `json:"username,omitempty"`                            // 正版玩家名称（从 Mojang 导入）
+	URL      *string `json:"url,omitempty"`                                 // HTTPS 纹理链接（域名需在 skinDomains 白名单内）
+	Name     *string `json:"name,omitempty"`                                // 皮肤名称（可选，默认取玩家名称或文件名）
+	Model    *uint8  `json:"model,omitempty" binding:"omitempty,oneof=1 2"` // 皮肤模型（可选，默认自动识别）
+	IsPublic *bool   `json:"is_public,omitempty"`                           // 是否公开（可选，默认 false）
+}
+
 // UpdateSkinRequest 更新皮肤请求
 type UpdateSkinRequest struct {
 	Name     *string `json:"name,omitempty"`      // 皮肤名称（可选）
@@ -27,15 +36,31 @@ type UpdateSkinRequest struct {
 // 不再嵌入 entity.SkinLibrary，改为显式字段定义。
 // Texture 字段从数据库的 int64 文件 ID 变更为 beacon-bucket 返回的下载链接。
 type SkinResponse struct {
-	ID             xSnowflake.SnowflakeID    `json:"id"`                          // 皮肤库记录 ID
-	UserID         *xSnowflake.SnowflakeID   `json:"user_id,omitempty"`           // 创建者/上传者用户 ID
-	Name           string                    `json:"name"`                        // 皮肤名称
-	TextureURL     string                    `json:"texture_url"`                 // 纹理文件下载链接（由 bucket.Get 解析）
-	TextureHash    string                    `json:"texture_hash"`                // 纹理 SHA256 哈希
-	Model          entity.ModelType          `json:"model"`                       // 皮肤模型 (1=classic, 2=slim)
-	IsPublic       bool                      `json:"is_public"`                   // 是否公开
-	UpdatedAt      time.Time                 `json:"updated_at"`                  // 更新时间
-	AssignmentType entityType.AssignmentType `json:"assignment_type,omitempty"`   // 关联类型（mine 模式下返回）
+	ID                    xSnowflake.SnowflakeID    `json:"id"`                                // 皮肤库记录 ID
+	UserID                *xSnowflake.SnowflakeID   `json:"user_id,omitempty"`                 // 创建者/上传者用户 ID
+	Name                  string                    `json:"name"`                              // 皮肤名称
+	TextureURL            string                    `json:"texture_url"`                       // 纹理文件下载链接（由 bucket.Get 解析）
+	TextureHash           string                    `json:"texture_hash"`                      // 纹理 SHA256 哈希
+	TextureVersion        int32                     `json:"texture_version"`                   // 当前纹理版本号
+	PendingTextureVersion *int32                    `json:"pending_texture_version,omitempty"` // 待审核纹理版本号（审核通过前继续使用当前纹理）
+	PendingTextureURL     string                    `json:"pending_texture_url,omitempty"`     // 待审核纹理下载链接（仅待审核列表返回）
+	Model                 entity.ModelType          `json:"model"`                             // 皮肤模型 (1=classic, 2=slim)
+	IsPublic              bool                      `json:"is_public"`                         // 是否公开
+	UpdatedAt             time.Time                 `json:"updated_at"`                        // 更新时间
+	LikeCount             int64                     `json:"like_count"`                        // 点赞数
+	CollectCount          int64                     `json:"collect_count"`                     // 收藏数
+	ReviewStatus          entityType.ReviewStatus   `json:"review_status"`                     // 审核状态 (1=pending, 2=approved, 3=rejected)
+	ReviewReason          *string                   `json:"review_reason,omitempty"`           // 审核驳回原因
+	ReportCount           int64                     `json:"report_count"`                      // 待处理举报数
+	IsHidden              bool                      `json:"is_hidden"`                         // 是否因举报自动隐藏
+	IsDefault             bool                      `json:"is_default"`                        // 是否为新用户默认发放的系统皮肤
+	IsDefaultEquip        bool                      `json:"is_default_equip"`                  // 是否为新游戏档案默认装备的系统皮肤
+	IsRetired             bool                      `json:"is_retired"`                        // 系统皮肤是否已停用
+	SimilarToID           *xSnowflake.SnowflakeID   `json:"similar_to_id,omitempty"`           // 疑似近似重复的已公开皮肤 ID（待审核时检测）
+	Tags                  []string                  `json:"tags"`                              // 标签列表
+	AssignmentType        entityType.AssignmentType `json:"assignment_type,omitempty"`         // 关联类型（mine 模式下返回）
+	ExpiresAt             *time.Time                `json:"expires_at,omitempty"`              // 限时赠送到期时间（mine 模式下返回，为空表示永久）
+	ExpiresIn             *int64                    `json:"expires_in,omitempty"`              // 限时赠送剩余秒数（mine 模式下返回）
 }
 
 // SkinListResponse 皮肤列表响应
@@ -44,6 +69,17 @@ type SkinListResponse struct {
 	Items []SkinResponse `json:"items"` // 皮肤列表
 }
 
+// SimilarSkinResponse 相似皮肤响应
+type SimilarSkinResponse struct {
+	Skin     SkinResponse `json:"skin"`     // 相似皮肤
+	Distance int          `json:"distance"` // 与查询皮肤的感知哈希汉明距离（越小越相似）
+}
+
+// SimilarSkinListResponse 相似皮肤列表响应
+type SimilarSkinListResponse struct {
+	Items []SimilarSkinResponse `json:"items"` // 相似皮肤列表（按汉明距离升序、上传时间升序）
+}
+
 // SkinSimpleResponse 皮肤精简响应（仅 ID + Name，用于选择器等场景）
 type SkinSimpleResponse struct {
 	ID   xSnowflake.SnowflakeID `json:"id"`   // 皮肤库记录 ID
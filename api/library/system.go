@@ -0,0 +1,34 @@
+package library
+
+// CreateSystemSkinRequest 创建系统皮肤请求
+type CreateSystemSkinRequest struct {
+	Name           string `json:"name" binding:"required"`            // 皮肤名称
+	Model          uint8  `json:"model" binding:"required,oneof=1 2"` // 皮肤模型 (1=classic, 2=slim)
+	Texture        string `json:"texture" binding:"required"`         // 皮肤纹理文件 base64
+	IsPublic       bool   `json:"is_public"`                          // 是否在公开画廊展示
+	IsDefault      bool   `json:"is_default"`                         // 是否发放给每个新用户
+	IsDefaultEquip bool   `json:"is_default_equip"`                   // 是否作为新游戏档案的默认装备皮肤（隐含 is_default）
+}
+
+// UpdateSystemSkinRequest 更新系统皮肤请求（字段为空表示保持原值）
+type UpdateSystemSkinRequest struct {
+	Name           *string `json:"name,omitempty"`             // 皮肤名称
+	IsPublic       *bool   `json:"is_public,omitempty"`        // 是否在公开画廊展示
+	IsDefault      *bool   `json:"is_default,omitempty"`       // 是否发放给每个新用户
+	IsDefaultEquip *bool   `json:"is_default_equip,omitempty"` // 是否作为新游戏档案的默认装备皮肤
+}
+
+// CreateSystemCapeRequest 创建系统披风请求
+type CreateSystemCapeRequest struct {
+	Name      string `json:"name" binding:"required"`    // 披风名称
+	Texture   string `json:"texture" binding:"required"` // 披风纹理文件 base64
+	IsPublic  bool   `json:"is_public"`                  // 是否在公开画廊展示
+	IsDefault bool   `json:"is_default"`                 // 是否发放给每个新用户
+}
+
+// UpdateSystemCapeRequest 更新系统披风请求（字段为空表示保持原值）
+type UpdateSystemCapeRequest struct {
+	Name      *string `json:"name,omitempty"`       // 披风名称
+	IsPublic  *bool   `json:"is_public,omitempty"`  // 是否在公开画廊展示
+	IsDefault *bool   `json:"is_default,omitempty"` // 是否发放给每个新用户
+}
@@ -0,0 +1,28 @@
+package library
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+)
+
+// ReplaceTextureRequest 替换资源纹理请求
+type ReplaceTextureRequest struct {
+	Texture string `json:"texture" binding:"required"` // 新纹理文件 base64
+}
+
+// TextureVersionResponse 纹理版本响应 DTO
+type TextureVersionResponse struct {
+	Version     int32                  `json:"version"`      // 版本号
+	TextureURL  string                 `json:"texture_url"`  // 纹理文件下载链接
+	TextureHash string                 `json:"texture_hash"` // 纹理 SHA256 哈希
+	IsCurrent   bool                   `json:"is_current"`   // 是否为当前生效版本
+	IsPending   bool                   `json:"is_pending"`   // 是否为待审核版本（审核通过后生效）
+	CreatedBy   xSnowflake.SnowflakeID `json:"created_by"`   // 上传该版本的用户 ID
+	CreatedAt   time.Time              `json:"created_at"`   // 版本创建时间
+}
+
+// TextureVersionListResponse 纹理版本列表响应
+type TextureVersionListResponse struct {
+	Items []TextureVersionResponse `json:"items"` // 纹理版本列表（版本号倒序）
+}
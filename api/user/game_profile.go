@@ -27,21 +27,33 @@ type SetCapeRequest struct {
 	CapeLibraryID *string `json:"cape_library_id,omitempty"` // 披风库 ID（null 或空字符串表示卸下）
 }
 
+// SaveOutfitRequest 创建/更新外观预设请求
+type SaveOutfitRequest struct {
+	Name          string  `json:"name" binding:"required,max=32"` // 预设名称
+	SkinLibraryID *string `json:"skin_library_id,omitempty"`      // 皮肤库 ID（null 或空字符串表示不含皮肤）
+	CapeLibraryID *string `json:"cape_library_id,omitempty"`      // 披风库 ID（null 或空字符串表示不含披风）
+}
+
 // GameProfileResponse 游戏档案响应 DTO。
 //
 // 不再嵌入 entity.GameProfile，改为显式字段定义。
 // 嵌套的 Skin/Cape 使用 library 包的 DTO（已包含 texture_url），
 // 不再使用 entity 的原始 Texture int64 字段。
 type GameProfileResponse struct {
-	ID            xSnowflake.SnowflakeID   `json:"id"`                        // 档案 ID
-	UserID        xSnowflake.SnowflakeID   `json:"user_id"`                   // 关联用户 ID
-	UUID          string                   `json:"uuid"`                      // UUIDv7 标识
-	Name          string                   `json:"name"`                      // 档案用户名
-	SkinLibraryID *xSnowflake.SnowflakeID  `json:"skin_library_id,omitempty"` // 装备的皮肤库 ID
-	CapeLibraryID *xSnowflake.SnowflakeID  `json:"cape_library_id,omitempty"` // 装备的披风库 ID
-	UpdatedAt     time.Time                `json:"updated_at"`                // 更新时间
-	Skin          *apiLibrary.SkinResponse `json:"skin,omitempty"`            // 装备的皮肤信息（含 texture_url）
-	Cape          *apiLibrary.CapeResponse `json:"cape,omitempty"`            // 装备的披风信息（含 texture_url）
+	ID                    xSnowflake.SnowflakeID   `json:"id"`                                 // 档案 ID
+	UserID                xSnowflake.SnowflakeID   `json:"user_id"`                            // 关联用户 ID
+	UUID                  string                   `json:"uuid"`                               // UUIDv7 标识
+	Name                  string                   `json:"name"`                               // 档案用户名
+	SkinLibraryID         *xSnowflake.SnowflakeID  `json:"skin_library_id,omitempty"`          // 装备的皮肤库 ID
+	CapeLibraryID         *xSnowflake.SnowflakeID  `json:"cape_library_id,omitempty"`          // 装备的披风库 ID
+	UpdatedAt             time.Time                `json:"updated_at"`                         // 更新时间
+	LastSeenAt            *time.Time               `json:"last_seen_at,omitempty"`             // 最近进服时间
+	NameChangedAt         *time.Time               `json:"name_changed_at,omitempty"`          // 最近一次改名时间
+	NameChangeAvailableAt *time.Time               `json:"name_change_available_at,omitempty"` // 改名冷却结束时间（缺省表示当前可改名）
+	LockedAt              *time.Time               `json:"locked_at,omitempty"`                // 管理员锁定时间（缺省表示未锁定）
+	LockReason            *string                  `json:"lock_reason,omitempty"`              // 锁定原因
+	Skin                  *apiLibrary.SkinResponse `json:"skin,omitempty"`                     // 装备的皮肤信息（含 texture_url）
+	Cape                  *apiLibrary.CapeResponse `json:"cape,omitempty"`                     // 装备的披风信息（含 texture_url）
 }
 
 // AdminAdjustQuotaRequest 管理员调整用户游戏档案配额请求
@@ -50,7 +62,220 @@ type AdminAdjustQuotaRequest struct {
 	Remark string `json:"remark" binding:"omitempty,max=255"` // 备注（可选，最长 255 字符）
 }
 
+// DeleteGameProfileRequest 删除游戏档案请求
+type DeleteGameProfileRequest struct {
+	ConfirmName string `json:"confirm_name" binding:"required"` // 确认名称（需与档案当前用户名一致）
+}
+
+// AdminDeleteGameProfileRequest 管理员删除游戏档案请求
+type AdminDeleteGameProfileRequest struct {
+	Reason string `json:"reason" binding:"required,max=200"` // 删除原因（写入配额日志备注与审计记录）
+}
+
+// DeleteGameProfileResponse 删除游戏档案响应
+type DeleteGameProfileResponse struct {
+	ProfileID         xSnowflake.SnowflakeID `json:"profile_id"`                    // 被删除的档案 ID
+	UserID            xSnowflake.SnowflakeID `json:"user_id"`                       // 原持有者用户 ID
+	UUID              string                 `json:"uuid"`                          // 被删除的档案 UUID
+	Name              string                 `json:"name"`                          // 被删除的档案用户名
+	RevokedTokens     int64                  `json:"revoked_tokens"`                // 被吊销的绑定令牌数量
+	NameReservedUntil *time.Time             `json:"name_reserved_until,omitempty"` // 名称保留到期时间（缺省表示已立即释放）
+}
+
+// CreateGameProfileTransferRequest 发起游戏档案转移请求
+type CreateGameProfileTransferRequest struct {
+	ToUserID xSnowflake.SnowflakeID `json:"to_user_id" binding:"required"` // 接收方用户 ID
+	Remark   string                 `json:"remark" binding:"max=200"`      // 附言（可选）
+}
+
+// GameProfileTransferResponse 游戏档案转移请求响应
+type GameProfileTransferResponse struct {
+	ID          xSnowflake.SnowflakeID `json:"id"`                     // 转移请求 ID
+	ProfileID   xSnowflake.SnowflakeID `json:"profile_id"`             // 游戏档案 ID
+	ProfileUUID string                 `json:"profile_uuid"`           // 游戏档案 UUID
+	ProfileName string                 `json:"profile_name"`           // 发起时的档案用户名
+	FromUserID  xSnowflake.SnowflakeID `json:"from_user_id"`           // 发起方用户 ID
+	ToUserID    xSnowflake.SnowflakeID `json:"to_user_id"`             // 接收方用户 ID
+	Direction   string                 `json:"direction"`              // 相对当前用户的方向（outgoing / incoming）
+	Status      string                 `json:"status"`                 // 请求状态（pending / accepted / rejected / cancelled / expired）
+	Remark      *string                `json:"remark,omitempty"`       // 发起方附言
+	ExpiresAt   time.Time              `json:"expires_at"`             // 到期时间
+	RespondedAt *time.Time             `json:"responded_at,omitempty"` // 处理时间
+	CreatedAt   time.Time              `json:"created_at"`             // 发起时间
+}
+
+// GameProfileTransferListResponse 游戏档案转移请求列表响应
+type GameProfileTransferListResponse struct {
+	Total int64                         `json:"total"` // 总记录数
+	Items []GameProfileTransferResponse `json:"items"` // 转移请求列表
+}
+
+// AcceptGameProfileTransferResponse 接受游戏档案转移响应
+type AcceptGameProfileTransferResponse struct {
+	Transfer      GameProfileTransferResponse `json:"transfer"`       // 已接受的转移请求
+	Profile       GameProfileResponse         `json:"profile"`        // 转移后的游戏档案
+	SkinUnbound   bool                        `json:"skin_unbound"`   // 是否因未拥有而卸下了原皮肤
+	CapeUnbound   bool                        `json:"cape_unbound"`   // 是否因未拥有而卸下了原披风
+	RevokedTokens int64                       `json:"revoked_tokens"` // 被吊销的发起方绑定令牌数量
+}
+
+// GameProfileNameHistoryResponse 游戏档案名称历史响应
+type GameProfileNameHistoryResponse struct {
+	Name       string                  `json:"name"`                  // 名称
+	Source     string                  `json:"source"`                // 名称来源（create / rename / admin）
+	UserID     xSnowflake.SnowflakeID  `json:"user_id"`               // 使用该名称时的持有者用户 ID
+	OperatorID *xSnowflake.SnowflakeID `json:"operator_id,omitempty"` // 操作管理员 ID（玩家自助改名时为空）
+	ChangedAt  time.Time               `json:"changed_at"`            // 生效时间
+}
+
+// GameProfileNameHistoryListResponse 游戏档案名称历史列表响应
+type GameProfileNameHistoryListResponse struct {
+	Items []GameProfileNameHistoryResponse `json:"items"` // 按生效时间升序的名称历史
+}
+
+// AdminReleaseNameReservationResponse 管理员释放名称保留响应
+type AdminReleaseNameReservationResponse struct {
+	Name     string `json:"name"`     // 被释放的名称
+	Released int64  `json:"released"` // 被释放的保留记录数
+}
+
+// GameProfileNameRuleRequest 创建或更新游戏档案名称规则请求
+type GameProfileNameRuleRequest struct {
+	Kind          string                  `json:"kind" binding:"required,oneof=reserved blocked"`                  // 规则类别（reserved / blocked）
+	MatchType     string                  `json:"match_type" binding:"required,oneof=exact prefix contains regex"` // 匹配方式（exact / prefix / contains / regex）
+	Pattern       string                  `json:"pattern" binding:"required,max=128"`                              // 匹配内容
+	AllowedUserID *xSnowflake.SnowflakeID `json:"allowed_user_id,omitempty"`                                       // 允许使用保留名称的用户 ID（仅保留规则，可选）
+	Reason        string                  `json:"reason" binding:"max=255"`                                        // 规则说明（可选）
+	IsEnabled     *bool                   `json:"is_enabled,omitempty"`                                            // 是否启用（缺省为 true）
+}
+
+// GameProfileNameRuleResponse 游戏档案名称规则响应
+type GameProfileNameRuleResponse struct {
+	ID            xSnowflake.SnowflakeID  `json:"id"`                        // 规则 ID
+	Kind          string                  `json:"kind"`                      // 规则类别（reserved / blocked）
+	MatchType     string                  `json:"match_type"`                // 匹配方式（exact / prefix / contains / regex）
+	Pattern       string                  `json:"pattern"`                   // 匹配内容
+	AllowedUserID *xSnowflake.SnowflakeID `json:"allowed_user_id,omitempty"` // 允许使用保留名称的用户 ID
+	Reason        string                  `json:"reason,omitempty"`          // 规则说明
+	IsEnabled     bool                    `json:"is_enabled"`                // 是否启用
+	CreatedAt     time.Time               `json:"created_at"`                // 创建时间
+	UpdatedAt     time.Time               `json:"updated_at"`                // 更新时间
+}
+
+// GameProfileNameRuleListResponse 游戏档案名称规则列表响应
+type GameProfileNameRuleListResponse struct {
+	Total int64                         `json:"total"` // 总记录数
+	Items []GameProfileNameRuleResponse `json:"items"` // 名称规则列表
+}
+
+// GameProfileNameAvailabilityResponse 游戏档案名称可用性检查响应
+type GameProfileNameAvailabilityResponse struct {
+	Name      string `json:"name"`              // 规范化后的名称
+	Available bool   `json:"available"`         // 当前用户是否可以使用该名称
+	Reason    string `json:"reason,omitempty"`  // 不可用原因（invalid / blocked / reserved / taken / held）
+	Message   string `json:"message,omitempty"` // 面向用户的提示信息
+}
+
+// GameProfileNameConflictGroupResponse 仅大小写不同的重复用户名分组响应
+type GameProfileNameConflictGroupResponse struct {
+	Key      string                `json:"key"`      // 归一化名称（小写）
+	Profiles []GameProfileResponse `json:"profiles"` // 冲突档案（按创建时间升序）
+}
+
+// GameProfileNameConflictReportResponse 用户名大小写冲突报告响应
+type GameProfileNameConflictReportResponse struct {
+	Enforced bool                                   `json:"enforced"` // 不区分大小写唯一索引是否已建立
+	Groups   []GameProfileNameConflictGroupResponse `json:"groups"`   // 冲突分组
+}
+
+// AdminRenameGameProfileRequest 管理员强制修改游戏档案用户名请求
+type AdminRenameGameProfileRequest struct {
+	Name   string `json:"name" binding:"required"`           // 新用户名
+	Reason string `json:"reason" binding:"required,max=200"` // 操作原因（写入审计记录）
+}
+
+// AdminGameProfileActionRequest 管理员处理游戏档案请求（清除皮肤、锁定、解锁）
+type AdminGameProfileActionRequest struct {
+	Reason string `json:"reason" binding:"required,max=200"` // 操作原因（写入审计记录）
+}
+
+// GameProfileAuditLogResponse 游戏档案管理员操作审计记录响应
+type GameProfileAuditLogResponse struct {
+	ID          xSnowflake.SnowflakeID `json:"id"`               // 记录 ID
+	ProfileID   xSnowflake.SnowflakeID `json:"profile_id"`       // 游戏档案 ID
+	ProfileUUID string                 `json:"profile_uuid"`     // 游戏档案 UUID
+	ProfileName string                 `json:"profile_name"`     // 操作时的游戏内用户名
+	UserID      xSnowflake.SnowflakeID `json:"user_id"`          // 操作时的持有者用户 ID
+	OperatorID  xSnowflake.SnowflakeID `json:"operator_id"`      // 操作管理员用户 ID
+	Action      string                 `json:"action"`           // 操作类型（rename / clear_skin / lock / unlock / delete）
+	Reason      string                 `json:"reason"`           // 操作原因
+	Detail      *string                `json:"detail,omitempty"` // 变更详情
+	CreatedAt   time.Time              `json:"created_at"`       // 操作时间
+}
+
+// GameProfileAuditLogListResponse 游戏档案管理员操作审计记录列表响应
+type GameProfileAuditLogListResponse struct {
+	Total int64                         `json:"total"` // 总记录数
+	Items []GameProfileAuditLogResponse `json:"items"` // 按操作时间倒序的审计记录
+}
+
 // GameProfileListResponse 游戏档案列表响应
 type GameProfileListResponse struct {
 	Items []GameProfileResponse `json:"items"` // 游戏档案列表
 }
+
+// GameProfileJoinResponse 游戏档案进服记录响应
+type GameProfileJoinResponse struct {
+	ID            xSnowflake.SnowflakeID `json:"id"`                       // 记录 ID
+	GameProfileID xSnowflake.SnowflakeID `json:"game_profile_id"`          // 游戏档案 ID
+	UserID        xSnowflake.SnowflakeID `json:"user_id"`                  // 关联用户 ID
+	ProfileName   string                 `json:"profile_name"`             // 进服时的游戏内用户名
+	ServerAddress *string                `json:"server_address,omitempty"` // 发起验证的服务端地址
+	ClientIP      *string                `json:"client_ip,omitempty"`      // 客户端 IP（仅管理员接口返回）
+	JoinedAt      time.Time              `json:"joined_at"`                // 进服时间
+}
+
+// GameProfileJoinListResponse 游戏档案进服记录列表响应
+type GameProfileJoinListResponse struct {
+	Total int64                     `json:"total"` // 总记录数
+	Items []GameProfileJoinResponse `json:"items"` // 进服记录列表
+}
+
+// AdminRecentJoinListResponse 管理员查询近期进服记录响应
+type AdminRecentJoinListResponse struct {
+	Hours          int                       `json:"hours"`           // 查询时间窗口（小时）
+	ActiveProfiles int64                     `json:"active_profiles"` // 时间窗口内去重活跃档案数
+	Total          int64                     `json:"total"`           // 总记录数
+	Items          []GameProfileJoinResponse `json:"items"`           // 进服记录列表
+}
+
+// DailyActiveResponse 每日活跃玩家统计项
+type DailyActiveResponse struct {
+	Day            string `json:"day"`             // 统计日期（YYYY-MM-DD）
+	ActiveProfiles int64  `json:"active_profiles"` // 活跃游戏档案数
+	ActiveUsers    int64  `json:"active_users"`    // 活跃用户数
+	JoinCount      int64  `json:"join_count"`      // 进服次数
+}
+
+// DailyActiveListResponse 每日活跃玩家统计响应
+type DailyActiveListResponse struct {
+	Days  int                   `json:"days"`  // 统计天数
+	Items []DailyActiveResponse `json:"items"` // 按日期倒序的统计列表
+}
+
+// OutfitResponse 外观预设响应
+type OutfitResponse struct {
+	ID            xSnowflake.SnowflakeID   `json:"id"`                        // 预设 ID
+	Name          string                   `json:"name"`                      // 预设名称
+	SkinLibraryID *xSnowflake.SnowflakeID  `json:"skin_library_id,omitempty"` // 皮肤库 ID
+	CapeLibraryID *xSnowflake.SnowflakeID  `json:"cape_library_id,omitempty"` // 披风库 ID
+	CreatedAt     time.Time                `json:"created_at"`                // 创建时间
+	UpdatedAt     time.Time                `json:"updated_at"`                // 更新时间
+	Skin          *apiLibrary.SkinResponse `json:"skin,omitempty"`            // 预设的皮肤信息（含 texture_url）
+	Cape          *apiLibrary.CapeResponse `json:"cape,omitempty"`            // 预设的披风信息（含 texture_url）
+}
+
+// OutfitListResponse 外观预设列表响应
+type OutfitListResponse struct {
+	Items []OutfitResponse `json:"items"` // 外观预设列表
+}
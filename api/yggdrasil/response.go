@@ -100,3 +100,9 @@ type BatchProfileItem struct {
 	ID   string `json:"id"`   // 角色无符号 UUID
 	Name string `json:"name"` // 角色名称
 }
+
+// NameHistoryItem 角色名称历史响应项（兼容 Mojang names 接口）
+type NameHistoryItem struct {
+	Name        string `json:"name"`                  // 角色名称
+	ChangedToAt *int64 `json:"changedToAt,omitempty"` // 改为该名称的时间戳（毫秒，初始名称不返回）
+}
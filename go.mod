@@ -13,6 +13,7 @@ require (
 	github.com/frontleaves-mc/frontleaves-yggleaf/proto v0.0.0
 	github.com/gin-gonic/gin v1.12.0
 	github.com/google/uuid v1.6.0
+	github.com/jackc/pgx/v5 v5.9.2
 	github.com/phalanx-labs/beacon-bucket-sdk v1.0.0-202604170444
 	github.com/phalanx-labs/beacon-sso-sdk v1.0.0-202604131739
 	github.com/redis/go-redis/v9 v9.19.0
@@ -52,7 +53,6 @@ require (
 	github.com/goccy/go-yaml v1.19.2 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
-	github.com/jackc/pgx/v5 v5.9.2 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
@@ -9,6 +9,7 @@ import (
 
 func (r *route) adminRouter(route gin.IRouter) {
 	userHandler := handler.NewHandler[handler.UserHandler](r.context, "UserHandler")
+	storageHandler := handler.NewHandler[handler.StorageHandler](r.context, "StorageHandler")
 
 	adminGroup := route.Group("/admin/users")
 	adminGroup.Use(bSdkMiddle.CheckAuth(r.context))
@@ -18,5 +19,26 @@ func (r *route) adminRouter(route gin.IRouter) {
 		adminGroup.GET("", userHandler.ListAdminUsers)
 		adminGroup.GET("/:user_id", userHandler.GetAdminUserDetail)
 		adminGroup.GET("/:user_id/game-profiles", userHandler.GetAdminUserGameProfiles)
+		adminGroup.PATCH("/:user_id/role", userHandler.UpdateUserRole)
+	}
+
+	quotaTierGroup := route.Group("/admin/quota-tiers")
+	quotaTierGroup.Use(bSdkMiddle.CheckAuth(r.context))
+	quotaTierGroup.Use(middleware.User(r.context))
+	quotaTierGroup.Use(middleware.SuperAdmin(r.context))
+	{
+		quotaTierGroup.GET("", userHandler.ListQuotaTiers)
+		quotaTierGroup.PUT("/:role_name", userHandler.UpdateQuotaTier)
+	}
+
+	storageGroup := route.Group("/admin/storage")
+	storageGroup.Use(bSdkMiddle.CheckAuth(r.context))
+	storageGroup.Use(middleware.User(r.context))
+	storageGroup.Use(middleware.SuperAdmin(r.context))
+	{
+		storageGroup.POST("/reconcile", storageHandler.StartReconcile)
+		storageGroup.GET("/reconcile/reports", storageHandler.ListReconcileReports)
+		storageGroup.GET("/reconcile/reports/:report_id", storageHandler.GetReconcileReport)
+		storageGroup.GET("/reconcile/reports/:report_id/issues", storageHandler.ListReconcileIssues)
 	}
 }
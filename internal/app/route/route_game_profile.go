@@ -18,12 +18,29 @@ func (r *route) gameProfileRouter(route gin.IRouter) {
 		gameProfileGroup.GET("", gameProfileHandler.ListGameProfiles)
 		gameProfileGroup.POST("", gameProfileHandler.AddGameProfile)
 		gameProfileGroup.GET("/quota", gameProfileHandler.GetQuota)
+		gameProfileGroup.GET("/name-availability", gameProfileHandler.CheckNameAvailability)
 		gameProfileGroup.GET("/:profile_id", gameProfileHandler.GetGameProfileDetail)
+		gameProfileGroup.DELETE("/:profile_id", gameProfileHandler.DeleteGameProfile)
 		gameProfileGroup.PATCH("/:profile_id/username", gameProfileHandler.ChangeUsername)
+		gameProfileGroup.GET("/:profile_id/recent-joins", gameProfileHandler.ListRecentJoins)
 
 		// --- 统一设置接口 ---
 		gameProfileGroup.PATCH("/:profile_id/skin", gameProfileHandler.SetSkin)
 		gameProfileGroup.PATCH("/:profile_id/cape", gameProfileHandler.SetCape)
+
+		// --- 外观预设 ---
+		gameProfileGroup.GET("/outfits", gameProfileHandler.ListOutfits)
+		gameProfileGroup.POST("/outfits", gameProfileHandler.CreateOutfit)
+		gameProfileGroup.PUT("/outfits/:outfit_id", gameProfileHandler.UpdateOutfit)
+		gameProfileGroup.DELETE("/outfits/:outfit_id", gameProfileHandler.DeleteOutfit)
+		gameProfileGroup.POST("/:profile_id/outfits/:outfit_id/apply", gameProfileHandler.ApplyOutfit)
+
+		// --- 档案转移 ---
+		gameProfileGroup.GET("/transfers", gameProfileHandler.ListTransfers)
+		gameProfileGroup.POST("/:profile_id/transfers", gameProfileHandler.CreateTransfer)
+		gameProfileGroup.POST("/transfers/:transfer_id/accept", gameProfileHandler.AcceptTransfer)
+		gameProfileGroup.POST("/transfers/:transfer_id/reject", gameProfileHandler.RejectTransfer)
+		gameProfileGroup.DELETE("/transfers/:transfer_id", gameProfileHandler.CancelTransfer)
 	}
 
 	// ---- 管理员路由组 ----
@@ -33,5 +50,22 @@ func (r *route) gameProfileRouter(route gin.IRouter) {
 	adminGroup.Use(middleware.SuperAdmin(r.context))
 	{
 		adminGroup.POST("/users/:user_id/quota", gameProfileHandler.AdjustQuotaAdmin)
+		adminGroup.DELETE("/profiles/:profile_id", gameProfileHandler.AdminDeleteGameProfile)
+		adminGroup.POST("/profiles/:profile_id/rename", gameProfileHandler.AdminRenameGameProfile)
+		adminGroup.POST("/profiles/:profile_id/skin/clear", gameProfileHandler.AdminClearProfileSkin)
+		adminGroup.POST("/profiles/:profile_id/lock", gameProfileHandler.AdminLockGameProfile)
+		adminGroup.POST("/profiles/:profile_id/unlock", gameProfileHandler.AdminUnlockGameProfile)
+		adminGroup.GET("/audit-logs", gameProfileHandler.AdminListAuditLogs)
+		adminGroup.GET("/profiles/:profile_id/names", gameProfileHandler.AdminListNameHistory)
+		adminGroup.POST("/profiles/:profile_id/rename-cooldown/reset", gameProfileHandler.AdminResetRenameCooldown)
+		adminGroup.DELETE("/name-reservations/:name", gameProfileHandler.AdminReleaseNameReservation)
+		adminGroup.GET("/name-rules", gameProfileHandler.AdminListNameRules)
+		adminGroup.POST("/name-rules", gameProfileHandler.AdminCreateNameRule)
+		adminGroup.PUT("/name-rules/:rule_id", gameProfileHandler.AdminUpdateNameRule)
+		adminGroup.DELETE("/name-rules/:rule_id", gameProfileHandler.AdminDeleteNameRule)
+		adminGroup.GET("/name-conflicts", gameProfileHandler.AdminGetNameConflictReport)
+		adminGroup.POST("/name-conflicts/enforce", gameProfileHandler.AdminEnforceNameCaseUniqueness)
+		adminGroup.GET("/joins", gameProfileHandler.AdminListRecentJoins)
+		adminGroup.GET("/daily-active", gameProfileHandler.AdminGetDailyActive)
 	}
 }
@@ -10,6 +10,9 @@ import (
 func (r *route) libraryRouter(route gin.IRouter) {
 	libraryHandler := handler.NewHandler[handler.LibraryHandler](r.context, "LibraryHandler")
 
+	// 私有分享公开预览接口（无需登录）
+	route.GET("/library/shares/:token", libraryHandler.GetSharePreview)
+
 	libraryGroup := route.Group("/library")
 	libraryGroup.Use(bSdkMiddle.CheckAuth(r.context))
 	libraryGroup.Use(middleware.User(r.context))
@@ -19,10 +22,23 @@ func (r *route) libraryRouter(route gin.IRouter) {
 		skinGroup := libraryGroup.Group("/skins")
 		{
 			skinGroup.POST("", libraryHandler.CreateSkin)
+			skinGroup.POST("/import", libraryHandler.ImportSkin)
 			skinGroup.GET("", libraryHandler.ListSkins)
 			skinGroup.GET("/list", libraryHandler.ListMySkinsSimple)
 			skinGroup.PATCH("/:skin_id", libraryHandler.UpdateSkin)
 			skinGroup.DELETE("/:skin_id", libraryHandler.DeleteSkin)
+			skinGroup.POST("/:skin_id/like", libraryHandler.LikeSkin)
+			skinGroup.DELETE("/:skin_id/like", libraryHandler.UnlikeSkin)
+			skinGroup.PUT("/:skin_id/tags", libraryHandler.SetSkinTags)
+			skinGroup.POST("/:skin_id/collect", libraryHandler.CollectSkin)
+			skinGroup.DELETE("/:skin_id/collect", libraryHandler.UncollectSkin)
+			skinGroup.POST("/:skin_id/report", libraryHandler.ReportSkin)
+			skinGroup.PUT("/:skin_id/texture", libraryHandler.ReplaceSkinTexture)
+			skinGroup.GET("/:skin_id/versions", libraryHandler.ListSkinTextureVersions)
+			skinGroup.POST("/:skin_id/versions/:version/rollback", libraryHandler.RollbackSkinTexture)
+			skinGroup.POST("/:skin_id/shares", libraryHandler.CreateSkinShare)
+			skinGroup.GET("/:skin_id/shares", libraryHandler.ListSkinShares)
+			skinGroup.DELETE("/:skin_id/shares/:share_id", libraryHandler.RevokeSkinShare)
 		}
 
 		// 披风相关接口
@@ -33,10 +49,41 @@ func (r *route) libraryRouter(route gin.IRouter) {
 			capeGroup.GET("/list", libraryHandler.ListMyCapesSimple)
 			capeGroup.PATCH("/:cape_id", libraryHandler.UpdateCape)
 			capeGroup.DELETE("/:cape_id", libraryHandler.DeleteCape)
+			capeGroup.POST("/:cape_id/like", libraryHandler.LikeCape)
+			capeGroup.DELETE("/:cape_id/like", libraryHandler.UnlikeCape)
+			capeGroup.PUT("/:cape_id/tags", libraryHandler.SetCapeTags)
+			capeGroup.POST("/:cape_id/collect", libraryHandler.CollectCape)
+			capeGroup.DELETE("/:cape_id/collect", libraryHandler.UncollectCape)
+			capeGroup.POST("/:cape_id/report", libraryHandler.ReportCape)
+			capeGroup.PUT("/:cape_id/texture", libraryHandler.ReplaceCapeTexture)
+			capeGroup.GET("/:cape_id/versions", libraryHandler.ListCapeTextureVersions)
+			capeGroup.POST("/:cape_id/versions/:version/rollback", libraryHandler.RollbackCapeTexture)
+			capeGroup.POST("/:cape_id/shares", libraryHandler.CreateCapeShare)
+			capeGroup.GET("/:cape_id/shares", libraryHandler.ListCapeShares)
+			capeGroup.DELETE("/:cape_id/shares/:share_id", libraryHandler.RevokeCapeShare)
+		}
+
+		// 公开画廊接口
+		galleryGroup := libraryGroup.Group("/gallery")
+		{
+			galleryGroup.GET("/skins", libraryHandler.ListSkinGallery)
+			galleryGroup.GET("/capes", libraryHandler.ListCapeGallery)
 		}
+		libraryGroup.GET("/tags", libraryHandler.ListPopularTags)
 
 		// 配额查询接口
 		libraryGroup.GET("/quota", libraryHandler.GetQuota)
+		libraryGroup.GET("/quota/logs", libraryHandler.ListQuotaLogs)
+
+		// 衣柜压缩包导入导出接口
+		libraryGroup.POST("/import", libraryHandler.ImportArchive)
+		libraryGroup.GET("/export", libraryHandler.ExportArchive)
+
+		// 兑换码兑换接口
+		libraryGroup.POST("/redeem", libraryHandler.Redeem)
+
+		// 私有分享领取接口
+		libraryGroup.POST("/shares/:token/claim", libraryHandler.ClaimShare)
 
 		// 管理员接口
 		adminGroup := libraryGroup.Group("/admin")
@@ -50,12 +97,59 @@ func (r *route) libraryRouter(route gin.IRouter) {
 			adminGroup.POST("/users/:user_id/capes/gift", libraryHandler.GiftCape)
 			adminGroup.DELETE("/users/:user_id/capes/:cape_library_id", libraryHandler.RevokeCape)
 
-			// 管理员配额同步
+			// 管理员配额同步、调整与变更日志
 			adminGroup.POST("/users/:user_id/quota/sync", libraryHandler.SyncQuota)
+			adminGroup.POST("/users/:user_id/quota", libraryHandler.AdjustQuota)
+			adminGroup.GET("/users/:user_id/quota/logs", libraryHandler.ListUserQuotaLogs)
 
 			// 管理员查询用户资源
 			adminGroup.GET("/users/:user_id/skins", libraryHandler.ListUserSkins)
 			adminGroup.GET("/users/:user_id/capes", libraryHandler.ListUserCapes)
+
+			// 管理员审核公开资源
+			adminGroup.GET("/reviews/skins", libraryHandler.ListPendingSkins)
+			adminGroup.POST("/reviews/skins/approve", libraryHandler.ApproveSkins)
+			adminGroup.POST("/reviews/skins/reject", libraryHandler.RejectSkins)
+			adminGroup.GET("/reviews/capes", libraryHandler.ListPendingCapes)
+			adminGroup.POST("/reviews/capes/approve", libraryHandler.ApproveCapes)
+			adminGroup.POST("/reviews/capes/reject", libraryHandler.RejectCapes)
+
+			// 管理员相似纹理查询
+			adminGroup.GET("/skins/:skin_id/similar", libraryHandler.ListSimilarSkins)
+			adminGroup.GET("/capes/:cape_id/similar", libraryHandler.ListSimilarCapes)
+
+			// 管理员举报处理
+			adminGroup.GET("/reports/skins", libraryHandler.ListReportedSkins)
+			adminGroup.GET("/reports/skins/:skin_id", libraryHandler.ListSkinReports)
+			adminGroup.POST("/reports/skins/:skin_id/dismiss", libraryHandler.DismissSkinReports)
+			adminGroup.POST("/reports/skins/:skin_id/takedown", libraryHandler.TakedownSkin)
+			adminGroup.GET("/reports/capes", libraryHandler.ListReportedCapes)
+			adminGroup.GET("/reports/capes/:cape_id", libraryHandler.ListCapeReports)
+			adminGroup.POST("/reports/capes/:cape_id/dismiss", libraryHandler.DismissCapeReports)
+			adminGroup.POST("/reports/capes/:cape_id/takedown", libraryHandler.TakedownCape)
+
+			// 管理员系统资源目录
+			adminGroup.POST("/system/skins", libraryHandler.CreateSystemSkin)
+			adminGroup.GET("/system/skins", libraryHandler.ListSystemSkins)
+			adminGroup.PATCH("/system/skins/:skin_id", libraryHandler.UpdateSystemSkin)
+			adminGroup.POST("/system/skins/:skin_id/retire", libraryHandler.RetireSystemSkin)
+			adminGroup.POST("/system/capes", libraryHandler.CreateSystemCape)
+			adminGroup.GET("/system/capes", libraryHandler.ListSystemCapes)
+			adminGroup.PATCH("/system/capes/:cape_id", libraryHandler.UpdateSystemCape)
+			adminGroup.POST("/system/capes/:cape_id/retire", libraryHandler.RetireSystemCape)
+
+			// 管理员资源发放活动
+			adminGroup.POST("/campaigns", libraryHandler.CreateCampaign)
+			adminGroup.GET("/campaigns", libraryHandler.ListCampaigns)
+			adminGroup.GET("/campaigns/:campaign_id", libraryHandler.GetCampaign)
+			adminGroup.GET("/campaigns/:campaign_id/grants", libraryHandler.ListCampaignGrants)
+			adminGroup.POST("/campaigns/:campaign_id/resume", libraryHandler.ResumeCampaign)
+			adminGroup.POST("/campaigns/:campaign_id/revoke", libraryHandler.RevokeCampaign)
+
+			// 管理员兑换码
+			adminGroup.POST("/redeem-codes", libraryHandler.GenerateRedeemCodes)
+			adminGroup.GET("/redeem-codes", libraryHandler.ListRedeemCodes)
+			adminGroup.POST("/redeem-codes/batches/:batch_id/disable", libraryHandler.DisableRedeemCodeBatch)
 		}
 	}
 }
@@ -0,0 +1,16 @@
+package route
+
+import (
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/handler"
+	"github.com/gin-gonic/gin"
+)
+
+func (r *route) storageRouter(route gin.IRouter) {
+	storageHandler := handler.NewHandler[handler.StorageHandler](r.context, "StorageHandler")
+
+	// 本地存储文件下载（公开，仅 STORAGE_BACKEND=local 时有文件）
+	storageGroup := route.Group("/storage")
+	{
+		storageGroup.GET("/files/:file_id", storageHandler.ServeFile)
+	}
+}
@@ -65,6 +65,9 @@ func (r *route) yggdrasilRouter() {
 	// #10: 批量查询角色（无需认证 — 同上）
 	yggGroup.POST("/api/profiles/minecraft", serverHandler.ProfilesBatchLookup)
 
+	// 查询角色名称历史（无需认证 — 同上，兼容 Mojang names 接口）
+	yggGroup.GET("/api/user/profiles/:uuid/names", serverHandler.NameHistory)
+
 	// 需 Bearer Token 认证的路由组（#11, #12 通过 Authorization 头认证）
 	// 注意：Gin 的 group.Use() 原地修改中间件链，此后注册到 yggGroup 的路由都会继承该中间件
 	authRequired := yggGroup.Use(yggmiddleware.YggdrasilBearerAuth(r.context))
@@ -24,4 +24,6 @@ func New(log *xLog.LogNamedLogger, ctx context.Context) *Prepare {
 
 func (p *Prepare) Prepare() {
 	p.prepareRole()
+	p.prepareQuotaTier()
+	p.prepareGameProfileNameIndex()
 }
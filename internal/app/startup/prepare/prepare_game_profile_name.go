@@ -0,0 +1,45 @@
+package prepare
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/logic"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+)
+
+// prepareGameProfileNameIndex 建立游戏档案用户名不区分大小写唯一索引
+//
+// Minecraft 按不区分大小写处理角色名称，数据库层以 lower(name) 唯一索引保证 "Steve" 与 "steve" 不能共存。
+// 存量数据中若已存在仅大小写不同的重复名称，索引无法建立：此时逐组输出冲突报告并跳过，不阻断启动，
+// 应用层的不区分大小写校验仍然生效。管理员处理冲突后可通过管理接口启用索引，或在下次启动时自动建立。
+func (p *Prepare) prepareGameProfileNameIndex() {
+	profileRepo := repository.NewGameProfileRepo(p.db)
+	if profileRepo.HasNameLowerUniqueIndex(p.ctx) {
+		return
+	}
+
+	conflicts, xErr := profileRepo.ListNameCaseConflicts(p.ctx, nil)
+	if xErr != nil {
+		p.log.Warn(p.ctx, fmt.Sprintf("检测游戏档案用户名大小写冲突失败: %s", xErr.ErrorMessage))
+		return
+	}
+
+	if groups := logic.GroupGameProfileNameConflicts(conflicts); len(groups) > 0 {
+		for _, group := range groups {
+			entries := make([]string, 0, len(group.Profiles))
+			for _, profile := range group.Profiles {
+				entries = append(entries, fmt.Sprintf("%s(档案ID=%s, 用户ID=%s)", profile.Name, profile.ID.String(), profile.UserID.String()))
+			}
+			p.log.Warn(p.ctx, fmt.Sprintf("用户名大小写冲突 [%s]: %s", group.Key, strings.Join(entries, ", ")))
+		}
+		p.log.Warn(p.ctx, fmt.Sprintf("检测到 %d 组仅大小写不同的重复用户名，暂未建立不区分大小写唯一索引；处理冲突后可通过 POST /admin/game-profile/name-conflicts/enforce 启用", len(groups)))
+		return
+	}
+
+	if xErr = profileRepo.CreateNameLowerUniqueIndex(p.ctx); xErr != nil {
+		p.log.Warn(p.ctx, fmt.Sprintf("建立游戏档案用户名唯一索引失败: %s", xErr.ErrorMessage))
+		return
+	}
+	p.log.Info(p.ctx, "已建立游戏档案用户名不区分大小写唯一索引")
+}
@@ -0,0 +1,28 @@
+package prepare
+
+import (
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm/clause"
+)
+
+// prepareQuotaTier 为系统默认角色预置配额档位
+//
+// 档位初始值与仓储层首次创建配额时的默认值一致（游戏档案 1，公开/私有皮肤 1/2，公开/私有披风 1/2），
+// 因此首次启用档位不会改变现有行为。已存在的档位保持不变（ON CONFLICT DO NOTHING），
+// 避免重启覆盖管理员修改过的配置。
+func (p *Prepare) prepareQuotaTier() {
+	roles := []entity.RoleName{entity.RoleSuperAdmin, entity.RoleAdmin, entity.RolePlayer}
+	for _, roleName := range roles {
+		tier := &entity.RoleQuotaTier{
+			RoleName:          roleName,
+			ProfileTotal:      1,
+			SkinsPublicTotal:  1,
+			SkinsPrivateTotal: 2,
+			CapesPublicTotal:  1,
+			CapesPrivateTotal: 2,
+		}
+		if err := p.db.Clauses(clause.OnConflict{DoNothing: true}).Create(tier).Error; err != nil {
+			p.log.Warn(p.ctx, "预置角色配额档位失败: "+err.Error())
+		}
+	}
+}
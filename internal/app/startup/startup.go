@@ -29,10 +29,11 @@ func Init() (context.Context, []xRegNode.RegNodeList) {
 	// 初始化注册
 	regNode = append(regNode, xRegNode.RegNodeList{Key: xCtx.DatabaseKey, Node: businessReg.databaseInit})
 	regNode = append(regNode, xRegNode.RegNodeList{Key: xCtx.RedisClientKey, Node: businessReg.nosqlInit})
-	regNode = append(regNode, xRegNode.RegNodeList{Key: bConst.CtxBucketKey, Node: businessReg.bucketInit})
+	regNode = append(regNode, xRegNode.RegNodeList{Key: bConst.CtxStorageKey, Node: businessReg.storageInit})
 	regNode = append(regNode, xRegNode.RegNodeList{Key: xCtx.Exec, Node: businessReg.businessDataPrepare})
 	regNode = append(regNode, xRegNode.RegNodeList{Key: bConst.CtxYggdrasilRSAKeyPair, Node: businessReg.yggdrasilRSAKeyInit})
 	regNode = append(regNode, xRegNode.RegNodeList{Key: xCtx.EmailClientKey, Node: xEmail.InitClient})
+	regNode = append(regNode, xRegNode.RegNodeList{Key: bConst.CtxScheduleKey, Node: businessReg.scheduleInit})
 
 	// 初始化 OAuth2
 	regNode = append(regNode, bSdkStartup.NewStartupConfig()...)
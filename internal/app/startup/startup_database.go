@@ -16,6 +16,7 @@ import (
 
 var migrateTables = []interface{}{
 	&entity.Role{},
+	&entity.RoleQuotaTier{},
 	&entity.User{},
 	&entity.GameProfile{},
 	&entity.SkinLibrary{},
@@ -23,10 +24,32 @@ var migrateTables = []interface{}{
 	&entity.LibraryQuota{},
 	&entity.GameProfileQuota{},
 	&entity.GameProfileQuotaLog{},
+	&entity.LibraryQuotaLog{},
 	&entity.UserSkinLibrary{},
 	&entity.UserCapeLibrary{},
 	&entity.GameToken{},
 	&entity.GameOnlineProfile{},
+	&entity.GameProfileJoinLog{},
+	&entity.GameProfileOutfit{},
+	&entity.GameProfileNameReservation{},
+	&entity.GameProfileTransfer{},
+	&entity.GameProfileNameHistory{},
+	&entity.GameProfileNameRule{},
+	&entity.GameProfileAuditLog{},
+	&entity.LibraryTag{},
+	&entity.LibraryLike{},
+	&entity.LibraryReport{},
+	&entity.LibraryTextureVersion{},
+	&entity.LibraryCampaign{},
+	&entity.LibraryCampaignGrant{},
+	&entity.RedeemCodeBatch{},
+	&entity.RedeemCode{},
+	&entity.RedeemCodeRedemption{},
+	&entity.LibraryShare{},
+	&entity.LibraryShareClaim{},
+	&entity.StorageFile{},
+	&entity.StorageReconcileReport{},
+	&entity.StorageReconcileIssue{},
 	// Issue System
 	&entity.IssueType{},
 	&entity.Issue{},
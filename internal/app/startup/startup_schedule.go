@@ -0,0 +1,90 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/logic"
+)
+
+const (
+	giftExpiryInterval       = time.Minute    // 限时赠送到期检查间隔
+	storageReconcileInterval = 24 * time.Hour // 存储对账试运行间隔
+)
+
+// scheduleInit 启动后台定时任务。
+//
+// 当前包含限时赠送到期收回任务：每分钟扫描一次已到期的皮肤/披风赠送，
+// 收回关联并卸下游戏档案上的对应装备。多实例部署时各实例会并行扫描，
+// 收回操作按到期条件删除，重复执行不会产生副作用。上下文取消时任务退出并停止计时器。
+//
+// 启动时另执行一次感知哈希回填：为缺少感知哈希的存量皮肤/披风下载纹理并计算哈希，
+// 回填完成后即退出；无法计算的资源在下次启动时重试。
+//
+// 另有存储对账任务：每天发起一次试运行对账，仅生成报告供管理员查看，不会删除任何文件；
+// 已有对账任务执行中时本次跳过。
+//
+// 注意: 需在数据库、缓存与对象存储初始化完成后注册。
+func (r *reg) scheduleInit(ctx context.Context) (any, error) {
+	log := xLog.WithName(xLog.NamedINIT)
+	log.Info(ctx, "正在启动后台定时任务...")
+
+	go func() {
+		ticker := time.NewTicker(giftExpiryInterval)
+		defer ticker.Stop()
+
+		libraryLogic := logic.NewLibraryLogic(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			skinCount, capeCount, xErr := libraryLogic.RevokeExpiredGifts(ctx)
+			if xErr != nil {
+				log.Warn(ctx, fmt.Sprintf("限时赠送到期收回失败: %s", xErr.ErrorMessage))
+				continue
+			}
+			if skinCount > 0 || capeCount > 0 {
+				log.Info(ctx, fmt.Sprintf("已收回到期限时赠送：皮肤 %d 个，披风 %d 个", skinCount, capeCount))
+			}
+		}
+	}()
+
+	go func() {
+		libraryLogic := logic.NewLibraryLogic(ctx)
+		skinCount, capeCount, xErr := libraryLogic.BackfillPerceptualHashes(ctx)
+		if xErr != nil {
+			log.Warn(ctx, fmt.Sprintf("回填纹理感知哈希中断: %s", xErr.ErrorMessage))
+		}
+		if skinCount > 0 || capeCount > 0 {
+			log.Info(ctx, fmt.Sprintf("已回填纹理感知哈希：皮肤 %d 个，披风 %d 个", skinCount, capeCount))
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(storageReconcileInterval)
+		defer ticker.Stop()
+
+		storageLogic := logic.NewStorageLogic(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			report, xErr := storageLogic.StartReconcile(ctx, nil, true)
+			if xErr != nil {
+				log.Warn(ctx, fmt.Sprintf("定时存储对账未启动: %s", xErr.ErrorMessage))
+				continue
+			}
+			log.Info(ctx, fmt.Sprintf("已发起定时存储对账(reportID=%d)", report.ID))
+		}
+	}()
+
+	return nil, nil
+}
@@ -0,0 +1,101 @@
+package startup
+
+import (
+	"context"
+	"fmt"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
+	bBucket "github.com/phalanx-labs/beacon-bucket-sdk"
+)
+
+// storageInit 初始化文件存储后端。
+//
+// 通过环境变量 `STORAGE_BACKEND` 选择后端：
+//   - bucket（默认）: beacon-bucket 对象存储服务，读取 `BUCKET_*` 系列配置；
+//   - local: 本地文件系统，文件保存在 `STORAGE_LOCAL_PATH` 目录，
+//     下载链接以 `STORAGE_LOCAL_PUBLIC_URL` 为前缀，由服务自身提供下载。
+//
+// 参数说明:
+//   - ctx: 用于日志追踪的上下文。
+//
+// 返回值:
+//   - any: 初始化成功的 `bStorage.Storage` 实例。
+//   - error: 后端类型未知或必要配置缺失时返回错误。
+func (r *reg) storageInit(ctx context.Context) (any, error) {
+	log := xLog.WithName(xLog.NamedINIT)
+
+	backend := bStorage.Backend(xEnv.GetEnvString(bConst.EnvStorageBackend, string(bStorage.BackendBucket)))
+	switch backend {
+	case bStorage.BackendBucket:
+		log.Debug(ctx, "初始化 BucketClient...")
+		return r.bucketStorageInit()
+	case bStorage.BackendLocal:
+		log.Debug(ctx, "初始化本地文件存储...")
+		localStorage, err := bStorage.NewLocalStorage(
+			xEnv.GetEnvString(bConst.EnvStorageLocalPath, "storage"),
+			xEnv.GetEnvString(bConst.EnvStorageLocalPublicURL, ""),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("初始化本地文件存储失败（请检查 %s / %s）: %w", bConst.EnvStorageLocalPath, bConst.EnvStorageLocalPublicURL, err)
+		}
+		return localStorage, nil
+	default:
+		return nil, fmt.Errorf("未知的文件存储后端: %s=%s（可选 bucket、local）", bConst.EnvStorageBackend, backend)
+	}
+}
+
+// bucketStorageInit 初始化 beacon-bucket 存储后端。
+//
+// 该方法通过读取环境变量 (`BUCKET_HOST`, `BUCKET_PORT` 等) 来配置连接参数
+// 和应用凭证，并校验皮肤、披风、Issue 附件各自的存储桶与存储路径配置。
+//
+// 注意: 请确保所有相关环境变量已正确设置。
+func (r *reg) bucketStorageInit() (any, error) {
+	var (
+		bucketHost   = xEnv.GetEnvString(bConst.EnvBucketHost, "")
+		bucketPort   = xEnv.GetEnvString(bConst.EnvBucketPort, "")
+		appAccessID  = xEnv.GetEnvString(bConst.EnvBucketAccessID, "")
+		appAccessKey = xEnv.GetEnvString(bConst.EnvBucketSecretKey, "")
+	)
+
+	if bucketHost == "" || bucketPort == "" || appAccessID == "" || appAccessKey == "" {
+		return nil, fmt.Errorf(
+			"缺少必要的 Bucket 配置环境变量: BUCKET_HOST=%s, BUCKET_PORT=%s, BUCKET_ACCESS_ID=%s, BUCKET_SECRET_KEY=%s",
+			bucketHost, bucketPort, appAccessID, appAccessKey,
+		)
+	}
+
+	// 校验业务级 BucketId/PathId 配置（Skin / Cape / Issue）
+	businessBuckets := []struct {
+		name     string
+		category bStorage.Category
+		bucketId xEnv.EnvKey
+		pathId   xEnv.EnvKey
+	}{
+		{"Skin", bStorage.CategorySkin, bConst.EnvBucketSkinBucketId, bConst.EnvBucketSkinPathId},
+		{"Cape", bStorage.CategoryCape, bConst.EnvBucketCapeBucketId, bConst.EnvBucketCapePathId},
+		{"Issue", bStorage.CategoryIssue, bConst.EnvBucketIssueBucketId, bConst.EnvBucketIssuePathId},
+	}
+	targets := make(map[bStorage.Category]bStorage.BucketTarget, len(businessBuckets))
+	for _, bb := range businessBuckets {
+		bid := xEnv.GetEnvString(bb.bucketId, "")
+		pid := xEnv.GetEnvString(bb.pathId, "")
+		if bid == "" || pid == "" {
+			return nil, fmt.Errorf(
+				"缺少必要的 %s Bucket 业务配置: %s=%s, %s=%s",
+				bb.name, bb.bucketId, bid, bb.pathId, pid,
+			)
+		}
+		targets[bb.category] = bStorage.BucketTarget{BucketID: bid, PathID: pid}
+	}
+
+	bucketClient := bBucket.NewClient(
+		bBucket.WithConnect(bucketHost, bucketPort),
+		bBucket.WithAppAccess(appAccessID, appAccessKey),
+	)
+
+	return bStorage.NewBucketStorage(bucketClient, targets), nil
+}
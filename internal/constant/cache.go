@@ -12,7 +12,12 @@ const (
 	CacheUserinfo         RedisKey = "user:info:%s"            // CacheUserinfo 用户实体缓存（UserCache 使用）
 	CacheUserAccess       RedisKey = "user:access:%s"          // CacheUserAccess AccessToken→User 缓存（AccessUserCache 使用，%s = MD5(token)）
 	CacheYggdrasilSession RedisKey = "yggdrasil:session:%s"    // CacheYggdrasilSession Yggdrasil 会话缓存（%s = serverId，已通过 JoinServerRequest.ServerID 的 max=256 binding tag 限制长度）
+	CacheYggdrasilSessionProfile RedisKey = "yggdrasil:session:profile:%s" // CacheYggdrasilSessionProfile 游戏档案的待验证会话索引（Set，%s = 无符号 UUID，成员为 serverId）
 	CacheIssue           RedisKey = "issue:%s"               // CacheIssue 问题实体缓存（IssueCache 使用，%s = snowflake ID）
+	CacheLibraryGallery        RedisKey = "library:gallery:%s:%d:%s" // CacheLibraryGallery 公开画廊分页缓存（GalleryCache 使用，%s = 资源种类，%d = 版本号，%s = 查询条件摘要）
+	CacheLibraryGalleryVersion RedisKey = "library:gallery:%s:version" // CacheLibraryGalleryVersion 公开画廊缓存版本号（递增即整体失效，%s = 资源种类）
+	CacheLibraryRedeemRateLimit RedisKey = "library:redeem:ratelimit:%s" // CacheLibraryRedeemRateLimit 兑换码兑换频率计数（固定窗口，%s = 用户 ID）
+	CacheGameProfileNameRules RedisKey = "game-profile:name-rules" // CacheGameProfileNameRules 已启用的游戏档案名称规则列表（GameProfileNameRuleCache 使用）
 )
 
 // Get 返回一个格式化后的 `RedisKey`，根据输入参数对原始键进行格式化并生成新的键。
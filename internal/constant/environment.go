@@ -14,6 +14,10 @@ const (
 	EnvBucketIssueBucketId xEnv.EnvKey = "BUCKET_ISSUE_BUCKET_ID" // Issue 附件存储桶 ID
 	EnvBucketIssuePathId   xEnv.EnvKey = "BUCKET_ISSUE_PATH_ID"   // Issue 附件存储路径 ID
 
+	EnvStorageBackend        xEnv.EnvKey = "STORAGE_BACKEND"          // 文件存储后端（bucket / local），默认 bucket
+	EnvStorageLocalPath      xEnv.EnvKey = "STORAGE_LOCAL_PATH"       // 本地文件存储目录（local 后端），默认 storage
+	EnvStorageLocalPublicURL xEnv.EnvKey = "STORAGE_LOCAL_PUBLIC_URL" // 本地文件存储下载链接前缀，即服务对外访问地址（local 后端）
+
 	EnvYggdrasilPrivateKeyPath   xEnv.EnvKey = "YGGDRASIL_PRIVATE_KEY_PATH"   // Yggdrasil RSA 私钥文件路径
 	EnvYggdrasilPublicKeyPath    xEnv.EnvKey = "YGGDRASIL_PUBLIC_KEY_PATH"    // Yggdrasil RSA 公钥文件路径
 	EnvYggdrasilSkinDomainsExtra xEnv.EnvKey = "YGGDRASIL_SKIN_DOMAINS_EXTRA" // 额外皮肤域名（逗号分隔，追加到 skinDomains 白名单）
@@ -21,4 +25,11 @@ const (
 	EnvGrpcSecretKey xEnv.EnvKey = "GRPC_SECRET_KEY" // gRPC 服务间调用的共享密钥
 
 	EnvFrontendURL xEnv.EnvKey = "FRONTEND_URL" // 前端站点 URL（用于邮件中的链接）
+
+	EnvLibraryReportHideThreshold xEnv.EnvKey = "LIBRARY_REPORT_HIDE_THRESHOLD" // 资源库举报自动隐藏阈值（待处理举报数达到该值后从公开列表隐藏）
+
+	EnvGameProfileNameReleaseHours    xEnv.EnvKey = "GAME_PROFILE_NAME_RELEASE_HOURS"    // 删除游戏档案后名称保留给原持有者的小时数（0 表示立即释放）
+	EnvGameProfileTransferExpireHours xEnv.EnvKey = "GAME_PROFILE_TRANSFER_EXPIRE_HOURS" // 游戏档案转移请求的有效小时数（接收方需在此时间内接受），默认 72
+	EnvGameProfileRenameCooldownDays  xEnv.EnvKey = "GAME_PROFILE_RENAME_COOLDOWN_DAYS"  // 游戏档案两次改名之间的冷却天数（0 表示不限制），默认 30
+	EnvGameProfileOldNameReserveDays  xEnv.EnvKey = "GAME_PROFILE_OLD_NAME_RESERVE_DAYS" // 改名后旧名称保留给原持有者的天数（0 表示立即释放），默认 37
 )
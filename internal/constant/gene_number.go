@@ -17,4 +17,26 @@ const (
 	GeneForIssueReply        xSnowflake.Gene = 43 // 问题回复
 	GeneForIssueAttachment   xSnowflake.Gene = 44 // 问题附件
 	GeneForGameOnlineProfile xSnowflake.Gene = 45 // 正版档案缓存
+	GeneForGameProfileJoinLog xSnowflake.Gene = 46 // 游戏档案进服记录
+	GeneForLibraryTag        xSnowflake.Gene = 47 // 资源库标签
+	GeneForLibraryLike       xSnowflake.Gene = 48 // 资源库点赞
+	GeneForLibraryReport     xSnowflake.Gene = 49 // 资源库举报
+	GeneForLibraryTextureVersion xSnowflake.Gene = 50 // 资源库纹理版本
+	GeneForLibraryCampaign xSnowflake.Gene = 51 // 资源发放活动
+	GeneForLibraryCampaignGrant xSnowflake.Gene = 52 // 资源发放活动用户记录
+	GeneForRedeemCodeBatch xSnowflake.Gene = 53 // 兑换码生成批次
+	GeneForRedeemCode xSnowflake.Gene = 54 // 兑换码
+	GeneForRedeemCodeRedemption xSnowflake.Gene = 55 // 兑换码兑换记录
+	GeneForLibraryQuotaLog xSnowflake.Gene = 56 // 资源库配额日志
+	GeneForStorageFile xSnowflake.Gene = 57 // 对象存储文件登记
+	GeneForStorageReconcileReport xSnowflake.Gene = 58 // 存储对账报告
+	GeneForStorageReconcileIssue xSnowflake.Gene = 59 // 存储对账问题
+	GeneForGameProfileOutfit xSnowflake.Gene = 60 // 游戏档案外观预设
+	GeneForLibraryShare xSnowflake.Gene = 61 // 资源私有分享链接
+	GeneForLibraryShareClaim xSnowflake.Gene = 62 // 资源私有分享领取记录
+	GeneForGameProfileNameReservation xSnowflake.Gene = 63 // 游戏档案名称保留
+	GeneForGameProfileTransfer xSnowflake.Gene = 64 // 游戏档案转移请求
+	GeneForGameProfileNameHistory xSnowflake.Gene = 65 // 游戏档案名称历史
+	GeneForGameProfileNameRule xSnowflake.Gene = 66 // 游戏档案名称规则（保留名称与屏蔽规则）
+	GeneForGameProfileAuditLog xSnowflake.Gene = 67 // 游戏档案管理员操作审计记录
 )
@@ -1,9 +1,12 @@
 package entity
 
 import (
+	"time"
+
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
 	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
 )
 
 // CapeLibrary 披风库实体，存储系统内置或用户上传的披风资源。
@@ -13,12 +16,27 @@ type CapeLibrary struct {
 	Name               string                  `gorm:"not null;type:varchar(64);comment:披风名称" json:"name"`                                                 // 披风名称
 	Texture            int64                   `gorm:"not null;type:bigint;comment:披风纹理文件ID(雪花算法)" json:"texture"`                                         // 披风纹理文件ID(雪花算法)
 	TextureHash        string                  `gorm:"not null;type:char(64);uniqueIndex:uk_cape_library_texture_hash;comment:披风纹理哈希" json:"texture_hash"` // 披风纹理哈希
+	TextureVersion     int32                   `gorm:"not null;type:integer;default:1;comment:当前纹理版本号" json:"texture_version"`                          // 当前纹理版本号
+	PendingTextureVersion *int32               `gorm:"type:integer;comment:待审核纹理版本号(审核通过后生效)" json:"pending_texture_version,omitempty"`          // 待审核纹理版本号（审核通过后替换当前纹理）
 	IsPublic           bool                    `gorm:"not null;type:boolean;default:false;index:idx_cape_library_is_public;comment:是否公开" json:"is_public"` // 是否公开
+	LikeCount          int64                   `gorm:"not null;type:bigint;default:0;comment:点赞数" json:"like_count"`                                       // 点赞数
+	CollectCount       int64                   `gorm:"not null;type:bigint;default:0;comment:收藏数" json:"collect_count"`                                    // 收藏数
+	ReviewStatus       entityType.ReviewStatus `gorm:"not null;type:smallint;default:2;index:idx_cape_library_review_status;comment:审核状态(1=pending,2=approved,3=rejected)" json:"review_status"` // 审核状态
+	ReviewReason       *string                 `gorm:"type:varchar(255);comment:审核驳回原因" json:"review_reason,omitempty"`                                      // 审核驳回原因
+	ReviewedBy         *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:审核管理员ID" json:"reviewed_by,omitempty"`                                          // 审核管理员ID
+	ReviewedAt         *time.Time              `gorm:"type:timestamptz;comment:审核时间" json:"reviewed_at,omitempty"`                                           // 审核时间
+	ReportCount        int64                   `gorm:"not null;type:bigint;default:0;comment:待处理举报数" json:"report_count"`                                 // 待处理举报数
+	IsHidden           bool                    `gorm:"not null;type:boolean;default:false;index:idx_cape_library_is_hidden;comment:是否因举报自动隐藏" json:"is_hidden"` // 是否因举报自动隐藏
+	IsDefault          bool                    `gorm:"not null;type:boolean;default:false;comment:是否为新用户默认发放的系统披风" json:"is_default"` // 是否为新用户默认发放的系统披风
+	IsRetired          bool                    `gorm:"not null;type:boolean;default:false;comment:系统披风是否已停用" json:"is_retired"` // 系统披风是否已停用
+	PerceptualHash     *int64                  `gorm:"type:bigint;comment:披风纹理感知哈希(pHash，非 PNG 或历史纹理为空)" json:"perceptual_hash,omitempty"` // 披风纹理感知哈希
+	SimilarToID        *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:疑似近似重复的已公开披风ID" json:"similar_to_id,omitempty"` // 疑似近似重复的已公开披风ID
 
 	// ----------
 	//  外键约束
 	// ----------
-	User *User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:SET NULL;comment:关联用户" json:"user,omitempty"` // 关联用户
+	User *User        `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:SET NULL;comment:关联用户" json:"user,omitempty"` // 关联用户
+	Tags []LibraryTag `gorm:"many2many:cape_library_tag;constraint:OnDelete:CASCADE;comment:关联标签" json:"tags,omitempty"`       // 关联标签
 }
 
 // GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
 	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
@@ -12,9 +14,13 @@ type GameProfile struct {
 	xModels.BaseEntity                         // 嵌入基础实体字段
 	UserID             xSnowflake.SnowflakeID  `gorm:"not null;index:idx_user_id;comment:关联用户ID" json:"user_id"`                                            // 关联用户ID
 	UUID               uuid.UUID               `gorm:"unique;not null;type:varchar(36);comment:Minecraft UUID" json:"uuid"`                                 // Minecraft UUID
-	Name               string                  `gorm:"not null;type:varchar(32);comment:游戏内用户名" json:"name"`                                                // 游戏内用户名
+	Name               string                  `gorm:"not null;type:varchar(32);comment:游戏内用户名" json:"name"`                                                // 游戏内用户名（保留显示大小写，唯一性不区分大小写）
 	SkinLibraryID      *xSnowflake.SnowflakeID `gorm:"type:bigint;index:idx_game_profile_skin_library_id;comment:关联皮肤库ID" json:"skin_library_id,omitempty"` // 关联皮肤库ID
 	CapeLibraryID      *xSnowflake.SnowflakeID `gorm:"type:bigint;index:idx_game_profile_cape_library_id;comment:关联披风库ID" json:"cape_library_id,omitempty"` // 关联披风库ID
+	LastSeenAt         *time.Time              `gorm:"index:idx_game_profile_last_seen_at;comment:最近进服时间" json:"last_seen_at,omitempty"`                   // 最近进服时间
+	NameChangedAt      *time.Time              `gorm:"type:timestamptz;comment:最近一次改名时间（改名冷却起点）" json:"name_changed_at,omitempty"`                          // 最近一次改名时间
+	LockedAt           *time.Time              `gorm:"type:timestamptz;comment:管理员锁定时间（为空表示未锁定）" json:"locked_at,omitempty"`                                // 管理员锁定时间
+	LockReason         *string                 `gorm:"type:varchar(255);comment:锁定原因" json:"lock_reason,omitempty"`                                         // 锁定原因
 
 	// ----------
 	//  外键约束
@@ -28,3 +34,10 @@ type GameProfile struct {
 func (_ *GameProfile) GetGene() xSnowflake.Gene {
 	return bConst.GeneForGameProfile
 }
+
+// IsLocked 判断档案是否已被管理员锁定。
+//
+// 锁定的档案无法通过 hasJoined 进入服务器，持有者也无法改名、更换外观、删除或转移该档案。
+func (g *GameProfile) IsLocked() bool {
+	return g.LockedAt != nil
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// GameProfileAuditAction 游戏档案管理员操作类型。
+type GameProfileAuditAction uint8
+
+const (
+	GameProfileAuditActionRename    GameProfileAuditAction = 1 // 强制改名
+	GameProfileAuditActionClearSkin GameProfileAuditAction = 2 // 清除装备的皮肤
+	GameProfileAuditActionLock      GameProfileAuditAction = 3 // 锁定档案
+	GameProfileAuditActionUnlock    GameProfileAuditAction = 4 // 解除锁定
+	GameProfileAuditActionDelete    GameProfileAuditAction = 5 // 删除档案
+)
+
+// GameProfileAuditLog 游戏档案管理员操作审计记录实体。
+//
+// 管理员绕过归属校验处理任意用户的档案时，与业务变更在同一事务内写入。
+// 档案删除后审计记录仍需保留，因此 ProfileID 不设外键，并冗余 UUID 与操作时的名称。
+type GameProfileAuditLog struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	ProfileID          xSnowflake.SnowflakeID `gorm:"not null;index:idx_game_profile_audit_log_profile_id;comment:游戏档案ID" json:"profile_id"` // 游戏档案ID
+	ProfileUUID        string                 `gorm:"not null;type:varchar(36);comment:游戏档案UUID" json:"profile_uuid"`                        // 游戏档案UUID
+	ProfileName        string                 `gorm:"not null;type:varchar(32);comment:操作时的游戏内用户名" json:"profile_name"`                      // 操作时的游戏内用户名
+	UserID             xSnowflake.SnowflakeID `gorm:"not null;index:idx_game_profile_audit_log_user_id;comment:操作时的持有者用户ID" json:"user_id"`  // 操作时的持有者用户ID
+	OperatorID         xSnowflake.SnowflakeID `gorm:"not null;comment:操作管理员用户ID" json:"operator_id"`                                         // 操作管理员用户ID
+	Action             GameProfileAuditAction `gorm:"not null;type:smallint;comment:操作类型(1=改名,2=清除皮肤,3=锁定,4=解锁,5=删除)" json:"action"`         // 操作类型
+	Reason             string                 `gorm:"not null;type:varchar(255);comment:操作原因" json:"reason"`                                 // 操作原因
+	Detail             *string                `gorm:"type:varchar(255);comment:变更详情" json:"detail,omitempty"`                                // 变更详情（如改名前后的名称）
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *GameProfileAuditLog) GetGene() xSnowflake.Gene {
+	return bConst.GeneForGameProfileAuditLog
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// GameProfileJoinLog 游戏档案进服记录实体，在 Yggdrasil hasJoined 验证通过时写入，用于在线统计与游玩历史。
+type GameProfileJoinLog struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	GameProfileID      xSnowflake.SnowflakeID `gorm:"not null;index:idx_game_profile_join_log_profile_joined,priority:1;comment:关联游戏档案ID" json:"game_profile_id"`                                 // 关联游戏档案ID
+	UserID             xSnowflake.SnowflakeID `gorm:"not null;index:idx_game_profile_join_log_user_id;comment:关联用户ID" json:"user_id"`                                                             // 关联用户ID
+	ProfileName        string                 `gorm:"not null;type:varchar(32);comment:进服时的游戏内用户名" json:"profile_name"`                                                                           // 进服时的游戏内用户名
+	ServerAddress      *string                `gorm:"type:varchar(64);comment:发起验证的服务端地址" json:"server_address,omitempty"`                                                                        // 发起验证的服务端地址（未知时为空）
+	ClientIP           *string                `gorm:"type:varchar(64);comment:客户端IP" json:"client_ip,omitempty"`                                                                                  // 客户端IP
+	JoinedAt           time.Time              `gorm:"not null;index:idx_game_profile_join_log_joined_at;index:idx_game_profile_join_log_profile_joined,priority:2;comment:进服时间" json:"joined_at"` // 进服时间
+
+	// ----------
+	//  外键约束
+	// ----------
+	GameProfile *GameProfile `gorm:"foreignKey:GameProfileID;references:ID;constraint:OnDelete:CASCADE;comment:关联游戏档案" json:"game_profile,omitempty"` // 关联游戏档案
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *GameProfileJoinLog) GetGene() xSnowflake.Gene {
+	return bConst.GeneForGameProfileJoinLog
+}
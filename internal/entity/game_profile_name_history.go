@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// GameProfileNameSource 游戏档案名称来源类型，标识历史记录中的名称是如何产生的。
+type GameProfileNameSource uint8
+
+const (
+	GameProfileNameSourceCreate GameProfileNameSource = 1 // 创建 — 档案创建时的初始名称（含历史档案首次改名时补录的原名称）
+	GameProfileNameSourceRename GameProfileNameSource = 2 // 改名 — 持有者自行修改
+	GameProfileNameSourceAdmin  GameProfileNameSource = 3 // 管理员改名 — 管理员强制修改（如处理违规名称）
+)
+
+// GameProfileNameHistory 游戏档案名称历史实体。
+//
+// 每条记录表示档案自 ChangedAt 起使用 Name，按 ChangedAt 升序即为完整的改名轨迹，
+// 对应 Mojang names 接口中的 name / changedToAt。UserID 为该名称生效时的持有者。
+type GameProfileNameHistory struct {
+	xModels.BaseEntity                         // 嵌入基础实体字段
+	ProfileID          xSnowflake.SnowflakeID  `gorm:"not null;index:idx_game_profile_name_history_profile_id;comment:关联游戏档案ID" json:"profile_id"` // 关联游戏档案ID
+	UserID             xSnowflake.SnowflakeID  `gorm:"not null;comment:名称生效时的持有者用户ID" json:"user_id"`                                              // 名称生效时的持有者用户ID
+	Name               string                  `gorm:"not null;type:varchar(32);comment:游戏内用户名" json:"name"`                                       // 游戏内用户名
+	Source             GameProfileNameSource   `gorm:"not null;type:smallint;comment:名称来源(1=创建,2=改名,3=管理员改名)" json:"source"`                       // 名称来源
+	OperatorID         *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:操作者用户ID（管理员代为操作时记录）" json:"operator_id,omitempty"`                       // 操作者用户ID
+	ChangedAt          time.Time               `gorm:"not null;type:timestamptz;comment:名称生效时间" json:"changed_at"`                                 // 名称生效时间
+
+	// ----------
+	//  外键约束
+	// ----------
+	Profile *GameProfile `gorm:"foreignKey:ProfileID;references:ID;constraint:OnDelete:CASCADE;comment:关联游戏档案" json:"profile,omitempty"` // 关联游戏档案
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *GameProfileNameHistory) GetGene() xSnowflake.Gene {
+	return bConst.GeneForGameProfileNameHistory
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// GameProfileNameReservation 游戏档案名称保留实体。
+//
+// 游戏档案删除或改名后，原名称在 ExpiresAt 之前仅允许原持有者（UserID）重新使用，
+// 防止名称在释放瞬间被他人抢注。到期后记录不再生效，无需主动清理。
+type GameProfileNameReservation struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	Name               string                 `gorm:"not null;type:varchar(32);index:idx_game_profile_name_reservation_name;comment:保留的游戏内用户名" json:"name"`          // 保留的游戏内用户名
+	UserID             xSnowflake.SnowflakeID `gorm:"not null;index:idx_game_profile_name_reservation_user_id;comment:原持有者用户ID" json:"user_id"`                      // 原持有者用户ID
+	ProfileUUID        string                 `gorm:"not null;type:varchar(36);comment:原游戏档案UUID" json:"profile_uuid"`                                               // 原游戏档案 UUID
+	ExpiresAt          time.Time              `gorm:"not null;type:timestamptz;index:idx_game_profile_name_reservation_expires_at;comment:保留到期时间" json:"expires_at"` // 保留到期时间
+
+	// ----------
+	//  外键约束
+	// ----------
+	User *User `gorm:"constraint:OnDelete:CASCADE;comment:关联用户" json:"user,omitempty"` // 原持有者
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *GameProfileNameReservation) GetGene() xSnowflake.Gene {
+	return bConst.GeneForGameProfileNameReservation
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// GameProfileNameRuleKind 游戏档案名称规则类别。
+type GameProfileNameRuleKind uint8
+
+const (
+	GameProfileNameRuleKindReserved GameProfileNameRuleKind = 1 // 保留 — 工作人员、品牌等名称，仅 AllowedUserID 可使用
+	GameProfileNameRuleKindBlocked  GameProfileNameRuleKind = 2 // 屏蔽 — 违规词、冒充管理员等，任何人均不可使用
+)
+
+// GameProfileNameMatchType 游戏档案名称规则匹配方式，所有匹配均不区分大小写。
+type GameProfileNameMatchType uint8
+
+const (
+	GameProfileNameMatchExact    GameProfileNameMatchType = 1 // 完全匹配
+	GameProfileNameMatchPrefix   GameProfileNameMatchType = 2 // 前缀匹配（如 "Admin_"）
+	GameProfileNameMatchContains GameProfileNameMatchType = 3 // 包含匹配（违规词表）
+	GameProfileNameMatchRegex    GameProfileNameMatchType = 4 // 正则匹配
+)
+
+// GameProfileNameRule 游戏档案名称规则实体。
+//
+// 由管理员维护，创建档案与修改用户名时校验：命中屏蔽规则的名称任何人不可使用；
+// 命中保留规则的名称仅 AllowedUserID 指定的用户可使用（为空表示无人可用）。
+type GameProfileNameRule struct {
+	xModels.BaseEntity                          // 嵌入基础实体字段
+	Kind               GameProfileNameRuleKind  `gorm:"not null;type:smallint;index:idx_game_profile_name_rule_kind;comment:规则类别(1=保留,2=屏蔽)" json:"kind"`           // 规则类别
+	MatchType          GameProfileNameMatchType `gorm:"not null;type:smallint;comment:匹配方式(1=完全,2=前缀,3=包含,4=正则)" json:"match_type"`                                 // 匹配方式
+	Pattern            string                   `gorm:"not null;type:varchar(128);comment:匹配内容" json:"pattern"`                                                     // 匹配内容
+	AllowedUserID      *xSnowflake.SnowflakeID  `gorm:"type:bigint;comment:允许使用保留名称的用户ID" json:"allowed_user_id,omitempty"`                                         // 允许使用保留名称的用户ID
+	Reason             string                   `gorm:"type:varchar(255);comment:规则说明" json:"reason,omitempty"`                                                     // 规则说明
+	IsEnabled          bool                     `gorm:"not null;type:boolean;default:true;index:idx_game_profile_name_rule_enabled;comment:是否启用" json:"is_enabled"` // 是否启用
+
+	compiledPattern *regexp.Regexp // 预编译的正则表达式（仅正则规则，由 CompilePattern 写入，不持久化）
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *GameProfileNameRule) GetGene() xSnowflake.Gene {
+	return bConst.GeneForGameProfileNameRule
+}
+
+// CompilePattern 预编译正则规则的匹配内容，供 Matches 复用；非正则规则无需编译，直接返回 nil。
+//
+// 应在规则从数据库或缓存加载后调用一次，避免每次匹配都重新编译。
+func (r *GameProfileNameRule) CompilePattern() error {
+	if r.MatchType != GameProfileNameMatchRegex {
+		return nil
+	}
+	re, err := CompileGameProfileNamePattern(r.Pattern)
+	if err != nil {
+		return err
+	}
+	r.compiledPattern = re
+	return nil
+}
+
+// Matches 判断名称是否命中该规则（不区分大小写）。
+//
+// 正则规则使用 CompilePattern 预编译的表达式，未编译或无法编译时视为未命中，
+// 写入前应通过 CompileGameProfileNamePattern 校验。
+func (r *GameProfileNameRule) Matches(name string) bool {
+	lowerName := strings.ToLower(name)
+	lowerPattern := strings.ToLower(r.Pattern)
+	switch r.MatchType {
+	case GameProfileNameMatchExact:
+		return lowerName == lowerPattern
+	case GameProfileNameMatchPrefix:
+		return strings.HasPrefix(lowerName, lowerPattern)
+	case GameProfileNameMatchContains:
+		return strings.Contains(lowerName, lowerPattern)
+	case GameProfileNameMatchRegex:
+		return r.compiledPattern != nil && r.compiledPattern.MatchString(name)
+	default:
+		return false
+	}
+}
+
+// CompileGameProfileNamePattern 以不区分大小写的方式编译名称规则中的正则表达式。
+func CompileGameProfileNamePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
@@ -0,0 +1,62 @@
+package entity
+
+import "testing"
+
+// TestGameProfileNameRuleMatches 验证各匹配方式均不区分大小写，且正则规则仅在预编译后生效。
+func TestGameProfileNameRuleMatches(t *testing.T) {
+	cases := []struct {
+		name      string
+		matchType GameProfileNameMatchType
+		pattern   string
+		compile   bool
+		input     string
+		want      bool
+	}{
+		{name: "完全匹配", matchType: GameProfileNameMatchExact, pattern: "Notch", input: "notch", want: true},
+		{name: "完全匹配不接受前缀", matchType: GameProfileNameMatchExact, pattern: "Notch", input: "Notch_2", want: false},
+		{name: "前缀匹配", matchType: GameProfileNameMatchPrefix, pattern: "Admin_", input: "ADMIN_steve", want: true},
+		{name: "前缀匹配不接受中间出现", matchType: GameProfileNameMatchPrefix, pattern: "Admin_", input: "the_admin_", want: false},
+		{name: "包含匹配", matchType: GameProfileNameMatchContains, pattern: "mod", input: "xXModeratorXx", want: true},
+		{name: "包含匹配未命中", matchType: GameProfileNameMatchContains, pattern: "mod", input: "Steve", want: false},
+		{name: "正则匹配", matchType: GameProfileNameMatchRegex, pattern: "^staff_[0-9]+$", compile: true, input: "STAFF_42", want: true},
+		{name: "正则匹配未命中", matchType: GameProfileNameMatchRegex, pattern: "^staff_[0-9]+$", compile: true, input: "staff_x", want: false},
+		{name: "正则规则未预编译视为未命中", matchType: GameProfileNameMatchRegex, pattern: "^staff_[0-9]+$", input: "staff_42", want: false},
+		{name: "正则无法编译视为未命中", matchType: GameProfileNameMatchRegex, pattern: "([a-z", compile: true, input: "([a-z", want: false},
+		{name: "未知匹配方式", matchType: 0, pattern: "steve", input: "steve", want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := &GameProfileNameRule{MatchType: tc.matchType, Pattern: tc.pattern}
+			if tc.compile {
+				_ = rule.CompilePattern()
+			}
+			if got := rule.Matches(tc.input); got != tc.want {
+				t.Errorf("Matches(%q) = %v, 期望 %v（规则 %q）", tc.input, got, tc.want, tc.pattern)
+			}
+		})
+	}
+}
+
+// TestGameProfileNameRuleCompilePattern 验证仅正则规则需要编译，非法正则返回错误。
+func TestGameProfileNameRuleCompilePattern(t *testing.T) {
+	cases := []struct {
+		name      string
+		matchType GameProfileNameMatchType
+		pattern   string
+		wantErr   bool
+	}{
+		{name: "合法正则", matchType: GameProfileNameMatchRegex, pattern: "^admin"},
+		{name: "非法正则", matchType: GameProfileNameMatchRegex, pattern: "([a-z", wantErr: true},
+		{name: "非正则规则不编译", matchType: GameProfileNameMatchContains, pattern: "([a-z"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := &GameProfileNameRule{MatchType: tc.matchType, Pattern: tc.pattern}
+			if err := rule.CompilePattern(); (err != nil) != tc.wantErr {
+				t.Errorf("CompilePattern() 错误 = %v, 期望返回错误: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
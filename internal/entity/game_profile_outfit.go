@@ -0,0 +1,32 @@
+package entity
+
+import (
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// GameProfileOutfit 游戏档案外观预设实体，保存用户常用的皮肤 + 披风组合。
+//
+// 预设归属于用户而非单个档案，可应用到该用户的任意游戏档案；
+// 皮肤或披风为空表示应用时卸下对应部位。应用时会按 UserSkinLibrary /
+// UserCapeLibrary 重新校验持有关系，预设本身不保证资源仍可装备。
+type GameProfileOutfit struct {
+	xModels.BaseEntity                         // 嵌入基础实体字段
+	UserID             xSnowflake.SnowflakeID  `gorm:"not null;uniqueIndex:uk_game_profile_outfit_user_name;comment:关联用户ID" json:"user_id"`                        // 关联用户ID
+	Name               string                  `gorm:"not null;type:varchar(32);uniqueIndex:uk_game_profile_outfit_user_name;comment:预设名称" json:"name"`            // 预设名称
+	SkinLibraryID      *xSnowflake.SnowflakeID `gorm:"type:bigint;index:idx_game_profile_outfit_skin_library_id;comment:关联皮肤库ID" json:"skin_library_id,omitempty"` // 关联皮肤库ID
+	CapeLibraryID      *xSnowflake.SnowflakeID `gorm:"type:bigint;index:idx_game_profile_outfit_cape_library_id;comment:关联披风库ID" json:"cape_library_id,omitempty"` // 关联披风库ID
+
+	// ----------
+	//  外键约束
+	// ----------
+	User        *User        `gorm:"constraint:OnDelete:CASCADE;comment:关联用户" json:"user,omitempty"`                                                  // 关联用户
+	SkinLibrary *SkinLibrary `gorm:"foreignKey:SkinLibraryID;references:ID;constraint:OnDelete:SET NULL;comment:关联皮肤库" json:"skin_library,omitempty"` // 关联皮肤库
+	CapeLibrary *CapeLibrary `gorm:"foreignKey:CapeLibraryID;references:ID;constraint:OnDelete:SET NULL;comment:关联披风库" json:"cape_library,omitempty"` // 关联披风库
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *GameProfileOutfit) GetGene() xSnowflake.Gene {
+	return bConst.GeneForGameProfileOutfit
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// GameProfileTransferStatus 游戏档案转移请求状态类型。
+type GameProfileTransferStatus uint8
+
+const (
+	GameProfileTransferStatusPending   GameProfileTransferStatus = 1 // 待接收 — 等待接收方接受或拒绝（到期后视为已过期）
+	GameProfileTransferStatusAccepted  GameProfileTransferStatus = 2 // 已接受 — 档案已转移至接收方
+	GameProfileTransferStatusRejected  GameProfileTransferStatus = 3 // 已拒绝 — 接收方拒绝接收
+	GameProfileTransferStatusCancelled GameProfileTransferStatus = 4 // 已取消 — 发起方撤回，或档案在接受前已不再属于发起方
+)
+
+// GameProfileTransfer 游戏档案转移请求实体。
+//
+// 由档案持有者（FromUserID）发起，接收方（ToUserID）需在 ExpiresAt 之前接受，
+// 接受后档案保持原 UUID 与名称归属到接收方。同一档案同一时间至多存在一个有效的待接收请求。
+type GameProfileTransfer struct {
+	xModels.BaseEntity                           // 嵌入基础实体字段
+	ProfileID          xSnowflake.SnowflakeID    `gorm:"not null;index:idx_game_profile_transfer_profile_id;comment:关联游戏档案ID" json:"profile_id"`    // 关联游戏档案ID
+	FromUserID         xSnowflake.SnowflakeID    `gorm:"not null;index:idx_game_profile_transfer_from_user_id;comment:发起方用户ID" json:"from_user_id"` // 发起方用户ID
+	ToUserID           xSnowflake.SnowflakeID    `gorm:"not null;index:idx_game_profile_transfer_to_user_id;comment:接收方用户ID" json:"to_user_id"`     // 接收方用户ID
+	ProfileUUID        string                    `gorm:"not null;type:varchar(36);comment:游戏档案UUID" json:"profile_uuid"`                            // 游戏档案 UUID
+	ProfileName        string                    `gorm:"not null;type:varchar(32);comment:发起时的档案用户名" json:"profile_name"`                           // 发起时的档案用户名
+	Status             GameProfileTransferStatus `gorm:"not null;type:smallint;default:1;comment:请求状态(1=待接收,2=已接受,3=已拒绝,4=已取消)" json:"status"`      // 请求状态
+	Remark             *string                   `gorm:"type:varchar(200);comment:发起方附言" json:"remark,omitempty"`                                   // 发起方附言
+	ExpiresAt          time.Time                 `gorm:"not null;type:timestamptz;comment:到期时间" json:"expires_at"`                                  // 到期时间
+	RespondedAt        *time.Time                `gorm:"type:timestamptz;comment:处理时间（接受/拒绝/取消）" json:"responded_at,omitempty"`                     // 处理时间
+
+	// ----------
+	//  外键约束
+	// ----------
+	Profile  *GameProfile `gorm:"foreignKey:ProfileID;references:ID;constraint:OnDelete:CASCADE;comment:关联游戏档案" json:"profile,omitempty"` // 关联游戏档案
+	FromUser *User        `gorm:"foreignKey:FromUserID;references:ID;constraint:OnDelete:CASCADE;comment:发起方" json:"from_user,omitempty"` // 发起方
+	ToUser   *User        `gorm:"foreignKey:ToUserID;references:ID;constraint:OnDelete:CASCADE;comment:接收方" json:"to_user,omitempty"`     // 接收方
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *GameProfileTransfer) GetGene() xSnowflake.Gene {
+	return bConst.GeneForGameProfileTransfer
+}
+
+// IsPending 判断转移请求在指定时间是否仍等待接收方处理（待接收且未到期）。
+func (t *GameProfileTransfer) IsPending(now time.Time) bool {
+	return t.Status == GameProfileTransferStatusPending && now.Before(t.ExpiresAt)
+}
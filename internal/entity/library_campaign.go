@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryCampaign 资源发放活动实体，记录向一批筛选用户批量赠送皮肤/披风的任务。
+//
+// 筛选条件之间为“且”关系，FilterUserIDs 为空表示不限定用户 ID。
+// 后台任务按用户 ID 升序分批处理，Cursor 记录最后处理的用户 ID，用于中断后恢复。
+type LibraryCampaign struct {
+	xModels.BaseEntity                               // 嵌入基础实体字段
+	Name                   string                    `gorm:"not null;type:varchar(64);comment:活动名称" json:"name"`                                                                                                           // 活动名称
+	Kind                   entityType.LibraryKind    `gorm:"not null;type:smallint;comment:资源种类(1=skin,2=cape)" json:"kind"`                                                                                               // 资源种类
+	LibraryID              xSnowflake.SnowflakeID    `gorm:"not null;index:idx_library_campaign_library_id;comment:发放的资源库记录ID" json:"library_id"`                                                                          // 发放的资源库记录ID
+	AssignmentType         entityType.AssignmentType `gorm:"not null;type:smallint;default:2;comment:发放关联类型(2=gift,3=admin)" json:"assignment_type"`                                                                       // 发放关联类型
+	Status                 entityType.CampaignStatus `gorm:"not null;type:smallint;default:1;index:idx_library_campaign_status;comment:活动状态(1=pending,2=running,3=completed,4=failed,5=revoking,6=revoked)" json:"status"` // 活动状态
+	FilterRoleName         *string                   `gorm:"type:varchar(32);comment:筛选条件-角色名称" json:"filter_role_name,omitempty"`                                                                                         // 筛选条件-角色名称
+	FilterRegisteredAfter  *time.Time                `gorm:"type:timestamptz;comment:筛选条件-注册时间下限" json:"filter_registered_after,omitempty"`                                                                                // 筛选条件-注册时间下限
+	FilterRegisteredBefore *time.Time                `gorm:"type:timestamptz;comment:筛选条件-注册时间上限" json:"filter_registered_before,omitempty"`                                                                               // 筛选条件-注册时间上限
+	FilterHasProfile       *bool                     `gorm:"type:boolean;comment:筛选条件-是否拥有游戏档案" json:"filter_has_profile,omitempty"`                                                                                       // 筛选条件-是否拥有游戏档案
+	FilterUserIDs          []xSnowflake.SnowflakeID  `gorm:"type:text;serializer:json;comment:筛选条件-指定用户ID列表" json:"filter_user_ids,omitempty"`                                                                             // 筛选条件-指定用户ID列表
+	Cursor                 xSnowflake.SnowflakeID    `gorm:"not null;type:bigint;default:0;comment:最后处理的用户ID" json:"cursor"`                                                                                               // 最后处理的用户ID
+	MatchedCount           int64                     `gorm:"not null;type:bigint;default:0;comment:开始执行时匹配的用户数" json:"matched_count"`                                                                                      // 开始执行时匹配的用户数
+	GrantedCount           int64                     `gorm:"not null;type:bigint;default:0;comment:已发放数" json:"granted_count"`                                                                                             // 已发放数
+	SkippedCount           int64                     `gorm:"not null;type:bigint;default:0;comment:已持有跳过数" json:"skipped_count"`                                                                                           // 已持有跳过数
+	FailedCount            int64                     `gorm:"not null;type:bigint;default:0;comment:发放失败数" json:"failed_count"`                                                                                             // 发放失败数
+	RevokedCount           int64                     `gorm:"not null;type:bigint;default:0;comment:已收回数" json:"revoked_count"`                                                                                             // 已收回数
+	LastError              *string                   `gorm:"type:varchar(255);comment:后台任务最近一次中断原因" json:"last_error,omitempty"`                                                                                           // 后台任务最近一次中断原因
+	CreatedBy              xSnowflake.SnowflakeID    `gorm:"not null;type:bigint;comment:创建管理员ID" json:"created_by"`                                                                                                       // 创建管理员ID
+	StartedAt              *time.Time                `gorm:"type:timestamptz;comment:开始执行时间" json:"started_at,omitempty"`                                                                                                  // 开始执行时间
+	FinishedAt             *time.Time                `gorm:"type:timestamptz;comment:发放完成时间" json:"finished_at,omitempty"`                                                                                                 // 发放完成时间
+	RevokedBy              *xSnowflake.SnowflakeID   `gorm:"type:bigint;comment:撤销管理员ID" json:"revoked_by,omitempty"`                                                                                                      // 撤销管理员ID
+	RevokedAt              *time.Time                `gorm:"type:timestamptz;comment:撤销完成时间" json:"revoked_at,omitempty"`                                                                                                  // 撤销完成时间
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryCampaign) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryCampaign
+}
+
+func (c *LibraryCampaign) BeforeCreate(_ *gorm.DB) error {
+	if !c.Kind.IsValid() {
+		return fmt.Errorf("无效的资源种类: %d", c.Kind)
+	}
+	if c.AssignmentType != entityType.AssignmentTypeGift && c.AssignmentType != entityType.AssignmentTypeAdmin {
+		return fmt.Errorf("无效的发放关联类型: %d", c.AssignmentType)
+	}
+	return nil
+}
+
+// LibraryCampaignGrant 资源发放活动的单用户处理记录。
+//
+// (CampaignID, UserID) 唯一，作为每个用户的幂等键：任务重试或恢复时已处理的用户不会重复发放。
+type LibraryCampaignGrant struct {
+	xModels.BaseEntity                                // 嵌入基础实体字段
+	CampaignID         xSnowflake.SnowflakeID         `gorm:"not null;uniqueIndex:uk_library_campaign_grant_user;comment:活动ID" json:"campaign_id"`                                               // 活动ID
+	UserID             xSnowflake.SnowflakeID         `gorm:"not null;uniqueIndex:uk_library_campaign_grant_user;index:idx_library_campaign_grant_user_id;comment:用户ID" json:"user_id"`          // 用户ID
+	Status             entityType.CampaignGrantStatus `gorm:"not null;type:smallint;index:idx_library_campaign_grant_status;comment:处理结果(1=granted,2=skipped,3=failed,4=revoked)" json:"status"` // 处理结果
+	Message            *string                        `gorm:"type:varchar(255);comment:失败原因" json:"message,omitempty"`                                                                           // 失败原因
+
+	// ----------
+	//  外键约束
+	// ----------
+	Campaign *LibraryCampaign `gorm:"foreignKey:CampaignID;references:ID;constraint:OnDelete:CASCADE;comment:关联活动" json:"campaign,omitempty"` // 关联活动
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryCampaignGrant) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryCampaignGrant
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"fmt"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryLike 资源库点赞实体，记录用户对公开皮肤/披风的点赞关系。
+//
+// 通过 Kind + LibraryID 多态指向 SkinLibrary 或 CapeLibrary，
+// 点赞总数冗余存储在资源记录的 LikeCount 字段，用于画廊排序。
+type LibraryLike struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	UserID             xSnowflake.SnowflakeID `gorm:"not null;uniqueIndex:uk_library_like_user_target;comment:点赞用户ID" json:"user_id"`                                                       // 点赞用户ID
+	Kind               entityType.LibraryKind `gorm:"not null;type:smallint;uniqueIndex:uk_library_like_user_target;index:idx_library_like_target;comment:资源种类(1=skin,2=cape)" json:"kind"` // 资源种类
+	LibraryID          xSnowflake.SnowflakeID `gorm:"not null;uniqueIndex:uk_library_like_user_target;index:idx_library_like_target;comment:资源库记录ID" json:"library_id"`                     // 资源库记录ID
+
+	// ----------
+	//  外键约束
+	// ----------
+	User *User `gorm:"constraint:OnDelete:CASCADE;comment:关联用户" json:"user,omitempty"` // 关联用户
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryLike) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryLike
+}
+
+func (l *LibraryLike) BeforeCreate(_ *gorm.DB) error {
+	if l.Kind.IsValid() {
+		return nil
+	}
+	return fmt.Errorf("无效的资源种类: %d", l.Kind)
+}
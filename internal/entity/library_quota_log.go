@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"fmt"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryQuotaLog 资源库配额变更日志实体，每条记录对应一个配额分项的一次已使用额度或总额度变化。
+type LibraryQuotaLog struct {
+	xModels.BaseEntity
+	UserID         xSnowflake.SnowflakeID        `gorm:"not null;index:idx_library_quota_log_user_id;comment:关联用户ID" json:"user_id"`                                       // 关联用户ID
+	OpType         entityType.LibraryQuotaOpType `gorm:"not null;type:smallint;index:idx_library_quota_log_op_type;comment:操作类型" json:"op_type"`                           // 操作类型
+	Bucket         entityType.LibraryQuotaBucket `gorm:"not null;type:smallint;comment:配额分项(1=skins_public,2=skins_private,3=capes_public,4=capes_private)" json:"bucket"` // 配额分项
+	Delta          int32                         `gorm:"not null;comment:额度变化值(正增负减，已使用额度优先)" json:"delta"`                                                                // 额度变化值
+	BeforeUsed     int32                         `gorm:"not null;comment:变更前已使用额度" json:"before_used"`                                                                     // 变更前已使用额度
+	AfterUsed      int32                         `gorm:"not null;comment:变更后已使用额度" json:"after_used"`                                                                      // 变更后已使用额度
+	BeforeTotal    int32                         `gorm:"not null;comment:变更前总额度" json:"before_total"`                                                                      // 变更前总额度
+	AfterTotal     int32                         `gorm:"not null;comment:变更后总额度" json:"after_total"`                                                                       // 变更后总额度
+	IdempotencyKey string                        `gorm:"not null;type:varchar(255);uniqueIndex:uk_library_quota_log_idempotency_key;comment:幂等键" json:"idempotency_key"`   // 幂等键
+	RefLibraryID   *xSnowflake.SnowflakeID       `gorm:"type:bigint;index:idx_library_quota_log_ref_library_id;comment:关联皮肤或披风ID" json:"ref_library_id,omitempty"`         // 关联皮肤或披风ID
+	OperatorID     *xSnowflake.SnowflakeID       `gorm:"type:bigint;comment:操作管理员ID" json:"operator_id,omitempty"`                                                         // 操作管理员ID
+	Remark         *string                       `gorm:"type:varchar(255);comment:备注" json:"remark,omitempty"`                                                             // 备注
+
+	// ----------
+	//  外键约束
+	// ----------
+	User *User `gorm:"constraint:OnDelete:CASCADE;comment:关联用户" json:"user,omitempty"` // 关联用户
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryQuotaLog) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryQuotaLog
+}
+
+// BeforeSave 校验操作类型与配额分项。
+func (l *LibraryQuotaLog) BeforeSave(_ *gorm.DB) error {
+	if !l.OpType.IsValid() {
+		return fmt.Errorf("invalid LibraryQuotaOpType: %d", l.OpType)
+	}
+	if !l.Bucket.IsValid() {
+		return fmt.Errorf("invalid LibraryQuotaBucket: %d", l.Bucket)
+	}
+	return nil
+}
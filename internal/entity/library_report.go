@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryReport 资源库举报实体，记录玩家对公开皮肤/披风的举报。
+//
+// 通过 Kind + LibraryID 多态指向 SkinLibrary 或 CapeLibrary，
+// 待处理举报数冗余存储在资源记录的 ReportCount 字段，达到阈值后资源自动隐藏。
+type LibraryReport struct {
+	xModels.BaseEntity                         // 嵌入基础实体字段
+	ReporterID         xSnowflake.SnowflakeID  `gorm:"not null;index:idx_library_report_reporter_id;comment:举报用户ID" json:"reporter_id"`                                                 // 举报用户ID
+	Kind               entityType.LibraryKind  `gorm:"not null;type:smallint;index:idx_library_report_target;comment:资源种类(1=skin,2=cape)" json:"kind"`                                  // 资源种类
+	LibraryID          xSnowflake.SnowflakeID  `gorm:"not null;index:idx_library_report_target;comment:资源库记录ID" json:"library_id"`                                                      // 资源库记录ID
+	Reason             entityType.ReportReason `gorm:"not null;type:smallint;comment:举报原因(1=offensive,2=copyright,3=spam,4=other)" json:"reason"`                                       // 举报原因
+	Description        *string                 `gorm:"type:varchar(255);comment:举报说明" json:"description,omitempty"`                                                                     // 举报说明
+	Status             entityType.ReportStatus `gorm:"not null;type:smallint;default:1;index:idx_library_report_status;comment:处理状态(1=pending,2=dismissed,3=taken_down)" json:"status"` // 处理状态
+	HandledBy          *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:处理管理员ID" json:"handled_by,omitempty"`                                                                         // 处理管理员ID
+	HandledAt          *time.Time              `gorm:"type:timestamptz;comment:处理时间" json:"handled_at,omitempty"`                                                                       // 处理时间
+
+	// ----------
+	//  外键约束
+	// ----------
+	Reporter *User `gorm:"foreignKey:ReporterID;references:ID;constraint:OnDelete:CASCADE;comment:举报用户" json:"reporter,omitempty"` // 举报用户
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryReport) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryReport
+}
+
+func (r *LibraryReport) BeforeCreate(_ *gorm.DB) error {
+	if !r.Kind.IsValid() {
+		return fmt.Errorf("无效的资源种类: %d", r.Kind)
+	}
+	if !r.Reason.IsValid() {
+		return fmt.Errorf("无效的举报原因: %d", r.Reason)
+	}
+	return nil
+}
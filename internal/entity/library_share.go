@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryShare 资源私有分享链接实体，允许上传者将私有皮肤/披风分享给好友。
+//
+// 通过 Kind + LibraryID 多态指向 SkinLibrary 或 CapeLibrary。持有 Token 的任何人都可以
+// 在到期前预览资源，登录用户可将其以 AssignmentTypeShare 关联添加到自己的资源库。
+// 撤销后链接立即失效，并移除尚未装备该资源的接收者关联。
+type LibraryShare struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	OwnerID            xSnowflake.SnowflakeID `gorm:"not null;index:idx_library_share_owner_id;comment:分享者用户ID" json:"owner_id"`                     // 分享者用户ID
+	Kind               entityType.LibraryKind `gorm:"not null;type:smallint;index:idx_library_share_target;comment:资源种类(1=skin,2=cape)" json:"kind"` // 资源种类
+	LibraryID          xSnowflake.SnowflakeID `gorm:"not null;index:idx_library_share_target;comment:资源库记录ID" json:"library_id"`                     // 资源库记录ID
+	Token              string                 `gorm:"not null;type:varchar(64);uniqueIndex:uk_library_share_token;comment:分享令牌" json:"token"`        // 分享令牌
+	ExpiresAt          time.Time              `gorm:"not null;type:timestamptz;comment:到期时间" json:"expires_at"`                                      // 到期时间
+	ClaimCount         int64                  `gorm:"not null;type:bigint;default:0;comment:领取人数" json:"claim_count"`                                // 领取人数
+	RevokedAt          *time.Time             `gorm:"type:timestamptz;comment:撤销时间" json:"revoked_at,omitempty"`                                     // 撤销时间
+
+	// ----------
+	//  外键约束
+	// ----------
+	Owner *User `gorm:"foreignKey:OwnerID;references:ID;constraint:OnDelete:CASCADE;comment:分享者" json:"owner,omitempty"` // 分享者
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryShare) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryShare
+}
+
+func (s *LibraryShare) BeforeCreate(_ *gorm.DB) error {
+	if !s.Kind.IsValid() {
+		return fmt.Errorf("无效的资源种类: %d", s.Kind)
+	}
+	return nil
+}
+
+// IsActive 判断分享链接在指定时间是否仍可使用（未撤销且未到期）。
+func (s *LibraryShare) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// LibraryShareClaim 资源私有分享领取记录，记录通过分享链接添加资源的接收者。
+//
+// 撤销分享时据此定位需要移除关联的接收者；同一用户对同一分享只记录一次。
+type LibraryShareClaim struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	ShareID            xSnowflake.SnowflakeID `gorm:"not null;uniqueIndex:uk_library_share_claim_share_user;comment:关联分享ID" json:"share_id"` // 关联分享ID
+	UserID             xSnowflake.SnowflakeID `gorm:"not null;uniqueIndex:uk_library_share_claim_share_user;comment:领取用户ID" json:"user_id"`  // 领取用户ID
+
+	// ----------
+	//  外键约束
+	// ----------
+	Share *LibraryShare `gorm:"foreignKey:ShareID;references:ID;constraint:OnDelete:CASCADE;comment:关联分享" json:"share,omitempty"` // 关联分享
+	User  *User         `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE;comment:领取用户" json:"user,omitempty"`   // 领取用户
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryShareClaim) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryShareClaim
+}
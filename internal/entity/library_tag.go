@@ -0,0 +1,20 @@
+package entity
+
+import (
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// LibraryTag 资源库标签实体，由用户为皮肤/披风定义，与 SkinLibrary、CapeLibrary 为多对多关系。
+//
+// 关联表由 GORM many2many 自动维护：皮肤为 skin_library_tag，披风为 cape_library_tag。
+type LibraryTag struct {
+	xModels.BaseEntity        // 嵌入基础实体字段
+	Name               string `gorm:"not null;type:varchar(32);uniqueIndex:uk_library_tag_name;comment:标签名称(小写规范化)" json:"name"` // 标签名称(小写规范化)
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryTag) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryTag
+}
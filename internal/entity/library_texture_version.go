@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"fmt"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryTextureVersion 资源库纹理版本实体，记录皮肤/披风历次使用过的纹理文件。
+//
+// 通过 Kind + LibraryID 多态指向 SkinLibrary 或 CapeLibrary，资源记录的 TextureVersion
+// 指向当前生效的版本号。替换纹理时追加新版本，回滚时仅切换当前版本号，不产生新记录。
+type LibraryTextureVersion struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	Kind               entityType.LibraryKind `gorm:"not null;type:smallint;uniqueIndex:uk_library_texture_version_target;index:idx_library_texture_version_hash;comment:资源种类(1=skin,2=cape)" json:"kind"` // 资源种类
+	LibraryID          xSnowflake.SnowflakeID `gorm:"not null;uniqueIndex:uk_library_texture_version_target;comment:资源库记录ID" json:"library_id"`                                                            // 资源库记录ID
+	Version            int32                  `gorm:"not null;type:integer;uniqueIndex:uk_library_texture_version_target;comment:版本号" json:"version"`                                                      // 版本号
+	Texture            int64                  `gorm:"not null;type:bigint;comment:纹理文件ID(雪花算法)" json:"texture"`                                                                                            // 纹理文件ID(雪花算法)
+	TextureHash        string                 `gorm:"not null;type:char(64);index:idx_library_texture_version_hash;comment:纹理哈希" json:"texture_hash"`                                                      // 纹理哈希
+	PerceptualHash     *int64                 `gorm:"type:bigint;comment:纹理感知哈希(pHash)" json:"perceptual_hash,omitempty"`                                                                                  // 纹理感知哈希(pHash)
+	CreatedBy          xSnowflake.SnowflakeID `gorm:"not null;comment:上传该版本的用户ID" json:"created_by"`                                                                                                       // 上传该版本的用户ID
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *LibraryTextureVersion) GetGene() xSnowflake.Gene {
+	return bConst.GeneForLibraryTextureVersion
+}
+
+func (v *LibraryTextureVersion) BeforeCreate(_ *gorm.DB) error {
+	if v.Kind.IsValid() {
+		return nil
+	}
+	return fmt.Errorf("无效的资源种类: %d", v.Kind)
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"gorm.io/gorm"
+)
+
+// RedeemCodeBatch 兑换码生成批次实体，记录一次批量生成操作。
+type RedeemCodeBatch struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	Count              int32                  `gorm:"not null;comment:生成数量" json:"count"`                     // 生成数量
+	Remark             *string                `gorm:"type:varchar(255);comment:备注" json:"remark,omitempty"`   // 备注
+	CreatedBy          xSnowflake.SnowflakeID `gorm:"not null;type:bigint;comment:生成管理员ID" json:"created_by"` // 生成管理员ID
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *RedeemCodeBatch) GetGene() xSnowflake.Gene {
+	return bConst.GeneForRedeemCodeBatch
+}
+
+// RedeemCode 兑换码实体，用户兑换后获得码上携带的资源与配额。
+//
+// 一个兑换码可同时携带皮肤、披风、资源库配额增量与游戏档案配额增量，至少携带一项。
+// 同一批次生成的兑换码共享 BatchID 与全部载荷配置；每个用户对同一兑换码仅能兑换一次。
+type RedeemCode struct {
+	xModels.BaseEntity                         // 嵌入基础实体字段
+	Code               string                  `gorm:"not null;type:varchar(32);uniqueIndex:uk_redeem_code_code;comment:兑换码" json:"code"`    // 兑换码
+	BatchID            xSnowflake.SnowflakeID  `gorm:"not null;type:bigint;index:idx_redeem_code_batch_id;comment:生成批次ID" json:"batch_id"`   // 生成批次ID
+	SkinLibraryID      *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:兑换获得的皮肤ID" json:"skin_library_id,omitempty"`                       // 兑换获得的皮肤ID
+	CapeLibraryID      *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:兑换获得的披风ID" json:"cape_library_id,omitempty"`                       // 兑换获得的披风ID
+	SkinsPrivateDelta  int32                   `gorm:"not null;default:0;comment:私有皮肤总额度增量" json:"skins_private_delta"`                      // 私有皮肤总额度增量
+	SkinsPublicDelta   int32                   `gorm:"not null;default:0;comment:公开皮肤总额度增量" json:"skins_public_delta"`                       // 公开皮肤总额度增量
+	CapesPrivateDelta  int32                   `gorm:"not null;default:0;comment:私有披风总额度增量" json:"capes_private_delta"`                      // 私有披风总额度增量
+	CapesPublicDelta   int32                   `gorm:"not null;default:0;comment:公开披风总额度增量" json:"capes_public_delta"`                       // 公开披风总额度增量
+	ProfileQuotaDelta  int32                   `gorm:"not null;default:0;comment:游戏档案总额度增量" json:"profile_quota_delta"`                      // 游戏档案总额度增量
+	MaxUses            int32                   `gorm:"not null;default:1;comment:最大兑换次数" json:"max_uses"`                                    // 最大兑换次数
+	UsedCount          int32                   `gorm:"not null;default:0;comment:已兑换次数" json:"used_count"`                                   // 已兑换次数
+	ExpiresAt          *time.Time              `gorm:"type:timestamptz;comment:过期时间(为空表示永不过期)" json:"expires_at,omitempty"`                  // 过期时间
+	AllowedRoles       []string                `gorm:"type:text;serializer:json;comment:允许兑换的角色名称列表(为空表示不限)" json:"allowed_roles,omitempty"` // 允许兑换的角色名称列表
+	IsDisabled         bool                    `gorm:"not null;type:boolean;default:false;comment:是否已停用" json:"is_disabled"`                 // 是否已停用
+
+	// ----------
+	//  外键约束
+	// ----------
+	Batch       *RedeemCodeBatch `gorm:"foreignKey:BatchID;references:ID;constraint:OnDelete:CASCADE;comment:关联生成批次" json:"batch,omitempty"`              // 关联生成批次
+	SkinLibrary *SkinLibrary     `gorm:"foreignKey:SkinLibraryID;references:ID;constraint:OnDelete:SET NULL;comment:关联皮肤库" json:"skin_library,omitempty"` // 关联皮肤库
+	CapeLibrary *CapeLibrary     `gorm:"foreignKey:CapeLibraryID;references:ID;constraint:OnDelete:SET NULL;comment:关联披风库" json:"cape_library,omitempty"` // 关联披风库
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *RedeemCode) GetGene() xSnowflake.Gene {
+	return bConst.GeneForRedeemCode
+}
+
+func (c *RedeemCode) BeforeCreate(_ *gorm.DB) error {
+	if c.MaxUses <= 0 {
+		return fmt.Errorf("无效的最大兑换次数: %d", c.MaxUses)
+	}
+	if c.SkinsPrivateDelta < 0 || c.SkinsPublicDelta < 0 || c.CapesPrivateDelta < 0 || c.CapesPublicDelta < 0 || c.ProfileQuotaDelta < 0 {
+		return fmt.Errorf("兑换码配额增量不能为负数")
+	}
+	return nil
+}
+
+// RedeemCodeRedemption 兑换码兑换记录，(code_id, user_id) 唯一，保证每个用户对同一兑换码仅兑换一次。
+type RedeemCodeRedemption struct {
+	xModels.BaseEntity                        // 嵌入基础实体字段
+	CodeID             xSnowflake.SnowflakeID `gorm:"not null;uniqueIndex:uk_redeem_code_redemption_code_user;comment:关联兑换码ID" json:"code_id"`                                         // 关联兑换码ID
+	UserID             xSnowflake.SnowflakeID `gorm:"not null;uniqueIndex:uk_redeem_code_redemption_code_user;index:idx_redeem_code_redemption_user_id;comment:兑换用户ID" json:"user_id"` // 兑换用户ID
+
+	// ----------
+	//  外键约束
+	// ----------
+	Code *RedeemCode `gorm:"foreignKey:CodeID;references:ID;constraint:OnDelete:CASCADE;comment:关联兑换码" json:"code,omitempty"` // 关联兑换码
+	User *User       `gorm:"constraint:OnDelete:CASCADE;comment:关联用户" json:"user,omitempty"`                                  // 关联用户
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *RedeemCodeRedemption) GetGene() xSnowflake.Gene {
+	return bConst.GeneForRedeemCodeRedemption
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"gorm.io/gorm"
+)
+
+// RoleQuotaTier 角色默认配额档位实体，使用 RoleName 作为主键，不继承 BaseEntity。
+//
+// 新用户创建时按其角色的档位初始化游戏档案与资源库配额总额度；
+// 管理员变更用户角色或重新应用档位时，仅将低于档位的总额度提升到档位值，不会降低已有额度。
+type RoleQuotaTier struct {
+	RoleName          RoleName                `gorm:"primaryKey;type:varchar(32);comment:角色名称" json:"role_name"`                     // 角色名称
+	ProfileTotal      int32                   `gorm:"not null;default:0;comment:游戏档案总额度" json:"profile_total"`                       // 游戏档案总额度
+	SkinsPublicTotal  int32                   `gorm:"not null;default:0;comment:公开皮肤总额度" json:"skins_public_total"`                  // 公开皮肤总额度
+	SkinsPrivateTotal int32                   `gorm:"not null;default:0;comment:私有皮肤总额度" json:"skins_private_total"`                 // 私有皮肤总额度
+	CapesPublicTotal  int32                   `gorm:"not null;default:0;comment:公开披风总额度" json:"capes_public_total"`                  // 公开披风总额度
+	CapesPrivateTotal int32                   `gorm:"not null;default:0;comment:私有披风总额度" json:"capes_private_total"`                 // 私有披风总额度
+	UpdatedBy         *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:最近修改管理员ID" json:"updated_by,omitempty"`                     // 最近修改管理员ID
+	CreatedAt         time.Time               `gorm:"not null;type:timestamptz;autoCreateTime:milli;comment:创建时间" json:"-"`          // 创建时间
+	UpdatedAt         time.Time               `gorm:"not null;type:timestamptz;autoUpdateTime:milli;comment:更新时间" json:"updated_at"` // 更新时间
+
+	// ----------
+	//  外键约束
+	// ----------
+	Role *Role `gorm:"foreignKey:RoleName;references:Name;constraint:OnDelete:CASCADE;comment:关联角色" json:"role,omitempty"` // 关联角色
+}
+
+// BeforeSave GORM 钩子，保存前校验档位额度均不为负数。
+func (t *RoleQuotaTier) BeforeSave(_ *gorm.DB) error {
+	if t.ProfileTotal < 0 || t.SkinsPublicTotal < 0 || t.SkinsPrivateTotal < 0 || t.CapesPublicTotal < 0 || t.CapesPrivateTotal < 0 {
+		return fmt.Errorf("角色配额档位额度不能为负数: %s", t.RoleName)
+	}
+	return nil
+}
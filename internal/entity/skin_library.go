@@ -2,10 +2,12 @@ package entity
 
 import (
 	"errors"
+	"time"
 
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
 	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
 	"gorm.io/gorm"
 )
 
@@ -24,13 +26,29 @@ type SkinLibrary struct {
 	Name               string                  `gorm:"not null;type:varchar(64);comment:皮肤名称" json:"name"`                                                 // 皮肤名称
 	Texture            int64                   `gorm:"not null;type:bigint;comment:皮肤纹理文件ID(雪花算法)" json:"texture"`                                         // 皮肤纹理文件ID(雪花算法)
 	TextureHash        string                  `gorm:"not null;type:char(64);uniqueIndex:uk_skin_library_texture_hash;comment:皮肤纹理哈希" json:"texture_hash"` // 皮肤纹理哈希
+	TextureVersion     int32                   `gorm:"not null;type:integer;default:1;comment:当前纹理版本号" json:"texture_version"`                          // 当前纹理版本号
+	PendingTextureVersion *int32               `gorm:"type:integer;comment:待审核纹理版本号(审核通过后生效)" json:"pending_texture_version,omitempty"`          // 待审核纹理版本号（审核通过后替换当前纹理）
 	Model              ModelType               `gorm:"not null;type:smallint;default:1;comment:皮肤模型(1=classic,2=slim)" json:"model"`                       // 皮肤模型(1=classic,2=slim)
 	IsPublic           bool                    `gorm:"not null;type:boolean;default:false;index:idx_skin_library_is_public;comment:是否公开" json:"is_public"` // 是否公开
+	LikeCount          int64                   `gorm:"not null;type:bigint;default:0;comment:点赞数" json:"like_count"`                                       // 点赞数
+	CollectCount       int64                   `gorm:"not null;type:bigint;default:0;comment:收藏数" json:"collect_count"`                                    // 收藏数
+	ReviewStatus       entityType.ReviewStatus `gorm:"not null;type:smallint;default:2;index:idx_skin_library_review_status;comment:审核状态(1=pending,2=approved,3=rejected)" json:"review_status"` // 审核状态
+	ReviewReason       *string                 `gorm:"type:varchar(255);comment:审核驳回原因" json:"review_reason,omitempty"`                                      // 审核驳回原因
+	ReviewedBy         *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:审核管理员ID" json:"reviewed_by,omitempty"`                                          // 审核管理员ID
+	ReviewedAt         *time.Time              `gorm:"type:timestamptz;comment:审核时间" json:"reviewed_at,omitempty"`                                           // 审核时间
+	ReportCount        int64                   `gorm:"not null;type:bigint;default:0;comment:待处理举报数" json:"report_count"`                                 // 待处理举报数
+	IsHidden           bool                    `gorm:"not null;type:boolean;default:false;index:idx_skin_library_is_hidden;comment:是否因举报自动隐藏" json:"is_hidden"` // 是否因举报自动隐藏
+	IsDefault          bool                    `gorm:"not null;type:boolean;default:false;comment:是否为新用户默认发放的系统皮肤" json:"is_default"` // 是否为新用户默认发放的系统皮肤
+	IsDefaultEquip     bool                    `gorm:"not null;type:boolean;default:false;comment:是否为新游戏档案默认装备的系统皮肤" json:"is_default_equip"` // 是否为新游戏档案默认装备的系统皮肤
+	IsRetired          bool                    `gorm:"not null;type:boolean;default:false;comment:系统皮肤是否已停用" json:"is_retired"` // 系统皮肤是否已停用
+	PerceptualHash     *int64                  `gorm:"type:bigint;comment:皮肤纹理感知哈希(pHash，非 PNG 或历史纹理为空)" json:"perceptual_hash,omitempty"` // 皮肤纹理感知哈希
+	SimilarToID        *xSnowflake.SnowflakeID `gorm:"type:bigint;comment:疑似近似重复的已公开皮肤ID" json:"similar_to_id,omitempty"` // 疑似近似重复的已公开皮肤ID
 
 	// ----------
 	//  外键约束
 	// ----------
-	User *User `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:SET NULL;comment:关联用户" json:"user,omitempty"` // 关联用户
+	User *User        `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:SET NULL;comment:关联用户" json:"user,omitempty"` // 关联用户
+	Tags []LibraryTag `gorm:"many2many:skin_library_tag;constraint:OnDelete:CASCADE;comment:关联标签" json:"tags,omitempty"`       // 关联标签
 }
 
 func (s *SkinLibrary) BeforeCreate(tx *gorm.DB) error {
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// StorageFile 对象存储文件登记实体，记录本服务上传到对象存储的每个文件。
+//
+// 对象存储不提供按目录列举文件的接口，存储对账依赖本表发现不再被任何数据库记录引用的孤儿文件：
+// 上传成功后立即登记，从对象存储删除成功后移除登记；对账任务会为已被引用但尚未登记的历史文件补登记。
+type StorageFile struct {
+	xModels.BaseEntity                              // 嵌入基础实体字段
+	FileID             int64                        `gorm:"not null;type:bigint;uniqueIndex:uk_storage_file_file_id;comment:存储桶文件ID(雪花算法)" json:"file_id"`                                     // 存储桶文件ID
+	Source             entityType.StorageFileSource `gorm:"not null;type:smallint;index:idx_storage_file_source;comment:文件来源(1=skin_texture,2=cape_texture,3=issue_attachment)" json:"source"` // 文件来源
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *StorageFile) GetGene() xSnowflake.Gene {
+	return bConst.GeneForStorageFile
+}
+
+// BeforeSave 校验文件来源。
+func (f *StorageFile) BeforeSave(_ *gorm.DB) error {
+	if !f.Source.IsValid() {
+		return fmt.Errorf("invalid StorageFileSource: %d", f.Source)
+	}
+	return nil
+}
+
+// StorageReconcileReport 存储对账报告实体，记录一次数据库与对象存储交叉核对的执行情况与汇总计数。
+//
+// DryRun 为 true 时仅记录发现的问题；为 false 时删除孤儿文件并确认仍处于缓存态的被引用文件，
+// 悬空引用与哈希不一致涉及用户数据，始终只记录不处理。
+type StorageReconcileReport struct {
+	xModels.BaseEntity                                   // 嵌入基础实体字段
+	DryRun             bool                              `gorm:"not null;type:boolean;default:true;comment:是否为试运行" json:"dry_run"`                                                                      // 是否为试运行
+	Status             entityType.StorageReconcileStatus `gorm:"not null;type:smallint;default:1;index:idx_storage_reconcile_report_status;comment:任务状态(1=running,2=completed,3=failed)" json:"status"` // 任务状态
+	TriggeredBy        *xSnowflake.SnowflakeID           `gorm:"type:bigint;comment:触发管理员ID(为空表示定时任务)" json:"triggered_by,omitempty"`                                                                   // 触发管理员ID
+	CheckedReferences  int64                             `gorm:"not null;default:0;comment:已核对的数据库引用数" json:"checked_references"`                                                                       // 已核对的数据库引用数
+	CheckedFiles       int64                             `gorm:"not null;default:0;comment:已核对的登记文件数" json:"checked_files"`                                                                             // 已核对的登记文件数
+	OrphanCount        int64                             `gorm:"not null;default:0;comment:孤儿文件数" json:"orphan_count"`                                                                                  // 孤儿文件数
+	DanglingCount      int64                             `gorm:"not null;default:0;comment:悬空引用数" json:"dangling_count"`                                                                                // 悬空引用数
+	MismatchCount      int64                             `gorm:"not null;default:0;comment:哈希不一致数" json:"mismatch_count"`                                                                               // 哈希不一致数
+	UnverifiedCount    int64                             `gorm:"not null;default:0;comment:缓存态引用数" json:"unverified_count"`                                                                             // 缓存态引用数
+	FixedCount         int64                             `gorm:"not null;default:0;comment:已自动修复数" json:"fixed_count"`                                                                                  // 已自动修复数
+	FailedCount        int64                             `gorm:"not null;default:0;comment:自动修复失败数" json:"failed_count"`                                                                                // 自动修复失败数
+	LastError          *string                           `gorm:"type:varchar(255);comment:任务中断原因" json:"last_error,omitempty"`                                                                          // 任务中断原因
+	FinishedAt         *time.Time                        `gorm:"type:timestamptz;comment:完成时间" json:"finished_at,omitempty"`                                                                            // 完成时间
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *StorageReconcileReport) GetGene() xSnowflake.Gene {
+	return bConst.GeneForStorageReconcileReport
+}
+
+// StorageReconcileIssue 存储对账问题实体，记录一次对账中发现的单个问题及其处理结果。
+type StorageReconcileIssue struct {
+	xModels.BaseEntity                               // 嵌入基础实体字段
+	ReportID           xSnowflake.SnowflakeID        `gorm:"not null;type:bigint;index:idx_storage_reconcile_issue_report_id;comment:关联对账报告ID" json:"report_id"`                         // 关联对账报告ID
+	Type               entityType.StorageIssueType   `gorm:"not null;type:smallint;comment:问题类型(1=orphan_file,2=dangling_reference,3=hash_mismatch,4=unverified_reference)" json:"type"` // 问题类型
+	Source             entityType.StorageFileSource  `gorm:"not null;type:smallint;comment:文件来源(1=skin_texture,2=cape_texture,3=issue_attachment)" json:"source"`                        // 文件来源
+	FileID             int64                         `gorm:"not null;type:bigint;comment:存储桶文件ID" json:"file_id"`                                                                        // 存储桶文件ID
+	RefID              *xSnowflake.SnowflakeID       `gorm:"type:bigint;comment:引用该文件的记录ID(皮肤/披风/附件)" json:"ref_id,omitempty"`                                                           // 引用该文件的记录ID
+	ExpectedHash       *string                       `gorm:"type:char(64);comment:数据库记录的纹理哈希" json:"expected_hash,omitempty"`                                                            // 数据库记录的纹理哈希
+	ActualHash         *string                       `gorm:"type:char(64);comment:对象存储文件的SHA256" json:"actual_hash,omitempty"`                                                           // 对象存储文件的SHA256
+	Action             entityType.StorageIssueAction `gorm:"not null;type:smallint;default:1;comment:处理动作(1=none,2=fixed,3=failed)" json:"action"`                                       // 处理动作
+	Message            *string                       `gorm:"type:varchar(255);comment:处理失败原因" json:"message,omitempty"`                                                                  // 处理失败原因
+
+	// ----------
+	//  外键约束
+	// ----------
+	Report *StorageReconcileReport `gorm:"foreignKey:ReportID;references:ID;constraint:OnDelete:CASCADE;comment:关联对账报告" json:"report,omitempty"` // 关联对账报告
+}
+
+// GetGene 返回 xSnowflake.Gene，用于标识该实体在 ID 生成时使用的基因类型。
+func (_ *StorageReconcileIssue) GetGene() xSnowflake.Gene {
+	return bConst.GeneForStorageReconcileIssue
+}
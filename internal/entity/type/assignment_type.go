@@ -12,12 +12,22 @@ const (
 
 	// AssignmentTypeAdmin 系统预置/管理员分配的资源，不计入配额消耗。
 	AssignmentTypeAdmin AssignmentType = 3
+
+	// AssignmentTypeCollect 用户从公开画廊收藏的他人资源，不计入配额消耗。
+	// 资源被上传者转为私有或删除后，收藏关联不再可装备。
+	AssignmentTypeCollect AssignmentType = 4
+
+	// AssignmentTypeShare 通过私有分享链接添加的他人资源，不计入配额消耗。
+	// 分享被撤销时，未装备该资源的接收者关联会被移除。
+	AssignmentTypeShare AssignmentType = 5
 )
 
 var assignmentTypeSet = map[AssignmentType]string{
-	AssignmentTypeNormal: "NORMAL",
-	AssignmentTypeGift:   "GIFT",
-	AssignmentTypeAdmin:  "ADMIN",
+	AssignmentTypeNormal:  "NORMAL",
+	AssignmentTypeGift:    "GIFT",
+	AssignmentTypeAdmin:   "ADMIN",
+	AssignmentTypeCollect: "COLLECT",
+	AssignmentTypeShare:   "SHARE",
 }
 
 // String 返回关联类型的字符串表示。
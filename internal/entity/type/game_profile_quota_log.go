@@ -6,13 +6,25 @@ type ObType struct {
 }
 
 var (
-	ObTypeAddGameProfile   = ObType{Name: "ADD_GAME_PROFILE", Type: 1}
-	ObTypeAdminAdjustQuota = ObType{Name: "ADMIN_ADJUST_QUOTA", Type: 1}
+	ObTypeAddGameProfile     = ObType{Name: "ADD_GAME_PROFILE", Type: 1}
+	ObTypeAdminAdjustQuota   = ObType{Name: "ADMIN_ADJUST_QUOTA", Type: 1}
+	ObTypeRedeemCode         = ObType{Name: "REDEEM_CODE", Type: 0}
+	ObTypeRoleQuotaTier      = ObType{Name: "ROLE_QUOTA_TIER", Type: 0}
+	ObTypeRoleQuotaTierLower = ObType{Name: "ROLE_QUOTA_TIER", Type: 1}
+	ObTypeDeleteGameProfile  = ObType{Name: "DELETE_GAME_PROFILE", Type: 0}
+	ObTypeTransferProfileIn  = ObType{Name: "TRANSFER_PROFILE_IN", Type: 1}
+	ObTypeTransferProfileOut = ObType{Name: "TRANSFER_PROFILE_OUT", Type: 0}
 )
 
 var gameProfileQuotaLogObTypeSet = map[ObType]string{
-	ObTypeAddGameProfile:   ObTypeAddGameProfile.Name,
-	ObTypeAdminAdjustQuota: ObTypeAdminAdjustQuota.Name,
+	ObTypeAddGameProfile:     ObTypeAddGameProfile.Name,
+	ObTypeAdminAdjustQuota:   ObTypeAdminAdjustQuota.Name,
+	ObTypeRedeemCode:         ObTypeRedeemCode.Name,
+	ObTypeRoleQuotaTier:      ObTypeRoleQuotaTier.Name,
+	ObTypeRoleQuotaTierLower: ObTypeRoleQuotaTierLower.Name,
+	ObTypeDeleteGameProfile:  ObTypeDeleteGameProfile.Name,
+	ObTypeTransferProfileIn:  ObTypeTransferProfileIn.Name,
+	ObTypeTransferProfileOut: ObTypeTransferProfileOut.Name,
 }
 
 func (t ObType) String() string {
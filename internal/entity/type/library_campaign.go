@@ -0,0 +1,85 @@
+package entityType
+
+// CampaignStatus 资源发放活动执行状态。
+type CampaignStatus uint8
+
+const (
+	// CampaignStatusPending 待执行：活动已创建，尚未开始发放。
+	CampaignStatusPending CampaignStatus = 1
+
+	// CampaignStatusRunning 执行中：后台任务正在分批发放。
+	CampaignStatusRunning CampaignStatus = 2
+
+	// CampaignStatusCompleted 已完成：所有匹配用户均已处理（可能存在失败条目）。
+	CampaignStatusCompleted CampaignStatus = 3
+
+	// CampaignStatusFailed 已中断：后台任务异常终止，可从游标处恢复。
+	CampaignStatusFailed CampaignStatus = 4
+
+	// CampaignStatusRevoking 撤销中：后台任务正在收回已发放的资源。
+	CampaignStatusRevoking CampaignStatus = 5
+
+	// CampaignStatusRevoked 已撤销：活动发放的资源已全部收回。
+	CampaignStatusRevoked CampaignStatus = 6
+)
+
+var campaignStatusSet = map[CampaignStatus]string{
+	CampaignStatusPending:   "PENDING",
+	CampaignStatusRunning:   "RUNNING",
+	CampaignStatusCompleted: "COMPLETED",
+	CampaignStatusFailed:    "FAILED",
+	CampaignStatusRevoking:  "REVOKING",
+	CampaignStatusRevoked:   "REVOKED",
+}
+
+// String 返回活动状态的字符串表示。
+func (s CampaignStatus) String() string {
+	if name, ok := campaignStatusSet[s]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验活动状态是否为合法值。
+func (s CampaignStatus) IsValid() bool {
+	_, ok := campaignStatusSet[s]
+	return ok
+}
+
+// CampaignGrantStatus 资源发放活动中单个用户的处理结果。
+type CampaignGrantStatus uint8
+
+const (
+	// CampaignGrantStatusGranted 已发放：本活动为该用户创建了资源关联。
+	CampaignGrantStatusGranted CampaignGrantStatus = 1
+
+	// CampaignGrantStatusSkipped 已跳过：用户此前已持有该资源。
+	CampaignGrantStatusSkipped CampaignGrantStatus = 2
+
+	// CampaignGrantStatusFailed 发放失败：错误原因记录在条目说明中。
+	CampaignGrantStatusFailed CampaignGrantStatus = 3
+
+	// CampaignGrantStatusRevoked 已收回：活动撤销时删除了本活动创建的关联。
+	CampaignGrantStatusRevoked CampaignGrantStatus = 4
+)
+
+var campaignGrantStatusSet = map[CampaignGrantStatus]string{
+	CampaignGrantStatusGranted: "GRANTED",
+	CampaignGrantStatusSkipped: "SKIPPED",
+	CampaignGrantStatusFailed:  "FAILED",
+	CampaignGrantStatusRevoked: "REVOKED",
+}
+
+// String 返回发放结果的字符串表示。
+func (s CampaignGrantStatus) String() string {
+	if name, ok := campaignGrantStatusSet[s]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验发放结果是否为合法值。
+func (s CampaignGrantStatus) IsValid() bool {
+	_, ok := campaignGrantStatusSet[s]
+	return ok
+}
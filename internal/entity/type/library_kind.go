@@ -0,0 +1,31 @@
+package entityType
+
+// LibraryKind 资源库资源种类，用于在点赞、举报等多态场景中区分皮肤与披风。
+type LibraryKind uint8
+
+const (
+	// LibraryKindSkin 皮肤资源（SkinLibrary）。
+	LibraryKindSkin LibraryKind = 1
+
+	// LibraryKindCape 披风资源（CapeLibrary）。
+	LibraryKindCape LibraryKind = 2
+)
+
+var libraryKindSet = map[LibraryKind]string{
+	LibraryKindSkin: "SKIN",
+	LibraryKindCape: "CAPE",
+}
+
+// String 返回资源种类的字符串表示。
+func (k LibraryKind) String() string {
+	if s, ok := libraryKindSet[k]; ok {
+		return s
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验资源种类是否为合法值。
+func (k LibraryKind) IsValid() bool {
+	_, ok := libraryKindSet[k]
+	return ok
+}
@@ -0,0 +1,111 @@
+package entityType
+
+// LibraryQuotaBucket 资源库配额分项。
+type LibraryQuotaBucket uint8
+
+const (
+	// LibraryQuotaBucketSkinsPublic 公开皮肤配额。
+	LibraryQuotaBucketSkinsPublic LibraryQuotaBucket = 1
+
+	// LibraryQuotaBucketSkinsPrivate 私有皮肤配额。
+	LibraryQuotaBucketSkinsPrivate LibraryQuotaBucket = 2
+
+	// LibraryQuotaBucketCapesPublic 公开披风配额。
+	LibraryQuotaBucketCapesPublic LibraryQuotaBucket = 3
+
+	// LibraryQuotaBucketCapesPrivate 私有披风配额。
+	LibraryQuotaBucketCapesPrivate LibraryQuotaBucket = 4
+)
+
+var libraryQuotaBucketSet = map[LibraryQuotaBucket]string{
+	LibraryQuotaBucketSkinsPublic:  "SKINS_PUBLIC",
+	LibraryQuotaBucketSkinsPrivate: "SKINS_PRIVATE",
+	LibraryQuotaBucketCapesPublic:  "CAPES_PUBLIC",
+	LibraryQuotaBucketCapesPrivate: "CAPES_PRIVATE",
+}
+
+// String 返回配额分项的字符串表示。
+func (b LibraryQuotaBucket) String() string {
+	if name, ok := libraryQuotaBucketSet[b]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验配额分项是否为合法值。
+func (b LibraryQuotaBucket) IsValid() bool {
+	_, ok := libraryQuotaBucketSet[b]
+	return ok
+}
+
+// QuotaBucketFor 根据资源种类与公开状态返回对应的配额分项。
+func QuotaBucketFor(kind LibraryKind, isPublic bool) LibraryQuotaBucket {
+	if kind == LibraryKindCape {
+		if isPublic {
+			return LibraryQuotaBucketCapesPublic
+		}
+		return LibraryQuotaBucketCapesPrivate
+	}
+	if isPublic {
+		return LibraryQuotaBucketSkinsPublic
+	}
+	return LibraryQuotaBucketSkinsPrivate
+}
+
+// LibraryQuotaOpType 资源库配额变更操作类型。
+type LibraryQuotaOpType uint8
+
+const (
+	// LibraryQuotaOpCreate 上传资源占用配额。
+	LibraryQuotaOpCreate LibraryQuotaOpType = 1
+
+	// LibraryQuotaOpDelete 删除资源释放配额。
+	LibraryQuotaOpDelete LibraryQuotaOpType = 2
+
+	// LibraryQuotaOpVisibility 公开/私有状态切换，在两个分项之间转移占用。
+	LibraryQuotaOpVisibility LibraryQuotaOpType = 3
+
+	// LibraryQuotaOpRecalculate 按实际资源数量重算已使用额度。
+	LibraryQuotaOpRecalculate LibraryQuotaOpType = 4
+
+	// LibraryQuotaOpAdminAdjust 管理员调整总额度。
+	LibraryQuotaOpAdminAdjust LibraryQuotaOpType = 5
+
+	// LibraryQuotaOpRedeemCode 兑换码增加总额度。
+	LibraryQuotaOpRedeemCode LibraryQuotaOpType = 6
+
+	// LibraryQuotaOpRoleTier 应用角色配额档位调整总额度。
+	LibraryQuotaOpRoleTier LibraryQuotaOpType = 7
+
+	// LibraryQuotaOpReviewReject 公开审核驳回，资源转为私有。
+	LibraryQuotaOpReviewReject LibraryQuotaOpType = 8
+
+	// LibraryQuotaOpTakedown 举报下架释放上传者配额。
+	LibraryQuotaOpTakedown LibraryQuotaOpType = 9
+)
+
+var libraryQuotaOpTypeSet = map[LibraryQuotaOpType]string{
+	LibraryQuotaOpCreate:       "CREATE",
+	LibraryQuotaOpDelete:       "DELETE",
+	LibraryQuotaOpVisibility:   "VISIBILITY",
+	LibraryQuotaOpRecalculate:  "RECALCULATE",
+	LibraryQuotaOpAdminAdjust:  "ADMIN_ADJUST",
+	LibraryQuotaOpRedeemCode:   "REDEEM_CODE",
+	LibraryQuotaOpRoleTier:     "ROLE_QUOTA_TIER",
+	LibraryQuotaOpReviewReject: "REVIEW_REJECT",
+	LibraryQuotaOpTakedown:     "TAKEDOWN",
+}
+
+// String 返回操作类型的字符串表示。
+func (o LibraryQuotaOpType) String() string {
+	if name, ok := libraryQuotaOpTypeSet[o]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验操作类型是否为合法值。
+func (o LibraryQuotaOpType) IsValid() bool {
+	_, ok := libraryQuotaOpTypeSet[o]
+	return ok
+}
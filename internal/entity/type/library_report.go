@@ -0,0 +1,73 @@
+package entityType
+
+// ReportReason 资源库举报原因分类。
+type ReportReason uint8
+
+const (
+	// ReportReasonOffensive 不当内容：色情、暴力、歧视等冒犯性图案。
+	ReportReasonOffensive ReportReason = 1
+
+	// ReportReasonCopyright 侵权：未经授权使用他人作品。
+	ReportReasonCopyright ReportReason = 2
+
+	// ReportReasonSpam 垃圾信息：广告、引流等无意义内容。
+	ReportReasonSpam ReportReason = 3
+
+	// ReportReasonOther 其他原因，需在描述中说明。
+	ReportReasonOther ReportReason = 4
+)
+
+var reportReasonSet = map[ReportReason]string{
+	ReportReasonOffensive: "OFFENSIVE",
+	ReportReasonCopyright: "COPYRIGHT",
+	ReportReasonSpam:      "SPAM",
+	ReportReasonOther:     "OTHER",
+}
+
+// String 返回举报原因的字符串表示。
+func (r ReportReason) String() string {
+	if name, ok := reportReasonSet[r]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验举报原因是否为合法值。
+func (r ReportReason) IsValid() bool {
+	_, ok := reportReasonSet[r]
+	return ok
+}
+
+// ReportStatus 资源库举报处理状态。
+type ReportStatus uint8
+
+const (
+	// ReportStatusPending 待处理：计入资源的待处理举报数。
+	ReportStatusPending ReportStatus = 1
+
+	// ReportStatusDismissed 已驳回：管理员认定举报不成立。
+	ReportStatusDismissed ReportStatus = 2
+
+	// ReportStatusTakenDown 已下架：管理员认定举报成立并下架资源。
+	ReportStatusTakenDown ReportStatus = 3
+)
+
+var reportStatusSet = map[ReportStatus]string{
+	ReportStatusPending:   "PENDING",
+	ReportStatusDismissed: "DISMISSED",
+	ReportStatusTakenDown: "TAKEN_DOWN",
+}
+
+// String 返回举报处理状态的字符串表示。
+func (s ReportStatus) String() string {
+	if name, ok := reportStatusSet[s]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验举报处理状态是否为合法值。
+func (s ReportStatus) IsValid() bool {
+	_, ok := reportStatusSet[s]
+	return ok
+}
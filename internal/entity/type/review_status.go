@@ -0,0 +1,49 @@
+package entityType
+
+// ReviewStatus 公开资源审核状态，决定公开皮肤/披风是否对其他玩家可见。
+type ReviewStatus uint8
+
+const (
+	// ReviewStatusPending 待审核：用户申请公开后进入审核队列，审核通过前对其他玩家不可见。
+	ReviewStatusPending ReviewStatus = 1
+
+	// ReviewStatusApproved 审核通过：公开资源对其他玩家可见（私有资源的默认状态）。
+	ReviewStatusApproved ReviewStatus = 2
+
+	// ReviewStatusRejected 审核驳回：资源被退回为私有，并记录驳回原因。
+	ReviewStatusRejected ReviewStatus = 3
+)
+
+var reviewStatusSet = map[ReviewStatus]string{
+	ReviewStatusPending:  "PENDING",
+	ReviewStatusApproved: "APPROVED",
+	ReviewStatusRejected: "REJECTED",
+}
+
+var reviewStatusChineseMap = map[ReviewStatus]string{
+	ReviewStatusPending:  "待审核",
+	ReviewStatusApproved: "审核通过",
+	ReviewStatusRejected: "审核驳回",
+}
+
+// String 返回审核状态的字符串表示。
+func (s ReviewStatus) String() string {
+	if name, ok := reviewStatusSet[s]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// ChineseName 返回审核状态的中文显示名，未知状态返回 String()。
+func (s ReviewStatus) ChineseName() string {
+	if name, ok := reviewStatusChineseMap[s]; ok {
+		return name
+	}
+	return s.String()
+}
+
+// IsValid 校验审核状态是否为合法值。
+func (s ReviewStatus) IsValid() bool {
+	_, ok := reviewStatusSet[s]
+	return ok
+}
@@ -0,0 +1,141 @@
+package entityType
+
+// StorageFileSource 对象存储文件的业务来源。
+type StorageFileSource uint8
+
+const (
+	// StorageFileSourceSkinTexture 皮肤纹理。
+	StorageFileSourceSkinTexture StorageFileSource = 1
+
+	// StorageFileSourceCapeTexture 披风纹理。
+	StorageFileSourceCapeTexture StorageFileSource = 2
+
+	// StorageFileSourceIssueAttachment 问题附件。
+	StorageFileSourceIssueAttachment StorageFileSource = 3
+)
+
+var storageFileSourceSet = map[StorageFileSource]string{
+	StorageFileSourceSkinTexture:     "SKIN_TEXTURE",
+	StorageFileSourceCapeTexture:     "CAPE_TEXTURE",
+	StorageFileSourceIssueAttachment: "ISSUE_ATTACHMENT",
+}
+
+// String 返回文件来源的字符串表示。
+func (s StorageFileSource) String() string {
+	if name, ok := storageFileSourceSet[s]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验文件来源是否为合法值。
+func (s StorageFileSource) IsValid() bool {
+	_, ok := storageFileSourceSet[s]
+	return ok
+}
+
+// StorageReconcileStatus 存储对账任务状态。
+type StorageReconcileStatus uint8
+
+const (
+	// StorageReconcileStatusRunning 执行中。
+	StorageReconcileStatusRunning StorageReconcileStatus = 1
+
+	// StorageReconcileStatusCompleted 已完成。
+	StorageReconcileStatusCompleted StorageReconcileStatus = 2
+
+	// StorageReconcileStatusFailed 执行中断。
+	StorageReconcileStatusFailed StorageReconcileStatus = 3
+)
+
+var storageReconcileStatusSet = map[StorageReconcileStatus]string{
+	StorageReconcileStatusRunning:   "RUNNING",
+	StorageReconcileStatusCompleted: "COMPLETED",
+	StorageReconcileStatusFailed:    "FAILED",
+}
+
+// String 返回对账任务状态的字符串表示。
+func (s StorageReconcileStatus) String() string {
+	if name, ok := storageReconcileStatusSet[s]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验对账任务状态是否为合法值。
+func (s StorageReconcileStatus) IsValid() bool {
+	_, ok := storageReconcileStatusSet[s]
+	return ok
+}
+
+// StorageIssueType 存储对账发现的问题类型。
+type StorageIssueType uint8
+
+const (
+	// StorageIssueOrphanFile 孤儿文件：对象存储中的文件已不被任何数据库记录引用。
+	StorageIssueOrphanFile StorageIssueType = 1
+
+	// StorageIssueDanglingReference 悬空引用：数据库记录引用的文件在对象存储中不存在。
+	StorageIssueDanglingReference StorageIssueType = 2
+
+	// StorageIssueHashMismatch 哈希不一致：数据库记录的纹理哈希与对象存储文件的 SHA256 不符。
+	StorageIssueHashMismatch StorageIssueType = 3
+
+	// StorageIssueUnverifiedReference 未确认引用：数据库记录引用的文件仍处于缓存态，到期后会被对象存储清理。
+	StorageIssueUnverifiedReference StorageIssueType = 4
+)
+
+var storageIssueTypeSet = map[StorageIssueType]string{
+	StorageIssueOrphanFile:          "ORPHAN_FILE",
+	StorageIssueDanglingReference:   "DANGLING_REFERENCE",
+	StorageIssueHashMismatch:        "HASH_MISMATCH",
+	StorageIssueUnverifiedReference: "UNVERIFIED_REFERENCE",
+}
+
+// String 返回问题类型的字符串表示。
+func (t StorageIssueType) String() string {
+	if name, ok := storageIssueTypeSet[t]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验问题类型是否为合法值。
+func (t StorageIssueType) IsValid() bool {
+	_, ok := storageIssueTypeSet[t]
+	return ok
+}
+
+// StorageIssueAction 存储对账对问题采取的处理动作。
+type StorageIssueAction uint8
+
+const (
+	// StorageIssueActionNone 仅记录，未处理（试运行或该类问题需人工处理）。
+	StorageIssueActionNone StorageIssueAction = 1
+
+	// StorageIssueActionFixed 已自动修复（删除孤儿文件或确认缓存态文件）。
+	StorageIssueActionFixed StorageIssueAction = 2
+
+	// StorageIssueActionFailed 自动修复失败。
+	StorageIssueActionFailed StorageIssueAction = 3
+)
+
+var storageIssueActionSet = map[StorageIssueAction]string{
+	StorageIssueActionNone:   "NONE",
+	StorageIssueActionFixed:  "FIXED",
+	StorageIssueActionFailed: "FAILED",
+}
+
+// String 返回处理动作的字符串表示。
+func (a StorageIssueAction) String() string {
+	if name, ok := storageIssueActionSet[a]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// IsValid 校验处理动作是否为合法值。
+func (a StorageIssueAction) IsValid() bool {
+	_, ok := storageIssueActionSet[a]
+	return ok
+}
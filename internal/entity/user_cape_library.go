@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"time"
 
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
@@ -15,9 +16,10 @@ import (
 // 设计同构于 UserSkinLibrary，用于披风资源的用户关联管理。
 type UserCapeLibrary struct {
 	xModels.BaseEntity                           // 嵌入基础实体字段
-	UserID             xSnowflake.SnowflakeID    `gorm:"not null;uniqueIndex:uk_user_cape_library_user_cape;comment:关联用户ID" json:"user_id"`             // 关联用户ID
-	CapeLibraryID      xSnowflake.SnowflakeID    `gorm:"not null;uniqueIndex:uk_user_cape_library_user_cape;comment:关联披风库ID" json:"cape_library_id"`    // 关联披风库ID
-	AssignmentType     entityType.AssignmentType `gorm:"not null;type:smallint;default:1;comment:关联类型(1=normal,2=gift,3=admin)" json:"assignment_type"` // 关联类型
+	UserID             xSnowflake.SnowflakeID    `gorm:"not null;uniqueIndex:uk_user_cape_library_user_cape;comment:关联用户ID" json:"user_id"`                               // 关联用户ID
+	CapeLibraryID      xSnowflake.SnowflakeID    `gorm:"not null;uniqueIndex:uk_user_cape_library_user_cape;comment:关联披风库ID" json:"cape_library_id"`                      // 关联披风库ID
+	AssignmentType     entityType.AssignmentType `gorm:"not null;type:smallint;default:1;comment:关联类型(1=normal,2=gift,3=admin,4=collect,5=share)" json:"assignment_type"` // 关联类型
+	ExpiresAt          *time.Time                `gorm:"type:timestamptz;index:idx_user_cape_library_expires_at;comment:赠送到期时间(为空表示永久)" json:"expires_at,omitempty"`      // 赠送到期时间（为空表示永久）
 
 	// ----------
 	//  外键约束
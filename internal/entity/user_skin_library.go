@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"time"
 
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
@@ -17,11 +18,14 @@ import (
 //   - normal：用户自行上传，计入配额
 //   - gift：管理员赠送，不计入配额
 //   - admin：系统预置，不计入配额
+//   - collect：从公开画廊收藏，不计入配额，仅在资源公开期间可装备
+//   - share：通过私有分享链接添加，不计入配额，分享撤销时未装备的关联会被移除
 type UserSkinLibrary struct {
 	xModels.BaseEntity                                                   // 嵌入基础实体字段
 	UserID         xSnowflake.SnowflakeID    `gorm:"not null;uniqueIndex:uk_user_skin_library_user_skin;comment:关联用户ID" json:"user_id"`                                // 关联用户ID
 	SkinLibraryID  xSnowflake.SnowflakeID    `gorm:"not null;uniqueIndex:uk_user_skin_library_user_skin;comment:关联皮肤库ID" json:"skin_library_id"`                       // 关联皮肤库ID
-	AssignmentType entityType.AssignmentType `gorm:"not null;type:smallint;default:1;comment:关联类型(1=normal,2=gift,3=admin)" json:"assignment_type"` // 关联类型
+	AssignmentType entityType.AssignmentType `gorm:"not null;type:smallint;default:1;comment:关联类型(1=normal,2=gift,3=admin,4=collect,5=share)" json:"assignment_type"` // 关联类型
+	ExpiresAt      *time.Time                `gorm:"type:timestamptz;index:idx_user_skin_library_expires_at;comment:赠送到期时间(为空表示永久)" json:"expires_at,omitempty"` // 赠送到期时间（为空表示永久）
 
 	// ----------
 	//  外键约束
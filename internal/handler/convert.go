@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"strconv"
+
+	apiAdmin "github.com/frontleaves-mc/frontleaves-yggleaf/api/admin"
 	apiIssue "github.com/frontleaves-mc/frontleaves-yggleaf/api/issue"
 	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
 	apiUser "github.com/frontleaves-mc/frontleaves-yggleaf/api/user"
@@ -10,15 +13,31 @@ import (
 // skinDTOToResponse 将 SkinDTO 转换为 api/library.SkinResponse DTO。
 func skinDTOToResponse(dto *models.SkinDTO) apiLibrary.SkinResponse {
 	return apiLibrary.SkinResponse{
-		ID:             dto.ID,
-		UserID:         dto.UserID,
-		Name:           dto.Name,
-		TextureURL:     dto.TextureURL,
-		TextureHash:    dto.TextureHash,
-		Model:          dto.Model,
-		IsPublic:       dto.IsPublic,
-		UpdatedAt:      dto.UpdatedAt,
-		AssignmentType: dto.AssignmentType,
+		ID:                    dto.ID,
+		UserID:                dto.UserID,
+		Name:                  dto.Name,
+		TextureURL:            dto.TextureURL,
+		TextureHash:           dto.TextureHash,
+		TextureVersion:        dto.TextureVersion,
+		PendingTextureVersion: dto.PendingTextureVersion,
+		PendingTextureURL:     dto.PendingTextureURL,
+		Model:                 dto.Model,
+		IsPublic:              dto.IsPublic,
+		UpdatedAt:             dto.UpdatedAt,
+		LikeCount:             dto.LikeCount,
+		CollectCount:          dto.CollectCount,
+		ReviewStatus:          dto.ReviewStatus,
+		ReviewReason:          dto.ReviewReason,
+		ReportCount:           dto.ReportCount,
+		IsHidden:              dto.IsHidden,
+		IsDefault:             dto.IsDefault,
+		IsDefaultEquip:        dto.IsDefaultEquip,
+		IsRetired:             dto.IsRetired,
+		SimilarToID:           dto.SimilarToID,
+		Tags:                  dto.Tags,
+		AssignmentType:        dto.AssignmentType,
+		ExpiresAt:             dto.ExpiresAt,
+		ExpiresIn:             dto.ExpiresIn,
 	}
 }
 
@@ -31,17 +50,44 @@ func skinDTOsToResponses(dtos []models.SkinDTO) []apiLibrary.SkinResponse {
 	return responses
 }
 
+// similarSkinDTOsToResponses 批量将 SimilarSkinDTO 列表转换为 SimilarSkinResponse 列表。
+func similarSkinDTOsToResponses(dtos []models.SimilarSkinDTO) []apiLibrary.SimilarSkinResponse {
+	responses := make([]apiLibrary.SimilarSkinResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiLibrary.SimilarSkinResponse{
+			Skin:     skinDTOToResponse(&dto.Skin),
+			Distance: dto.Distance,
+		}
+	}
+	return responses
+}
+
 // capeDTOToResponse 将 CapeDTO 转换为 api/library.CapeResponse DTO。
 func capeDTOToResponse(dto *models.CapeDTO) apiLibrary.CapeResponse {
 	return apiLibrary.CapeResponse{
-		ID:             dto.ID,
-		UserID:         dto.UserID,
-		Name:           dto.Name,
-		TextureURL:     dto.TextureURL,
-		TextureHash:    dto.TextureHash,
-		IsPublic:       dto.IsPublic,
-		UpdatedAt:      dto.UpdatedAt,
-		AssignmentType: dto.AssignmentType,
+		ID:                    dto.ID,
+		UserID:                dto.UserID,
+		Name:                  dto.Name,
+		TextureURL:            dto.TextureURL,
+		TextureHash:           dto.TextureHash,
+		TextureVersion:        dto.TextureVersion,
+		PendingTextureVersion: dto.PendingTextureVersion,
+		PendingTextureURL:     dto.PendingTextureURL,
+		IsPublic:              dto.IsPublic,
+		UpdatedAt:             dto.UpdatedAt,
+		LikeCount:             dto.LikeCount,
+		CollectCount:          dto.CollectCount,
+		ReviewStatus:          dto.ReviewStatus,
+		ReviewReason:          dto.ReviewReason,
+		ReportCount:           dto.ReportCount,
+		IsHidden:              dto.IsHidden,
+		IsDefault:             dto.IsDefault,
+		IsRetired:             dto.IsRetired,
+		SimilarToID:           dto.SimilarToID,
+		Tags:                  dto.Tags,
+		AssignmentType:        dto.AssignmentType,
+		ExpiresAt:             dto.ExpiresAt,
+		ExpiresIn:             dto.ExpiresIn,
 	}
 }
 
@@ -54,15 +100,190 @@ func capeDTOsToResponses(dtos []models.CapeDTO) []apiLibrary.CapeResponse {
 	return responses
 }
 
+// similarCapeDTOsToResponses 批量将 SimilarCapeDTO 列表转换为 SimilarCapeResponse 列表。
+func similarCapeDTOsToResponses(dtos []models.SimilarCapeDTO) []apiLibrary.SimilarCapeResponse {
+	responses := make([]apiLibrary.SimilarCapeResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiLibrary.SimilarCapeResponse{
+			Cape:     capeDTOToResponse(&dto.Cape),
+			Distance: dto.Distance,
+		}
+	}
+	return responses
+}
+
 // gameProfileDTOToResponse 将 GameProfileDTO 转换为 api/user.GameProfileResponse DTO。
 func gameProfileDTOToResponse(dto *models.GameProfileDTO) apiUser.GameProfileResponse {
 	resp := apiUser.GameProfileResponse{
+		ID:                    dto.ID,
+		UserID:                dto.UserID,
+		UUID:                  dto.UUID,
+		Name:                  dto.Name,
+		SkinLibraryID:         dto.SkinLibraryID,
+		CapeLibraryID:         dto.CapeLibraryID,
+		UpdatedAt:             dto.UpdatedAt,
+		LastSeenAt:            dto.LastSeenAt,
+		NameChangedAt:         dto.NameChangedAt,
+		NameChangeAvailableAt: dto.NameChangeAvailableAt,
+		LockedAt:              dto.LockedAt,
+		LockReason:            dto.LockReason,
+	}
+	if dto.Skin != nil {
+		skinResp := skinDTOToResponse(dto.Skin)
+		resp.Skin = &skinResp
+	}
+	if dto.Cape != nil {
+		capeResp := capeDTOToResponse(dto.Cape)
+		resp.Cape = &capeResp
+	}
+	return resp
+}
+
+// gameProfileDTOsToResponses 批量将 GameProfileDTO 列表转换为 GameProfileResponse DTO 列表。
+func gameProfileDTOsToResponses(dtos []models.GameProfileDTO) []apiUser.GameProfileResponse {
+	responses := make([]apiUser.GameProfileResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = gameProfileDTOToResponse(&dto)
+	}
+	return responses
+}
+
+// gameProfileDeletionDTOToResponse 将 GameProfileDeletionDTO 转换为 api/user.DeleteGameProfileResponse。
+func gameProfileDeletionDTOToResponse(dto *models.GameProfileDeletionDTO) apiUser.DeleteGameProfileResponse {
+	return apiUser.DeleteGameProfileResponse{
+		ProfileID:         dto.ProfileID,
+		UserID:            dto.UserID,
+		UUID:              dto.UUID,
+		Name:              dto.Name,
+		RevokedTokens:     dto.RevokedTokens,
+		NameReservedUntil: dto.NameReservedUntil,
+	}
+}
+
+// gameProfileTransferDTOToResponse 将 GameProfileTransferDTO 转换为 api/user.GameProfileTransferResponse。
+func gameProfileTransferDTOToResponse(dto models.GameProfileTransferDTO) apiUser.GameProfileTransferResponse {
+	return apiUser.GameProfileTransferResponse{
+		ID:          dto.ID,
+		ProfileID:   dto.ProfileID,
+		ProfileUUID: dto.ProfileUUID,
+		ProfileName: dto.ProfileName,
+		FromUserID:  dto.FromUserID,
+		ToUserID:    dto.ToUserID,
+		Direction:   dto.Direction,
+		Status:      dto.Status,
+		Remark:      dto.Remark,
+		ExpiresAt:   dto.ExpiresAt,
+		RespondedAt: dto.RespondedAt,
+		CreatedAt:   dto.CreatedAt,
+	}
+}
+
+// gameProfileTransferDTOsToResponses 批量将 GameProfileTransferDTO 转换为响应列表。
+func gameProfileTransferDTOsToResponses(dtos []models.GameProfileTransferDTO) []apiUser.GameProfileTransferResponse {
+	responses := make([]apiUser.GameProfileTransferResponse, 0, len(dtos))
+	for _, dto := range dtos {
+		responses = append(responses, gameProfileTransferDTOToResponse(dto))
+	}
+	return responses
+}
+
+// gameProfileTransferAcceptDTOToResponse 将 GameProfileTransferAcceptDTO 转换为 api/user.AcceptGameProfileTransferResponse。
+func gameProfileTransferAcceptDTOToResponse(dto *models.GameProfileTransferAcceptDTO) apiUser.AcceptGameProfileTransferResponse {
+	return apiUser.AcceptGameProfileTransferResponse{
+		Transfer:      gameProfileTransferDTOToResponse(dto.Transfer),
+		Profile:       gameProfileDTOToResponse(dto.Profile),
+		SkinUnbound:   dto.SkinUnbound,
+		CapeUnbound:   dto.CapeUnbound,
+		RevokedTokens: dto.RevokedTokens,
+	}
+}
+
+// gameProfileNameHistoryDTOsToResponses 批量将 GameProfileNameHistoryDTO 转换为响应列表。
+func gameProfileNameHistoryDTOsToResponses(dtos []models.GameProfileNameHistoryDTO) []apiUser.GameProfileNameHistoryResponse {
+	responses := make([]apiUser.GameProfileNameHistoryResponse, 0, len(dtos))
+	for _, dto := range dtos {
+		responses = append(responses, apiUser.GameProfileNameHistoryResponse{
+			Name:       dto.Name,
+			Source:     dto.Source,
+			UserID:     dto.UserID,
+			OperatorID: dto.OperatorID,
+			ChangedAt:  dto.ChangedAt,
+		})
+	}
+	return responses
+}
+
+// gameProfileNameRuleDTOToResponse 将 GameProfileNameRuleDTO 转换为 api/user.GameProfileNameRuleResponse。
+func gameProfileNameRuleDTOToResponse(dto *models.GameProfileNameRuleDTO) apiUser.GameProfileNameRuleResponse {
+	return apiUser.GameProfileNameRuleResponse{
+		ID:            dto.ID,
+		Kind:          dto.Kind,
+		MatchType:     dto.MatchType,
+		Pattern:       dto.Pattern,
+		AllowedUserID: dto.AllowedUserID,
+		Reason:        dto.Reason,
+		IsEnabled:     dto.IsEnabled,
+		CreatedAt:     dto.CreatedAt,
+		UpdatedAt:     dto.UpdatedAt,
+	}
+}
+
+// gameProfileNameRuleDTOsToResponses 批量将 GameProfileNameRuleDTO 转换为响应列表。
+func gameProfileNameRuleDTOsToResponses(dtos []models.GameProfileNameRuleDTO) []apiUser.GameProfileNameRuleResponse {
+	responses := make([]apiUser.GameProfileNameRuleResponse, 0, len(dtos))
+	for i := range dtos {
+		responses = append(responses, gameProfileNameRuleDTOToResponse(&dtos[i]))
+	}
+	return responses
+}
+
+// gameProfileNameConflictReportDTOToResponse 将 GameProfileNameConflictReportDTO 转换为 api/user.GameProfileNameConflictReportResponse。
+func gameProfileNameConflictReportDTOToResponse(dto *models.GameProfileNameConflictReportDTO) apiUser.GameProfileNameConflictReportResponse {
+	groups := make([]apiUser.GameProfileNameConflictGroupResponse, 0, len(dto.Groups))
+	for _, group := range dto.Groups {
+		profiles := make([]apiUser.GameProfileResponse, 0, len(group.Profiles))
+		for i := range group.Profiles {
+			profiles = append(profiles, gameProfileDTOToResponse(&group.Profiles[i]))
+		}
+		groups = append(groups, apiUser.GameProfileNameConflictGroupResponse{
+			Key:      group.Key,
+			Profiles: profiles,
+		})
+	}
+	return apiUser.GameProfileNameConflictReportResponse{
+		Enforced: dto.Enforced,
+		Groups:   groups,
+	}
+}
+
+// gameProfileAuditLogDTOsToResponses 批量将 GameProfileAuditLogDTO 转换为 api/user.GameProfileAuditLogResponse 列表。
+func gameProfileAuditLogDTOsToResponses(dtos []models.GameProfileAuditLogDTO) []apiUser.GameProfileAuditLogResponse {
+	responses := make([]apiUser.GameProfileAuditLogResponse, 0, len(dtos))
+	for _, dto := range dtos {
+		responses = append(responses, apiUser.GameProfileAuditLogResponse{
+			ID:          dto.ID,
+			ProfileID:   dto.ProfileID,
+			ProfileUUID: dto.ProfileUUID,
+			ProfileName: dto.ProfileName,
+			UserID:      dto.UserID,
+			OperatorID:  dto.OperatorID,
+			Action:      dto.Action,
+			Reason:      dto.Reason,
+			Detail:      dto.Detail,
+			CreatedAt:   dto.CreatedAt,
+		})
+	}
+	return responses
+}
+
+// outfitDTOToResponse 将 GameProfileOutfitDTO 转换为 api/user.OutfitResponse。
+func outfitDTOToResponse(dto *models.GameProfileOutfitDTO) apiUser.OutfitResponse {
+	resp := apiUser.OutfitResponse{
 		ID:            dto.ID,
-		UserID:        dto.UserID,
-		UUID:          dto.UUID,
 		Name:          dto.Name,
 		SkinLibraryID: dto.SkinLibraryID,
 		CapeLibraryID: dto.CapeLibraryID,
+		CreatedAt:     dto.CreatedAt,
 		UpdatedAt:     dto.UpdatedAt,
 	}
 	if dto.Skin != nil {
@@ -76,11 +297,46 @@ func gameProfileDTOToResponse(dto *models.GameProfileDTO) apiUser.GameProfileRes
 	return resp
 }
 
-// gameProfileDTOsToResponses 批量将 GameProfileDTO 列表转换为 GameProfileResponse DTO 列表。
-func gameProfileDTOsToResponses(dtos []models.GameProfileDTO) []apiUser.GameProfileResponse {
-	responses := make([]apiUser.GameProfileResponse, len(dtos))
+// outfitDTOsToResponses 批量将 GameProfileOutfitDTO 列表转换为 OutfitResponse 列表。
+func outfitDTOsToResponses(dtos []models.GameProfileOutfitDTO) []apiUser.OutfitResponse {
+	responses := make([]apiUser.OutfitResponse, len(dtos))
 	for i, dto := range dtos {
-		responses[i] = gameProfileDTOToResponse(&dto)
+		responses[i] = outfitDTOToResponse(&dto)
+	}
+	return responses
+}
+
+// gameProfileJoinDTOsToResponses 批量将 GameProfileJoinDTO 列表转换为 GameProfileJoinResponse 列表。
+//
+// withClientIP 为 false 时隐藏客户端 IP（玩家视角）。
+func gameProfileJoinDTOsToResponses(dtos []models.GameProfileJoinDTO, withClientIP bool) []apiUser.GameProfileJoinResponse {
+	responses := make([]apiUser.GameProfileJoinResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiUser.GameProfileJoinResponse{
+			ID:            dto.ID,
+			GameProfileID: dto.GameProfileID,
+			UserID:        dto.UserID,
+			ProfileName:   dto.ProfileName,
+			ServerAddress: dto.ServerAddress,
+			JoinedAt:      dto.JoinedAt,
+		}
+		if withClientIP {
+			responses[i].ClientIP = dto.ClientIP
+		}
+	}
+	return responses
+}
+
+// dailyActiveDTOsToResponses 批量将 DailyActiveDTO 列表转换为 DailyActiveResponse 列表。
+func dailyActiveDTOsToResponses(dtos []models.DailyActiveDTO) []apiUser.DailyActiveResponse {
+	responses := make([]apiUser.DailyActiveResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiUser.DailyActiveResponse{
+			Day:            dto.Day,
+			ActiveProfiles: dto.ActiveProfiles,
+			ActiveUsers:    dto.ActiveUsers,
+			JoinCount:      dto.JoinCount,
+		}
 	}
 	return responses
 }
@@ -228,3 +484,280 @@ func issueTypeDTOToResponse(dto *models.IssueTypeDTO) apiIssue.IssueTypeListItem
 		SortOrder:   dto.SortOrder,
 	}
 }
+
+// libraryTagDTOsToResponses 批量将 LibraryTagDTO 列表转换为 api/library.TagResponse 列表。
+func libraryTagDTOsToResponses(dtos []models.LibraryTagDTO) []apiLibrary.TagResponse {
+	responses := make([]apiLibrary.TagResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiLibrary.TagResponse{
+			Name:       dto.Name,
+			UsageCount: dto.UsageCount,
+		}
+	}
+	return responses
+}
+
+// libraryLikeDTOToResponse 将 LibraryLikeDTO 转换为 api/library.LikeResponse。
+func libraryLikeDTOToResponse(dto *models.LibraryLikeDTO) apiLibrary.LikeResponse {
+	return apiLibrary.LikeResponse{
+		Liked:     dto.Liked,
+		LikeCount: dto.LikeCount,
+	}
+}
+
+// libraryReportDTOsToResponses 批量将 LibraryReportDTO 列表转换为 ReportResponse DTO 列表。
+func libraryReportDTOsToResponses(dtos []models.LibraryReportDTO) []apiLibrary.ReportResponse {
+	responses := make([]apiLibrary.ReportResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiLibrary.ReportResponse{
+			ID:          dto.ID,
+			ReporterID:  dto.ReporterID,
+			Kind:        dto.Kind,
+			LibraryID:   dto.LibraryID,
+			Reason:      dto.Reason,
+			Description: dto.Description,
+			Status:      dto.Status,
+			CreatedAt:   dto.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// textureVersionDTOsToResponses 将 LibraryTextureVersionDTO 列表转换为 api/library.TextureVersionResponse 列表。
+func textureVersionDTOsToResponses(dtos []models.LibraryTextureVersionDTO) []apiLibrary.TextureVersionResponse {
+	responses := make([]apiLibrary.TextureVersionResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiLibrary.TextureVersionResponse{
+			Version:     dto.Version,
+			TextureURL:  dto.TextureURL,
+			TextureHash: dto.TextureHash,
+			IsCurrent:   dto.IsCurrent,
+			IsPending:   dto.IsPending,
+			CreatedBy:   dto.CreatedBy,
+			CreatedAt:   dto.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// libraryImportResultsToResponse 将 LibraryImportResultDTO 列表汇总为 api/library.ArchiveImportResponse。
+func libraryImportResultsToResponse(dtos []models.LibraryImportResultDTO) apiLibrary.ArchiveImportResponse {
+	response := apiLibrary.ArchiveImportResponse{
+		Total: len(dtos),
+		Items: make([]apiLibrary.ArchiveImportItemResponse, len(dtos)),
+	}
+	for i, dto := range dtos {
+		if dto.Status == models.LibraryImportStatusCreated {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		response.Items[i] = apiLibrary.ArchiveImportItemResponse{
+			File:    dto.File,
+			Kind:    dto.Kind,
+			Status:  dto.Status,
+			ID:      dto.ID,
+			Message: dto.Message,
+		}
+	}
+	return response
+}
+
+// libraryCampaignDTOToResponse 将 LibraryCampaignDTO 转换为 api/library.CampaignResponse。
+func libraryCampaignDTOToResponse(dto *models.LibraryCampaignDTO) apiLibrary.CampaignResponse {
+	return apiLibrary.CampaignResponse{
+		ID:             dto.ID,
+		Name:           dto.Name,
+		Kind:           dto.Kind,
+		LibraryID:      dto.LibraryID,
+		AssignmentType: dto.AssignmentType,
+		Status:         dto.Status,
+		Filter: apiLibrary.CampaignFilterResponse{
+			RoleName:         dto.Filter.RoleName,
+			RegisteredAfter:  dto.Filter.RegisteredAfter,
+			RegisteredBefore: dto.Filter.RegisteredBefore,
+			HasProfile:       dto.Filter.HasProfile,
+			UserIDs:          dto.Filter.UserIDs,
+		},
+		MatchedCount: dto.MatchedCount,
+		GrantedCount: dto.GrantedCount,
+		SkippedCount: dto.SkippedCount,
+		FailedCount:  dto.FailedCount,
+		RevokedCount: dto.RevokedCount,
+		LastError:    dto.LastError,
+		CreatedBy:    dto.CreatedBy,
+		StartedAt:    dto.StartedAt,
+		FinishedAt:   dto.FinishedAt,
+		RevokedBy:    dto.RevokedBy,
+		RevokedAt:    dto.RevokedAt,
+		CreatedAt:    dto.CreatedAt,
+		UpdatedAt:    dto.UpdatedAt,
+	}
+}
+
+// libraryCampaignGrantDTOsToResponses 将 LibraryCampaignGrantDTO 列表转换为 api/library.CampaignGrantResponse 列表。
+func libraryCampaignGrantDTOsToResponses(dtos []models.LibraryCampaignGrantDTO) []apiLibrary.CampaignGrantResponse {
+	responses := make([]apiLibrary.CampaignGrantResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiLibrary.CampaignGrantResponse{
+			UserID:    dto.UserID,
+			Status:    dto.Status,
+			Message:   dto.Message,
+			UpdatedAt: dto.UpdatedAt,
+		}
+	}
+	return responses
+}
+
+// libraryShareDTOToResponse 将 LibraryShareDTO 转换为 api/library.ShareResponse。
+func libraryShareDTOToResponse(dto *models.LibraryShareDTO) apiLibrary.ShareResponse {
+	return apiLibrary.ShareResponse{
+		ID:         dto.ID,
+		Kind:       dto.Kind,
+		LibraryID:  dto.LibraryID,
+		Token:      dto.Token,
+		PreviewURL: dto.PreviewURL,
+		ExpiresAt:  dto.ExpiresAt,
+		ClaimCount: dto.ClaimCount,
+		RevokedAt:  dto.RevokedAt,
+		CreatedAt:  dto.CreatedAt,
+	}
+}
+
+// libraryShareDTOsToResponses 将 LibraryShareDTO 列表转换为 api/library.ShareResponse 列表。
+func libraryShareDTOsToResponses(dtos []models.LibraryShareDTO) []apiLibrary.ShareResponse {
+	responses := make([]apiLibrary.ShareResponse, len(dtos))
+	for i := range dtos {
+		responses[i] = libraryShareDTOToResponse(&dtos[i])
+	}
+	return responses
+}
+
+// librarySharePreviewDTOToResponse 将 LibrarySharePreviewDTO 转换为 api/library.SharePreviewResponse。
+func librarySharePreviewDTOToResponse(dto *models.LibrarySharePreviewDTO) apiLibrary.SharePreviewResponse {
+	return apiLibrary.SharePreviewResponse{
+		Kind:       dto.Kind,
+		LibraryID:  dto.LibraryID,
+		Name:       dto.Name,
+		TextureURL: dto.TextureURL,
+		Model:      dto.Model,
+		ExpiresAt:  dto.ExpiresAt,
+	}
+}
+
+// redeemCodeDTOsToResponses 将 RedeemCodeDTO 列表转换为 api/library.RedeemCodeResponse 列表。
+func redeemCodeDTOsToResponses(dtos []models.RedeemCodeDTO) []apiLibrary.RedeemCodeResponse {
+	responses := make([]apiLibrary.RedeemCodeResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiLibrary.RedeemCodeResponse{
+			ID:                dto.ID,
+			Code:              dto.Code,
+			BatchID:           dto.BatchID,
+			SkinLibraryID:     dto.Payload.SkinLibraryID,
+			CapeLibraryID:     dto.Payload.CapeLibraryID,
+			SkinsPrivateDelta: dto.Payload.SkinsPrivateDelta,
+			SkinsPublicDelta:  dto.Payload.SkinsPublicDelta,
+			CapesPrivateDelta: dto.Payload.CapesPrivateDelta,
+			CapesPublicDelta:  dto.Payload.CapesPublicDelta,
+			ProfileQuotaDelta: dto.Payload.ProfileQuotaDelta,
+			MaxUses:           dto.Payload.MaxUses,
+			UsedCount:         dto.UsedCount,
+			ExpiresAt:         dto.Payload.ExpiresAt,
+			AllowedRoles:      dto.Payload.AllowedRoles,
+			IsDisabled:        dto.IsDisabled,
+			CreatedAt:         dto.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// redeemCodeBatchDTOToResponse 将 RedeemCodeBatchDTO 转换为 api/library.RedeemCodeBatchResponse。
+func redeemCodeBatchDTOToResponse(dto *models.RedeemCodeBatchDTO) apiLibrary.RedeemCodeBatchResponse {
+	return apiLibrary.RedeemCodeBatchResponse{
+		ID:        dto.ID,
+		Count:     dto.Count,
+		Remark:    dto.Remark,
+		CreatedBy: dto.CreatedBy,
+		CreatedAt: dto.CreatedAt,
+		Codes:     redeemCodeDTOsToResponses(dto.Codes),
+	}
+}
+
+// redeemResultDTOToResponse 将 RedeemResultDTO 转换为 api/library.RedeemResultResponse。
+func redeemResultDTOToResponse(dto *models.RedeemResultDTO) apiLibrary.RedeemResultResponse {
+	return apiLibrary.RedeemResultResponse{
+		Code:              dto.Code,
+		SkinLibraryID:     dto.SkinLibraryID,
+		CapeLibraryID:     dto.CapeLibraryID,
+		SkinsPrivateDelta: dto.SkinsPrivateDelta,
+		SkinsPublicDelta:  dto.SkinsPublicDelta,
+		CapesPrivateDelta: dto.CapesPrivateDelta,
+		CapesPublicDelta:  dto.CapesPublicDelta,
+		ProfileQuotaDelta: dto.ProfileQuotaDelta,
+	}
+}
+
+// libraryQuotaLogDTOsToResponses 将 LibraryQuotaLogDTO 列表转换为 api/library.QuotaLogResponse 列表。
+func libraryQuotaLogDTOsToResponses(dtos []models.LibraryQuotaLogDTO) []apiLibrary.QuotaLogResponse {
+	responses := make([]apiLibrary.QuotaLogResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiLibrary.QuotaLogResponse{
+			ID:           dto.ID,
+			OpType:       dto.OpType,
+			Bucket:       dto.Bucket,
+			Delta:        dto.Delta,
+			BeforeUsed:   dto.BeforeUsed,
+			AfterUsed:    dto.AfterUsed,
+			BeforeTotal:  dto.BeforeTotal,
+			AfterTotal:   dto.AfterTotal,
+			RefLibraryID: dto.RefLibraryID,
+			OperatorID:   dto.OperatorID,
+			Remark:       dto.Remark,
+			CreatedAt:    dto.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// storageReconcileReportDTOToResponse 将 StorageReconcileReportDTO 转换为 api/admin.StorageReconcileReportResponse。
+func storageReconcileReportDTOToResponse(dto *models.StorageReconcileReportDTO) apiAdmin.StorageReconcileReportResponse {
+	return apiAdmin.StorageReconcileReportResponse{
+		ID:                dto.ID,
+		DryRun:            dto.DryRun,
+		Status:            dto.Status,
+		TriggeredBy:       dto.TriggeredBy,
+		CheckedReferences: dto.CheckedReferences,
+		CheckedFiles:      dto.CheckedFiles,
+		OrphanCount:       dto.OrphanCount,
+		DanglingCount:     dto.DanglingCount,
+		MismatchCount:     dto.MismatchCount,
+		UnverifiedCount:   dto.UnverifiedCount,
+		FixedCount:        dto.FixedCount,
+		FailedCount:       dto.FailedCount,
+		LastError:         dto.LastError,
+		FinishedAt:        dto.FinishedAt,
+		CreatedAt:         dto.CreatedAt,
+		UpdatedAt:         dto.UpdatedAt,
+	}
+}
+
+// storageReconcileIssueDTOsToResponses 将 StorageReconcileIssueDTO 列表转换为 api/admin.StorageReconcileIssueResponse 列表。
+func storageReconcileIssueDTOsToResponses(dtos []models.StorageReconcileIssueDTO) []apiAdmin.StorageReconcileIssueResponse {
+	responses := make([]apiAdmin.StorageReconcileIssueResponse, len(dtos))
+	for i, dto := range dtos {
+		responses[i] = apiAdmin.StorageReconcileIssueResponse{
+			ID:           dto.ID,
+			ReportID:     dto.ReportID,
+			Type:         dto.Type,
+			Source:       dto.Source,
+			FileID:       strconv.FormatInt(dto.FileID, 10),
+			RefID:        dto.RefID,
+			ExpectedHash: dto.ExpectedHash,
+			ActualHash:   dto.ActualHash,
+			Action:       dto.Action,
+			Message:      dto.Message,
+			CreatedAt:    dto.CreatedAt,
+		}
+	}
+	return responses
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"strconv"
+
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
@@ -10,6 +12,11 @@ import (
 	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
 )
 
+const (
+	defaultJoinWindowHours = 24 // 近期进服查询默认时间窗口（小时）
+	defaultDailyActiveDays = 7  // 每日活跃统计默认天数
+)
+
 // AddGameProfile 创建当前用户的游戏档案
 //
 // @Summary     [玩家] 创建游戏档案
@@ -57,7 +64,7 @@ func (h *GameProfileHandler) AddGameProfile(ctx *gin.Context) {
 // ChangeUsername 修改当前用户指定游戏档案用户名
 //
 // @Summary     [玩家] 修改用户名
-// @Description 根据档案 ID 修改游戏档案用户名
+// @Description 根据档案 ID 修改游戏档案用户名。两次改名之间存在冷却期；改名后旧名称在保留期内仅可由自己重新使用
 // @Tags        游戏档案接口
 // @Accept      json
 // @Produce     json
@@ -66,6 +73,7 @@ func (h *GameProfileHandler) AddGameProfile(ctx *gin.Context) {
 // @Success     200   {object}  xBase.BaseResponse{data=apiUser.GameProfileResponse} "修改成功"
 // @Failure     400   {object}  xBase.BaseResponse                               "请求参数错误"
 // @Failure     401   {object}  xBase.BaseResponse                               "未授权"
+// @Failure     403   {object}  xBase.BaseResponse                               "改名冷却中或游戏档案已被锁定"
 // @Failure     404   {object}  xBase.BaseResponse                               "资源不存在"
 // @Failure     409   {object}  xBase.BaseResponse                               "资源冲突"
 // @Router      /game-profile/{profile_id}/username [PATCH]
@@ -286,6 +294,7 @@ func (h *GameProfileHandler) AdjustQuotaAdmin(ctx *gin.Context) {
 // @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileResponse} "操作成功"
 // @Failure     400 {object} xBase.BaseResponse "请求参数错误"
 // @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "游戏档案已被锁定"
 // @Failure     404 {object} xBase.BaseResponse "资源不存在"
 // @Router      /game-profile/{profile_id}/skin [PATCH]
 func (h *GameProfileHandler) SetSkin(ctx *gin.Context) {
@@ -345,6 +354,7 @@ func (h *GameProfileHandler) SetSkin(ctx *gin.Context) {
 // @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileResponse} "操作成功"
 // @Failure     400 {object} xBase.BaseResponse "请求参数错误"
 // @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "游戏档案已被锁定"
 // @Failure     404 {object} xBase.BaseResponse "资源不存在"
 // @Router      /game-profile/{profile_id}/cape [PATCH]
 func (h *GameProfileHandler) SetCape(ctx *gin.Context) {
@@ -391,3 +401,156 @@ func (h *GameProfileHandler) SetCape(ctx *gin.Context) {
 
 	xResult.SuccessHasData(ctx, "设置披风成功", gameProfileDTOToResponse(profile))
 }
+
+// ==================== Join History Handlers ====================
+
+// ListRecentJoins 获取指定游戏档案的最近进服记录
+//
+// @Summary     [玩家] 获取最近游玩记录
+// @Description 分页获取当前用户指定游戏档案的进服历史，按进服时间倒序
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileJoinListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Router      /game-profile/{profile_id}/recent-joins [GET]
+func (h *GameProfileHandler) ListRecentJoins(ctx *gin.Context) {
+	h.log.Info(ctx, "ListRecentJoins - 获取最近游玩记录")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	page, pageSize := h.parsePagination(ctx)
+
+	joins, total, xErr := h.service.gameProfileLogic.ListRecentJoins(ctx.Request.Context(), userID, profileID, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiUser.GameProfileJoinListResponse{
+		Total: total,
+		Items: gameProfileJoinDTOsToResponses(joins, false),
+	}
+	xResult.SuccessHasData(ctx, "获取最近游玩记录成功", response)
+}
+
+// AdminListRecentJoins 查询最近 N 小时内的进服记录（管理员）
+//
+// @Summary     [超管] 查询近期进服记录
+// @Description 分页查询最近 N 小时内所有玩家的进服记录，并返回时间窗口内的去重活跃档案数
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       hours query int false "时间窗口（小时），默认 24，最大 720"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.AdminRecentJoinListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/joins [GET]
+func (h *GameProfileHandler) AdminListRecentJoins(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminListRecentJoins - 管理员查询近期进服记录")
+
+	hours, err := strconv.Atoi(ctx.DefaultQuery("hours", strconv.Itoa(defaultJoinWindowHours)))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析时间窗口失败", true, err))
+		return
+	}
+
+	page, pageSize := h.parsePagination(ctx)
+
+	joins, total, activeProfiles, xErr := h.service.gameProfileLogic.ListJoinsWithinHours(ctx.Request.Context(), hours, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiUser.AdminRecentJoinListResponse{
+		Hours:          hours,
+		ActiveProfiles: activeProfiles,
+		Total:          total,
+		Items:          gameProfileJoinDTOsToResponses(joins, true),
+	}
+	xResult.SuccessHasData(ctx, "获取近期进服记录成功", response)
+}
+
+// AdminGetDailyActive 查询每日活跃玩家统计（管理员）
+//
+// @Summary     [超管] 每日活跃玩家统计
+// @Description 按自然日聚合最近 N 天的活跃档案数、活跃用户数与进服次数
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       days query int false "统计天数，默认 7，最大 90"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.DailyActiveListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/daily-active [GET]
+func (h *GameProfileHandler) AdminGetDailyActive(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminGetDailyActive - 管理员查询每日活跃玩家统计")
+
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", strconv.Itoa(defaultDailyActiveDays)))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析统计天数失败", true, err))
+		return
+	}
+
+	stats, xErr := h.service.gameProfileLogic.GetDailyActive(ctx.Request.Context(), days)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiUser.DailyActiveListResponse{
+		Days:  days,
+		Items: dailyActiveDTOsToResponses(stats),
+	}
+	xResult.SuccessHasData(ctx, "获取每日活跃玩家统计成功", response)
+}
+
+// ==================== Helper Methods ====================
+
+func (h *GameProfileHandler) parsePagination(ctx *gin.Context) (int, int) {
+	pageStr := ctx.DefaultQuery("page", strconv.Itoa(defaultPage))
+	pageSizeStr := ctx.DefaultQuery("page_size", strconv.Itoa(defaultPageSize))
+
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		page = defaultPage
+	}
+
+	pageSize, err := strconv.Atoi(pageSizeStr)
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
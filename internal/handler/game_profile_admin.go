@@ -0,0 +1,272 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiUser "github.com/frontleaves-mc/frontleaves-yggleaf/api/user"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// AdminRenameGameProfile 管理员强制修改游戏档案用户名
+//
+// @Summary     [超管] 强制修改游戏档案用户名
+// @Description 管理员修改任意用户游戏档案的用户名（如处理违规名称），不受改名冷却与保留名称规则限制，旧名称立即释放。名称历史来源记为 admin，操作者与原因写入审计记录
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       request body apiUser.AdminRenameGameProfileRequest true "管理员强制改名请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileResponse} "修改成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案不存在"
+// @Failure     409 {object} xBase.BaseResponse "用户名已存在或处于保留期"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/profiles/{profile_id}/rename [POST]
+func (h *GameProfileHandler) AdminRenameGameProfile(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminRenameGameProfile - 管理员强制修改游戏档案用户名")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.AdminRenameGameProfileRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	profile, xErr := h.service.gameProfileLogic.AdminRenameGameProfile(ctx.Request.Context(), operatorID, profileID, req.Name, req.Reason)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "修改用户名成功", gameProfileDTOToResponse(profile))
+}
+
+// AdminClearProfileSkin 管理员清除游戏档案皮肤
+//
+// @Summary     [超管] 清除游戏档案皮肤
+// @Description 卸下任意用户游戏档案当前装备的皮肤，皮肤仍保留在持有者的皮肤库中。操作者与原因写入审计记录
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       request body apiUser.AdminGameProfileActionRequest true "管理员操作请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileResponse} "清除成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案不存在"
+// @Failure     409 {object} xBase.BaseResponse "游戏档案未装备皮肤"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/profiles/{profile_id}/skin/clear [POST]
+func (h *GameProfileHandler) AdminClearProfileSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminClearProfileSkin - 管理员清除游戏档案皮肤")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.AdminGameProfileActionRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	profile, xErr := h.service.gameProfileLogic.AdminClearProfileSkin(ctx.Request.Context(), operatorID, profileID, req.Reason)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "清除皮肤成功", gameProfileDTOToResponse(profile))
+}
+
+// AdminLockGameProfile 管理员锁定游戏档案
+//
+// @Summary     [超管] 锁定游戏档案
+// @Description 锁定任意用户的游戏档案：锁定后无法进入服务器，持有者也无法改名、更换外观、删除或转移该档案。操作者与原因写入审计记录，原因同时作为锁定原因展示
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       request body apiUser.AdminGameProfileActionRequest true "管理员操作请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileResponse} "锁定成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案不存在"
+// @Failure     409 {object} xBase.BaseResponse "游戏档案已处于锁定状态"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/profiles/{profile_id}/lock [POST]
+func (h *GameProfileHandler) AdminLockGameProfile(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminLockGameProfile - 管理员锁定游戏档案")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.AdminGameProfileActionRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	profile, xErr := h.service.gameProfileLogic.AdminLockGameProfile(ctx.Request.Context(), operatorID, profileID, req.Reason)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "锁定游戏档案成功", gameProfileDTOToResponse(profile))
+}
+
+// AdminUnlockGameProfile 管理员解锁游戏档案
+//
+// @Summary     [超管] 解锁游戏档案
+// @Description 解除游戏档案的锁定，操作者与原因写入审计记录
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       request body apiUser.AdminGameProfileActionRequest true "管理员操作请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileResponse} "解锁成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案不存在"
+// @Failure     409 {object} xBase.BaseResponse "游戏档案未被锁定"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/profiles/{profile_id}/unlock [POST]
+func (h *GameProfileHandler) AdminUnlockGameProfile(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminUnlockGameProfile - 管理员解锁游戏档案")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.AdminGameProfileActionRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	profile, xErr := h.service.gameProfileLogic.AdminUnlockGameProfile(ctx.Request.Context(), operatorID, profileID, req.Reason)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "解锁游戏档案成功", gameProfileDTOToResponse(profile))
+}
+
+// AdminListAuditLogs 管理员查询游戏档案审计记录
+//
+// @Summary     [超管] 查询游戏档案审计记录
+// @Description 分页查询管理员对游戏档案的操作记录（改名、清除皮肤、锁定、解锁、删除），可按档案或持有者过滤，档案删除后记录仍保留
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id query string false "游戏档案 ID"
+// @Param       user_id    query string false "操作时的持有者用户 ID"
+// @Param       page       query int    false "页码（默认 1）"
+// @Param       page_size  query int    false "每页数量（默认 20，最大 100）"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileAuditLogListResponse} "查询成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/audit-logs [GET]
+func (h *GameProfileHandler) AdminListAuditLogs(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminListAuditLogs - 管理员查询游戏档案审计记录")
+
+	var profileID *xSnowflake.SnowflakeID
+	if raw := ctx.Query("profile_id"); raw != "" {
+		parsed, err := xSnowflake.ParseSnowflakeID(raw)
+		if err != nil {
+			_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+			return
+		}
+		profileID = &parsed
+	}
+	var userID *xSnowflake.SnowflakeID
+	if raw := ctx.Query("user_id"); raw != "" {
+		parsed, err := xSnowflake.ParseSnowflakeID(raw)
+		if err != nil {
+			_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+			return
+		}
+		userID = &parsed
+	}
+
+	page, pageSize := h.parsePagination(ctx)
+
+	items, total, xErr := h.service.gameProfileLogic.AdminListAuditLogs(ctx.Request.Context(), profileID, userID, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取审计记录成功", apiUser.GameProfileAuditLogListResponse{
+		Total: total,
+		Items: gameProfileAuditLogDTOsToResponses(items),
+	})
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiUser "github.com/frontleaves-mc/frontleaves-yggleaf/api/user"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// DeleteGameProfile 删除游戏档案
+//
+// @Summary     [玩家] 删除游戏档案
+// @Description 删除自己的游戏档案并退还一个档案配额。需提交与档案用户名一致的 confirm_name 作为确认；绑定该档案的令牌会被吊销，待验证的进服会话会被清除。服务端配置了名称保留期时，名称在保留期内仅可由自己重新使用
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       request body apiUser.DeleteGameProfileRequest true "删除游戏档案请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.DeleteGameProfileResponse} "删除成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误或确认名称不一致"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "游戏档案已被锁定"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案不存在"
+// @Security    BearerAuth
+// @Router      /game-profile/{profile_id} [DELETE]
+func (h *GameProfileHandler) DeleteGameProfile(ctx *gin.Context) {
+	h.log.Info(ctx, "DeleteGameProfile - 删除游戏档案")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.DeleteGameProfileRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.gameProfileLogic.DeleteGameProfile(ctx.Request.Context(), userID, profileID, req.ConfirmName)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "删除游戏档案成功", gameProfileDeletionDTOToResponse(result))
+}
+
+// AdminDeleteGameProfile 管理员删除游戏档案
+//
+// @Summary     [超管] 删除游戏档案
+// @Description 管理员删除任意用户的游戏档案并退还其一个档案配额，名称立即释放，锁定的档案同样可以删除。删除原因与操作者记录在配额日志与审计记录中
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       request body apiUser.AdminDeleteGameProfileRequest true "管理员删除游戏档案请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.DeleteGameProfileResponse} "删除成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案不存在"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/profiles/{profile_id} [DELETE]
+func (h *GameProfileHandler) AdminDeleteGameProfile(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminDeleteGameProfile - 管理员删除游戏档案")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.AdminDeleteGameProfileRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.gameProfileLogic.AdminDeleteGameProfile(ctx.Request.Context(), operatorID, profileID, req.Reason)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "删除游戏档案成功", gameProfileDeletionDTOToResponse(result))
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiUser "github.com/frontleaves-mc/frontleaves-yggleaf/api/user"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// AdminListNameHistory 管理员查询游戏档案名称历史
+//
+// @Summary     [超管] 查询游戏档案名称历史
+// @Description 按生效时间升序返回游戏档案曾使用的全部名称，包含来源、当时的持有者与操作管理员
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileNameHistoryListResponse} "查询成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案不存在"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/profiles/{profile_id}/names [GET]
+func (h *GameProfileHandler) AdminListNameHistory(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminListNameHistory - 管理员查询游戏档案名称历史")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	items, xErr := h.service.gameProfileLogic.AdminListNameHistory(ctx.Request.Context(), profileID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取名称历史成功", apiUser.GameProfileNameHistoryListResponse{
+		Items: gameProfileNameHistoryDTOsToResponses(items),
+	})
+}
+
+// AdminResetRenameCooldown 管理员重置游戏档案改名冷却
+//
+// @Summary     [超管] 重置改名冷却
+// @Description 清除游戏档案的最近改名时间，使持有者可立即再次修改用户名
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileResponse} "重置成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案不存在"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/profiles/{profile_id}/rename-cooldown/reset [POST]
+func (h *GameProfileHandler) AdminResetRenameCooldown(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminResetRenameCooldown - 管理员重置游戏档案改名冷却")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	profile, xErr := h.service.gameProfileLogic.AdminResetRenameCooldown(ctx.Request.Context(), operatorID, profileID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "重置改名冷却成功", gameProfileDTOToResponse(profile))
+}
+
+// AdminReleaseNameReservation 管理员释放名称保留
+//
+// @Summary     [超管] 释放名称保留
+// @Description 立即释放指定名称的全部有效保留记录（包括删除档案和改名产生的保留），释放后任何用户均可使用该名称
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       name path string true "被保留的名称"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.AdminReleaseNameReservationResponse} "释放成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/name-reservations/{name} [DELETE]
+func (h *GameProfileHandler) AdminReleaseNameReservation(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminReleaseNameReservation - 管理员释放名称保留")
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	name := ctx.Param("name")
+	released, xErr := h.service.gameProfileLogic.AdminReleaseNameReservation(ctx.Request.Context(), operatorID, name)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "释放名称保留成功", apiUser.AdminReleaseNameReservationResponse{
+		Name:     name,
+		Released: released,
+	})
+}
+
+// AdminGetNameConflictReport 管理员查看用户名大小写冲突报告
+//
+// @Summary     [超管] 查看用户名大小写冲突
+// @Description 列出仅大小写不同的重复用户名（如 Steve 与 steve），并标明不区分大小写唯一约束是否已启用。冲突需通过改名或删除档案处理
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileNameConflictReportResponse} "查询成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/name-conflicts [GET]
+func (h *GameProfileHandler) AdminGetNameConflictReport(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminGetNameConflictReport - 管理员查看用户名大小写冲突报告")
+
+	report, xErr := h.service.gameProfileLogic.AdminGetNameConflictReport(ctx.Request.Context())
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取用户名冲突报告成功", gameProfileNameConflictReportDTOToResponse(report))
+}
+
+// AdminEnforceNameCaseUniqueness 管理员启用用户名不区分大小写唯一约束
+//
+// @Summary     [超管] 启用用户名不区分大小写唯一约束
+// @Description 在全部大小写冲突处理完成后建立 lower(name) 唯一索引；仍存在冲突时拒绝执行，已启用时直接返回当前状态
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileNameConflictReportResponse} "启用成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     409 {object} xBase.BaseResponse "仍存在仅大小写不同的重复用户名"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/name-conflicts/enforce [POST]
+func (h *GameProfileHandler) AdminEnforceNameCaseUniqueness(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminEnforceNameCaseUniqueness - 管理员启用用户名不区分大小写唯一约束")
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	report, xErr := h.service.gameProfileLogic.AdminEnforceNameCaseUniqueness(ctx.Request.Context(), operatorID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "启用用户名唯一约束成功", gameProfileNameConflictReportDTOToResponse(report))
+}
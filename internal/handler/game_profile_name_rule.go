@@ -0,0 +1,239 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiUser "github.com/frontleaves-mc/frontleaves-yggleaf/api/user"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// CheckNameAvailability 检查游戏档案名称可用性
+//
+// @Summary     [玩家] 检查名称可用性
+// @Description 检查名称对当前用户是否可用（格式、保留名称与屏蔽规则、是否已被占用、是否处于他人的保留期），供前端在输入时实时提示。结果仅为预检，最终以创建或改名时的校验为准
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       name query string true "待检查的名称"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileNameAvailabilityResponse} "检查成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Security    BearerAuth
+// @Router      /game-profile/name-availability [GET]
+func (h *GameProfileHandler) CheckNameAvailability(ctx *gin.Context) {
+	h.log.Info(ctx, "CheckNameAvailability - 检查游戏档案名称可用性")
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.gameProfileLogic.CheckNameAvailability(ctx.Request.Context(), userID, ctx.Query("name"))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "检查名称可用性成功", apiUser.GameProfileNameAvailabilityResponse{
+		Name:      result.Name,
+		Available: result.Available,
+		Reason:    result.Reason,
+		Message:   result.Message,
+	})
+}
+
+// AdminListNameRules 管理员查询名称规则
+//
+// @Summary     [超管] 查询名称规则
+// @Description 分页查询保留名称与屏蔽规则，可按规则类别过滤
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       kind      query string false "规则类别（reserved / blocked）"
+// @Param       page      query int    false "页码（默认 1）"
+// @Param       page_size query int    false "每页数量（默认 20，最大 100）"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileNameRuleListResponse} "查询成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/name-rules [GET]
+func (h *GameProfileHandler) AdminListNameRules(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminListNameRules - 管理员查询游戏档案名称规则")
+
+	page, pageSize := h.parsePagination(ctx)
+
+	rules, total, xErr := h.service.gameProfileLogic.AdminListNameRules(ctx.Request.Context(), ctx.Query("kind"), page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取名称规则成功", apiUser.GameProfileNameRuleListResponse{
+		Total: total,
+		Items: gameProfileNameRuleDTOsToResponses(rules),
+	})
+}
+
+// AdminCreateNameRule 管理员创建名称规则
+//
+// @Summary     [超管] 创建名称规则
+// @Description 创建保留名称或屏蔽规则，所有匹配均不区分大小写。保留规则可指定允许使用该名称的用户；屏蔽规则对所有人生效。非正则规则的匹配内容只允许字母、数字和下划线
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiUser.GameProfileNameRuleRequest true "名称规则请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileNameRuleResponse} "创建成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误或正则表达式无效"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "允许使用的用户不存在"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/name-rules [POST]
+func (h *GameProfileHandler) AdminCreateNameRule(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminCreateNameRule - 管理员创建游戏档案名称规则")
+
+	req := xUtil.Bind(ctx, &apiUser.GameProfileNameRuleRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	rule, xErr := h.service.gameProfileLogic.AdminCreateNameRule(ctx.Request.Context(), operatorID, models.GameProfileNameRuleInput{
+		Kind:          req.Kind,
+		MatchType:     req.MatchType,
+		Pattern:       req.Pattern,
+		AllowedUserID: req.AllowedUserID,
+		Reason:        req.Reason,
+		IsEnabled:     req.IsEnabled == nil || *req.IsEnabled,
+	})
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "创建名称规则成功", gameProfileNameRuleDTOToResponse(rule))
+}
+
+// AdminUpdateNameRule 管理员更新名称规则
+//
+// @Summary     [超管] 更新名称规则
+// @Description 以请求内容整体替换指定名称规则，校验规则与创建时一致
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       rule_id path string true "名称规则 ID"
+// @Param       request body apiUser.GameProfileNameRuleRequest true "名称规则请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileNameRuleResponse} "更新成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误或正则表达式无效"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "名称规则或允许使用的用户不存在"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/name-rules/{rule_id} [PUT]
+func (h *GameProfileHandler) AdminUpdateNameRule(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminUpdateNameRule - 管理员更新游戏档案名称规则")
+
+	ruleID, err := xSnowflake.ParseSnowflakeID(ctx.Param("rule_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析名称规则 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.GameProfileNameRuleRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	rule, xErr := h.service.gameProfileLogic.AdminUpdateNameRule(ctx.Request.Context(), operatorID, ruleID, models.GameProfileNameRuleInput{
+		Kind:          req.Kind,
+		MatchType:     req.MatchType,
+		Pattern:       req.Pattern,
+		AllowedUserID: req.AllowedUserID,
+		Reason:        req.Reason,
+		IsEnabled:     req.IsEnabled == nil || *req.IsEnabled,
+	})
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "更新名称规则成功", gameProfileNameRuleDTOToResponse(rule))
+}
+
+// AdminDeleteNameRule 管理员删除名称规则
+//
+// @Summary     [超管] 删除名称规则
+// @Description 删除指定名称规则，删除后立即对新的创建与改名请求生效
+// @Tags        管理员-游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       rule_id path string true "名称规则 ID"
+// @Success     200 {object} xBase.BaseResponse "删除成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "名称规则不存在"
+// @Security    BearerAuth
+// @Router      /admin/game-profile/name-rules/{rule_id} [DELETE]
+func (h *GameProfileHandler) AdminDeleteNameRule(ctx *gin.Context) {
+	h.log.Info(ctx, "AdminDeleteNameRule - 管理员删除游戏档案名称规则")
+
+	ruleID, err := xSnowflake.ParseSnowflakeID(ctx.Param("rule_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析名称规则 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return
+	}
+
+	if xErr = h.service.gameProfileLogic.AdminDeleteNameRule(ctx.Request.Context(), operatorID, ruleID); xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.Success(ctx, "删除名称规则成功")
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiUser "github.com/frontleaves-mc/frontleaves-yggleaf/api/user"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// ListOutfits 获取当前用户的外观预设列表
+//
+// @Summary     [玩家] 获取外观预设列表
+// @Description 获取当前用户保存的全部皮肤 + 披风外观预设
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.OutfitListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Router      /game-profile/outfits [GET]
+func (h *GameProfileHandler) ListOutfits(ctx *gin.Context) {
+	h.log.Info(ctx, "ListOutfits - 获取外观预设列表")
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	outfits, xErr := h.service.gameProfileLogic.ListOutfits(ctx.Request.Context(), userID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取外观预设列表成功", apiUser.OutfitListResponse{
+		Items: outfitDTOsToResponses(outfits),
+	})
+}
+
+// CreateOutfit 创建外观预设
+//
+// @Summary     [玩家] 创建外观预设
+// @Description 保存一套皮肤 + 披风组合，皮肤与披风至少包含一项，且需为当前可装备的资源
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiUser.SaveOutfitRequest true "保存外观预设请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.OutfitResponse} "创建成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "未拥有预设中的资源"
+// @Failure     409 {object} xBase.BaseResponse "资源冲突"
+// @Failure     503 {object} xBase.BaseResponse "资源耗尽"
+// @Router      /game-profile/outfits [POST]
+func (h *GameProfileHandler) CreateOutfit(ctx *gin.Context) {
+	h.log.Info(ctx, "CreateOutfit - 创建外观预设")
+
+	req := xUtil.Bind(ctx, &apiUser.SaveOutfitRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	skinLibraryID, ok := parseOptionalSnowflakeID(ctx, req.SkinLibraryID, "解析皮肤库 ID 失败")
+	if !ok {
+		return
+	}
+	capeLibraryID, ok := parseOptionalSnowflakeID(ctx, req.CapeLibraryID, "解析披风库 ID 失败")
+	if !ok {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	outfit, xErr := h.service.gameProfileLogic.CreateOutfit(ctx.Request.Context(), userID, req.Name, skinLibraryID, capeLibraryID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "创建外观预设成功", outfitDTOToResponse(outfit))
+}
+
+// UpdateOutfit 更新外观预设
+//
+// @Summary     [玩家] 更新外观预设
+// @Description 整体替换外观预设的名称与皮肤、披风组合
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       outfit_id path string true "外观预设 ID"
+// @Param       request body apiUser.SaveOutfitRequest true "保存外观预设请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.OutfitResponse} "更新成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "未拥有预设中的资源"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "资源冲突"
+// @Router      /game-profile/outfits/{outfit_id} [PUT]
+func (h *GameProfileHandler) UpdateOutfit(ctx *gin.Context) {
+	h.log.Info(ctx, "UpdateOutfit - 更新外观预设")
+
+	outfitID, err := xSnowflake.ParseSnowflakeID(ctx.Param("outfit_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析外观预设 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.SaveOutfitRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	skinLibraryID, ok := parseOptionalSnowflakeID(ctx, req.SkinLibraryID, "解析皮肤库 ID 失败")
+	if !ok {
+		return
+	}
+	capeLibraryID, ok := parseOptionalSnowflakeID(ctx, req.CapeLibraryID, "解析披风库 ID 失败")
+	if !ok {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	outfit, xErr := h.service.gameProfileLogic.UpdateOutfit(ctx.Request.Context(), userID, outfitID, req.Name, skinLibraryID, capeLibraryID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "更新外观预设成功", outfitDTOToResponse(outfit))
+}
+
+// DeleteOutfit 删除外观预设
+//
+// @Summary     [玩家] 删除外观预设
+// @Description 删除外观预设，已应用到游戏档案上的外观不受影响
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       outfit_id path string true "外观预设 ID"
+// @Success     200 {object} xBase.BaseResponse "删除成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Router      /game-profile/outfits/{outfit_id} [DELETE]
+func (h *GameProfileHandler) DeleteOutfit(ctx *gin.Context) {
+	h.log.Info(ctx, "DeleteOutfit - 删除外观预设")
+
+	outfitID, err := xSnowflake.ParseSnowflakeID(ctx.Param("outfit_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析外观预设 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	xErr = h.service.gameProfileLogic.DeleteOutfit(ctx.Request.Context(), userID, outfitID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.Success(ctx, "删除外观预设成功")
+}
+
+// ApplyOutfit 将外观预设应用到游戏档案
+//
+// @Summary     [玩家] 应用外观预设
+// @Description 按当前持有关系校验预设中的皮肤与披风后，一次性同时更新档案的皮肤与披风；预设中为空的部位会被卸下
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       outfit_id path string true "外观预设 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileResponse} "应用成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "未拥有预设中的资源或游戏档案已被锁定"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Router      /game-profile/{profile_id}/outfits/{outfit_id}/apply [POST]
+func (h *GameProfileHandler) ApplyOutfit(ctx *gin.Context) {
+	h.log.Info(ctx, "ApplyOutfit - 应用外观预设")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+	outfitID, err := xSnowflake.ParseSnowflakeID(ctx.Param("outfit_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析外观预设 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	profile, xErr := h.service.gameProfileLogic.ApplyOutfit(ctx.Request.Context(), userID, profileID, outfitID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "应用外观预设成功", gameProfileDTOToResponse(profile))
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiUser "github.com/frontleaves-mc/frontleaves-yggleaf/api/user"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// CreateTransfer 发起游戏档案转移
+//
+// @Summary     [玩家] 发起游戏档案转移
+// @Description 将自己的游戏档案（保留 UUID 与用户名）转移给另一位用户。接收方需在有效期内接受，接受时校验并占用接收方的档案配额，同时退还发起方配额。同一档案同一时间仅允许一个待接收的请求
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       profile_id path string true "游戏档案 ID"
+// @Param       request body apiUser.CreateGameProfileTransferRequest true "发起游戏档案转移请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileTransferResponse} "发起成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "接收方账号已被封禁或游戏档案已被锁定"
+// @Failure     404 {object} xBase.BaseResponse "游戏档案或接收方不存在"
+// @Failure     409 {object} xBase.BaseResponse "已有待接收的转移请求"
+// @Security    BearerAuth
+// @Router      /game-profile/{profile_id}/transfers [POST]
+func (h *GameProfileHandler) CreateTransfer(ctx *gin.Context) {
+	h.log.Info(ctx, "CreateTransfer - 发起游戏档案转移")
+
+	profileID, err := xSnowflake.ParseSnowflakeID(ctx.Param("profile_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析游戏档案 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiUser.CreateGameProfileTransferRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	transfer, xErr := h.service.gameProfileLogic.CreateTransfer(ctx.Request.Context(), userID, profileID, req.ToUserID, req.Remark)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "发起游戏档案转移成功", gameProfileTransferDTOToResponse(*transfer))
+}
+
+// ListTransfers 获取游戏档案转移请求列表
+//
+// @Summary     [玩家] 获取游戏档案转移请求
+// @Description 分页获取当前用户发起（outgoing）或接收（incoming）的游戏档案转移请求，按发起时间倒序
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileTransferListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Security    BearerAuth
+// @Router      /game-profile/transfers [GET]
+func (h *GameProfileHandler) ListTransfers(ctx *gin.Context) {
+	h.log.Info(ctx, "ListTransfers - 获取游戏档案转移请求")
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	page, pageSize := h.parsePagination(ctx)
+
+	transfers, total, xErr := h.service.gameProfileLogic.ListTransfers(ctx.Request.Context(), userID, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiUser.GameProfileTransferListResponse{
+		Total: total,
+		Items: gameProfileTransferDTOsToResponses(transfers),
+	}
+	xResult.SuccessHasData(ctx, "获取游戏档案转移请求成功", response)
+}
+
+// AcceptTransfer 接受游戏档案转移
+//
+// @Summary     [玩家] 接受游戏档案转移
+// @Description 接收方接受转移请求，档案归属到当前用户。接收方未拥有的原装备皮肤/披风会被卸下，发起方绑定该档案的令牌会被吊销
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       transfer_id path string true "转移请求 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.AcceptGameProfileTransferResponse} "接受成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "游戏档案已被锁定"
+// @Failure     404 {object} xBase.BaseResponse "转移请求不存在"
+// @Failure     409 {object} xBase.BaseResponse "转移请求已处理或已过期"
+// @Failure     503 {object} xBase.BaseResponse "游戏档案配额不足"
+// @Security    BearerAuth
+// @Router      /game-profile/transfers/{transfer_id}/accept [POST]
+func (h *GameProfileHandler) AcceptTransfer(ctx *gin.Context) {
+	h.log.Info(ctx, "AcceptTransfer - 接受游戏档案转移")
+
+	transferID, err := xSnowflake.ParseSnowflakeID(ctx.Param("transfer_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析转移请求 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.gameProfileLogic.AcceptTransfer(ctx.Request.Context(), userID, transferID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "接受游戏档案转移成功", gameProfileTransferAcceptDTOToResponse(result))
+}
+
+// RejectTransfer 拒绝游戏档案转移
+//
+// @Summary     [玩家] 拒绝游戏档案转移
+// @Description 接收方拒绝待接收的转移请求
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       transfer_id path string true "转移请求 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileTransferResponse} "拒绝成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "转移请求不存在"
+// @Failure     409 {object} xBase.BaseResponse "转移请求已处理或已过期"
+// @Security    BearerAuth
+// @Router      /game-profile/transfers/{transfer_id}/reject [POST]
+func (h *GameProfileHandler) RejectTransfer(ctx *gin.Context) {
+	h.log.Info(ctx, "RejectTransfer - 拒绝游戏档案转移")
+
+	transferID, err := xSnowflake.ParseSnowflakeID(ctx.Param("transfer_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析转移请求 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	transfer, xErr := h.service.gameProfileLogic.RejectTransfer(ctx.Request.Context(), userID, transferID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "拒绝游戏档案转移成功", gameProfileTransferDTOToResponse(*transfer))
+}
+
+// CancelTransfer 取消游戏档案转移
+//
+// @Summary     [玩家] 取消游戏档案转移
+// @Description 发起方撤回待接收的转移请求
+// @Tags        游戏档案接口
+// @Accept      json
+// @Produce     json
+// @Param       transfer_id path string true "转移请求 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiUser.GameProfileTransferResponse} "取消成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "转移请求不存在"
+// @Failure     409 {object} xBase.BaseResponse "转移请求已处理或已过期"
+// @Security    BearerAuth
+// @Router      /game-profile/transfers/{transfer_id} [DELETE]
+func (h *GameProfileHandler) CancelTransfer(ctx *gin.Context) {
+	h.log.Info(ctx, "CancelTransfer - 取消游戏档案转移")
+
+	transferID, err := xSnowflake.ParseSnowflakeID(ctx.Param("transfer_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析转移请求 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	transfer, xErr := h.service.gameProfileLogic.CancelTransfer(ctx.Request.Context(), userID, transferID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "取消游戏档案转移成功", gameProfileTransferDTOToResponse(*transfer))
+}
@@ -17,6 +17,7 @@ type service struct {
 	libraryLogic     *logic.LibraryLogic
 	issueLogic       *logic.IssueLogic
 	syncLogic        *logic.SyncLogic
+	storageLogic     *logic.StorageLogic
 	oauthLogic       *bSdkLogic.BusinessLogic
 }
 
@@ -76,6 +77,7 @@ func NewHandler[T IHandler](ctx context.Context, handlerName string) *T {
 			libraryLogic:     libraryLogic,
 			issueLogic:       issueLogic,
 			syncLogic:        syncLogic,
+			storageLogic:     logic.NewStorageLogic(ctx),
 			oauthLogic:       bSdkLogic.NewBusiness(ctx),
 		},
 	}
@@ -96,3 +98,6 @@ type LibraryHandler handler
 
 // SyncHandler 模组同步接口
 type SyncHandler handler
+
+// StorageHandler 存储接口（对账与本地文件下载）
+type StorageHandler handler
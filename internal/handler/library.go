@@ -62,10 +62,53 @@ func (h *LibraryHandler) CreateSkin(ctx *gin.Context) {
 	xResult.SuccessHasData(ctx, "创建皮肤成功", skinDTOToResponse(skin))
 }
 
+// ImportSkin 导入皮肤
+//
+// @Summary     [玩家] 导入皮肤
+// @Description 从正版玩家名称或 HTTPS 纹理链接导入皮肤到我的资源库。链接域名需在 skinDomains 白名单内，纹理不超过 1 MiB；未指定模型时正版皮肤取 Mojang 元数据，链接导入按纹理像素识别。导入与上传同样计入配额并参与去重
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.ImportSkinRequest true "导入皮肤请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinResponse} "导入成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误、链接不允许或纹理无效"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "正版玩家不存在或未设置皮肤"
+// @Failure     503 {object} xBase.BaseResponse "Mojang 服务暂不可用"
+// @Router      /library/skins/import [POST]
+func (h *LibraryHandler) ImportSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "ImportSkin - 导入皮肤")
+
+	req := xUtil.Bind(ctx, &apiLibrary.ImportSkinRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	skin, xErr := h.service.libraryLogic.ImportSkin(ctx.Request.Context(), userID, req.Username, req.URL, req.Name, req.Model, req.IsPublic)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "导入皮肤成功", skinDTOToResponse(skin))
+}
+
 // ListSkins 获取皮肤列表
 //
 // @Summary     [玩家] 获取皮肤列表
-// @Description 分页获取皮肤列表，支持 mine（我的皮肤）和 market（市场皮肤）两种模式
+// @Description 分页获取皮肤列表，支持 mine（我的皮肤）和 market（市场皮肤）两种模式；mine 模式下限时赠送的皮肤返回到期时间与剩余秒数
 // @Tags        资源库接口
 // @Accept      json
 // @Produce     json
@@ -262,7 +305,7 @@ func (h *LibraryHandler) CreateCape(ctx *gin.Context) {
 // ListCapes 获取披风列表
 //
 // @Summary     [玩家] 获取披风列表
-// @Description 分页获取披风列表，支持 mine（我的披风）和 market（市场披风）两种模式
+// @Description 分页获取披风列表，支持 mine（我的披风）和 market（市场披风）两种模式；mine 模式下限时赠送的披风返回到期时间与剩余秒数
 // @Tags        资源库接口
 // @Accept      json
 // @Produce     json
@@ -527,7 +570,7 @@ func (h *LibraryHandler) GetQuota(ctx *gin.Context) {
 // GiftSkin 管理员赠送皮肤
 //
 // @Summary     [超管] 赠送皮肤
-// @Description 管理员向指定用户赠送皮肤，支持 gift 和 admin 两种分配类型
+// @Description 管理员向指定用户赠送皮肤，支持 gift 和 admin 两种分配类型；可设置到期时间，到期后自动收回并卸下装备
 // @Tags        管理员-资源库接口
 // @Accept      json
 // @Produce     json
@@ -573,7 +616,7 @@ func (h *LibraryHandler) GiftSkin(ctx *gin.Context) {
 	}
 
 	assignmentType := entityType.AssignmentType(req.AssignmentType)
-	result, xErr := h.service.libraryLogic.GiftSkin(ctx.Request.Context(), operatorID, targetUserID, skinLibraryID, assignmentType)
+	result, xErr := h.service.libraryLogic.GiftSkin(ctx.Request.Context(), operatorID, targetUserID, skinLibraryID, assignmentType, req.ExpiresAt)
 	if xErr != nil {
 		_ = ctx.Error(xErr)
 		return
@@ -625,7 +668,7 @@ func (h *LibraryHandler) RevokeSkin(ctx *gin.Context) {
 // GiftCape 管理员赠送披风
 //
 // @Summary     [超管] 赠送披风
-// @Description 管理员向指定用户赠送披风，支持 gift 和 admin 两种分配类型
+// @Description 管理员向指定用户赠送披风，支持 gift 和 admin 两种分配类型；可设置到期时间，到期后自动收回并卸下装备
 // @Tags        管理员-资源库接口
 // @Accept      json
 // @Produce     json
@@ -671,7 +714,7 @@ func (h *LibraryHandler) GiftCape(ctx *gin.Context) {
 	}
 
 	assignmentType := entityType.AssignmentType(req.AssignmentType)
-	result, xErr := h.service.libraryLogic.GiftCape(ctx.Request.Context(), operatorID, targetUserID, capeLibraryID, assignmentType)
+	result, xErr := h.service.libraryLogic.GiftCape(ctx.Request.Context(), operatorID, targetUserID, capeLibraryID, assignmentType, req.ExpiresAt)
 	if xErr != nil {
 		_ = ctx.Error(xErr)
 		return
@@ -723,7 +766,7 @@ func (h *LibraryHandler) RevokeCape(ctx *gin.Context) {
 // SyncQuota 管理员同步用户配额
 //
 // @Summary     [超管] 同步配额
-// @Description 管理员重新计算并同步指定用户的资源库配额
+// @Description 管理员重新计算并同步指定用户的资源库配额，与实际数量存在偏差的分项会写入配额日志
 // @Tags        管理员-资源库接口
 // @Accept      json
 // @Produce     json
@@ -743,7 +786,12 @@ func (h *LibraryHandler) SyncQuota(ctx *gin.Context) {
 		return
 	}
 
-	xErr := h.service.libraryLogic.RecalculateQuota(ctx.Request.Context(), userID)
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	xErr := h.service.libraryLogic.RecalculateQuota(ctx.Request.Context(), operatorID, userID)
 	if xErr != nil {
 		_ = ctx.Error(xErr)
 		return
@@ -752,7 +800,6 @@ func (h *LibraryHandler) SyncQuota(ctx *gin.Context) {
 	xResult.Success(ctx, "同步配额成功")
 }
 
-
 // ListUserSkins 查询指定用户的皮肤列表（管理员）
 //
 // @Summary     [超管] 查询用户皮肤列表
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+// libraryArchiveMaxBodySize 导入请求体大小上限：压缩包 32 MiB 上限之外预留 1 MiB 给 multipart 表单开销。
+const libraryArchiveMaxBodySize = 33 << 20
+
+// ==================== Archive Handlers ====================
+
+// ImportArchive 导入衣柜压缩包
+//
+// @Summary     [玩家] 导入衣柜压缩包
+// @Description 上传包含 PNG 纹理的 zip 压缩包批量创建皮肤与披风，可选 manifest.json 指定名称、模型与公开状态；每个条目独立走去重与配额流程并返回逐条结果
+// @Tags        资源库接口
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       file formData file true "zip 压缩包（不超过 32 MiB，最多 100 个 PNG）"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ArchiveImportResponse} "导入完成"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Router      /library/import [POST]
+func (h *LibraryHandler) ImportArchive(ctx *gin.Context) {
+	h.log.Info(ctx, "ImportArchive - 导入衣柜压缩包")
+
+	// 解析 multipart 前限制请求体大小，避免超大请求体被完整读入内存或临时文件
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, libraryArchiveMaxBodySize)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "压缩包大小不能超过 32 MiB", true, err))
+			return
+		}
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "缺少导入文件 file", true, err))
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "读取导入文件失败", true, err))
+		return
+	}
+	defer file.Close()
+
+	results, xErr := h.service.libraryLogic.ImportArchive(ctx.Request.Context(), userID, file, fileHeader.Size)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "导入衣柜压缩包完成", libraryImportResultsToResponse(results))
+}
+
+// ExportArchive 导出衣柜压缩包
+//
+// @Summary     [玩家] 导出衣柜压缩包
+// @Description 以 zip 流式导出当前用户自主上传与被赠送的全部皮肤和披风纹理，根目录附带 manifest.json，可直接用于导入
+// @Tags        资源库接口
+// @Produce     application/zip
+// @Success     200 {file} file "zip 文件流"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Router      /library/export [GET]
+func (h *LibraryHandler) ExportArchive(ctx *gin.Context) {
+	h.log.Info(ctx, "ExportArchive - 导出衣柜压缩包")
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	entries, xErr := h.service.libraryLogic.ListArchiveExportEntries(ctx.Request.Context(), userID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	ctx.Header("Content-Type", "application/zip")
+	ctx.Header("Content-Disposition", "attachment; filename=\"wardrobe-"+userID.String()+".zip\"")
+	ctx.Status(200)
+	if err := h.service.libraryLogic.WriteArchive(ctx.Request.Context(), entries, ctx.Writer); err != nil {
+		h.log.Warn(ctx, "导出衣柜压缩包中断: "+err.Error())
+	}
+}
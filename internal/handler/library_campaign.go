@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== Campaign Handlers ====================
+
+// CreateCampaign 创建资源发放活动（管理员）
+//
+// @Summary     [超管] 创建资源发放活动
+// @Description 按筛选条件（角色、注册时间范围、是否拥有游戏档案、指定用户）向匹配用户批量赠送皮肤或披风。活动创建后立即在后台分批执行，每个用户仅处理一次，已拥有该资源的用户记为跳过
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.CreateCampaignRequest true "创建发放活动请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CampaignResponse} "创建成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限或资源已停用"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/campaigns [POST]
+func (h *LibraryHandler) CreateCampaign(ctx *gin.Context) {
+	h.log.Info(ctx, "CreateCampaign - 创建资源发放活动")
+
+	req := xUtil.Bind(ctx, &apiLibrary.CreateCampaignRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	libraryID, err := xSnowflake.ParseSnowflakeID(req.LibraryID)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析资源 ID 失败", true, err))
+		return
+	}
+
+	userIDs := make([]xSnowflake.SnowflakeID, 0, len(req.Filter.UserIDs))
+	for _, rawID := range req.Filter.UserIDs {
+		userID, err := xSnowflake.ParseSnowflakeID(rawID)
+		if err != nil {
+			_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+			return
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	assignmentType := entityType.AssignmentType(req.AssignmentType)
+	if assignmentType == 0 {
+		assignmentType = entityType.AssignmentTypeGift
+	}
+
+	campaign, xErr := h.service.libraryLogic.CreateCampaign(
+		ctx.Request.Context(),
+		operatorID,
+		req.Name,
+		entityType.LibraryKind(req.Kind),
+		libraryID,
+		assignmentType,
+		models.LibraryCampaignFilterDTO{
+			RoleName:         req.Filter.RoleName,
+			RegisteredAfter:  req.Filter.RegisteredAfter,
+			RegisteredBefore: req.Filter.RegisteredBefore,
+			HasProfile:       req.Filter.HasProfile,
+			UserIDs:          userIDs,
+		},
+	)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "创建发放活动成功", libraryCampaignDTOToResponse(campaign))
+}
+
+// ListCampaigns 资源发放活动列表（管理员）
+//
+// @Summary     [超管] 资源发放活动列表
+// @Description 分页查询资源发放活动及其进度，按创建时间倒序
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CampaignListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/campaigns [GET]
+func (h *LibraryHandler) ListCampaigns(ctx *gin.Context) {
+	h.log.Info(ctx, "ListCampaigns - 资源发放活动列表")
+
+	page, pageSize := h.parsePagination(ctx)
+
+	campaigns, total, xErr := h.service.libraryLogic.ListCampaigns(ctx.Request.Context(), page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	items := make([]apiLibrary.CampaignResponse, len(campaigns))
+	for i := range campaigns {
+		items[i] = libraryCampaignDTOToResponse(&campaigns[i])
+	}
+	xResult.SuccessHasData(ctx, "获取发放活动列表成功", apiLibrary.CampaignListResponse{Total: total, Items: items})
+}
+
+// GetCampaign 资源发放活动详情（管理员）
+//
+// @Summary     [超管] 资源发放活动详情
+// @Description 查询资源发放活动的状态与进度（匹配、已发放、已跳过、失败、已收回数量及中断原因）
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       campaign_id path string true "活动 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CampaignResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "活动不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/campaigns/{campaign_id} [GET]
+func (h *LibraryHandler) GetCampaign(ctx *gin.Context) {
+	h.log.Info(ctx, "GetCampaign - 资源发放活动详情")
+
+	campaignID, ok := h.parseCampaignID(ctx)
+	if !ok {
+		return
+	}
+
+	campaign, xErr := h.service.libraryLogic.GetCampaign(ctx.Request.Context(), campaignID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取发放活动详情成功", libraryCampaignDTOToResponse(campaign))
+}
+
+// ListCampaignGrants 资源发放活动处理记录（管理员）
+//
+// @Summary     [超管] 资源发放活动处理记录
+// @Description 分页查询资源发放活动中每个用户的处理结果，可按结果筛选（如仅查看失败记录）
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       campaign_id path string true "活动 ID"
+// @Param       status query int false "处理结果 (1=granted, 2=skipped, 3=failed, 4=revoked)"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CampaignGrantListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "活动不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/campaigns/{campaign_id}/grants [GET]
+func (h *LibraryHandler) ListCampaignGrants(ctx *gin.Context) {
+	h.log.Info(ctx, "ListCampaignGrants - 资源发放活动处理记录")
+
+	campaignID, ok := h.parseCampaignID(ctx)
+	if !ok {
+		return
+	}
+
+	var status *entityType.CampaignGrantStatus
+	if rawStatus := ctx.Query("status"); rawStatus != "" {
+		parsed, err := strconv.ParseUint(rawStatus, 10, 8)
+		if err != nil {
+			_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "无效的处理结果状态", true, err))
+			return
+		}
+		grantStatus := entityType.CampaignGrantStatus(parsed)
+		status = &grantStatus
+	}
+	page, pageSize := h.parsePagination(ctx)
+
+	grants, total, xErr := h.service.libraryLogic.ListCampaignGrants(ctx.Request.Context(), campaignID, status, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取发放活动处理记录成功", apiLibrary.CampaignGrantListResponse{
+		Total: total,
+		Items: libraryCampaignGrantDTOsToResponses(grants),
+	})
+}
+
+// ResumeCampaign 恢复资源发放活动（管理员）
+//
+// @Summary     [超管] 恢复资源发放活动
+// @Description 恢复执行已中断或超过 10 分钟无进度的发放活动，从上次处理到的用户之后继续，已处理的用户不会重复发放
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       campaign_id path string true "活动 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CampaignResponse} "恢复成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "活动不存在"
+// @Failure     409 {object} xBase.BaseResponse "活动正在执行或已结束"
+// @Security    BearerAuth
+// @Router      /library/admin/campaigns/{campaign_id}/resume [POST]
+func (h *LibraryHandler) ResumeCampaign(ctx *gin.Context) {
+	h.log.Info(ctx, "ResumeCampaign - 恢复资源发放活动")
+
+	campaignID, ok := h.parseCampaignID(ctx)
+	if !ok {
+		return
+	}
+
+	campaign, xErr := h.service.libraryLogic.ResumeCampaign(ctx.Request.Context(), campaignID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "恢复发放活动成功", libraryCampaignDTOToResponse(campaign))
+}
+
+// RevokeCampaign 撤销资源发放活动（管理员）
+//
+// @Summary     [超管] 撤销资源发放活动
+// @Description 在后台收回本活动发放出去的全部资源并卸下对应装备；用户自行上传或收藏的同一资源不受影响。撤销中断后可再次调用继续
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       campaign_id path string true "活动 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CampaignResponse} "已开始撤销"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "活动不存在"
+// @Failure     409 {object} xBase.BaseResponse "活动当前状态不可撤销"
+// @Security    BearerAuth
+// @Router      /library/admin/campaigns/{campaign_id}/revoke [POST]
+func (h *LibraryHandler) RevokeCampaign(ctx *gin.Context) {
+	h.log.Info(ctx, "RevokeCampaign - 撤销资源发放活动")
+
+	campaignID, ok := h.parseCampaignID(ctx)
+	if !ok {
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	campaign, xErr := h.service.libraryLogic.RevokeCampaign(ctx.Request.Context(), operatorID, campaignID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "已开始撤销发放活动", libraryCampaignDTOToResponse(campaign))
+}
+
+// parseCampaignID 解析路径参数中的活动 ID，失败时写入错误并返回 false。
+func (h *LibraryHandler) parseCampaignID(ctx *gin.Context) (xSnowflake.SnowflakeID, bool) {
+	campaignID, err := xSnowflake.ParseSnowflakeID(ctx.Param("campaign_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析活动 ID 失败", true, err))
+		return 0, false
+	}
+	return campaignID, true
+}
@@ -0,0 +1,615 @@
+package handler
+
+import (
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// ==================== Gallery Handlers ====================
+
+// ListSkinGallery 公开皮肤画廊
+//
+// @Summary     [玩家] 公开皮肤画廊
+// @Description 分页查询公开皮肤，支持名称搜索、上传者、模型、标签过滤及多种排序
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       keyword query string false "名称关键字"
+// @Param       uploader_id query string false "上传者用户 ID"
+// @Param       model query int false "皮肤模型 (1=classic, 2=slim)"
+// @Param       tag query string false "标签名称"
+// @Param       sort query string false "排序方式：newest/equipped/collected/liked，默认 newest"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Router      /library/gallery/skins [GET]
+func (h *LibraryHandler) ListSkinGallery(ctx *gin.Context) {
+	h.log.Info(ctx, "ListSkinGallery - 查询公开皮肤画廊")
+
+	query, xErr := h.parseGalleryQuery(ctx)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	skins, total, xErr := h.service.libraryLogic.ListSkinGallery(ctx.Request.Context(), *query)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiLibrary.SkinListResponse{
+		Total: total,
+		Items: skinDTOsToResponses(skins),
+	}
+	xResult.SuccessHasData(ctx, "获取皮肤画廊成功", response)
+}
+
+// ListCapeGallery 公开披风画廊
+//
+// @Summary     [玩家] 公开披风画廊
+// @Description 分页查询公开披风，支持名称搜索、上传者、标签过滤及多种排序
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       keyword query string false "名称关键字"
+// @Param       uploader_id query string false "上传者用户 ID"
+// @Param       tag query string false "标签名称"
+// @Param       sort query string false "排序方式：newest/equipped/collected/liked，默认 newest"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Router      /library/gallery/capes [GET]
+func (h *LibraryHandler) ListCapeGallery(ctx *gin.Context) {
+	h.log.Info(ctx, "ListCapeGallery - 查询公开披风画廊")
+
+	query, xErr := h.parseGalleryQuery(ctx)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	capes, total, xErr := h.service.libraryLogic.ListCapeGallery(ctx.Request.Context(), *query)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiLibrary.CapeListResponse{
+		Total: total,
+		Items: capeDTOsToResponses(capes),
+	}
+	xResult.SuccessHasData(ctx, "获取披风画廊成功", response)
+}
+
+// ListPopularTags 获取热门标签
+//
+// @Summary     [玩家] 获取热门标签
+// @Description 按皮肤与披风引用次数倒序返回热门标签
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       limit query int false "返回数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.TagListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Router      /library/tags [GET]
+func (h *LibraryHandler) ListPopularTags(ctx *gin.Context) {
+	h.log.Info(ctx, "ListPopularTags - 获取热门标签")
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	if err != nil {
+		limit = 0
+	}
+
+	tags, xErr := h.service.libraryLogic.ListPopularTags(ctx.Request.Context(), limit)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取热门标签成功", apiLibrary.TagListResponse{
+		Items: libraryTagDTOsToResponses(tags),
+	})
+}
+
+// ==================== Like Handlers ====================
+
+// LikeSkin 点赞皮肤
+//
+// @Summary     [玩家] 点赞皮肤
+// @Description 对公开皮肤点赞，每个用户对同一皮肤仅能点赞一次
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.LikeResponse} "点赞成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "已点赞"
+// @Router      /library/skins/{skin_id}/like [POST]
+func (h *LibraryHandler) LikeSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "LikeSkin - 点赞皮肤")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.libraryLogic.LikeSkin(ctx.Request.Context(), userID, skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "点赞皮肤成功", libraryLikeDTOToResponse(result))
+}
+
+// UnlikeSkin 取消点赞皮肤
+//
+// @Summary     [玩家] 取消点赞皮肤
+// @Description 取消对皮肤的点赞
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.LikeResponse} "取消成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "尚未点赞"
+// @Router      /library/skins/{skin_id}/like [DELETE]
+func (h *LibraryHandler) UnlikeSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "UnlikeSkin - 取消点赞皮肤")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.libraryLogic.UnlikeSkin(ctx.Request.Context(), userID, skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "取消点赞皮肤成功", libraryLikeDTOToResponse(result))
+}
+
+// LikeCape 点赞披风
+//
+// @Summary     [玩家] 点赞披风
+// @Description 对公开披风点赞，每个用户对同一披风仅能点赞一次
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.LikeResponse} "点赞成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "已点赞"
+// @Router      /library/capes/{cape_id}/like [POST]
+func (h *LibraryHandler) LikeCape(ctx *gin.Context) {
+	h.log.Info(ctx, "LikeCape - 点赞披风")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.libraryLogic.LikeCape(ctx.Request.Context(), userID, capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "点赞披风成功", libraryLikeDTOToResponse(result))
+}
+
+// UnlikeCape 取消点赞披风
+//
+// @Summary     [玩家] 取消点赞披风
+// @Description 取消对披风的点赞
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.LikeResponse} "取消成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "尚未点赞"
+// @Router      /library/capes/{cape_id}/like [DELETE]
+func (h *LibraryHandler) UnlikeCape(ctx *gin.Context) {
+	h.log.Info(ctx, "UnlikeCape - 取消点赞披风")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.libraryLogic.UnlikeCape(ctx.Request.Context(), userID, capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "取消点赞披风成功", libraryLikeDTOToResponse(result))
+}
+
+// ==================== Collect Handlers ====================
+
+// CollectSkin 收藏皮肤
+//
+// @Summary     [玩家] 收藏皮肤
+// @Description 将公开皮肤收藏到我的资源库，收藏不计入配额；皮肤转为私有或被删除后不再可装备
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinResponse} "收藏成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "已拥有"
+// @Router      /library/skins/{skin_id}/collect [POST]
+func (h *LibraryHandler) CollectSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "CollectSkin - 收藏皮肤")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	skin, xErr := h.service.libraryLogic.CollectSkin(ctx.Request.Context(), userID, skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "收藏皮肤成功", skinDTOToResponse(skin))
+}
+
+// UncollectSkin 取消收藏皮肤
+//
+// @Summary     [玩家] 取消收藏皮肤
+// @Description 取消收藏皮肤，并卸下当前用户档案上装备的该皮肤
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse "取消成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "非收藏资源"
+// @Failure     404 {object} xBase.BaseResponse "尚未收藏"
+// @Router      /library/skins/{skin_id}/collect [DELETE]
+func (h *LibraryHandler) UncollectSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "UncollectSkin - 取消收藏皮肤")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	xErr = h.service.libraryLogic.UncollectSkin(ctx.Request.Context(), userID, skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.Success(ctx, "取消收藏皮肤成功")
+}
+
+// CollectCape 收藏披风
+//
+// @Summary     [玩家] 收藏披风
+// @Description 将公开披风收藏到我的资源库，收藏不计入配额；披风转为私有或被删除后不再可装备
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeResponse} "收藏成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "已拥有"
+// @Router      /library/capes/{cape_id}/collect [POST]
+func (h *LibraryHandler) CollectCape(ctx *gin.Context) {
+	h.log.Info(ctx, "CollectCape - 收藏披风")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	cape, xErr := h.service.libraryLogic.CollectCape(ctx.Request.Context(), userID, capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "收藏披风成功", capeDTOToResponse(cape))
+}
+
+// UncollectCape 取消收藏披风
+//
+// @Summary     [玩家] 取消收藏披风
+// @Description 取消收藏披风，并卸下当前用户档案上装备的该披风
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse "取消成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "非收藏资源"
+// @Failure     404 {object} xBase.BaseResponse "尚未收藏"
+// @Router      /library/capes/{cape_id}/collect [DELETE]
+func (h *LibraryHandler) UncollectCape(ctx *gin.Context) {
+	h.log.Info(ctx, "UncollectCape - 取消收藏披风")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	xErr = h.service.libraryLogic.UncollectCape(ctx.Request.Context(), userID, capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.Success(ctx, "取消收藏披风成功")
+}
+
+// ==================== Tag Handlers ====================
+
+// SetSkinTags 设置皮肤标签
+//
+// @Summary     [玩家] 设置皮肤标签
+// @Description 整体替换皮肤标签，仅资源创建者可操作；标签自动转为小写并去重
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Param       request body apiLibrary.SetTagsRequest true "设置标签请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinResponse} "设置成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "无权限"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Router      /library/skins/{skin_id}/tags [PUT]
+func (h *LibraryHandler) SetSkinTags(ctx *gin.Context) {
+	h.log.Info(ctx, "SetSkinTags - 设置皮肤标签")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.SetTagsRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	skin, xErr := h.service.libraryLogic.SetSkinTags(ctx.Request.Context(), userID, skinID, req.Tags)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "设置皮肤标签成功", skinDTOToResponse(skin))
+}
+
+// SetCapeTags 设置披风标签
+//
+// @Summary     [玩家] 设置披风标签
+// @Description 整体替换披风标签，仅资源创建者可操作；标签自动转为小写并去重
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Param       request body apiLibrary.SetTagsRequest true "设置标签请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeResponse} "设置成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "无权限"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Router      /library/capes/{cape_id}/tags [PUT]
+func (h *LibraryHandler) SetCapeTags(ctx *gin.Context) {
+	h.log.Info(ctx, "SetCapeTags - 设置披风标签")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.SetTagsRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	cape, xErr := h.service.libraryLogic.SetCapeTags(ctx.Request.Context(), userID, capeID, req.Tags)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "设置披风标签成功", capeDTOToResponse(cape))
+}
+
+// ==================== Gallery Helper Methods ====================
+
+// parseGalleryQuery 从查询字符串解析画廊查询参数。
+func (h *LibraryHandler) parseGalleryQuery(ctx *gin.Context) (*models.LibraryGalleryQuery, *xError.Error) {
+	page, pageSize := h.parsePagination(ctx)
+	query := &models.LibraryGalleryQuery{
+		Keyword:  ctx.Query("keyword"),
+		Tag:      ctx.Query("tag"),
+		Sort:     ctx.Query("sort"),
+		Page:     page,
+		PageSize: pageSize,
+	}
+
+	if uploaderStr := ctx.Query("uploader_id"); uploaderStr != "" {
+		uploaderID, err := xSnowflake.ParseSnowflakeID(uploaderStr)
+		if err != nil {
+			return nil, xError.NewError(ctx, xError.ParameterError, "解析上传者 ID 失败", true, err)
+		}
+		query.UploaderID = &uploaderID
+	}
+
+	if modelStr := ctx.Query("model"); modelStr != "" {
+		modelVal, err := strconv.ParseUint(modelStr, 10, 8)
+		if err != nil {
+			return nil, xError.NewError(ctx, xError.ParameterError, "无效的皮肤模型参数", true, err)
+		}
+		model := entity.ModelType(modelVal)
+		query.Model = &model
+	}
+
+	return query, nil
+}
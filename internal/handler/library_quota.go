@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// ==================== Quota Log Handlers ====================
+
+// ListQuotaLogs 获取当前用户的资源库配额变更日志
+//
+// @Summary     [玩家] 资源库配额变更日志
+// @Description 按时间倒序分页查询当前用户的资源库配额变更记录，包括上传、删除、公开状态切换、重算、管理员调整、兑换码与角色档位等来源
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       bucket query int false "配额分项 (1=skins_public, 2=skins_private, 3=capes_public, 4=capes_private)"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.QuotaLogListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Security    BearerAuth
+// @Router      /library/quota/logs [GET]
+func (h *LibraryHandler) ListQuotaLogs(ctx *gin.Context) {
+	h.log.Info(ctx, "ListQuotaLogs - 获取资源库配额变更日志")
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	h.respondQuotaLogs(ctx, userID)
+}
+
+// ListUserQuotaLogs 查询指定用户的资源库配额变更日志（管理员）
+//
+// @Summary     [超管] 用户资源库配额变更日志
+// @Description 按时间倒序分页查询指定用户的资源库配额变更记录
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       user_id path string true "目标用户 ID"
+// @Param       bucket query int false "配额分项 (1=skins_public, 2=skins_private, 3=capes_public, 4=capes_private)"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.QuotaLogListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/users/{user_id}/quota/logs [GET]
+func (h *LibraryHandler) ListUserQuotaLogs(ctx *gin.Context) {
+	h.log.Info(ctx, "ListUserQuotaLogs - 查询用户资源库配额变更日志")
+
+	userID, err := xSnowflake.ParseSnowflakeID(ctx.Param("user_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	h.respondQuotaLogs(ctx, userID)
+}
+
+// AdjustQuota 管理员调整指定用户的资源库配额
+//
+// @Summary     [超管] 调整用户资源库配额
+// @Description 管理员调整指定用户某一配额分项的总额度（相对变化量），调整后的总额度不能小于 0 或已使用额度，调整会写入配额日志；同一 request_id 重复提交不会重复调整
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       user_id path string true "目标用户 ID"
+// @Param       request body apiLibrary.AdjustQuotaRequest true "调整配额请求"
+// @Success     200 {object} xBase.BaseResponse{data=entity.LibraryQuota} "调整成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "用户配额不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/users/{user_id}/quota [POST]
+func (h *LibraryHandler) AdjustQuota(ctx *gin.Context) {
+	h.log.Info(ctx, "AdjustQuota - 管理员调整资源库配额")
+
+	targetUserID, err := xSnowflake.ParseSnowflakeID(ctx.Param("user_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析目标用户 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.AdjustQuotaRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	quota, xErr := h.service.libraryLogic.AdjustLibraryQuotaAdmin(
+		ctx.Request.Context(),
+		operatorID,
+		targetUserID,
+		entityType.LibraryQuotaBucket(req.Bucket),
+		req.Delta,
+		req.RequestID,
+		req.Remark,
+	)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "调整资源库配额成功", quota)
+}
+
+// respondQuotaLogs 解析分项筛选与分页参数，查询并返回指定用户的配额变更日志。
+func (h *LibraryHandler) respondQuotaLogs(ctx *gin.Context, userID xSnowflake.SnowflakeID) {
+	var bucket *entityType.LibraryQuotaBucket
+	if rawBucket := ctx.Query("bucket"); rawBucket != "" {
+		parsed, err := strconv.ParseUint(rawBucket, 10, 8)
+		if err != nil {
+			_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析配额分项失败", true, err))
+			return
+		}
+		value := entityType.LibraryQuotaBucket(parsed)
+		bucket = &value
+	}
+	page, pageSize := h.parsePagination(ctx)
+
+	logs, total, xErr := h.service.libraryLogic.ListLibraryQuotaLogs(ctx.Request.Context(), userID, bucket, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取资源库配额日志成功", apiLibrary.QuotaLogListResponse{
+		Total: total,
+		Items: libraryQuotaLogDTOsToResponses(logs),
+	})
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// ==================== Redeem Code Handlers ====================
+
+// GenerateRedeemCodes 批量生成兑换码（管理员）
+//
+// @Summary     [超管] 批量生成兑换码
+// @Description 按同一载荷（皮肤、披风、资源库配额增量、游戏档案配额增量，至少一项）批量生成兑换码，可设置每个兑换码的最大兑换次数、过期时间与允许兑换的角色
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.GenerateRedeemCodesRequest true "批量生成兑换码请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.RedeemCodeBatchResponse} "生成成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限或资源已停用"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/redeem-codes [POST]
+func (h *LibraryHandler) GenerateRedeemCodes(ctx *gin.Context) {
+	h.log.Info(ctx, "GenerateRedeemCodes - 批量生成兑换码")
+
+	req := xUtil.Bind(ctx, &apiLibrary.GenerateRedeemCodesRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	skinLibraryID, ok := parseOptionalSnowflakeID(ctx, req.SkinLibraryID, "解析皮肤 ID 失败")
+	if !ok {
+		return
+	}
+	capeLibraryID, ok := parseOptionalSnowflakeID(ctx, req.CapeLibraryID, "解析披风 ID 失败")
+	if !ok {
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	maxUses := req.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+
+	batch, xErr := h.service.libraryLogic.GenerateRedeemCodes(
+		ctx.Request.Context(),
+		operatorID,
+		models.RedeemCodePayloadDTO{
+			SkinLibraryID:     skinLibraryID,
+			CapeLibraryID:     capeLibraryID,
+			SkinsPrivateDelta: req.SkinsPrivateDelta,
+			SkinsPublicDelta:  req.SkinsPublicDelta,
+			CapesPrivateDelta: req.CapesPrivateDelta,
+			CapesPublicDelta:  req.CapesPublicDelta,
+			ProfileQuotaDelta: req.ProfileQuotaDelta,
+			MaxUses:           maxUses,
+			ExpiresAt:         req.ExpiresAt,
+			AllowedRoles:      req.AllowedRoles,
+		},
+		req.Count,
+		req.Remark,
+	)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "生成兑换码成功", redeemCodeBatchDTOToResponse(batch))
+}
+
+// ListRedeemCodes 兑换码列表（管理员）
+//
+// @Summary     [超管] 兑换码列表
+// @Description 分页查询兑换码及其兑换次数，可按生成批次筛选
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       batch_id query string false "生成批次 ID"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.RedeemCodeListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/redeem-codes [GET]
+func (h *LibraryHandler) ListRedeemCodes(ctx *gin.Context) {
+	h.log.Info(ctx, "ListRedeemCodes - 兑换码列表")
+
+	var batchID *xSnowflake.SnowflakeID
+	if rawBatchID := ctx.Query("batch_id"); rawBatchID != "" {
+		parsed, ok := parseOptionalSnowflakeID(ctx, &rawBatchID, "解析批次 ID 失败")
+		if !ok {
+			return
+		}
+		batchID = parsed
+	}
+	page, pageSize := h.parsePagination(ctx)
+
+	codes, total, xErr := h.service.libraryLogic.ListRedeemCodes(ctx.Request.Context(), batchID, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取兑换码列表成功", apiLibrary.RedeemCodeListResponse{
+		Total: total,
+		Items: redeemCodeDTOsToResponses(codes),
+	})
+}
+
+// DisableRedeemCodeBatch 停用兑换码批次（管理员）
+//
+// @Summary     [超管] 停用兑换码批次
+// @Description 停用指定批次的全部兑换码，停用后无法再兑换；已兑换获得的资源与配额不会被收回
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       batch_id path string true "生成批次 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.DisableRedeemCodeBatchResponse} "停用成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "批次不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/redeem-codes/batches/{batch_id}/disable [POST]
+func (h *LibraryHandler) DisableRedeemCodeBatch(ctx *gin.Context) {
+	h.log.Info(ctx, "DisableRedeemCodeBatch - 停用兑换码批次")
+
+	batchID, err := xSnowflake.ParseSnowflakeID(ctx.Param("batch_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析批次 ID 失败", true, err))
+		return
+	}
+
+	disabled, xErr := h.service.libraryLogic.DisableRedeemCodeBatch(ctx.Request.Context(), batchID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "停用兑换码批次成功", apiLibrary.DisableRedeemCodeBatchResponse{Disabled: disabled})
+}
+
+// Redeem 兑换兑换码
+//
+// @Summary     兑换兑换码
+// @Description 兑换兑换码获得对应的皮肤、披风或配额。兑换码忽略大小写与 - 分隔符，每个用户对同一兑换码仅能兑换一次，每分钟最多尝试 5 次
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.RedeemRequest true "兑换请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.RedeemResultResponse} "兑换成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "兑换码已过期或不满足角色限制"
+// @Failure     404 {object} xBase.BaseResponse "兑换码无效"
+// @Failure     409 {object} xBase.BaseResponse "已兑换过、已被领完或已拥有对应资源"
+// @Failure     429 {object} xBase.BaseResponse "兑换尝试过于频繁"
+// @Security    BearerAuth
+// @Router      /library/redeem [POST]
+func (h *LibraryHandler) Redeem(ctx *gin.Context) {
+	h.log.Info(ctx, "Redeem - 兑换兑换码")
+
+	req := xUtil.Bind(ctx, &apiLibrary.RedeemRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	result, xErr := h.service.libraryLogic.RedeemCode(ctx.Request.Context(), userID, req.Code)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "兑换成功", redeemResultDTOToResponse(result))
+}
+
+// parseOptionalSnowflakeID 解析可选的 Snowflake ID，空值返回 nil，解析失败时写入错误并返回 false。
+func parseOptionalSnowflakeID(ctx *gin.Context, raw *string, message string) (*xSnowflake.SnowflakeID, bool) {
+	if raw == nil || *raw == "" {
+		return nil, true
+	}
+	id, err := xSnowflake.ParseSnowflakeID(*raw)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, message, true, err))
+		return nil, false
+	}
+	return &id, true
+}
@@ -0,0 +1,379 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== Report Handlers ====================
+
+// ReportSkin 举报皮肤
+//
+// @Summary     [玩家] 举报皮肤
+// @Description 举报公开皮肤，同一皮肤仅能存在一条待处理举报；待处理举报数达到阈值后皮肤自动从公开列表隐藏
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Param       request body apiLibrary.ReportRequest true "举报请求"
+// @Success     200 {object} xBase.BaseResponse "举报成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "已举报"
+// @Router      /library/skins/{skin_id}/report [POST]
+func (h *LibraryHandler) ReportSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "ReportSkin - 举报皮肤")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.ReportRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	xErr := h.service.libraryLogic.ReportSkin(ctx.Request.Context(), userID, skinID, entityType.ReportReason(req.Reason), req.Description)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.Success(ctx, "举报皮肤成功")
+}
+
+// ReportCape 举报披风
+//
+// @Summary     [玩家] 举报披风
+// @Description 举报公开披风，同一披风仅能存在一条待处理举报；待处理举报数达到阈值后披风自动从公开列表隐藏
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Param       request body apiLibrary.ReportRequest true "举报请求"
+// @Success     200 {object} xBase.BaseResponse "举报成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "已举报"
+// @Router      /library/capes/{cape_id}/report [POST]
+func (h *LibraryHandler) ReportCape(ctx *gin.Context) {
+	h.log.Info(ctx, "ReportCape - 举报披风")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.ReportRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	xErr := h.service.libraryLogic.ReportCape(ctx.Request.Context(), userID, capeID, entityType.ReportReason(req.Reason), req.Description)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.Success(ctx, "举报披风成功")
+}
+
+// ListReportedSkins 被举报皮肤收件箱（管理员）
+//
+// @Summary     [超管] 被举报皮肤列表
+// @Description 管理员分页查询存在待处理举报的皮肤，已自动隐藏的优先，其次按待处理举报数倒序
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reports/skins [GET]
+func (h *LibraryHandler) ListReportedSkins(ctx *gin.Context) {
+	h.log.Info(ctx, "ListReportedSkins - 被举报皮肤列表")
+
+	page, pageSize := h.parsePagination(ctx)
+
+	skins, total, xErr := h.service.libraryLogic.ListReportedSkins(ctx.Request.Context(), page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiLibrary.SkinListResponse{
+		Total: total,
+		Items: skinDTOsToResponses(skins),
+	}
+	xResult.SuccessHasData(ctx, "获取被举报皮肤列表成功", response)
+}
+
+// ListSkinReports 皮肤举报明细（管理员）
+//
+// @Summary     [超管] 皮肤举报明细
+// @Description 管理员查询指定皮肤的全部待处理举报
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReportListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reports/skins/{skin_id} [GET]
+func (h *LibraryHandler) ListSkinReports(ctx *gin.Context) {
+	h.log.Info(ctx, "ListSkinReports - 皮肤举报明细")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	reports, xErr := h.service.libraryLogic.ListSkinReports(ctx.Request.Context(), skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取皮肤举报明细成功", apiLibrary.ReportListResponse{Items: libraryReportDTOsToResponses(reports)})
+}
+
+// DismissSkinReports 驳回皮肤举报（管理员）
+//
+// @Summary     [超管] 驳回皮肤举报
+// @Description 管理员驳回指定皮肤的全部待处理举报，清零举报数并恢复在公开列表中展示
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReportResultResponse} "驳回成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "没有待处理的举报"
+// @Security    BearerAuth
+// @Router      /library/admin/reports/skins/{skin_id}/dismiss [POST]
+func (h *LibraryHandler) DismissSkinReports(ctx *gin.Context) {
+	h.log.Info(ctx, "DismissSkinReports - 驳回皮肤举报")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	count, xErr := h.service.libraryLogic.DismissSkinReports(ctx.Request.Context(), operatorID, skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "驳回皮肤举报成功", apiLibrary.ReportResultResponse{Count: count})
+}
+
+// TakedownSkin 下架皮肤（管理员）
+//
+// @Summary     [超管] 下架皮肤
+// @Description 管理员下架皮肤：从所有用户撤销、卸下全部游戏档案上的装备、删除皮肤及纹理文件，并将待处理举报标记为已下架
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReportResultResponse} "下架成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/reports/skins/{skin_id}/takedown [POST]
+func (h *LibraryHandler) TakedownSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "TakedownSkin - 下架皮肤")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	count, xErr := h.service.libraryLogic.TakedownSkin(ctx.Request.Context(), operatorID, skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "下架皮肤成功", apiLibrary.ReportResultResponse{Count: count})
+}
+
+// ListReportedCapes 被举报披风收件箱（管理员）
+//
+// @Summary     [超管] 被举报披风列表
+// @Description 管理员分页查询存在待处理举报的披风，已自动隐藏的优先，其次按待处理举报数倒序
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reports/capes [GET]
+func (h *LibraryHandler) ListReportedCapes(ctx *gin.Context) {
+	h.log.Info(ctx, "ListReportedCapes - 被举报披风列表")
+
+	page, pageSize := h.parsePagination(ctx)
+
+	capes, total, xErr := h.service.libraryLogic.ListReportedCapes(ctx.Request.Context(), page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiLibrary.CapeListResponse{
+		Total: total,
+		Items: capeDTOsToResponses(capes),
+	}
+	xResult.SuccessHasData(ctx, "获取被举报披风列表成功", response)
+}
+
+// ListCapeReports 披风举报明细（管理员）
+//
+// @Summary     [超管] 披风举报明细
+// @Description 管理员查询指定披风的全部待处理举报
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReportListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reports/capes/{cape_id} [GET]
+func (h *LibraryHandler) ListCapeReports(ctx *gin.Context) {
+	h.log.Info(ctx, "ListCapeReports - 披风举报明细")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	reports, xErr := h.service.libraryLogic.ListCapeReports(ctx.Request.Context(), capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取披风举报明细成功", apiLibrary.ReportListResponse{Items: libraryReportDTOsToResponses(reports)})
+}
+
+// DismissCapeReports 驳回披风举报（管理员）
+//
+// @Summary     [超管] 驳回披风举报
+// @Description 管理员驳回指定披风的全部待处理举报，清零举报数并恢复在公开列表中展示
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReportResultResponse} "驳回成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "没有待处理的举报"
+// @Security    BearerAuth
+// @Router      /library/admin/reports/capes/{cape_id}/dismiss [POST]
+func (h *LibraryHandler) DismissCapeReports(ctx *gin.Context) {
+	h.log.Info(ctx, "DismissCapeReports - 驳回披风举报")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	count, xErr := h.service.libraryLogic.DismissCapeReports(ctx.Request.Context(), operatorID, capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "驳回披风举报成功", apiLibrary.ReportResultResponse{Count: count})
+}
+
+// TakedownCape 下架披风（管理员）
+//
+// @Summary     [超管] 下架披风
+// @Description 管理员下架披风：从所有用户撤销、卸下全部游戏档案上的装备、删除披风及纹理文件，并将待处理举报标记为已下架
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReportResultResponse} "下架成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/reports/capes/{cape_id}/takedown [POST]
+func (h *LibraryHandler) TakedownCape(ctx *gin.Context) {
+	h.log.Info(ctx, "TakedownCape - 下架披风")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	count, xErr := h.service.libraryLogic.TakedownCape(ctx.Request.Context(), operatorID, capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "下架披风成功", apiLibrary.ReportResultResponse{Count: count})
+}
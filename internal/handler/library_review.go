@@ -0,0 +1,256 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// ==================== Review Handlers ====================
+
+// ListPendingSkins 待审核皮肤列表（管理员）
+//
+// @Summary     [超管] 待审核皮肤列表
+// @Description 管理员按提交时间先后分页查询待审核的公开皮肤
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reviews/skins [GET]
+func (h *LibraryHandler) ListPendingSkins(ctx *gin.Context) {
+	h.log.Info(ctx, "ListPendingSkins - 待审核皮肤列表")
+
+	page, pageSize := h.parsePagination(ctx)
+
+	skins, total, xErr := h.service.libraryLogic.ListPendingSkins(ctx.Request.Context(), page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiLibrary.SkinListResponse{
+		Total: total,
+		Items: skinDTOsToResponses(skins),
+	}
+	xResult.SuccessHasData(ctx, "获取待审核皮肤列表成功", response)
+}
+
+// ListPendingCapes 待审核披风列表（管理员）
+//
+// @Summary     [超管] 待审核披风列表
+// @Description 管理员按提交时间先后分页查询待审核的公开披风
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reviews/capes [GET]
+func (h *LibraryHandler) ListPendingCapes(ctx *gin.Context) {
+	h.log.Info(ctx, "ListPendingCapes - 待审核披风列表")
+
+	page, pageSize := h.parsePagination(ctx)
+
+	capes, total, xErr := h.service.libraryLogic.ListPendingCapes(ctx.Request.Context(), page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiLibrary.CapeListResponse{
+		Total: total,
+		Items: capeDTOsToResponses(capes),
+	}
+	xResult.SuccessHasData(ctx, "获取待审核披风列表成功", response)
+}
+
+// ApproveSkins 批量审核通过皮肤（管理员）
+//
+// @Summary     [超管] 批量审核通过皮肤
+// @Description 管理员批量通过待审核皮肤，通过后出现在公开画廊并邮件通知上传者
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.ReviewApproveRequest true "审核通过请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReviewResultResponse} "审核成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reviews/skins/approve [POST]
+func (h *LibraryHandler) ApproveSkins(ctx *gin.Context) {
+	h.log.Info(ctx, "ApproveSkins - 批量审核通过皮肤")
+
+	req := xUtil.Bind(ctx, &apiLibrary.ReviewApproveRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	reviewerID, ids, ok := h.parseReviewInput(ctx, req.IDs)
+	if !ok {
+		return
+	}
+
+	count, xErr := h.service.libraryLogic.ReviewSkins(ctx.Request.Context(), reviewerID, ids, true, "")
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "审核通过皮肤成功", apiLibrary.ReviewResultResponse{Count: count})
+}
+
+// RejectSkins 批量审核驳回皮肤（管理员）
+//
+// @Summary     [超管] 批量审核驳回皮肤
+// @Description 管理员批量驳回待审核皮肤，驳回后皮肤退回私有并邮件通知上传者驳回原因
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.ReviewRejectRequest true "审核驳回请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReviewResultResponse} "驳回成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reviews/skins/reject [POST]
+func (h *LibraryHandler) RejectSkins(ctx *gin.Context) {
+	h.log.Info(ctx, "RejectSkins - 批量审核驳回皮肤")
+
+	req := xUtil.Bind(ctx, &apiLibrary.ReviewRejectRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	reviewerID, ids, ok := h.parseReviewInput(ctx, req.IDs)
+	if !ok {
+		return
+	}
+
+	count, xErr := h.service.libraryLogic.ReviewSkins(ctx.Request.Context(), reviewerID, ids, false, req.Reason)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "审核驳回皮肤成功", apiLibrary.ReviewResultResponse{Count: count})
+}
+
+// ApproveCapes 批量审核通过披风（管理员）
+//
+// @Summary     [超管] 批量审核通过披风
+// @Description 管理员批量通过待审核披风，通过后出现在公开画廊并邮件通知上传者
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.ReviewApproveRequest true "审核通过请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReviewResultResponse} "审核成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reviews/capes/approve [POST]
+func (h *LibraryHandler) ApproveCapes(ctx *gin.Context) {
+	h.log.Info(ctx, "ApproveCapes - 批量审核通过披风")
+
+	req := xUtil.Bind(ctx, &apiLibrary.ReviewApproveRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	reviewerID, ids, ok := h.parseReviewInput(ctx, req.IDs)
+	if !ok {
+		return
+	}
+
+	count, xErr := h.service.libraryLogic.ReviewCapes(ctx.Request.Context(), reviewerID, ids, true, "")
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "审核通过披风成功", apiLibrary.ReviewResultResponse{Count: count})
+}
+
+// RejectCapes 批量审核驳回披风（管理员）
+//
+// @Summary     [超管] 批量审核驳回披风
+// @Description 管理员批量驳回待审核披风，驳回后披风退回私有并邮件通知上传者驳回原因
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.ReviewRejectRequest true "审核驳回请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ReviewResultResponse} "驳回成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/reviews/capes/reject [POST]
+func (h *LibraryHandler) RejectCapes(ctx *gin.Context) {
+	h.log.Info(ctx, "RejectCapes - 批量审核驳回披风")
+
+	req := xUtil.Bind(ctx, &apiLibrary.ReviewRejectRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	reviewerID, ids, ok := h.parseReviewInput(ctx, req.IDs)
+	if !ok {
+		return
+	}
+
+	count, xErr := h.service.libraryLogic.ReviewCapes(ctx.Request.Context(), reviewerID, ids, false, req.Reason)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "审核驳回披风成功", apiLibrary.ReviewResultResponse{Count: count})
+}
+
+// parseReviewInput 解析审核操作者 ID 与资源 ID 列表，失败时已写入错误并返回 false。
+func (h *LibraryHandler) parseReviewInput(ctx *gin.Context, rawIDs []string) (xSnowflake.SnowflakeID, []xSnowflake.SnowflakeID, bool) {
+	reviewerID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return 0, nil, false
+	}
+
+	ids := make([]xSnowflake.SnowflakeID, 0, len(rawIDs))
+	for _, raw := range rawIDs {
+		id, err := xSnowflake.ParseSnowflakeID(raw)
+		if err != nil {
+			_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析资源库 ID 失败", true, err))
+			return 0, nil, false
+		}
+		ids = append(ids, id)
+	}
+	return reviewerID, ids, true
+}
+
+// parseOperatorID 从当前请求的授权信息中解析操作者用户 ID，失败时已写入错误并返回 false。
+func (h *LibraryHandler) parseOperatorID(ctx *gin.Context) (xSnowflake.SnowflakeID, bool) {
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return 0, false
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return 0, false
+	}
+	return operatorID, true
+}
@@ -0,0 +1,378 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// ==================== Share Handlers ====================
+
+// CreateSkinShare 创建皮肤私有分享链接
+//
+// @Summary     [玩家] 创建皮肤私有分享链接
+// @Description 为自己上传的私有皮肤生成限时分享令牌，返回公开预览链接。已公开且审核通过的皮肤可直接收藏，无需分享
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Param       request body apiLibrary.CreateShareRequest true "分享请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ShareResponse} "创建成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误或皮肤已公开"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "不是皮肤创建者"
+// @Failure     404 {object} xBase.BaseResponse "皮肤不存在"
+// @Security    BearerAuth
+// @Router      /library/skins/{skin_id}/shares [POST]
+func (h *LibraryHandler) CreateSkinShare(ctx *gin.Context) {
+	h.log.Info(ctx, "CreateSkinShare - 创建皮肤私有分享链接")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.CreateShareRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	share, xErr := h.service.libraryLogic.CreateSkinShare(ctx.Request.Context(), userID, skinID, req.ExpiresInHours)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "创建皮肤分享链接成功", libraryShareDTOToResponse(share))
+}
+
+// CreateCapeShare 创建披风私有分享链接
+//
+// @Summary     [玩家] 创建披风私有分享链接
+// @Description 为自己上传的私有披风生成限时分享令牌，返回公开预览链接。已公开且审核通过的披风可直接收藏，无需分享
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Param       request body apiLibrary.CreateShareRequest true "分享请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ShareResponse} "创建成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误或披风已公开"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "不是披风创建者"
+// @Failure     404 {object} xBase.BaseResponse "披风不存在"
+// @Security    BearerAuth
+// @Router      /library/capes/{cape_id}/shares [POST]
+func (h *LibraryHandler) CreateCapeShare(ctx *gin.Context) {
+	h.log.Info(ctx, "CreateCapeShare - 创建披风私有分享链接")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.CreateShareRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	share, xErr := h.service.libraryLogic.CreateCapeShare(ctx.Request.Context(), userID, capeID, req.ExpiresInHours)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "创建披风分享链接成功", libraryShareDTOToResponse(share))
+}
+
+// ListSkinShares 皮肤私有分享链接列表
+//
+// @Summary     [玩家] 皮肤私有分享链接列表
+// @Description 查询自己为某个皮肤创建的全部分享链接（含已过期与已撤销），按创建时间倒序排列
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ShareListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "不是皮肤创建者"
+// @Failure     404 {object} xBase.BaseResponse "皮肤不存在"
+// @Security    BearerAuth
+// @Router      /library/skins/{skin_id}/shares [GET]
+func (h *LibraryHandler) ListSkinShares(ctx *gin.Context) {
+	h.log.Info(ctx, "ListSkinShares - 皮肤私有分享链接列表")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	shares, xErr := h.service.libraryLogic.ListSkinShares(ctx.Request.Context(), userID, skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取皮肤分享链接成功", apiLibrary.ShareListResponse{Items: libraryShareDTOsToResponses(shares)})
+}
+
+// ListCapeShares 披风私有分享链接列表
+//
+// @Summary     [玩家] 披风私有分享链接列表
+// @Description 查询自己为某个披风创建的全部分享链接（含已过期与已撤销），按创建时间倒序排列
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.ShareListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "不是披风创建者"
+// @Failure     404 {object} xBase.BaseResponse "披风不存在"
+// @Security    BearerAuth
+// @Router      /library/capes/{cape_id}/shares [GET]
+func (h *LibraryHandler) ListCapeShares(ctx *gin.Context) {
+	h.log.Info(ctx, "ListCapeShares - 披风私有分享链接列表")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	shares, xErr := h.service.libraryLogic.ListCapeShares(ctx.Request.Context(), userID, capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取披风分享链接成功", apiLibrary.ShareListResponse{Items: libraryShareDTOsToResponses(shares)})
+}
+
+// RevokeSkinShare 撤销皮肤私有分享链接
+//
+// @Summary     [玩家] 撤销皮肤私有分享链接
+// @Description 撤销分享链接后令牌立即失效，通过该链接领取且未在任何游戏档案上装备该皮肤的用户将被移出资源库；已装备的用户保留关联
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Param       share_id path string true "分享 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.RevokeShareResponse} "撤销成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "分享不存在"
+// @Failure     409 {object} xBase.BaseResponse "分享已撤销"
+// @Security    BearerAuth
+// @Router      /library/skins/{skin_id}/shares/{share_id} [DELETE]
+func (h *LibraryHandler) RevokeSkinShare(ctx *gin.Context) {
+	h.log.Info(ctx, "RevokeSkinShare - 撤销皮肤私有分享链接")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	shareID, err := xSnowflake.ParseSnowflakeID(ctx.Param("share_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析分享 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	share, detached, xErr := h.service.libraryLogic.RevokeShare(ctx.Request.Context(), userID, entityType.LibraryKindSkin, skinID, shareID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "撤销皮肤分享链接成功", apiLibrary.RevokeShareResponse{
+		Share:         libraryShareDTOToResponse(share),
+		DetachedCount: detached,
+	})
+}
+
+// RevokeCapeShare 撤销披风私有分享链接
+//
+// @Summary     [玩家] 撤销披风私有分享链接
+// @Description 撤销分享链接后令牌立即失效，通过该链接领取且未在任何游戏档案上装备该披风的用户将被移出资源库；已装备的用户保留关联
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Param       share_id path string true "分享 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.RevokeShareResponse} "撤销成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "分享不存在"
+// @Failure     409 {object} xBase.BaseResponse "分享已撤销"
+// @Security    BearerAuth
+// @Router      /library/capes/{cape_id}/shares/{share_id} [DELETE]
+func (h *LibraryHandler) RevokeCapeShare(ctx *gin.Context) {
+	h.log.Info(ctx, "RevokeCapeShare - 撤销披风私有分享链接")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	shareID, err := xSnowflake.ParseSnowflakeID(ctx.Param("share_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析分享 ID 失败", true, err))
+		return
+	}
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	share, detached, xErr := h.service.libraryLogic.RevokeShare(ctx.Request.Context(), userID, entityType.LibraryKindCape, capeID, shareID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "撤销披风分享链接成功", apiLibrary.RevokeShareResponse{
+		Share:         libraryShareDTOToResponse(share),
+		DetachedCount: detached,
+	})
+}
+
+// GetSharePreview 私有分享公开预览
+//
+// @Summary     私有分享公开预览
+// @Description 通过分享令牌预览被分享的皮肤或披风，无需登录。令牌已过期、已撤销或资源已删除时返回 404
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       token path string true "分享令牌"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SharePreviewResponse} "获取成功"
+// @Failure     404 {object} xBase.BaseResponse "分享不存在或已失效"
+// @Router      /library/shares/{token} [GET]
+func (h *LibraryHandler) GetSharePreview(ctx *gin.Context) {
+	h.log.Info(ctx, "GetSharePreview - 私有分享公开预览")
+
+	preview, xErr := h.service.libraryLogic.GetSharePreview(ctx.Request.Context(), ctx.Param("token"))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取分享预览成功", librarySharePreviewDTOToResponse(preview))
+}
+
+// ClaimShare 领取私有分享
+//
+// @Summary     [玩家] 领取私有分享
+// @Description 通过分享令牌将被分享的皮肤或披风加入我的资源库，不计入配额；分享者撤销链接时，未装备该资源的领取者将被移出
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       token path string true "分享令牌"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SharePreviewResponse} "领取成功"
+// @Failure     400 {object} xBase.BaseResponse "不能领取自己的分享"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "分享不存在或已失效"
+// @Failure     409 {object} xBase.BaseResponse "已拥有该资源"
+// @Security    BearerAuth
+// @Router      /library/shares/{token}/claim [POST]
+func (h *LibraryHandler) ClaimShare(ctx *gin.Context) {
+	h.log.Info(ctx, "ClaimShare - 领取私有分享")
+
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	userID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析用户 ID 失败", true, err))
+		return
+	}
+
+	preview, xErr := h.service.libraryLogic.ClaimShare(ctx.Request.Context(), userID, ctx.Param("token"))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "领取分享成功", librarySharePreviewDTOToResponse(preview))
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== Similar Texture Handlers ====================
+
+// ListSimilarSkins 相似皮肤查询（管理员）
+//
+// @Summary     [超管] 相似皮肤查询
+// @Description 按纹理感知哈希查询与指定皮肤近似的全部皮肤（含私有与待审核），按汉明距离升序、上传时间升序排列，用于处理盗用投诉。历史上传或非 PNG 纹理没有感知哈希，无法查询
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Param       max_distance query int false "最大汉明距离，默认 10，最大 20"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SimilarSkinListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误或皮肤暂无感知哈希"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "皮肤不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/skins/{skin_id}/similar [GET]
+func (h *LibraryHandler) ListSimilarSkins(ctx *gin.Context) {
+	h.log.Info(ctx, "ListSimilarSkins - 相似皮肤查询")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+	maxDistance, ok := h.parseMaxDistance(ctx)
+	if !ok {
+		return
+	}
+
+	skins, xErr := h.service.libraryLogic.ListSimilarSkins(ctx.Request.Context(), skinID, maxDistance)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取相似皮肤成功", apiLibrary.SimilarSkinListResponse{Items: similarSkinDTOsToResponses(skins)})
+}
+
+// ListSimilarCapes 相似披风查询（管理员）
+//
+// @Summary     [超管] 相似披风查询
+// @Description 按纹理感知哈希查询与指定披风近似的全部披风（含私有与待审核），按汉明距离升序、上传时间升序排列，用于处理盗用投诉。历史上传或非 PNG 纹理没有感知哈希，无法查询
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Param       max_distance query int false "最大汉明距离，默认 10，最大 20"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SimilarCapeListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误或披风暂无感知哈希"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "披风不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/capes/{cape_id}/similar [GET]
+func (h *LibraryHandler) ListSimilarCapes(ctx *gin.Context) {
+	h.log.Info(ctx, "ListSimilarCapes - 相似披风查询")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+	maxDistance, ok := h.parseMaxDistance(ctx)
+	if !ok {
+		return
+	}
+
+	capes, xErr := h.service.libraryLogic.ListSimilarCapes(ctx.Request.Context(), capeID, maxDistance)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取相似披风成功", apiLibrary.SimilarCapeListResponse{Items: similarCapeDTOsToResponses(capes)})
+}
+
+// parseMaxDistance 解析相似纹理查询的最大汉明距离，未传时返回 0（使用默认值），失败时写入错误并返回 false。
+func (h *LibraryHandler) parseMaxDistance(ctx *gin.Context) (int, bool) {
+	raw := ctx.Query("max_distance")
+	if raw == "" {
+		return 0, true
+	}
+	maxDistance, err := strconv.Atoi(raw)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "max_distance 必须为整数", true, err))
+		return 0, false
+	}
+	return maxDistance, true
+}
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== System Catalog Handlers ====================
+
+// CreateSystemSkin 创建系统皮肤（管理员）
+//
+// @Summary     [超管] 创建系统皮肤
+// @Description 上传系统内置皮肤，不归属任何用户、不占用配额；可标记为新用户默认发放，或作为新游戏档案的默认装备皮肤
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.CreateSystemSkinRequest true "创建系统皮肤请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinResponse} "创建成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     409 {object} xBase.BaseResponse "纹理已存在"
+// @Security    BearerAuth
+// @Router      /library/admin/system/skins [POST]
+func (h *LibraryHandler) CreateSystemSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "CreateSystemSkin - 创建系统皮肤")
+
+	req := xUtil.Bind(ctx, &apiLibrary.CreateSystemSkinRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	skin, xErr := h.service.libraryLogic.CreateSystemSkin(ctx.Request.Context(), req.Name, req.Model, req.Texture, req.IsPublic, req.IsDefault, req.IsDefaultEquip)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "创建系统皮肤成功", skinDTOToResponse(skin))
+}
+
+// ListSystemSkins 系统皮肤目录（管理员）
+//
+// @Summary     [超管] 系统皮肤目录
+// @Description 分页查询系统内置皮肤，默认不含已停用皮肤
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       include_retired query bool false "是否包含已停用皮肤，默认 false"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/system/skins [GET]
+func (h *LibraryHandler) ListSystemSkins(ctx *gin.Context) {
+	h.log.Info(ctx, "ListSystemSkins - 系统皮肤目录")
+
+	page, pageSize := h.parsePagination(ctx)
+	includeRetired := ctx.Query("include_retired") == "true"
+
+	skins, total, xErr := h.service.libraryLogic.ListSystemSkins(ctx.Request.Context(), includeRetired, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiLibrary.SkinListResponse{
+		Total: total,
+		Items: skinDTOsToResponses(skins),
+	}
+	xResult.SuccessHasData(ctx, "获取系统皮肤目录成功", response)
+}
+
+// UpdateSystemSkin 更新系统皮肤（管理员）
+//
+// @Summary     [超管] 更新系统皮肤
+// @Description 更新系统皮肤的名称、公开状态与默认标记，未传字段保持原值；已停用皮肤不能设为公开或默认
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Param       request body apiLibrary.UpdateSystemSkinRequest true "更新系统皮肤请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinResponse} "更新成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "系统皮肤不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/system/skins/{skin_id} [PATCH]
+func (h *LibraryHandler) UpdateSystemSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "UpdateSystemSkin - 更新系统皮肤")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.UpdateSystemSkinRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	skin, xErr := h.service.libraryLogic.UpdateSystemSkin(ctx.Request.Context(), skinID, req.Name, req.IsPublic, req.IsDefault, req.IsDefaultEquip)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "更新系统皮肤成功", skinDTOToResponse(skin))
+}
+
+// RetireSystemSkin 停用系统皮肤（管理员）
+//
+// @Summary     [超管] 停用系统皮肤
+// @Description 停用后皮肤不再公开、不再发放给新用户或被新游戏档案默认装备，已持有的用户不受影响
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse "停用成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "系统皮肤不存在"
+// @Failure     409 {object} xBase.BaseResponse "已停用"
+// @Security    BearerAuth
+// @Router      /library/admin/system/skins/{skin_id}/retire [POST]
+func (h *LibraryHandler) RetireSystemSkin(ctx *gin.Context) {
+	h.log.Info(ctx, "RetireSystemSkin - 停用系统皮肤")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	if xErr := h.service.libraryLogic.RetireSystemSkin(ctx.Request.Context(), skinID); xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.Success(ctx, "停用系统皮肤成功")
+}
+
+// CreateSystemCape 创建系统披风（管理员）
+//
+// @Summary     [超管] 创建系统披风
+// @Description 上传系统内置披风，不归属任何用户、不占用配额；可标记为新用户默认发放
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiLibrary.CreateSystemCapeRequest true "创建系统披风请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeResponse} "创建成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     409 {object} xBase.BaseResponse "纹理已存在"
+// @Security    BearerAuth
+// @Router      /library/admin/system/capes [POST]
+func (h *LibraryHandler) CreateSystemCape(ctx *gin.Context) {
+	h.log.Info(ctx, "CreateSystemCape - 创建系统披风")
+
+	req := xUtil.Bind(ctx, &apiLibrary.CreateSystemCapeRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	cape, xErr := h.service.libraryLogic.CreateSystemCape(ctx.Request.Context(), req.Name, req.Texture, req.IsPublic, req.IsDefault)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "创建系统披风成功", capeDTOToResponse(cape))
+}
+
+// ListSystemCapes 系统披风目录（管理员）
+//
+// @Summary     [超管] 系统披风目录
+// @Description 分页查询系统内置披风，默认不含已停用披风
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       include_retired query bool false "是否包含已停用披风，默认 false"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /library/admin/system/capes [GET]
+func (h *LibraryHandler) ListSystemCapes(ctx *gin.Context) {
+	h.log.Info(ctx, "ListSystemCapes - 系统披风目录")
+
+	page, pageSize := h.parsePagination(ctx)
+	includeRetired := ctx.Query("include_retired") == "true"
+
+	capes, total, xErr := h.service.libraryLogic.ListSystemCapes(ctx.Request.Context(), includeRetired, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	response := apiLibrary.CapeListResponse{
+		Total: total,
+		Items: capeDTOsToResponses(capes),
+	}
+	xResult.SuccessHasData(ctx, "获取系统披风目录成功", response)
+}
+
+// UpdateSystemCape 更新系统披风（管理员）
+//
+// @Summary     [超管] 更新系统披风
+// @Description 更新系统披风的名称、公开状态与默认标记，未传字段保持原值；已停用披风不能设为公开或默认
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Param       request body apiLibrary.UpdateSystemCapeRequest true "更新系统披风请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeResponse} "更新成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "系统披风不存在"
+// @Security    BearerAuth
+// @Router      /library/admin/system/capes/{cape_id} [PATCH]
+func (h *LibraryHandler) UpdateSystemCape(ctx *gin.Context) {
+	h.log.Info(ctx, "UpdateSystemCape - 更新系统披风")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.UpdateSystemCapeRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	cape, xErr := h.service.libraryLogic.UpdateSystemCape(ctx.Request.Context(), capeID, req.Name, req.IsPublic, req.IsDefault)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "更新系统披风成功", capeDTOToResponse(cape))
+}
+
+// RetireSystemCape 停用系统披风（管理员）
+//
+// @Summary     [超管] 停用系统披风
+// @Description 停用后披风不再公开、不再发放给新用户，已持有的用户不受影响
+// @Tags        管理员-资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse "停用成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "系统披风不存在"
+// @Failure     409 {object} xBase.BaseResponse "已停用"
+// @Security    BearerAuth
+// @Router      /library/admin/system/capes/{cape_id}/retire [POST]
+func (h *LibraryHandler) RetireSystemCape(ctx *gin.Context) {
+	h.log.Info(ctx, "RetireSystemCape - 停用系统披风")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	if xErr := h.service.libraryLogic.RetireSystemCape(ctx.Request.Context(), capeID); xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.Success(ctx, "停用系统披风成功")
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	"github.com/gin-gonic/gin"
+)
+
+// ==================== Texture Version Handlers ====================
+
+// ReplaceSkinTexture 替换皮肤纹理
+//
+// @Summary     [玩家] 替换皮肤纹理
+// @Description 上传者替换皮肤纹理，皮肤 ID、点赞、收藏、标签与装备关系保持不变；旧纹理保留为历史版本，公开皮肤替换后重新进入审核
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Param       request body apiLibrary.ReplaceTextureRequest true "替换纹理请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinResponse} "替换成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "纹理已被其他皮肤使用"
+// @Router      /library/skins/{skin_id}/texture [PUT]
+func (h *LibraryHandler) ReplaceSkinTexture(ctx *gin.Context) {
+	h.log.Info(ctx, "ReplaceSkinTexture - 替换皮肤纹理")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.ReplaceTextureRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	skin, xErr := h.service.libraryLogic.ReplaceSkinTexture(ctx.Request.Context(), userID, skinID, req.Texture)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "替换皮肤纹理成功", skinDTOToResponse(skin))
+}
+
+// ListSkinTextureVersions 获取皮肤纹理版本历史
+//
+// @Summary     [玩家] 获取皮肤纹理版本历史
+// @Description 上传者查看皮肤的全部纹理版本，按版本号倒序返回并标记当前版本
+// @Tags        资源库接口
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.TextureVersionListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Router      /library/skins/{skin_id}/versions [GET]
+func (h *LibraryHandler) ListSkinTextureVersions(ctx *gin.Context) {
+	h.log.Info(ctx, "ListSkinTextureVersions - 获取皮肤纹理版本历史")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	versions, xErr := h.service.libraryLogic.ListSkinTextureVersions(ctx.Request.Context(), userID, skinID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取皮肤纹理版本历史成功", apiLibrary.TextureVersionListResponse{
+		Items: textureVersionDTOsToResponses(versions),
+	})
+}
+
+// RollbackSkinTexture 回滚皮肤纹理
+//
+// @Summary     [玩家] 回滚皮肤纹理
+// @Description 上传者将皮肤纹理切换到指定历史版本，公开皮肤回滚后重新进入审核
+// @Tags        资源库接口
+// @Produce     json
+// @Param       skin_id path string true "皮肤 ID"
+// @Param       version path int true "目标版本号"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.SkinResponse} "回滚成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源或版本不存在"
+// @Router      /library/skins/{skin_id}/versions/{version}/rollback [POST]
+func (h *LibraryHandler) RollbackSkinTexture(ctx *gin.Context) {
+	h.log.Info(ctx, "RollbackSkinTexture - 回滚皮肤纹理")
+
+	skinID, err := xSnowflake.ParseSnowflakeID(ctx.Param("skin_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析皮肤 ID 失败", true, err))
+		return
+	}
+
+	version, err := strconv.ParseInt(ctx.Param("version"), 10, 32)
+	if err != nil || version <= 0 {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "版本号必须为正整数", true))
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	skin, xErr := h.service.libraryLogic.RollbackSkinTexture(ctx.Request.Context(), userID, skinID, int32(version))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "回滚皮肤纹理成功", skinDTOToResponse(skin))
+}
+
+// ReplaceCapeTexture 替换披风纹理
+//
+// @Summary     [玩家] 替换披风纹理
+// @Description 上传者替换披风纹理，披风 ID、点赞、收藏、标签与装备关系保持不变；旧纹理保留为历史版本，公开披风替换后重新进入审核
+// @Tags        资源库接口
+// @Accept      json
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Param       request body apiLibrary.ReplaceTextureRequest true "替换纹理请求"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeResponse} "替换成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Failure     409 {object} xBase.BaseResponse "纹理已被其他披风使用"
+// @Router      /library/capes/{cape_id}/texture [PUT]
+func (h *LibraryHandler) ReplaceCapeTexture(ctx *gin.Context) {
+	h.log.Info(ctx, "ReplaceCapeTexture - 替换披风纹理")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiLibrary.ReplaceTextureRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	cape, xErr := h.service.libraryLogic.ReplaceCapeTexture(ctx.Request.Context(), userID, capeID, req.Texture)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "替换披风纹理成功", capeDTOToResponse(cape))
+}
+
+// ListCapeTextureVersions 获取披风纹理版本历史
+//
+// @Summary     [玩家] 获取披风纹理版本历史
+// @Description 上传者查看披风的全部纹理版本，按版本号倒序返回并标记当前版本
+// @Tags        资源库接口
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.TextureVersionListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源不存在"
+// @Router      /library/capes/{cape_id}/versions [GET]
+func (h *LibraryHandler) ListCapeTextureVersions(ctx *gin.Context) {
+	h.log.Info(ctx, "ListCapeTextureVersions - 获取披风纹理版本历史")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	versions, xErr := h.service.libraryLogic.ListCapeTextureVersions(ctx.Request.Context(), userID, capeID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取披风纹理版本历史成功", apiLibrary.TextureVersionListResponse{
+		Items: textureVersionDTOsToResponses(versions),
+	})
+}
+
+// RollbackCapeTexture 回滚披风纹理
+//
+// @Summary     [玩家] 回滚披风纹理
+// @Description 上传者将披风纹理切换到指定历史版本，公开披风回滚后重新进入审核
+// @Tags        资源库接口
+// @Produce     json
+// @Param       cape_id path string true "披风 ID"
+// @Param       version path int true "目标版本号"
+// @Success     200 {object} xBase.BaseResponse{data=apiLibrary.CapeResponse} "回滚成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     404 {object} xBase.BaseResponse "资源或版本不存在"
+// @Router      /library/capes/{cape_id}/versions/{version}/rollback [POST]
+func (h *LibraryHandler) RollbackCapeTexture(ctx *gin.Context) {
+	h.log.Info(ctx, "RollbackCapeTexture - 回滚披风纹理")
+
+	capeID, err := xSnowflake.ParseSnowflakeID(ctx.Param("cape_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析披风 ID 失败", true, err))
+		return
+	}
+
+	version, err := strconv.ParseInt(ctx.Param("version"), 10, 32)
+	if err != nil || version <= 0 {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "版本号必须为正整数", true))
+		return
+	}
+
+	userID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	cape, xErr := h.service.libraryLogic.RollbackCapeTexture(ctx.Request.Context(), userID, capeID, int32(version))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "回滚披风纹理成功", capeDTOToResponse(cape))
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiAdmin "github.com/frontleaves-mc/frontleaves-yggleaf/api/admin"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// StartReconcile 发起存储对账（管理员）
+//
+// @Summary     [超管] 发起存储对账
+// @Description 在后台核对皮肤纹理、披风纹理与问题附件的数据库引用和对象存储文件，生成孤儿文件、悬空引用、哈希不一致与缓存态引用报告。默认试运行仅生成报告；apply 为 true 时确认缓存态引用，并删除登记超过 1 小时且未被引用的孤儿文件。悬空引用与哈希不一致始终只记录不处理
+// @Tags        管理员-存储接口
+// @Accept      json
+// @Produce     json
+// @Param       request body apiAdmin.StartStorageReconcileRequest false "对账模式"
+// @Success     200 {object} xBase.BaseResponse{data=admin.StorageReconcileReportResponse} "已开始对账"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     409 {object} xBase.BaseResponse "已有对账任务正在执行"
+// @Security    BearerAuth
+// @Router      /admin/storage/reconcile [POST]
+func (h *StorageHandler) StartReconcile(ctx *gin.Context) {
+	h.log.Info(ctx, "StartReconcile - 发起存储对账")
+
+	req := &apiAdmin.StartStorageReconcileRequest{}
+	if ctx.Request.ContentLength > 0 {
+		req = xUtil.Bind(ctx, req).Data()
+		if req == nil {
+			return
+		}
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	report, xErr := h.service.storageLogic.StartReconcile(ctx.Request.Context(), &operatorID, !req.Apply)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "已开始存储对账", storageReconcileReportDTOToResponse(report))
+}
+
+// ListReconcileReports 存储对账报告列表（管理员）
+//
+// @Summary     [超管] 存储对账报告列表
+// @Description 分页查询存储对账报告及其进度，按创建时间倒序
+// @Tags        管理员-存储接口
+// @Accept      json
+// @Produce     json
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=admin.StorageReconcileReportListResponse} "获取成功"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Security    BearerAuth
+// @Router      /admin/storage/reconcile/reports [GET]
+func (h *StorageHandler) ListReconcileReports(ctx *gin.Context) {
+	h.log.Info(ctx, "ListReconcileReports - 存储对账报告列表")
+
+	page, pageSize := h.parsePagination(ctx)
+
+	reports, total, xErr := h.service.storageLogic.ListReconcileReports(ctx.Request.Context(), page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	items := make([]apiAdmin.StorageReconcileReportResponse, len(reports))
+	for i := range reports {
+		items[i] = storageReconcileReportDTOToResponse(&reports[i])
+	}
+	xResult.SuccessHasData(ctx, "获取存储对账报告列表成功", apiAdmin.StorageReconcileReportListResponse{Total: total, Items: items})
+}
+
+// GetReconcileReport 存储对账报告详情（管理员）
+//
+// @Summary     [超管] 存储对账报告详情
+// @Description 查询存储对账报告的状态、各类问题数量与处理结果
+// @Tags        管理员-存储接口
+// @Accept      json
+// @Produce     json
+// @Param       report_id path string true "报告 ID"
+// @Success     200 {object} xBase.BaseResponse{data=admin.StorageReconcileReportResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "报告不存在"
+// @Security    BearerAuth
+// @Router      /admin/storage/reconcile/reports/{report_id} [GET]
+func (h *StorageHandler) GetReconcileReport(ctx *gin.Context) {
+	h.log.Info(ctx, "GetReconcileReport - 存储对账报告详情")
+
+	reportID, ok := h.parseReportID(ctx)
+	if !ok {
+		return
+	}
+
+	report, xErr := h.service.storageLogic.GetReconcileReport(ctx.Request.Context(), reportID)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取存储对账报告成功", storageReconcileReportDTOToResponse(report))
+}
+
+// ListReconcileIssues 存储对账问题列表（管理员）
+//
+// @Summary     [超管] 存储对账问题列表
+// @Description 分页查询存储对账报告中发现的问题，可按问题类型筛选
+// @Tags        管理员-存储接口
+// @Accept      json
+// @Produce     json
+// @Param       report_id path string true "报告 ID"
+// @Param       type query int false "问题类型 (1=orphan_file, 2=dangling_reference, 3=hash_mismatch, 4=unverified_reference)"
+// @Param       page query int false "页码，默认 1"
+// @Param       page_size query int false "每页数量，默认 20，最大 100"
+// @Success     200 {object} xBase.BaseResponse{data=admin.StorageReconcileIssueListResponse} "获取成功"
+// @Failure     400 {object} xBase.BaseResponse "请求参数错误"
+// @Failure     401 {object} xBase.BaseResponse "未授权"
+// @Failure     403 {object} xBase.BaseResponse "需要超级管理员权限"
+// @Failure     404 {object} xBase.BaseResponse "报告不存在"
+// @Security    BearerAuth
+// @Router      /admin/storage/reconcile/reports/{report_id}/issues [GET]
+func (h *StorageHandler) ListReconcileIssues(ctx *gin.Context) {
+	h.log.Info(ctx, "ListReconcileIssues - 存储对账问题列表")
+
+	reportID, ok := h.parseReportID(ctx)
+	if !ok {
+		return
+	}
+
+	var issueType *entityType.StorageIssueType
+	if rawType := ctx.Query("type"); rawType != "" {
+		parsed, err := strconv.ParseUint(rawType, 10, 8)
+		if err != nil || !entityType.StorageIssueType(parsed).IsValid() {
+			_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "无效的问题类型", true, err))
+			return
+		}
+		parsedType := entityType.StorageIssueType(parsed)
+		issueType = &parsedType
+	}
+	page, pageSize := h.parsePagination(ctx)
+
+	issues, total, xErr := h.service.storageLogic.ListReconcileIssues(ctx.Request.Context(), reportID, issueType, page, pageSize)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取存储对账问题列表成功", apiAdmin.StorageReconcileIssueListResponse{
+		Total: total,
+		Items: storageReconcileIssueDTOsToResponses(issues),
+	})
+}
+
+// ServeFile 下载本地存储文件
+//
+// @Summary     [公开] 下载本地存储文件
+// @Description 文件存储后端为 local 时，由服务自身提供皮肤、披风纹理与问题附件的下载，支持条件请求与断点续传；其他后端下始终返回文件不存在
+// @Tags        存储接口
+// @Produce     application/octet-stream
+// @Param       file_id path string true "文件 ID"
+// @Success     200 {file} file "文件流"
+// @Failure     404 {object} xBase.BaseResponse "文件不存在"
+// @Router      /storage/files/{file_id} [GET]
+func (h *StorageHandler) ServeFile(ctx *gin.Context) {
+	h.log.Info(ctx, "ServeFile - 下载本地存储文件")
+
+	file, mimeType, modTime, xErr := h.service.storageLogic.OpenLocalFile(ctx.Request.Context(), ctx.Param("file_id"))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+	defer file.Close()
+
+	// 文件 ID 对应的内容不可变，允许客户端与 CDN 长期缓存
+	ctx.Header("Content-Type", mimeType)
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	http.ServeContent(ctx.Writer, ctx.Request, "", modTime, file)
+}
+
+// ==================== Helper Methods ====================
+
+// parseReportID 解析路径中的对账报告 ID，失败时写入错误并返回 false。
+func (h *StorageHandler) parseReportID(ctx *gin.Context) (xSnowflake.SnowflakeID, bool) {
+	reportID, err := xSnowflake.ParseSnowflakeID(ctx.Param("report_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析报告 ID 失败", true, err))
+		return 0, false
+	}
+	return reportID, true
+}
+
+// parseOperatorID 解析当前操作者的用户 ID，失败时写入错误并返回 false。
+func (h *StorageHandler) parseOperatorID(ctx *gin.Context) (xSnowflake.SnowflakeID, bool) {
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return 0, false
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return 0, false
+	}
+	return operatorID, true
+}
+
+// parsePagination 解析分页参数，非法值回退为默认值，每页数量不超过上限。
+func (h *StorageHandler) parsePagination(ctx *gin.Context) (int, int) {
+	page, err := strconv.Atoi(ctx.DefaultQuery("page", strconv.Itoa(defaultPage)))
+	if err != nil || page < 1 {
+		page = defaultPage
+	}
+
+	pageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", strconv.Itoa(defaultPageSize)))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return page, pageSize
+}
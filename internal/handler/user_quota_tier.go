@@ -0,0 +1,133 @@
+package handler
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	apiAdmin "github.com/frontleaves-mc/frontleaves-yggleaf/api/admin"
+	"github.com/gin-gonic/gin"
+	bSdkUtil "github.com/phalanx-labs/beacon-sso-sdk/utility"
+)
+
+// ListQuotaTiers 管理员获取角色配额档位列表
+//
+// @Summary 	[超管] 角色配额档位列表
+// @Description 获取各角色的默认配额档位（游戏档案、公开/私有皮肤、公开/私有披风总额度）
+// @Tags        管理员-用户接口
+// @Accept      json
+// @Produce     json
+// @Success     200   {object}  xBase.BaseResponse{data=admin.QuotaTierListResponse}	"查询成功"
+// @Failure     401   {object}  xBase.BaseResponse         				"未授权"
+// @Failure     403   {object}  xBase.BaseResponse          			"需要超级管理员权限"
+// @Security    BearerAuth
+// @Router       /admin/quota-tiers [GET]
+func (h *UserHandler) ListQuotaTiers(ctx *gin.Context) {
+	h.log.Info(ctx, "ListQuotaTiers - 管理员获取角色配额档位列表")
+
+	response, xErr := h.service.userLogic.ListQuotaTiers(ctx.Request.Context())
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "获取角色配额档位成功", response)
+}
+
+// UpdateQuotaTier 管理员修改角色配额档位
+//
+// @Summary 	[超管] 修改角色配额档位
+// @Description 创建或修改角色的默认配额档位。新用户与变更为该角色的用户按档位设置配额；reapply 为 true 时在后台将档位重新应用到该角色的现有用户，仅提升低于档位的额度，不会降低手动调整的额度
+// @Tags        管理员-用户接口
+// @Accept      json
+// @Produce     json
+// @Param       role_name path string true "角色名称"
+// @Param       request body apiAdmin.UpdateQuotaTierRequest true "角色配额档位"
+// @Success     200   {object}  xBase.BaseResponse{data=admin.UpdateQuotaTierResponse}	"修改成功"
+// @Failure     400   {object}  xBase.BaseResponse          			"请求参数错误"
+// @Failure     401   {object}  xBase.BaseResponse         				"未授权"
+// @Failure     403   {object}  xBase.BaseResponse          			"需要超级管理员权限"
+// @Failure     404   {object}  xBase.BaseResponse          			"角色不存在"
+// @Security    BearerAuth
+// @Router       /admin/quota-tiers/{role_name} [PUT]
+func (h *UserHandler) UpdateQuotaTier(ctx *gin.Context) {
+	h.log.Info(ctx, "UpdateQuotaTier - 管理员修改角色配额档位")
+
+	req := xUtil.Bind(ctx, &apiAdmin.UpdateQuotaTierRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	response, xErr := h.service.userLogic.UpdateQuotaTier(ctx.Request.Context(), operatorID, ctx.Param("role_name"), req)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "修改角色配额档位成功", response)
+}
+
+// UpdateUserRole 管理员变更用户角色
+//
+// @Summary 	[超管] 变更用户角色
+// @Description 变更用户角色并应用目标角色的配额档位，仅提升低于档位的额度，降级角色时不会收回已有额度
+// @Tags        管理员-用户接口
+// @Accept      json
+// @Produce     json
+// @Param       user_id path string true "目标用户 ID"
+// @Param       request body apiAdmin.UpdateUserRoleRequest true "目标角色"
+// @Success     200   {object}  xBase.BaseResponse{data=admin.AdminUserItem}	"变更成功"
+// @Failure     400   {object}  xBase.BaseResponse          			"请求参数错误"
+// @Failure     401   {object}  xBase.BaseResponse         				"未授权"
+// @Failure     403   {object}  xBase.BaseResponse          			"需要超级管理员权限或试图变更自己的角色"
+// @Failure     404   {object}  xBase.BaseResponse          			"用户或角色不存在"
+// @Security    BearerAuth
+// @Router       /admin/users/{user_id}/role [PATCH]
+func (h *UserHandler) UpdateUserRole(ctx *gin.Context) {
+	h.log.Info(ctx, "UpdateUserRole - 管理员变更用户角色")
+
+	targetUserID, err := xSnowflake.ParseSnowflakeID(ctx.Param("user_id"))
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "无效的用户 ID", true, err))
+		return
+	}
+
+	req := xUtil.Bind(ctx, &apiAdmin.UpdateUserRoleRequest{}).Data()
+	if req == nil {
+		return
+	}
+
+	operatorID, ok := h.parseOperatorID(ctx)
+	if !ok {
+		return
+	}
+
+	response, xErr := h.service.userLogic.ChangeUserRole(ctx.Request.Context(), operatorID, targetUserID, req.RoleName)
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return
+	}
+
+	xResult.SuccessHasData(ctx, "变更用户角色成功", response)
+}
+
+// parseOperatorID 解析当前操作者的用户 ID，失败时写入错误并返回 false。
+func (h *UserHandler) parseOperatorID(ctx *gin.Context) (xSnowflake.SnowflakeID, bool) {
+	userinfo, xErr := h.service.oauthLogic.Userinfo(ctx, bSdkUtil.GetAuthorization(ctx))
+	if xErr != nil {
+		_ = ctx.Error(xErr)
+		return 0, false
+	}
+
+	operatorID, err := xSnowflake.ParseSnowflakeID(userinfo.Sub)
+	if err != nil {
+		_ = ctx.Error(xError.NewError(ctx, xError.ParameterError, "解析操作者 ID 失败", true, err))
+		return 0, false
+	}
+	return operatorID, true
+}
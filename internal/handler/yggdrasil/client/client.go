@@ -113,7 +113,7 @@ func (h *ClientHandler) Authenticate(ctx *gin.Context) {
 // @Param       request body apiYgg.RefreshRequest true "刷新令牌请求"
 // @Success     200   {object}  apiYgg.RefreshResponse     "刷新成功"
 // @Failure     400   {object}  apiYgg.YggdrasilError     "参数错误（角色已绑定仍指定）"
-// @Failure     403   {object}  apiYgg.YggdrasilError     "令牌无效或已过期"
+// @Failure     403   {object}  apiYgg.YggdrasilError     "令牌无效或已过期，或所选角色已被锁定"
 // @Failure     500   {object}  apiYgg.YggdrasilError     "服务器内部错误"
 // @Router      /authserver/refresh [post]
 func (h *ClientHandler) Refresh(ctx *gin.Context) {
@@ -4,6 +4,7 @@
 //   - #8: GET /sessionserver/session/minecraft/hasJoined — 服务端验证客户端
 //   - #9: GET /sessionserver/session/minecraft/profile/{uuid} — 查询角色属性
 //   - #10: POST /api/profiles/minecraft — 按名称批量查询角色
+//   - GET /api/user/profiles/{uuid}/names — 查询角色名称历史
 package server
 
 import (
@@ -63,8 +64,8 @@ func (h *ServerHandler) HasJoined(ctx *gin.Context) {
 		return
 	}
 
-	// 调用 Logic 层验证会话
-	profileResp, found, xErr := h.Service.Logic().HasJoined(ctx.Request.Context(), username, serverId, ip)
+	// 调用 Logic 层验证会话（请求方即 Minecraft 服务端，其地址用于进服记录）
+	profileResp, found, xErr := h.Service.Logic().HasJoined(ctx.Request.Context(), username, serverId, ip, ctx.ClientIP())
 	if xErr != nil {
 		apiYgg.AbortYggError(ctx, http.StatusInternalServerError, "InternalServerError", "会话验证失败")
 		return
@@ -168,3 +169,37 @@ func (h *ServerHandler) ProfilesBatchLookup(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, items)
 }
+
+// NameHistory 查询指定角色的名称历史
+//
+// @Summary     [服务端] 查询角色名称历史
+// @Description 兼容 Mojang names 接口，按时间升序返回角色曾使用的全部名称。首个名称不含 changedToAt，其余名称的 changedToAt 为改名时间戳（毫秒）。
+// @Tags        Yggdrasil-会话接口
+// @Accept      json
+// @Produce     json
+// @Param       uuid  path  string true  "角色的无符号 UUID（32 位十六进制字符串）"
+// @Success     200   {object}  []apiYgg.NameHistoryItem  "查询成功"
+// @Failure     204   {object}  nil                       "角色不存在"
+// @Failure     500   {object}  apiYgg.YggdrasilError     "服务器内部错误"
+// @Router      /api/user/profiles/{uuid}/names [get]
+func (h *ServerHandler) NameHistory(ctx *gin.Context) {
+	h.Log.Info(ctx, "NameHistory - 查询角色名称历史")
+
+	uuid := ctx.Param("uuid")
+	if !yggdrasil.IsValidUnsignedUUID(uuid) {
+		apiYgg.YggNoContent(ctx)
+		return
+	}
+
+	items, found, xErr := h.Service.Logic().QueryNameHistory(ctx.Request.Context(), uuid)
+	if xErr != nil {
+		apiYgg.AbortYggError(ctx, http.StatusInternalServerError, "InternalServerError", "查询角色名称历史失败")
+		return
+	}
+	if !found {
+		apiYgg.YggNoContent(ctx)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
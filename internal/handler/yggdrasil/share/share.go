@@ -8,9 +8,7 @@ package share
 
 import (
 	"net/http"
-	"strings"
 
-	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
 	apiYgg "github.com/frontleaves-mc/frontleaves-yggleaf/api/yggdrasil"
 	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
@@ -54,7 +52,7 @@ func (h *ShareHandler) APIMetadata(ctx *gin.Context) {
 			},
 			FeatureNonEmailLogin: true,
 		},
-		SkinDomains:        buildSkinDomains(),
+		SkinDomains:        yggdrasil.SkinDomains(),
 		SignaturePublickey: h.Service.Logic().GetPubKeyPEM(),
 	}
 
@@ -193,24 +191,3 @@ func (h *ShareHandler) verifyOwnership(ctx *gin.Context, gameToken *entity.GameT
 
 	return profile, true
 }
-
-// buildSkinDomains 构建 skinDomains 白名单列表。
-//
-// 基础域名来自常量配置（主域名 + 后缀通配），额外域名通过环境变量
-// YGGDRASIL_SKIN_DOMAINS_EXTRA（逗号分隔）追加。
-// 这允许 beacon-bucket 等 CDN 返回的纹理 URL 域名动态加入白名单，
-// 解决 Minecraft 游戏客户端严格校验 skinDomains 导致皮肤不显示的问题。
-func buildSkinDomains() []string {
-	domains := []string{
-		bConst.YggdrasilSkinDomainSuffix,
-		"textures.minecraft.net", // Mojang 正版纹理域名（在线档案回退使用）
-	}
-	if extra := xEnv.GetEnvString(bConst.EnvYggdrasilSkinDomainsExtra, ""); extra != "" {
-		for _, d := range strings.Split(extra, ",") {
-			if trimmed := strings.TrimSpace(d); trimmed != "" {
-				domains = append(domains, trimmed)
-			}
-		}
-	}
-	return domains
-}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
@@ -13,6 +14,7 @@ import (
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+	repocache "github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/cache"
 	repotxn "github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/txn"
 	"github.com/google/uuid"
 )
@@ -20,6 +22,9 @@ import (
 const (
 	gameProfileNameMinLength = 3
 	gameProfileNameMaxLength = 16
+
+	joinWindowMaxHours = 24 * 30 // 近期进服查询最大时间窗口（小时）
+	dailyActiveMaxDays = 90      // 每日活跃统计最大天数
 )
 
 var gameProfileNameRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
@@ -35,7 +40,18 @@ type gameProfileRepo struct {
 	quotaLog          *repository.GameProfileQuotaLogRepo    // 游戏档案配额日志仓储
 	userSkinLib       *repository.UserSkinLibraryRepo        // 用户皮肤关联仓储
 	userCapeLib       *repository.UserCapeLibraryRepo        // 用户披风关联仓储
+	skinLib           *repository.SkinLibraryRepo                // 皮肤库仓储（查询默认装备皮肤）
 	onlineProfileRepo *repository.GameOnlineProfileRepo      // 正版档案缓存仓储（Mojang 回退）
+	joinLog           *repository.GameProfileJoinLogRepo     // 进服记录仓储
+	outfit            *repository.GameProfileOutfitRepo          // 外观预设仓储
+	nameHold          *repository.GameProfileNameReservationRepo // 名称保留仓储
+	transfer          *repository.GameProfileTransferRepo        // 档案转移请求仓储
+	nameHistory       *repository.GameProfileNameHistoryRepo     // 名称历史仓储
+	nameRule          *repository.GameProfileNameRuleRepo        // 名称规则仓储（保留名称与屏蔽规则）
+	nameRuleCache     *repocache.GameProfileNameRuleCache        // 已启用名称规则缓存
+	auditLog          *repository.GameProfileAuditLogRepo        // 管理员操作审计记录仓储
+	user              *repository.UserRepo                       // 用户仓储（校验转移接收方）
+	sessionCache      *repocache.SessionCache                    // Yggdrasil 会话缓存（删除档案时清理待验证会话）
 	txn               *repotxn.GameProfileTxnRepo           // 游戏档案事务协调仓储
 }
 
@@ -77,6 +93,10 @@ func NewGameProfileLogic(ctx context.Context, libraryLogic *LibraryLogic) *GameP
 	userSkinLibRepo := repository.NewUserSkinLibraryRepo(db)
 	userCapeLibRepo := repository.NewUserCapeLibraryRepo(db)
 	onlineProfileRepo := repository.NewGameOnlineProfileRepo(db)
+	nameHoldRepo := repository.NewGameProfileNameReservationRepo(db)
+	transferRepo := repository.NewGameProfileTransferRepo(db)
+	nameHistoryRepo := repository.NewGameProfileNameHistoryRepo(db)
+	auditLogRepo := repository.NewGameProfileAuditLogRepo(db)
 
 	return &GameProfileLogic{
 		logic: logic{
@@ -89,8 +109,28 @@ func NewGameProfileLogic(ctx context.Context, libraryLogic *LibraryLogic) *GameP
 			quotaLog:          quotaLogRepo,
 			userSkinLib:       userSkinLibRepo,
 			userCapeLib:       userCapeLibRepo,
+			skinLib:           repository.NewSkinLibraryRepo(db),
 			onlineProfileRepo: onlineProfileRepo,
-			txn:               repotxn.NewGameProfileTxnRepo(db, profileRepo, quotaRepo, quotaLogRepo),
+			joinLog:           repository.NewGameProfileJoinLogRepo(db),
+			outfit:            repository.NewGameProfileOutfitRepo(db),
+			nameHold:          nameHoldRepo,
+			transfer:          transferRepo,
+			nameHistory:       nameHistoryRepo,
+			nameRule:          repository.NewGameProfileNameRuleRepo(db),
+			nameRuleCache:     &repocache.GameProfileNameRuleCache{RDB: rdb, TTL: gameProfileNameRuleCacheTTL},
+			auditLog:          auditLogRepo,
+			user:              repository.NewUserRepo(db, rdb),
+			sessionCache:      &repocache.SessionCache{RDB: rdb},
+			txn: repotxn.NewGameProfileTxnRepo(
+				db, profileRepo, quotaRepo, quotaLogRepo,
+				repository.NewGameTokenRepo(db),
+				nameHoldRepo,
+				transferRepo,
+				userSkinLibRepo,
+				userCapeLibRepo,
+				nameHistoryRepo,
+				auditLogRepo,
+			),
 		},
 		libraryLogic: libraryLogic,
 	}
@@ -99,7 +139,7 @@ func NewGameProfileLogic(ctx context.Context, libraryLogic *LibraryLogic) *GameP
 // AddGameProfile 为指定用户新增游戏档案。
 //
 // 该方法执行以下业务流程：
-//  1. 校验用户 ID 有效性与名称合法性（长度、格式）
+//  1. 校验用户 ID 有效性与名称合法性（长度、格式、保留名称与屏蔽规则）
 //  2. 生成 V7 UUID 作为档案唯一标识
 //  3. 构建游戏档案实体
 //  4. 委托 Repository 层在事务内完成：配额检查 → 唯一性校验 → 档案创建 → 配额扣减 → 日志记录
@@ -123,6 +163,9 @@ func (l *GameProfileLogic) AddGameProfile(ctx context.Context, userID xSnowflake
 	if xErr != nil {
 		return nil, xErr
 	}
+	if xErr = l.checkGameProfileNameRules(ctx, userID, normalizedName); xErr != nil {
+		return nil, xErr
+	}
 
 	profileUUID, err := uuid.NewV7()
 	if err != nil {
@@ -134,6 +177,7 @@ func (l *GameProfileLogic) AddGameProfile(ctx context.Context, userID xSnowflake
 		UUID:   profileUUID,
 		Name:   normalizedName,
 	}
+	profile.SkinLibraryID = l.resolveDefaultEquipSkin(ctx)
 
 	// 委托 Repository 层在事务内完成创建与配额操作
 	createdProfile, xErr := l.repo.txn.AddProfileWithQuota(ctx, profile)
@@ -141,7 +185,7 @@ func (l *GameProfileLogic) AddGameProfile(ctx context.Context, userID xSnowflake
 		return nil, xErr
 	}
 
-	// 新创建的档案无装备，直接构建 DTO
+	// 新创建的档案至多装备默认皮肤，直接构建 DTO
 	return &models.GameProfileDTO{
 		ID:            createdProfile.ID,
 		UserID:        createdProfile.UserID,
@@ -153,16 +197,31 @@ func (l *GameProfileLogic) AddGameProfile(ctx context.Context, userID xSnowflake
 	}, nil
 }
 
+// resolveDefaultEquipSkin 查询新游戏档案应默认装备的系统皮肤。
+//
+// 默认装备皮肤存在时返回其 ID，用户尚未持有的关联由 AddProfileWithQuota 在创建事务内补发；
+// 查询失败仅记录警告日志，档案以无装备状态创建。
+func (l *GameProfileLogic) resolveDefaultEquipSkin(ctx context.Context) *xSnowflake.SnowflakeID {
+	skin, found, xErr := l.repo.skinLib.GetDefaultEquip(ctx, nil)
+	if xErr != nil {
+		l.log.Warn(ctx, string("查询默认装备皮肤失败: "+xErr.ErrorMessage))
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	return &skin.ID
+}
+
 // ChangeUsername 修改指定游戏档案的用户名。
 //
 // 该方法执行以下业务流程：
 //  1. 校验档案归属权（档案必须属于当前用户）
 //  2. 校验并规范化新用户名
 //  3. 短路优化：若名称未变更则直接返回
-//  4. 检查新名称是否与其他档案冲突
-//  5. 更新档案名称
-//
-// 该方法为单表更新操作，无需事务包裹。
+//  4. 校验新用户名未命中保留名称与屏蔽规则
+//  5. 委托 Repository 层在事务内完成：改名冷却校验 → 唯一性与保留期校验 →
+//     名称历史记录 → 旧名称保留 → 档案名称更新
 //
 // 参数:
 //   - ctx: Gin 上下文对象，用于传递请求范围的数据与控制流程。
@@ -183,45 +242,27 @@ func (l *GameProfileLogic) ChangeUsername(ctx context.Context, userID xSnowflake
 	if !found {
 		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
 	}
+	if xErr = ensureGameProfileUnlocked(ctx, profile); xErr != nil {
+		return nil, xErr
+	}
 
 	normalizedName, xErr := validateGameProfileName(ctx, newName)
 	if xErr != nil {
 		return nil, xErr
 	}
 	if profile.Name == normalizedName {
-		return &models.GameProfileDTO{
-			ID:            profile.ID,
-			UserID:        profile.UserID,
-			UUID:          profile.UUID.String(),
-			Name:          profile.Name,
-			SkinLibraryID: profile.SkinLibraryID,
-			CapeLibraryID: profile.CapeLibraryID,
-			UpdatedAt:     profile.UpdatedAt,
-		}, nil
-	}
-
-	nameExisted, xErr := l.repo.profile.ExistsByNameExceptID(ctx, nil, normalizedName, profile.ID)
-	if xErr != nil {
-		return nil, xErr
+		return newGameProfileDTO(profile), nil
 	}
-	if nameExisted {
-		return nil, xError.NewError(ctx, xError.DataConflict, "用户名已存在", true)
+	if xErr = l.checkGameProfileNameRules(ctx, userID, normalizedName); xErr != nil {
+		return nil, xErr
 	}
 
-	updatedProfile, xErr := l.repo.profile.UpdateName(ctx, nil, profile.ID, normalizedName)
+	now := time.Now()
+	updatedProfile, xErr := l.repo.txn.RenameProfile(ctx, userID, profile.ID, normalizedName, now, gameProfileRenameCooldown(), gameProfileOldNameReserveUntil(now), nil)
 	if xErr != nil {
 		return nil, xErr
 	}
-
-	return &models.GameProfileDTO{
-		ID:            updatedProfile.ID,
-		UserID:        updatedProfile.UserID,
-		UUID:          updatedProfile.UUID.String(),
-		Name:          updatedProfile.Name,
-		SkinLibraryID: updatedProfile.SkinLibraryID,
-		CapeLibraryID: updatedProfile.CapeLibraryID,
-		UpdatedAt:     updatedProfile.UpdatedAt,
-	}, nil
+	return newGameProfileDTO(updatedProfile), nil
 }
 
 // GetGameProfileDetail 获取指定游戏档案的详情（含关联皮肤和披风）。
@@ -280,15 +321,7 @@ func (l *GameProfileLogic) ListGameProfiles(ctx context.Context, userID xSnowfla
 	responses := make([]models.GameProfileDTO, len(profiles))
 	profileIDs := make([]xSnowflake.SnowflakeID, len(profiles))
 	for i, p := range profiles {
-		responses[i] = models.GameProfileDTO{
-			ID:            p.ID,
-			UserID:        p.UserID,
-			UUID:          p.UUID.String(),
-			Name:          p.Name,
-			SkinLibraryID: p.SkinLibraryID,
-			CapeLibraryID: p.CapeLibraryID,
-			UpdatedAt:     p.UpdatedAt,
-		}
+		responses[i] = *newGameProfileDTO(&p)
 		profileIDs[i] = p.ID
 	}
 
@@ -391,27 +424,30 @@ func (l *GameProfileLogic) AdjustQuotaAdmin(ctx context.Context, operatorID xSno
 //
 // 该方法执行以下业务流程：
 //  1. 校验档案归属权（档案必须属于当前用户）
-//  2. 校验用户是否拥有该皮肤（通过 UserSkinLibrary 关联校验）
+//  2. 校验用户是否拥有该皮肤（通过 UserSkinLibrary 关联校验，收藏类型要求皮肤仍公开）
 //  3. 更新档案的 SkinLibraryID
 func (l *GameProfileLogic) EquipSkin(ctx context.Context, userID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, skinLibraryID xSnowflake.SnowflakeID) (*models.GameProfileDTO, *xError.Error) {
 	l.log.Info(ctx, "EquipSkin - 装备皮肤")
 
 	// 1. 校验档案归属权
-	_, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
+	profile, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
 	if xErr != nil {
 		return nil, xErr
 	}
 	if !found {
 		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
 	}
+	if xErr = ensureGameProfileUnlocked(ctx, profile); xErr != nil {
+		return nil, xErr
+	}
 
 	// 2. 校验用户是否拥有该皮肤
-	hasSkin, xErr := l.repo.userSkinLib.ExistsByUserAndSkin(ctx, nil, userID, skinLibraryID)
+	hasSkin, xErr := l.repo.userSkinLib.ExistsEquippableByUserAndSkin(ctx, nil, userID, skinLibraryID)
 	if xErr != nil {
 		return nil, xErr
 	}
 	if !hasSkin {
-		return nil, xError.NewError(ctx, xError.PermissionDenied, "您未拥有该皮肤，无法装备", true)
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "您未拥有该皮肤或收藏的皮肤已不再公开，无法装备", true)
 	}
 
 	// 3. 更新档案的 SkinLibraryID
@@ -421,7 +457,7 @@ func (l *GameProfileLogic) EquipSkin(ctx context.Context, userID xSnowflake.Snow
 	}
 
 	// 4. 重新获取完整档案（含 Preload 的关联数据）用于构建 DTO
-	profile, found, xErr := l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
+	profile, found, xErr = l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
 	if xErr != nil {
 		return nil, xErr
 	}
@@ -438,21 +474,24 @@ func (l *GameProfileLogic) EquipCape(ctx context.Context, userID xSnowflake.Snow
 	l.log.Info(ctx, "EquipCape - 装备披风")
 
 	// 1. 校验档案归属权
-	_, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
+	profile, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
 	if xErr != nil {
 		return nil, xErr
 	}
 	if !found {
 		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
 	}
+	if xErr = ensureGameProfileUnlocked(ctx, profile); xErr != nil {
+		return nil, xErr
+	}
 
 	// 2. 校验用户是否拥有该披风
-	hasCape, xErr := l.repo.userCapeLib.ExistsByUserAndCape(ctx, nil, userID, capeLibraryID)
+	hasCape, xErr := l.repo.userCapeLib.ExistsEquippableByUserAndCape(ctx, nil, userID, capeLibraryID)
 	if xErr != nil {
 		return nil, xErr
 	}
 	if !hasCape {
-		return nil, xError.NewError(ctx, xError.PermissionDenied, "您未拥有该披风，无法装备", true)
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "您未拥有该披风或收藏的披风已不再公开，无法装备", true)
 	}
 
 	// 3. 更新档案的 CapeLibraryID
@@ -462,7 +501,7 @@ func (l *GameProfileLogic) EquipCape(ctx context.Context, userID xSnowflake.Snow
 	}
 
 	// 4. 重新获取完整档案（含 Preload 的关联数据）用于构建 DTO
-	profile, found, xErr := l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
+	profile, found, xErr = l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
 	if xErr != nil {
 		return nil, xErr
 	}
@@ -476,13 +515,16 @@ func (l *GameProfileLogic) EquipCape(ctx context.Context, userID xSnowflake.Snow
 func (l *GameProfileLogic) UnequipSkin(ctx context.Context, userID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID) (*models.GameProfileDTO, *xError.Error) {
 	l.log.Info(ctx, "UnequipSkin - 卸下皮肤")
 
-	_, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
+	profile, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
 	if xErr != nil {
 		return nil, xErr
 	}
 	if !found {
 		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
 	}
+	if xErr = ensureGameProfileUnlocked(ctx, profile); xErr != nil {
+		return nil, xErr
+	}
 
 	// 更新档案的 SkinLibraryID（卸下设为 nil）
 	_, xErr = l.repo.profile.UpdateSkinLibraryID(ctx, nil, profileID, nil)
@@ -491,7 +533,7 @@ func (l *GameProfileLogic) UnequipSkin(ctx context.Context, userID xSnowflake.Sn
 	}
 
 	// 重新获取完整档案（含 Preload 的关联数据）用于构建 DTO
-	profile, found, xErr := l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
+	profile, found, xErr = l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
 	if xErr != nil {
 		return nil, xErr
 	}
@@ -505,13 +547,16 @@ func (l *GameProfileLogic) UnequipSkin(ctx context.Context, userID xSnowflake.Sn
 func (l *GameProfileLogic) UnequipCape(ctx context.Context, userID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID) (*models.GameProfileDTO, *xError.Error) {
 	l.log.Info(ctx, "UnequipCape - 卸下披风")
 
-	_, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
+	profile, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
 	if xErr != nil {
 		return nil, xErr
 	}
 	if !found {
 		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
 	}
+	if xErr = ensureGameProfileUnlocked(ctx, profile); xErr != nil {
+		return nil, xErr
+	}
 
 	_, xErr = l.repo.profile.UpdateCapeLibraryID(ctx, nil, profileID, nil)
 	if xErr != nil {
@@ -519,7 +564,7 @@ func (l *GameProfileLogic) UnequipCape(ctx context.Context, userID xSnowflake.Sn
 	}
 
 	// 重新获取完整档案（含 Preload 的关联数据）用于构建 DTO
-	profile, found, xErr := l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
+	profile, found, xErr = l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
 	if xErr != nil {
 		return nil, xErr
 	}
@@ -553,21 +598,152 @@ func (l *GameProfileLogic) SetCape(ctx context.Context, userID xSnowflake.Snowfl
 	return l.EquipCape(ctx, userID, profileID, *capeLibraryID)
 }
 
+// ListRecentJoins 分页获取指定游戏档案的最近进服记录（玩家视角）。
+//
+// 校验档案归属权后，按进服时间倒序返回该档案的进服历史。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - userID: 操作者的雪花 ID。
+//   - profileID: 目标游戏档案的雪花 ID。
+//   - page: 页码。
+//   - pageSize: 每页数量。
+//
+// 返回值:
+//   - []models.GameProfileJoinDTO: 进服记录列表。
+//   - int64: 总记录数。
+//   - *xError.Error: 业务校验失败或数据操作过程中发生的错误。
+func (l *GameProfileLogic) ListRecentJoins(ctx context.Context, userID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, page int, pageSize int) ([]models.GameProfileJoinDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListRecentJoins - 获取游戏档案最近进服记录")
+
+	_, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	if !found {
+		return nil, 0, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+
+	logs, total, xErr := l.repo.joinLog.ListByProfileID(ctx, nil, profileID, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	return buildJoinDTOs(logs), total, nil
+}
+
+// ListJoinsWithinHours 分页获取最近 N 小时内的全部进服记录（管理员视角）。
+//
+// 同时返回时间窗口内去重后的活跃档案数。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - hours: 时间窗口（小时），取值 1 至 joinWindowMaxHours。
+//   - page: 页码。
+//   - pageSize: 每页数量。
+//
+// 返回值:
+//   - []models.GameProfileJoinDTO: 进服记录列表。
+//   - int64: 总记录数。
+//   - int64: 时间窗口内去重活跃档案数。
+//   - *xError.Error: 业务校验失败或数据操作过程中发生的错误。
+func (l *GameProfileLogic) ListJoinsWithinHours(ctx context.Context, hours int, page int, pageSize int) ([]models.GameProfileJoinDTO, int64, int64, *xError.Error) {
+	l.log.Info(ctx, "ListJoinsWithinHours - 获取近期进服记录")
+
+	if hours < 1 || hours > joinWindowMaxHours {
+		return nil, 0, 0, xError.NewError(ctx, xError.ParameterError, fmt.Sprintf("无效时间窗口：hours 需在 1-%d 之间", joinWindowMaxHours), true)
+	}
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+
+	logs, total, xErr := l.repo.joinLog.ListSince(ctx, nil, since, page, pageSize)
+	if xErr != nil {
+		return nil, 0, 0, xErr
+	}
+	activeProfiles, xErr := l.repo.joinLog.CountDistinctProfilesSince(ctx, nil, since)
+	if xErr != nil {
+		return nil, 0, 0, xErr
+	}
+	return buildJoinDTOs(logs), total, activeProfiles, nil
+}
+
+// GetDailyActive 获取最近 N 天的每日活跃玩家统计（管理员视角）。
+//
+// 统计区间从 N-1 天前的 00:00 开始（含今天），按日期倒序返回；无进服记录的日期不返回。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - days: 统计天数，取值 1 至 dailyActiveMaxDays。
+//
+// 返回值:
+//   - []models.DailyActiveDTO: 每日活跃统计列表。
+//   - *xError.Error: 业务校验失败或数据操作过程中发生的错误。
+func (l *GameProfileLogic) GetDailyActive(ctx context.Context, days int) ([]models.DailyActiveDTO, *xError.Error) {
+	l.log.Info(ctx, "GetDailyActive - 获取每日活跃玩家统计")
+
+	if days < 1 || days > dailyActiveMaxDays {
+		return nil, xError.NewError(ctx, xError.ParameterError, fmt.Sprintf("无效统计天数：days 需在 1-%d 之间", dailyActiveMaxDays), true)
+	}
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -(days - 1))
+
+	rows, xErr := l.repo.joinLog.AggregateDailyActive(ctx, nil, since)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	dtos := make([]models.DailyActiveDTO, len(rows))
+	for i, row := range rows {
+		dtos[i] = models.DailyActiveDTO{
+			Day:            row.Day.Format(time.DateOnly),
+			ActiveProfiles: row.ActiveProfiles,
+			ActiveUsers:    row.ActiveUsers,
+			JoinCount:      row.JoinCount,
+		}
+	}
+	return dtos, nil
+}
+
+// buildJoinDTOs 将进服记录实体列表转换为 GameProfileJoinDTO 列表。
+func buildJoinDTOs(logs []entity.GameProfileJoinLog) []models.GameProfileJoinDTO {
+	dtos := make([]models.GameProfileJoinDTO, len(logs))
+	for i, joinLog := range logs {
+		dtos[i] = models.GameProfileJoinDTO{
+			ID:            joinLog.ID,
+			GameProfileID: joinLog.GameProfileID,
+			UserID:        joinLog.UserID,
+			ProfileName:   joinLog.ProfileName,
+			ServerAddress: joinLog.ServerAddress,
+			ClientIP:      joinLog.ClientIP,
+			JoinedAt:      joinLog.JoinedAt,
+		}
+	}
+	return dtos
+}
+
+// newGameProfileDTO 将 GameProfile 实体的基础字段转换为 GameProfileDTO（不含纹理信息）。
+func newGameProfileDTO(profile *entity.GameProfile) *models.GameProfileDTO {
+	return &models.GameProfileDTO{
+		ID:                    profile.ID,
+		UserID:                profile.UserID,
+		UUID:                  profile.UUID.String(),
+		Name:                  profile.Name,
+		SkinLibraryID:         profile.SkinLibraryID,
+		CapeLibraryID:         profile.CapeLibraryID,
+		UpdatedAt:             profile.UpdatedAt,
+		LastSeenAt:            profile.LastSeenAt,
+		NameChangedAt:         profile.NameChangedAt,
+		NameChangeAvailableAt: gameProfileNameChangeAvailableAt(profile, time.Now()),
+		LockedAt:              profile.LockedAt,
+		LockReason:            profile.LockReason,
+	}
+}
+
 // buildProfileDTO 将 GameProfile 实体转换为 GameProfileDTO。
 //
 // 若 profile 关联了 SkinLibrary 或 CapeLibrary（GORM Preload），则调用
 // LibraryLogic 的构建方法解析纹理链接。
 // 若本平台未设置皮肤/披风，尝试从正版档案缓存（Mojang 回退）填充。
 func (l *GameProfileLogic) buildProfileDTO(ctx context.Context, profile *entity.GameProfile) (*models.GameProfileDTO, *xError.Error) {
-	resp := &models.GameProfileDTO{
-		ID:            profile.ID,
-		UserID:        profile.UserID,
-		UUID:          profile.UUID.String(),
-		Name:          profile.Name,
-		SkinLibraryID: profile.SkinLibraryID,
-		CapeLibraryID: profile.CapeLibraryID,
-		UpdatedAt:     profile.UpdatedAt,
-	}
+	resp := newGameProfileDTO(profile)
 
 	if profile.SkinLibrary != nil {
 		skinResp, xErr := l.libraryLogic.buildSkinDTO(ctx, profile.SkinLibrary)
@@ -0,0 +1,211 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/logic/yggdrasil"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+// AdminRenameGameProfile 管理员强制修改任意用户游戏档案的用户名（如处理违规名称）。
+//
+// 复用玩家改名的事务路径：名称唯一性与他人保留期照常校验，改名冷却与保留名称规则不作限制；
+// 旧名称不为持有者保留，立即释放。名称历史来源记为管理员改名，并在同一事务内写入审计记录。
+// 改名后持有者进入正常的改名冷却，如需立即自助改名可再重置冷却。
+func (l *GameProfileLogic) AdminRenameGameProfile(ctx context.Context, operatorID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, newName string, reason string) (*models.GameProfileDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminRenameGameProfile - 管理员强制修改游戏档案用户名")
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "操作原因不能为空", true)
+	}
+	normalizedName, xErr := validateGameProfileName(ctx, newName)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	profile, xErr := l.getAdminTargetProfile(ctx, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if profile.Name == normalizedName {
+		return nil, xError.NewError(ctx, xError.ParameterError, "新用户名与当前用户名相同", true)
+	}
+
+	_, xErr = l.repo.txn.RenameProfile(ctx, profile.UserID, profile.ID, normalizedName, time.Now(), 0, nil, &entity.GameProfileAuditLog{
+		OperatorID: operatorID,
+		Action:     entity.GameProfileAuditActionRename,
+		Reason:     reason,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+	l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)将游戏档案(ID=%s)用户名由 %s 修改为 %s", operatorID.String(), profile.ID.String(), profile.Name, normalizedName))
+
+	return l.getProfileDetailDTO(ctx, profile.ID, profile.UserID)
+}
+
+// AdminClearProfileSkin 管理员清除任意用户游戏档案装备的皮肤，皮肤本身仍保留在持有者的皮肤库中。
+func (l *GameProfileLogic) AdminClearProfileSkin(ctx context.Context, operatorID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, reason string) (*models.GameProfileDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminClearProfileSkin - 管理员清除游戏档案皮肤")
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "操作原因不能为空", true)
+	}
+
+	profile, xErr := l.getAdminTargetProfile(ctx, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	_, xErr = l.repo.txn.ClearProfileSkinWithAudit(ctx, profile.UserID, profile.ID, &entity.GameProfileAuditLog{
+		OperatorID: operatorID,
+		Action:     entity.GameProfileAuditActionClearSkin,
+		Reason:     reason,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+	l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)清除游戏档案(ID=%s)的皮肤", operatorID.String(), profile.ID.String()))
+
+	return l.getProfileDetailDTO(ctx, profile.ID, profile.UserID)
+}
+
+// AdminLockGameProfile 管理员锁定任意用户的游戏档案。
+//
+// 锁定后档案无法通过 hasJoined 进入服务器，持有者也无法改名、更换外观、删除或转移该档案；
+// 已写入的待验证会话在提交后一并清理。
+func (l *GameProfileLogic) AdminLockGameProfile(ctx context.Context, operatorID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, reason string) (*models.GameProfileDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminLockGameProfile - 管理员锁定游戏档案")
+
+	now := time.Now()
+	return l.updateProfileLock(ctx, operatorID, profileID, &now, reason)
+}
+
+// AdminUnlockGameProfile 管理员解除游戏档案的锁定。
+func (l *GameProfileLogic) AdminUnlockGameProfile(ctx context.Context, operatorID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, reason string) (*models.GameProfileDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminUnlockGameProfile - 管理员解锁游戏档案")
+
+	return l.updateProfileLock(ctx, operatorID, profileID, nil, reason)
+}
+
+// AdminListAuditLogs 管理员分页查询游戏档案审计记录，可按档案或持有者过滤（档案删除后记录仍可查询）。
+func (l *GameProfileLogic) AdminListAuditLogs(ctx context.Context, profileID *xSnowflake.SnowflakeID, userID *xSnowflake.SnowflakeID, page int, pageSize int) ([]models.GameProfileAuditLogDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "AdminListAuditLogs - 管理员查询游戏档案审计记录")
+
+	auditLogs, total, xErr := l.repo.auditLog.List(ctx, nil, profileID, userID, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	items := make([]models.GameProfileAuditLogDTO, 0, len(auditLogs))
+	for i := range auditLogs {
+		items = append(items, buildGameProfileAuditLogDTO(&auditLogs[i]))
+	}
+	return items, total, nil
+}
+
+// updateProfileLock 委托事务仓储更新锁定状态并写入审计记录，lockedAt 为 nil 表示解锁。
+func (l *GameProfileLogic) updateProfileLock(ctx context.Context, operatorID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, lockedAt *time.Time, reason string) (*models.GameProfileDTO, *xError.Error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "操作原因不能为空", true)
+	}
+
+	profile, xErr := l.getAdminTargetProfile(ctx, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	action := entity.GameProfileAuditActionUnlock
+	if lockedAt != nil {
+		action = entity.GameProfileAuditActionLock
+	}
+	_, xErr = l.repo.txn.UpdateProfileLockWithAudit(ctx, profile.UserID, profile.ID, lockedAt, &entity.GameProfileAuditLog{
+		OperatorID: operatorID,
+		Action:     action,
+		Reason:     reason,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	if lockedAt != nil {
+		l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)锁定游戏档案(ID=%s)", operatorID.String(), profile.ID.String()))
+		if err := l.repo.sessionCache.DeleteByProfile(ctx, yggdrasil.EncodeUnsignedUUID(profile.UUID)); err != nil {
+			l.log.Warn(ctx, fmt.Sprintf("清理游戏档案会话缓存失败（hasJoined 仍会拒绝锁定档案）: %v", err))
+		}
+	} else {
+		l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)解锁游戏档案(ID=%s)", operatorID.String(), profile.ID.String()))
+	}
+
+	return l.getProfileDetailDTO(ctx, profile.ID, profile.UserID)
+}
+
+// getAdminTargetProfile 管理员操作时按 ID 查询档案（不校验归属）。
+func (l *GameProfileLogic) getAdminTargetProfile(ctx context.Context, profileID xSnowflake.SnowflakeID) (*entity.GameProfile, *xError.Error) {
+	profile, found, xErr := l.repo.profile.GetByID(ctx, nil, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	return profile, nil
+}
+
+// getProfileDetailDTO 重新获取完整档案（含 Preload 的关联数据）并构建 DTO。
+func (l *GameProfileLogic) getProfileDetailDTO(ctx context.Context, profileID xSnowflake.SnowflakeID, userID xSnowflake.SnowflakeID) (*models.GameProfileDTO, *xError.Error) {
+	detail, found, xErr := l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	return l.buildProfileDTO(ctx, detail)
+}
+
+// ensureGameProfileUnlocked 校验档案未被管理员锁定，供持有者修改档案前调用。
+func ensureGameProfileUnlocked(ctx context.Context, profile *entity.GameProfile) *xError.Error {
+	if profile.IsLocked() {
+		return xError.NewError(ctx, xError.OperationDenied, "游戏档案已被管理员锁定，暂不可操作", true)
+	}
+	return nil
+}
+
+// buildGameProfileAuditLogDTO 将审计记录实体转换为 DTO。
+func buildGameProfileAuditLogDTO(auditLog *entity.GameProfileAuditLog) models.GameProfileAuditLogDTO {
+	var action string
+	switch auditLog.Action {
+	case entity.GameProfileAuditActionRename:
+		action = "rename"
+	case entity.GameProfileAuditActionClearSkin:
+		action = "clear_skin"
+	case entity.GameProfileAuditActionLock:
+		action = "lock"
+	case entity.GameProfileAuditActionUnlock:
+		action = "unlock"
+	case entity.GameProfileAuditActionDelete:
+		action = "delete"
+	}
+	return models.GameProfileAuditLogDTO{
+		ID:          auditLog.ID,
+		ProfileID:   auditLog.ProfileID,
+		ProfileUUID: auditLog.ProfileUUID,
+		ProfileName: auditLog.ProfileName,
+		UserID:      auditLog.UserID,
+		OperatorID:  auditLog.OperatorID,
+		Action:      action,
+		Reason:      auditLog.Reason,
+		Detail:      auditLog.Detail,
+		CreatedAt:   auditLog.CreatedAt,
+	}
+}
@@ -0,0 +1,100 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/logic/yggdrasil"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+// DeleteGameProfile 玩家删除自己的游戏档案并退还档案配额。
+//
+// confirmName 必须与档案当前用户名完全一致，防止误删。
+// 若配置了 GAME_PROFILE_NAME_RELEASE_HOURS，删除后名称在该时长内仅保留给当前用户，
+// 期间其他用户无法创建或改名为该名称；未配置或为 0 时名称立即释放。
+func (l *GameProfileLogic) DeleteGameProfile(ctx context.Context, userID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, confirmName string) (*models.GameProfileDeletionDTO, *xError.Error) {
+	l.log.Info(ctx, "DeleteGameProfile - 删除游戏档案")
+
+	profile, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	if xErr = ensureGameProfileUnlocked(ctx, profile); xErr != nil {
+		return nil, xErr
+	}
+	if strings.TrimSpace(confirmName) != profile.Name {
+		return nil, xError.NewError(ctx, xError.ParameterError, "确认名称与游戏档案用户名不一致", true)
+	}
+
+	var reserveUntil *time.Time
+	if hours := xEnv.GetEnvInt(bConst.EnvGameProfileNameReleaseHours, 0); hours > 0 {
+		until := time.Now().Add(time.Duration(hours) * time.Hour)
+		reserveUntil = &until
+	}
+
+	return l.deleteProfile(ctx, profile, "玩家删除游戏档案", reserveUntil, nil)
+}
+
+// AdminDeleteGameProfile 管理员删除任意用户的游戏档案并退还其档案配额。
+//
+// 管理员删除通常用于处理违规名称，因此不为原持有者保留名称，名称立即释放。
+// 删除原因与操作者写入配额日志备注，并在同一事务内写入审计记录。锁定的档案同样可以删除。
+func (l *GameProfileLogic) AdminDeleteGameProfile(ctx context.Context, operatorID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, reason string) (*models.GameProfileDeletionDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminDeleteGameProfile - 管理员删除游戏档案")
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "删除原因不能为空", true)
+	}
+
+	profile, found, xErr := l.repo.profile.GetByID(ctx, nil, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+
+	remark := fmt.Sprintf("管理员 %s 删除游戏档案：%s", operatorID.String(), reason)
+	return l.deleteProfile(ctx, profile, remark, nil, &entity.GameProfileAuditLog{
+		OperatorID: operatorID,
+		Action:     entity.GameProfileAuditActionDelete,
+		Reason:     reason,
+	})
+}
+
+// deleteProfile 委托事务仓储删除档案，并在提交后清理该档案的待验证会话。
+// audit 非空时（管理员删除）在同一事务内写入审计记录。
+//
+// 会话存放于 Redis，无法与数据库事务一同提交；清理失败仅记录警告日志——
+// 会话 TTL 很短，且档案删除后 hasJoined 按 UUID 已无法查到档案。
+func (l *GameProfileLogic) deleteProfile(ctx context.Context, profile *entity.GameProfile, remark string, reserveUntil *time.Time, audit *entity.GameProfileAuditLog) (*models.GameProfileDeletionDTO, *xError.Error) {
+	deleted, revokedTokens, xErr := l.repo.txn.DeleteProfileWithRefund(ctx, profile.UserID, profile.ID, &remark, reserveUntil, audit)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	if err := l.repo.sessionCache.DeleteByProfile(ctx, yggdrasil.EncodeUnsignedUUID(deleted.UUID)); err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("清理游戏档案会话缓存失败（TTL 兜底仍生效）: %v", err))
+	}
+
+	return &models.GameProfileDeletionDTO{
+		ProfileID:         deleted.ID,
+		UserID:            deleted.UserID,
+		UUID:              deleted.UUID.String(),
+		Name:              deleted.Name,
+		RevokedTokens:     revokedTokens,
+		NameReservedUntil: reserveUntil,
+	}, nil
+}
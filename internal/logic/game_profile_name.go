@@ -0,0 +1,224 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+const (
+	defaultGameProfileRenameCooldownDays = 30 // 默认改名冷却天数
+	defaultGameProfileOldNameReserveDays = 37 // 默认旧名称保留天数（与 Mojang 一致）
+)
+
+// AdminListNameHistory 管理员查询游戏档案的名称历史（按生效时间升序）。
+//
+// 尚未改过名的历史档案没有历史记录，此时以当前名称与档案创建时间补齐一条初始记录。
+func (l *GameProfileLogic) AdminListNameHistory(ctx context.Context, profileID xSnowflake.SnowflakeID) ([]models.GameProfileNameHistoryDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminListNameHistory - 管理员查询游戏档案名称历史")
+
+	profile, found, xErr := l.repo.profile.GetByID(ctx, nil, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+
+	histories, xErr := l.repo.nameHistory.ListByProfileID(ctx, nil, profile.ID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if len(histories) == 0 {
+		histories = []entity.GameProfileNameHistory{{
+			ProfileID: profile.ID,
+			UserID:    profile.UserID,
+			Name:      profile.Name,
+			Source:    entity.GameProfileNameSourceCreate,
+			ChangedAt: profile.CreatedAt,
+		}}
+	}
+
+	items := make([]models.GameProfileNameHistoryDTO, 0, len(histories))
+	for i := range histories {
+		items = append(items, buildGameProfileNameHistoryDTO(&histories[i]))
+	}
+	return items, nil
+}
+
+// AdminResetRenameCooldown 管理员重置游戏档案的改名冷却，使持有者可立即再次改名。
+func (l *GameProfileLogic) AdminResetRenameCooldown(ctx context.Context, operatorID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID) (*models.GameProfileDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminResetRenameCooldown - 管理员重置游戏档案改名冷却")
+
+	profile, found, xErr := l.repo.profile.GetByID(ctx, nil, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+
+	if xErr = l.repo.profile.ClearNameChangedAt(ctx, nil, profile.ID); xErr != nil {
+		return nil, xErr
+	}
+	l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)重置游戏档案(ID=%s)改名冷却", operatorID.String(), profile.ID.String()))
+
+	detail, found, xErr := l.repo.profile.GetDetailByID(ctx, nil, profile.ID, profile.UserID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	return l.buildProfileDTO(ctx, detail)
+}
+
+// AdminReleaseNameReservation 管理员立即释放名称的全部有效保留记录（含删除档案与改名产生的保留）。
+//
+// 返回被释放的保留记录数，名称未处于保留期时返回 0。
+func (l *GameProfileLogic) AdminReleaseNameReservation(ctx context.Context, operatorID xSnowflake.SnowflakeID, name string) (int64, *xError.Error) {
+	l.log.Info(ctx, "AdminReleaseNameReservation - 管理员释放名称保留")
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, xError.NewError(ctx, xError.ParameterError, "名称不能为空", true)
+	}
+	released, xErr := l.repo.nameHold.ReleaseActiveByName(ctx, nil, name, time.Now())
+	if xErr != nil {
+		return 0, xErr
+	}
+	l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)释放名称 %s 的保留记录 %d 条", operatorID.String(), name, released))
+	return released, nil
+}
+
+// gameProfileRenameCooldown 读取两次改名之间的冷却时长，配置为 0 或负数时不限制。
+func gameProfileRenameCooldown() time.Duration {
+	days := xEnv.GetEnvInt(bConst.EnvGameProfileRenameCooldownDays, defaultGameProfileRenameCooldownDays)
+	if days <= 0 {
+		return 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// gameProfileOldNameReserveUntil 计算改名后旧名称的保留到期时间，配置为 0 或负数时返回 nil（立即释放）。
+func gameProfileOldNameReserveUntil(now time.Time) *time.Time {
+	days := xEnv.GetEnvInt(bConst.EnvGameProfileOldNameReserveDays, defaultGameProfileOldNameReserveDays)
+	if days <= 0 {
+		return nil
+	}
+	until := now.AddDate(0, 0, days)
+	return &until
+}
+
+// gameProfileNameChangeAvailableAt 计算档案改名冷却的结束时间，当前已可改名时返回 nil。
+func gameProfileNameChangeAvailableAt(profile *entity.GameProfile, now time.Time) *time.Time {
+	cooldown := gameProfileRenameCooldown()
+	if cooldown <= 0 || profile.NameChangedAt == nil {
+		return nil
+	}
+	availableAt := profile.NameChangedAt.Add(cooldown)
+	if !now.Before(availableAt) {
+		return nil
+	}
+	return &availableAt
+}
+
+// buildGameProfileNameHistoryDTO 将名称历史实体转换为 DTO。
+func buildGameProfileNameHistoryDTO(history *entity.GameProfileNameHistory) models.GameProfileNameHistoryDTO {
+	source := "create"
+	switch history.Source {
+	case entity.GameProfileNameSourceRename:
+		source = "rename"
+	case entity.GameProfileNameSourceAdmin:
+		source = "admin"
+	}
+	return models.GameProfileNameHistoryDTO{
+		Name:       history.Name,
+		Source:     source,
+		UserID:     history.UserID,
+		OperatorID: history.OperatorID,
+		ChangedAt:  history.ChangedAt,
+	}
+}
+
+// AdminGetNameConflictReport 管理员查看仅大小写不同的重复用户名报告。
+//
+// 报告实时计算，并标明不区分大小写唯一索引是否已建立；索引建立后不会再产生新的冲突。
+func (l *GameProfileLogic) AdminGetNameConflictReport(ctx context.Context) (*models.GameProfileNameConflictReportDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminGetNameConflictReport - 管理员查看用户名大小写冲突报告")
+
+	profiles, xErr := l.repo.profile.ListNameCaseConflicts(ctx, nil)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	return &models.GameProfileNameConflictReportDTO{
+		Enforced: l.repo.profile.HasNameLowerUniqueIndex(ctx),
+		Groups:   GroupGameProfileNameConflicts(profiles),
+	}, nil
+}
+
+// GroupGameProfileNameConflicts 按小写名称将档案分组为冲突报告，分组按归一化名称升序排列。
+//
+// 组内保持输入顺序（仓储已按创建时间升序返回）；不依赖输入按名称排序，仅含一个档案的分组不视为冲突。
+// 管理接口的冲突报告与启动阶段的冲突日志共用该分组逻辑。
+func GroupGameProfileNameConflicts(profiles []entity.GameProfile) []models.GameProfileNameConflictGroupDTO {
+	indexByKey := make(map[string]int, len(profiles))
+	groups := make([]models.GameProfileNameConflictGroupDTO, 0)
+	for i := range profiles {
+		key := strings.ToLower(profiles[i].Name)
+		idx, ok := indexByKey[key]
+		if !ok {
+			idx = len(groups)
+			indexByKey[key] = idx
+			groups = append(groups, models.GameProfileNameConflictGroupDTO{Key: key})
+		}
+		groups[idx].Profiles = append(groups[idx].Profiles, *newGameProfileDTO(&profiles[i]))
+	}
+
+	conflicts := make([]models.GameProfileNameConflictGroupDTO, 0, len(groups))
+	for _, group := range groups {
+		if len(group.Profiles) > 1 {
+			conflicts = append(conflicts, group)
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Key < conflicts[j].Key
+	})
+	return conflicts
+}
+
+// AdminEnforceNameCaseUniqueness 管理员在冲突处理完成后建立用户名不区分大小写唯一索引。
+//
+// 仍存在冲突时返回 DataConflict，已建立时直接返回当前报告。
+func (l *GameProfileLogic) AdminEnforceNameCaseUniqueness(ctx context.Context, operatorID xSnowflake.SnowflakeID) (*models.GameProfileNameConflictReportDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminEnforceNameCaseUniqueness - 管理员启用用户名不区分大小写唯一约束")
+
+	report, xErr := l.AdminGetNameConflictReport(ctx)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if report.Enforced {
+		return report, nil
+	}
+	if len(report.Groups) > 0 {
+		return nil, xError.NewError(ctx, xError.DataConflict, fmt.Sprintf("仍有 %d 组仅大小写不同的重复用户名，请处理后再启用", len(report.Groups)), true)
+	}
+
+	if xErr = l.repo.profile.CreateNameLowerUniqueIndex(ctx); xErr != nil {
+		return nil, xErr
+	}
+	l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)建立游戏档案用户名不区分大小写唯一索引", operatorID.String()))
+
+	report.Enforced = true
+	return report, nil
+}
@@ -0,0 +1,343 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+const (
+	gameProfileNameRuleCacheTTL      = 10 * time.Minute // 已启用名称规则缓存时长
+	gameProfileNameRulePatternMaxLen = 128              // 名称规则匹配内容最大长度
+)
+
+// AdminListNameRules 管理员分页查询名称规则，kind 为空时返回全部类别。
+func (l *GameProfileLogic) AdminListNameRules(ctx context.Context, kind string, page int, pageSize int) ([]models.GameProfileNameRuleDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "AdminListNameRules - 管理员查询游戏档案名称规则")
+
+	var kindFilter *entity.GameProfileNameRuleKind
+	if kind != "" {
+		parsed, ok := parseGameProfileNameRuleKind(kind)
+		if !ok {
+			return nil, 0, xError.NewError(ctx, xError.ParameterError, "无效的规则类别", true)
+		}
+		kindFilter = &parsed
+	}
+
+	rules, total, xErr := l.repo.nameRule.List(ctx, nil, kindFilter, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	items := make([]models.GameProfileNameRuleDTO, 0, len(rules))
+	for i := range rules {
+		items = append(items, buildGameProfileNameRuleDTO(&rules[i]))
+	}
+	return items, total, nil
+}
+
+// AdminCreateNameRule 管理员创建名称规则。
+func (l *GameProfileLogic) AdminCreateNameRule(ctx context.Context, operatorID xSnowflake.SnowflakeID, input models.GameProfileNameRuleInput) (*models.GameProfileNameRuleDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminCreateNameRule - 管理员创建游戏档案名称规则")
+
+	rule := &entity.GameProfileNameRule{}
+	if xErr := l.applyNameRuleInput(ctx, rule, input); xErr != nil {
+		return nil, xErr
+	}
+
+	created, xErr := l.repo.nameRule.Create(ctx, nil, rule)
+	if xErr != nil {
+		return nil, xErr
+	}
+	l.invalidateNameRuleCache(ctx)
+	l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)创建名称规则(ID=%s)", operatorID.String(), created.ID.String()))
+
+	dto := buildGameProfileNameRuleDTO(created)
+	return &dto, nil
+}
+
+// AdminUpdateNameRule 管理员更新名称规则（整体替换）。
+func (l *GameProfileLogic) AdminUpdateNameRule(ctx context.Context, operatorID xSnowflake.SnowflakeID, ruleID xSnowflake.SnowflakeID, input models.GameProfileNameRuleInput) (*models.GameProfileNameRuleDTO, *xError.Error) {
+	l.log.Info(ctx, "AdminUpdateNameRule - 管理员更新游戏档案名称规则")
+
+	rule, found, xErr := l.repo.nameRule.GetByID(ctx, nil, ruleID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "名称规则不存在", true)
+	}
+	if xErr = l.applyNameRuleInput(ctx, rule, input); xErr != nil {
+		return nil, xErr
+	}
+
+	updated, xErr := l.repo.nameRule.Update(ctx, nil, rule)
+	if xErr != nil {
+		return nil, xErr
+	}
+	l.invalidateNameRuleCache(ctx)
+	l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)更新名称规则(ID=%s)", operatorID.String(), updated.ID.String()))
+
+	dto := buildGameProfileNameRuleDTO(updated)
+	return &dto, nil
+}
+
+// AdminDeleteNameRule 管理员删除名称规则。
+func (l *GameProfileLogic) AdminDeleteNameRule(ctx context.Context, operatorID xSnowflake.SnowflakeID, ruleID xSnowflake.SnowflakeID) *xError.Error {
+	l.log.Info(ctx, "AdminDeleteNameRule - 管理员删除游戏档案名称规则")
+
+	_, found, xErr := l.repo.nameRule.GetByID(ctx, nil, ruleID)
+	if xErr != nil {
+		return xErr
+	}
+	if !found {
+		return xError.NewError(ctx, xError.ResourceNotFound, "名称规则不存在", true)
+	}
+
+	if xErr = l.repo.nameRule.DeleteByID(ctx, nil, ruleID); xErr != nil {
+		return xErr
+	}
+	l.invalidateNameRuleCache(ctx)
+	l.log.Info(ctx, fmt.Sprintf("管理员(ID=%s)删除名称规则(ID=%s)", operatorID.String(), ruleID.String()))
+	return nil
+}
+
+// CheckNameAvailability 检查名称对当前用户是否可用。
+//
+// 依次校验格式、屏蔽与保留规则、是否已被占用、是否处于他人的保留期，
+// 命中任一项即返回不可用及原因。该接口仅做预检，最终以创建或改名时的事务内校验为准。
+func (l *GameProfileLogic) CheckNameAvailability(ctx context.Context, userID xSnowflake.SnowflakeID, name string) (*models.GameProfileNameAvailabilityDTO, *xError.Error) {
+	l.log.Info(ctx, "CheckNameAvailability - 检查游戏档案名称可用性")
+
+	normalizedName := strings.TrimSpace(name)
+	result := &models.GameProfileNameAvailabilityDTO{Name: normalizedName}
+	unavailable := func(reason string, message string) (*models.GameProfileNameAvailabilityDTO, *xError.Error) {
+		result.Reason = reason
+		result.Message = message
+		return result, nil
+	}
+
+	if len(normalizedName) < gameProfileNameMinLength || len(normalizedName) > gameProfileNameMaxLength {
+		return unavailable("invalid", "用户名长度必须在 3-16 个字符之间")
+	}
+	if !gameProfileNameRegex.MatchString(normalizedName) {
+		return unavailable("invalid", "用户名只允许字母、数字和下划线")
+	}
+
+	rule, xErr := l.matchGameProfileNameRule(ctx, userID, normalizedName)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if rule != nil {
+		if rule.Kind == entity.GameProfileNameRuleKindBlocked {
+			return unavailable("blocked", "该用户名包含不允许使用的内容")
+		}
+		return unavailable("reserved", "该用户名为保留名称")
+	}
+
+	taken, xErr := l.repo.profile.ExistsByNameExceptID(ctx, nil, normalizedName, 0)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if taken {
+		return unavailable("taken", "用户名已存在")
+	}
+
+	held, xErr := l.repo.nameHold.ExistsActiveForOthers(ctx, nil, normalizedName, userID, time.Now())
+	if xErr != nil {
+		return nil, xErr
+	}
+	if held {
+		return unavailable("held", "用户名处于保留期，暂不可使用")
+	}
+
+	result.Available = true
+	return result, nil
+}
+
+// checkGameProfileNameRules 校验名称未命中屏蔽规则，且命中的保留规则允许 userID 使用。
+func (l *GameProfileLogic) checkGameProfileNameRules(ctx context.Context, userID xSnowflake.SnowflakeID, name string) *xError.Error {
+	rule, xErr := l.matchGameProfileNameRule(ctx, userID, name)
+	if xErr != nil {
+		return xErr
+	}
+	if rule == nil {
+		return nil
+	}
+	if rule.Kind == entity.GameProfileNameRuleKindBlocked {
+		return xError.NewError(ctx, xError.ParameterError, "该用户名包含不允许使用的内容", true)
+	}
+	return xError.NewError(ctx, xError.DataConflict, "该用户名为保留名称，暂不可使用", true)
+}
+
+// matchGameProfileNameRule 返回名称命中的首条拦截规则，未被拦截时返回 nil。
+//
+// 屏蔽规则优先于保留规则；保留规则的 AllowedUserID 与 userID 一致时不拦截。
+func (l *GameProfileLogic) matchGameProfileNameRule(ctx context.Context, userID xSnowflake.SnowflakeID, name string) (*entity.GameProfileNameRule, *xError.Error) {
+	rules, xErr := l.loadEnabledNameRules(ctx)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	var reserved *entity.GameProfileNameRule
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Matches(name) {
+			continue
+		}
+		if rule.Kind == entity.GameProfileNameRuleKindBlocked {
+			return rule, nil
+		}
+		if reserved == nil && (rule.AllowedUserID == nil || *rule.AllowedUserID != userID) {
+			reserved = rule
+		}
+	}
+	return reserved, nil
+}
+
+// loadEnabledNameRules 读取全部已启用的名称规则，优先使用缓存，缓存异常时回退数据库。
+// 返回前预编译正则规则，同一批规则匹配时不再重复编译。
+func (l *GameProfileLogic) loadEnabledNameRules(ctx context.Context) ([]entity.GameProfileNameRule, *xError.Error) {
+	rules, hit, err := l.repo.nameRuleCache.Get(ctx)
+	if err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("读取名称规则缓存失败(可忽略): %v", err))
+	}
+	if !hit {
+		var xErr *xError.Error
+		rules, xErr = l.repo.nameRule.ListEnabled(ctx, nil)
+		if xErr != nil {
+			return nil, xErr
+		}
+		if err := l.repo.nameRuleCache.Set(ctx, rules); err != nil {
+			l.log.Warn(ctx, fmt.Sprintf("写入名称规则缓存失败: %v", err))
+		}
+	}
+
+	for i := range rules {
+		if err := rules[i].CompilePattern(); err != nil {
+			l.log.Warn(ctx, fmt.Sprintf("名称规则(ID=%s)正则无法编译，已忽略: %v", rules[i].ID.String(), err))
+		}
+	}
+	return rules, nil
+}
+
+// invalidateNameRuleCache 清除名称规则缓存，失败仅记录警告（缓存 TTL 兜底）。
+func (l *GameProfileLogic) invalidateNameRuleCache(ctx context.Context) {
+	if err := l.repo.nameRuleCache.Invalidate(ctx); err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("清除名称规则缓存失败: %v", err))
+	}
+}
+
+// applyNameRuleInput 校验输入并写入规则实体。
+//
+// 非正则规则的匹配内容只能包含字母、数字和下划线（否则永远不会命中合法名称）；
+// 正则规则必须可编译；仅保留规则可指定允许使用的用户，且该用户必须存在。
+func (l *GameProfileLogic) applyNameRuleInput(ctx context.Context, rule *entity.GameProfileNameRule, input models.GameProfileNameRuleInput) *xError.Error {
+	kind, ok := parseGameProfileNameRuleKind(input.Kind)
+	if !ok {
+		return xError.NewError(ctx, xError.ParameterError, "无效的规则类别", true)
+	}
+	matchType, ok := parseGameProfileNameMatchType(input.MatchType)
+	if !ok {
+		return xError.NewError(ctx, xError.ParameterError, "无效的匹配方式", true)
+	}
+
+	pattern := strings.TrimSpace(input.Pattern)
+	if pattern == "" || len(pattern) > gameProfileNameRulePatternMaxLen {
+		return xError.NewError(ctx, xError.ParameterError, "匹配内容长度必须在 1-128 个字符之间", true)
+	}
+	if matchType == entity.GameProfileNameMatchRegex {
+		if _, err := entity.CompileGameProfileNamePattern(pattern); err != nil {
+			return xError.NewError(ctx, xError.ParameterError, "无效的正则表达式", true, err)
+		}
+	} else if !gameProfileNameRegex.MatchString(pattern) {
+		return xError.NewError(ctx, xError.ParameterError, "非正则规则的匹配内容只允许字母、数字和下划线", true)
+	}
+
+	if input.AllowedUserID != nil {
+		if kind != entity.GameProfileNameRuleKindReserved {
+			return xError.NewError(ctx, xError.ParameterError, "仅保留规则可指定允许使用的用户", true)
+		}
+		_, found, xErr := l.repo.user.Get(ctx, input.AllowedUserID.String())
+		if xErr != nil {
+			return xErr
+		}
+		if !found {
+			return xError.NewError(ctx, xError.ResourceNotFound, "允许使用的用户不存在", true)
+		}
+	}
+
+	rule.Kind = kind
+	rule.MatchType = matchType
+	rule.Pattern = pattern
+	rule.AllowedUserID = input.AllowedUserID
+	rule.Reason = strings.TrimSpace(input.Reason)
+	rule.IsEnabled = input.IsEnabled
+	return nil
+}
+
+// parseGameProfileNameRuleKind 将规则类别字符串解析为枚举值。
+func parseGameProfileNameRuleKind(kind string) (entity.GameProfileNameRuleKind, bool) {
+	switch kind {
+	case "reserved":
+		return entity.GameProfileNameRuleKindReserved, true
+	case "blocked":
+		return entity.GameProfileNameRuleKindBlocked, true
+	default:
+		return 0, false
+	}
+}
+
+// parseGameProfileNameMatchType 将匹配方式字符串解析为枚举值。
+func parseGameProfileNameMatchType(matchType string) (entity.GameProfileNameMatchType, bool) {
+	switch matchType {
+	case "exact":
+		return entity.GameProfileNameMatchExact, true
+	case "prefix":
+		return entity.GameProfileNameMatchPrefix, true
+	case "contains":
+		return entity.GameProfileNameMatchContains, true
+	case "regex":
+		return entity.GameProfileNameMatchRegex, true
+	default:
+		return 0, false
+	}
+}
+
+// buildGameProfileNameRuleDTO 将名称规则实体转换为 DTO。
+func buildGameProfileNameRuleDTO(rule *entity.GameProfileNameRule) models.GameProfileNameRuleDTO {
+	kind := "reserved"
+	if rule.Kind == entity.GameProfileNameRuleKindBlocked {
+		kind = "blocked"
+	}
+
+	var matchType string
+	switch rule.MatchType {
+	case entity.GameProfileNameMatchPrefix:
+		matchType = "prefix"
+	case entity.GameProfileNameMatchContains:
+		matchType = "contains"
+	case entity.GameProfileNameMatchRegex:
+		matchType = "regex"
+	default:
+		matchType = "exact"
+	}
+
+	return models.GameProfileNameRuleDTO{
+		ID:            rule.ID,
+		Kind:          kind,
+		MatchType:     matchType,
+		Pattern:       rule.Pattern,
+		AllowedUserID: rule.AllowedUserID,
+		Reason:        rule.Reason,
+		IsEnabled:     rule.IsEnabled,
+		CreatedAt:     rule.CreatedAt,
+		UpdatedAt:     rule.UpdatedAt,
+	}
+}
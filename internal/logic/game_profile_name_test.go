@@ -0,0 +1,78 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+)
+
+// TestGroupGameProfileNameConflicts 验证大小写冲突分组：按小写名称归并、分组按名称升序、组内保持输入顺序、忽略单档案分组。
+func TestGroupGameProfileNameConflicts(t *testing.T) {
+	profile := func(id int64, name string) entity.GameProfile {
+		return entity.GameProfile{
+			BaseEntity: xModels.BaseEntity{ID: xSnowflake.SnowflakeID(id)},
+			Name:       name,
+		}
+	}
+
+	cases := []struct {
+		name     string
+		profiles []entity.GameProfile
+		want     map[string][]int64 // 归一化名称 → 组内档案 ID（按顺序）
+		wantKeys []string
+	}{
+		{
+			name:     "无档案",
+			profiles: nil,
+			wantKeys: []string{},
+		},
+		{
+			name:     "仅单个档案不构成冲突",
+			profiles: []entity.GameProfile{profile(1, "Steve"), profile(2, "Alex")},
+			wantKeys: []string{},
+		},
+		{
+			name:     "已按名称排序的输入",
+			profiles: []entity.GameProfile{profile(1, "alex"), profile(2, "ALEX"), profile(3, "Steve"), profile(4, "steve")},
+			want:     map[string][]int64{"alex": {1, 2}, "steve": {3, 4}},
+			wantKeys: []string{"alex", "steve"},
+		},
+		{
+			name:     "名称交错的输入",
+			profiles: []entity.GameProfile{profile(1, "Steve"), profile(2, "alex"), profile(3, "STEVE"), profile(4, "Alex"), profile(5, "steve")},
+			want:     map[string][]int64{"alex": {2, 4}, "steve": {1, 3, 5}},
+			wantKeys: []string{"alex", "steve"},
+		},
+		{
+			name:     "混入无冲突档案",
+			profiles: []entity.GameProfile{profile(1, "Notch"), profile(2, "jeb_"), profile(3, "JEB_")},
+			want:     map[string][]int64{"jeb_": {2, 3}},
+			wantKeys: []string{"jeb_"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			groups := GroupGameProfileNameConflicts(tc.profiles)
+
+			keys := make([]string, 0, len(groups))
+			for _, group := range groups {
+				keys = append(keys, group.Key)
+
+				ids := make([]int64, 0, len(group.Profiles))
+				for _, p := range group.Profiles {
+					ids = append(ids, p.ID.Int64())
+				}
+				if !reflect.DeepEqual(ids, tc.want[group.Key]) {
+					t.Errorf("分组 %q 的档案 ID = %v, 期望 %v", group.Key, ids, tc.want[group.Key])
+				}
+			}
+			if !reflect.DeepEqual(keys, tc.wantKeys) {
+				t.Errorf("分组名称 = %v, 期望 %v", keys, tc.wantKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+const (
+	outfitNameMaxLength = 32 // 外观预设名称最大长度（字符）
+	outfitMaxPerUser    = 20 // 每个用户最多保存的外观预设数量
+)
+
+// ListOutfits 获取当前用户的全部外观预设。
+func (l *GameProfileLogic) ListOutfits(ctx context.Context, userID xSnowflake.SnowflakeID) ([]models.GameProfileOutfitDTO, *xError.Error) {
+	l.log.Info(ctx, "ListOutfits - 获取外观预设列表")
+
+	outfits, xErr := l.repo.outfit.ListByUserID(ctx, nil, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	dtos := make([]models.GameProfileOutfitDTO, 0, len(outfits))
+	for i := range outfits {
+		dto, xErr := l.buildOutfitDTO(ctx, &outfits[i])
+		if xErr != nil {
+			return nil, xErr
+		}
+		dtos = append(dtos, *dto)
+	}
+	return dtos, nil
+}
+
+// CreateOutfit 为当前用户保存一套外观预设。
+//
+// 该方法执行以下业务流程：
+//  1. 校验名称合法性，皮肤与披风至少包含一项
+//  2. 校验预设数量上限与名称唯一性
+//  3. 校验用户当前持有预设中的皮肤与披风（与装备时的校验一致）
+//  4. 创建预设记录
+func (l *GameProfileLogic) CreateOutfit(ctx context.Context, userID xSnowflake.SnowflakeID, name string, skinLibraryID *xSnowflake.SnowflakeID, capeLibraryID *xSnowflake.SnowflakeID) (*models.GameProfileOutfitDTO, *xError.Error) {
+	l.log.Info(ctx, "CreateOutfit - 创建外观预设")
+
+	normalizedName, skinLibraryID, capeLibraryID, xErr := validateOutfitInput(ctx, name, skinLibraryID, capeLibraryID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	count, xErr := l.repo.outfit.CountByUserID(ctx, nil, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if count >= outfitMaxPerUser {
+		return nil, xError.NewError(ctx, xError.ResourceExhausted, fmt.Sprintf("外观预设数量已达上限（%d 套）", outfitMaxPerUser), true)
+	}
+
+	if xErr := l.ensureOutfitNameAvailable(ctx, userID, normalizedName, 0); xErr != nil {
+		return nil, xErr
+	}
+	if xErr := l.ensureOutfitEquippable(ctx, userID, skinLibraryID, capeLibraryID); xErr != nil {
+		return nil, xErr
+	}
+
+	created, xErr := l.repo.outfit.Create(ctx, nil, &entity.GameProfileOutfit{
+		UserID:        userID,
+		Name:          normalizedName,
+		SkinLibraryID: skinLibraryID,
+		CapeLibraryID: capeLibraryID,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+	return l.getOutfitDTO(ctx, userID, created.ID)
+}
+
+// UpdateOutfit 更新当前用户的外观预设（名称与皮肤、披风组合整体替换）。
+func (l *GameProfileLogic) UpdateOutfit(ctx context.Context, userID xSnowflake.SnowflakeID, outfitID xSnowflake.SnowflakeID, name string, skinLibraryID *xSnowflake.SnowflakeID, capeLibraryID *xSnowflake.SnowflakeID) (*models.GameProfileOutfitDTO, *xError.Error) {
+	l.log.Info(ctx, "UpdateOutfit - 更新外观预设")
+
+	normalizedName, skinLibraryID, capeLibraryID, xErr := validateOutfitInput(ctx, name, skinLibraryID, capeLibraryID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	outfit, found, xErr := l.repo.outfit.GetByIDAndUserID(ctx, nil, outfitID, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "外观预设不存在", true)
+	}
+
+	if xErr := l.ensureOutfitNameAvailable(ctx, userID, normalizedName, outfit.ID); xErr != nil {
+		return nil, xErr
+	}
+	if xErr := l.ensureOutfitEquippable(ctx, userID, skinLibraryID, capeLibraryID); xErr != nil {
+		return nil, xErr
+	}
+
+	outfit.Name = normalizedName
+	outfit.SkinLibraryID = skinLibraryID
+	outfit.CapeLibraryID = capeLibraryID
+	if xErr := l.repo.outfit.Update(ctx, nil, outfit); xErr != nil {
+		return nil, xErr
+	}
+	return l.getOutfitDTO(ctx, userID, outfit.ID)
+}
+
+// DeleteOutfit 删除当前用户的外观预设，已应用到档案上的外观不受影响。
+func (l *GameProfileLogic) DeleteOutfit(ctx context.Context, userID xSnowflake.SnowflakeID, outfitID xSnowflake.SnowflakeID) *xError.Error {
+	l.log.Info(ctx, "DeleteOutfit - 删除外观预设")
+
+	deleted, xErr := l.repo.outfit.DeleteByIDAndUserID(ctx, nil, outfitID, userID)
+	if xErr != nil {
+		return xErr
+	}
+	if !deleted {
+		return xError.NewError(ctx, xError.ResourceNotFound, "外观预设不存在", true)
+	}
+	return nil
+}
+
+// ApplyOutfit 将外观预设应用到指定游戏档案。
+//
+// 该方法执行以下业务流程：
+//  1. 校验档案与预设的归属权
+//  2. 按当前持有关系重新校验预设中的皮肤与披风（预设保存后资源可能已被转移、过期或取消公开）
+//  3. 以单条 UPDATE 同时写入皮肤与披风绑定，预设中为空的部位会被卸下
+//
+// Yggdrasil 档案查询在每次请求时实时读取绑定并签名，本服务不缓存已签名的档案，
+// 因此绑定一次性写入后客户端只会观察到一次外观变更，无需额外的缓存失效。
+func (l *GameProfileLogic) ApplyOutfit(ctx context.Context, userID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, outfitID xSnowflake.SnowflakeID) (*models.GameProfileDTO, *xError.Error) {
+	l.log.Info(ctx, "ApplyOutfit - 应用外观预设")
+
+	// 1. 校验档案与预设归属权
+	profile, found, xErr := l.repo.profile.GetByIDAndUserID(ctx, nil, profileID, userID, false)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	if xErr = ensureGameProfileUnlocked(ctx, profile); xErr != nil {
+		return nil, xErr
+	}
+
+	outfit, found, xErr := l.repo.outfit.GetByIDAndUserID(ctx, nil, outfitID, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "外观预设不存在", true)
+	}
+
+	// 2. 按当前持有关系校验
+	if xErr := l.ensureOutfitEquippable(ctx, userID, outfit.SkinLibraryID, outfit.CapeLibraryID); xErr != nil {
+		return nil, xErr
+	}
+
+	// 3. 同时更新皮肤与披风绑定
+	_, xErr = l.repo.profile.UpdateEquipment(ctx, nil, profileID, outfit.SkinLibraryID, outfit.CapeLibraryID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	// 4. 重新获取完整档案（含 Preload 的关联数据）用于构建 DTO
+	profile, found, xErr = l.repo.profile.GetDetailByID(ctx, nil, profileID, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	return l.buildProfileDTO(ctx, profile)
+}
+
+// ensureOutfitNameAvailable 校验用户下外观预设名称未被占用（排除指定预设 ID）。
+func (l *GameProfileLogic) ensureOutfitNameAvailable(ctx context.Context, userID xSnowflake.SnowflakeID, name string, exceptID xSnowflake.SnowflakeID) *xError.Error {
+	existed, xErr := l.repo.outfit.ExistsByUserAndNameExceptID(ctx, nil, userID, name, exceptID)
+	if xErr != nil {
+		return xErr
+	}
+	if existed {
+		return xError.NewError(ctx, xError.DataConflict, "已存在同名外观预设", true)
+	}
+	return nil
+}
+
+// ensureOutfitEquippable 校验用户当前可装备预设中的皮肤与披风。
+//
+// 校验规则与 EquipSkin / EquipCape 一致：通过 UserSkinLibrary / UserCapeLibrary
+// 关联判断持有关系，收藏类型要求资源仍公开。
+func (l *GameProfileLogic) ensureOutfitEquippable(ctx context.Context, userID xSnowflake.SnowflakeID, skinLibraryID *xSnowflake.SnowflakeID, capeLibraryID *xSnowflake.SnowflakeID) *xError.Error {
+	if skinLibraryID != nil {
+		hasSkin, xErr := l.repo.userSkinLib.ExistsEquippableByUserAndSkin(ctx, nil, userID, *skinLibraryID)
+		if xErr != nil {
+			return xErr
+		}
+		if !hasSkin {
+			return xError.NewError(ctx, xError.PermissionDenied, "您未拥有预设中的皮肤或收藏的皮肤已不再公开，无法装备", true)
+		}
+	}
+	if capeLibraryID != nil {
+		hasCape, xErr := l.repo.userCapeLib.ExistsEquippableByUserAndCape(ctx, nil, userID, *capeLibraryID)
+		if xErr != nil {
+			return xErr
+		}
+		if !hasCape {
+			return xError.NewError(ctx, xError.PermissionDenied, "您未拥有预设中的披风或收藏的披风已不再公开，无法装备", true)
+		}
+	}
+	return nil
+}
+
+// getOutfitDTO 重新查询外观预设（含 Preload 的关联数据）并构建 DTO。
+func (l *GameProfileLogic) getOutfitDTO(ctx context.Context, userID xSnowflake.SnowflakeID, outfitID xSnowflake.SnowflakeID) (*models.GameProfileOutfitDTO, *xError.Error) {
+	outfit, found, xErr := l.repo.outfit.GetByIDAndUserID(ctx, nil, outfitID, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "外观预设不存在", true)
+	}
+	return l.buildOutfitDTO(ctx, outfit)
+}
+
+// buildOutfitDTO 将 GameProfileOutfit 实体转换为 GameProfileOutfitDTO，并解析关联资源的纹理链接。
+func (l *GameProfileLogic) buildOutfitDTO(ctx context.Context, outfit *entity.GameProfileOutfit) (*models.GameProfileOutfitDTO, *xError.Error) {
+	resp := &models.GameProfileOutfitDTO{
+		ID:            outfit.ID,
+		Name:          outfit.Name,
+		SkinLibraryID: outfit.SkinLibraryID,
+		CapeLibraryID: outfit.CapeLibraryID,
+		CreatedAt:     outfit.CreatedAt,
+		UpdatedAt:     outfit.UpdatedAt,
+	}
+
+	if outfit.SkinLibrary != nil {
+		skinResp, xErr := l.libraryLogic.buildSkinDTO(ctx, outfit.SkinLibrary)
+		if xErr != nil {
+			return nil, xErr
+		}
+		resp.Skin = skinResp
+	}
+
+	if outfit.CapeLibrary != nil {
+		capeResp, xErr := l.libraryLogic.buildCapeDTO(ctx, outfit.CapeLibrary)
+		if xErr != nil {
+			return nil, xErr
+		}
+		resp.Cape = capeResp
+	}
+
+	return resp, nil
+}
+
+// validateOutfitInput 校验并规范化外观预设的名称与资源 ID。
+//
+// 名称去除首尾空白后需为 1-32 个字符；零值资源 ID 视为未设置，
+// 皮肤与披风至少需要包含一项。
+func validateOutfitInput(ctx context.Context, name string, skinLibraryID *xSnowflake.SnowflakeID, capeLibraryID *xSnowflake.SnowflakeID) (string, *xSnowflake.SnowflakeID, *xSnowflake.SnowflakeID, *xError.Error) {
+	normalizedName := strings.TrimSpace(name)
+	if normalizedName == "" {
+		return "", nil, nil, xError.NewError(ctx, xError.ParameterError, "外观预设名称不能为空", true)
+	}
+	if utf8.RuneCountInString(normalizedName) > outfitNameMaxLength {
+		return "", nil, nil, xError.NewError(ctx, xError.ParameterError, fmt.Sprintf("外观预设名称长度不能超过 %d 个字符", outfitNameMaxLength), true)
+	}
+
+	if skinLibraryID != nil && skinLibraryID.IsZero() {
+		skinLibraryID = nil
+	}
+	if capeLibraryID != nil && capeLibraryID.IsZero() {
+		capeLibraryID = nil
+	}
+	if skinLibraryID == nil && capeLibraryID == nil {
+		return "", nil, nil, xError.NewError(ctx, xError.ParameterError, "外观预设至少需要包含皮肤或披风", true)
+	}
+	return normalizedName, skinLibraryID, capeLibraryID, nil
+}
@@ -0,0 +1,187 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/logic/yggdrasil"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+const defaultGameProfileTransferExpireHours = 72 // 转移请求默认有效小时数
+
+// CreateTransfer 发起游戏档案转移请求。
+//
+// 档案持有者指定接收方用户，接收方需在有效期内接受后档案才会转移。
+// 发起时仅校验接收方存在且未被封禁，配额在接受时校验，避免长期占用接收方配额。
+func (l *GameProfileLogic) CreateTransfer(ctx context.Context, userID xSnowflake.SnowflakeID, profileID xSnowflake.SnowflakeID, toUserID xSnowflake.SnowflakeID, remark string) (*models.GameProfileTransferDTO, *xError.Error) {
+	l.log.Info(ctx, "CreateTransfer - 发起游戏档案转移")
+
+	if toUserID.IsZero() {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效接收方用户 ID：不能为 0", true)
+	}
+	if toUserID == userID {
+		return nil, xError.NewError(ctx, xError.ParameterError, "不能将游戏档案转移给自己", true)
+	}
+
+	recipient, found, xErr := l.repo.user.Get(ctx, toUserID.String())
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "接收方用户不存在", true)
+	}
+	if recipient.HasBan {
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "接收方账号已被封禁，无法接收游戏档案", true)
+	}
+
+	now := time.Now()
+	hours := xEnv.GetEnvInt(bConst.EnvGameProfileTransferExpireHours, defaultGameProfileTransferExpireHours)
+	if hours <= 0 {
+		hours = defaultGameProfileTransferExpireHours
+	}
+	transfer := &entity.GameProfileTransfer{
+		ProfileID:  profileID,
+		FromUserID: userID,
+		ToUserID:   toUserID,
+		ExpiresAt:  now.Add(time.Duration(hours) * time.Hour),
+	}
+	if remark = strings.TrimSpace(remark); remark != "" {
+		transfer.Remark = &remark
+	}
+
+	created, xErr := l.repo.txn.CreateTransfer(ctx, transfer, now)
+	if xErr != nil {
+		return nil, xErr
+	}
+	dto := buildGameProfileTransferDTO(created, userID, now)
+	return &dto, nil
+}
+
+// ListTransfers 分页获取当前用户发起或接收的游戏档案转移请求。
+func (l *GameProfileLogic) ListTransfers(ctx context.Context, userID xSnowflake.SnowflakeID, page int, pageSize int) ([]models.GameProfileTransferDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListTransfers - 获取游戏档案转移请求")
+
+	transfers, total, xErr := l.repo.transfer.ListByUserID(ctx, nil, userID, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	now := time.Now()
+	items := make([]models.GameProfileTransferDTO, 0, len(transfers))
+	for i := range transfers {
+		items = append(items, buildGameProfileTransferDTO(&transfers[i], userID, now))
+	}
+	return items, total, nil
+}
+
+// AcceptTransfer 接收方接受游戏档案转移。
+//
+// 事务内完成配额校验与双方配额变更、卸下接收方未拥有的皮肤与披风、吊销发起方绑定令牌并转移持有者；
+// 提交后清理该档案的待验证会话，避免发起方以旧会话通过 hasJoined。
+// 会话清理失败仅记录警告日志（会话 TTL 很短，且绑定令牌已吊销）。
+func (l *GameProfileLogic) AcceptTransfer(ctx context.Context, userID xSnowflake.SnowflakeID, transferID xSnowflake.SnowflakeID) (*models.GameProfileTransferAcceptDTO, *xError.Error) {
+	l.log.Info(ctx, "AcceptTransfer - 接受游戏档案转移")
+
+	now := time.Now()
+	result, xErr := l.repo.txn.AcceptTransfer(ctx, transferID, userID, now)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	if err := l.repo.sessionCache.DeleteByProfile(ctx, yggdrasil.EncodeUnsignedUUID(result.Profile.UUID)); err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("清理游戏档案会话缓存失败（TTL 兜底仍生效）: %v", err))
+	}
+
+	profile, found, xErr := l.repo.profile.GetDetailByID(ctx, nil, result.Profile.ID, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	profileDTO, xErr := l.buildProfileDTO(ctx, profile)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	return &models.GameProfileTransferAcceptDTO{
+		Transfer:      buildGameProfileTransferDTO(result.Transfer, userID, now),
+		Profile:       profileDTO,
+		SkinUnbound:   result.SkinUnbound,
+		CapeUnbound:   result.CapeUnbound,
+		RevokedTokens: result.RevokedTokens,
+	}, nil
+}
+
+// RejectTransfer 接收方拒绝游戏档案转移。
+func (l *GameProfileLogic) RejectTransfer(ctx context.Context, userID xSnowflake.SnowflakeID, transferID xSnowflake.SnowflakeID) (*models.GameProfileTransferDTO, *xError.Error) {
+	l.log.Info(ctx, "RejectTransfer - 拒绝游戏档案转移")
+
+	return l.closeTransfer(ctx, userID, transferID, entity.GameProfileTransferStatusRejected)
+}
+
+// CancelTransfer 发起方取消游戏档案转移。
+func (l *GameProfileLogic) CancelTransfer(ctx context.Context, userID xSnowflake.SnowflakeID, transferID xSnowflake.SnowflakeID) (*models.GameProfileTransferDTO, *xError.Error) {
+	l.log.Info(ctx, "CancelTransfer - 取消游戏档案转移")
+
+	return l.closeTransfer(ctx, userID, transferID, entity.GameProfileTransferStatusCancelled)
+}
+
+// closeTransfer 委托事务仓储将待接收的转移请求置为指定终态。
+func (l *GameProfileLogic) closeTransfer(ctx context.Context, userID xSnowflake.SnowflakeID, transferID xSnowflake.SnowflakeID, status entity.GameProfileTransferStatus) (*models.GameProfileTransferDTO, *xError.Error) {
+	now := time.Now()
+	closed, xErr := l.repo.txn.CloseTransfer(ctx, transferID, userID, status, now)
+	if xErr != nil {
+		return nil, xErr
+	}
+	dto := buildGameProfileTransferDTO(closed, userID, now)
+	return &dto, nil
+}
+
+// buildGameProfileTransferDTO 将转移请求实体转换为 DTO，方向与状态按 viewerID 与 now 计算。
+//
+// 已到期但未处理的请求不会回写数据库，仅在展示时标记为 expired。
+func buildGameProfileTransferDTO(transfer *entity.GameProfileTransfer, viewerID xSnowflake.SnowflakeID, now time.Time) models.GameProfileTransferDTO {
+	direction := "incoming"
+	if transfer.FromUserID == viewerID {
+		direction = "outgoing"
+	}
+
+	var status string
+	switch transfer.Status {
+	case entity.GameProfileTransferStatusAccepted:
+		status = "accepted"
+	case entity.GameProfileTransferStatusRejected:
+		status = "rejected"
+	case entity.GameProfileTransferStatusCancelled:
+		status = "cancelled"
+	default:
+		status = "pending"
+		if !transfer.IsPending(now) {
+			status = "expired"
+		}
+	}
+
+	return models.GameProfileTransferDTO{
+		ID:          transfer.ID,
+		ProfileID:   transfer.ProfileID,
+		ProfileUUID: transfer.ProfileUUID,
+		ProfileName: transfer.ProfileName,
+		FromUserID:  transfer.FromUserID,
+		ToUserID:    transfer.ToUserID,
+		Direction:   direction,
+		Status:      status,
+		Remark:      transfer.Remark,
+		ExpiresAt:   transfer.ExpiresAt,
+		RespondedAt: transfer.RespondedAt,
+		CreatedAt:   transfer.CreatedAt,
+	}
+}
@@ -16,14 +16,14 @@ import (
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
 	repocache "github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/cache"
 	repotxn "github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/txn"
-	bBucketApi "github.com/phalanx-labs/beacon-bucket-sdk/api"
-	bBucket "github.com/phalanx-labs/beacon-bucket-sdk"
 	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
 	bCtx "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/context"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
 )
 
 const (
@@ -44,16 +44,17 @@ type issueRepo struct {
 	attachmentRepo *repository.IssueAttachmentRepo // 附件仓储
 	issueTypeRepo  *repository.IssueTypeRepo       // 类型仓储
 	userRepo       *repository.UserRepo            // 用户仓储
+	storageRepo    *repository.StorageFileRepo     // 对象存储文件登记仓储
 	cache          *repocache.IssueCache           // Redis 缓存层
 	txn            *repotxn.IssueTxnRepo           // 事务协调仓储
 }
 
 // issueHelper 问题外部服务辅助器。
 //
-// 封装对象存储（Bucket）客户端等外部依赖，用于处理附件上传等
+// 封装文件存储后端等外部依赖，用于处理附件上传等
 // 不属于数据库事务范围的外部服务调用。
 type issueHelper struct {
-	bucket *bBucket.BucketClient // 对象存储客户端
+	storage bStorage.Storage // 文件存储后端
 }
 
 // IssueLogic 问题业务逻辑处理者。
@@ -97,49 +98,41 @@ func NewIssueLogic(ctx context.Context) *IssueLogic {
 			attachmentRepo: attachmentRepo,
 			issueTypeRepo:  issueTypeRepo,
 			userRepo:       userRepo,
+			storageRepo:    repository.NewStorageFileRepo(db),
 			cache:          &repocache.IssueCache{RDB: rdb, TTL: 15 * time.Minute},
 			txn: repotxn.NewIssueTxnRepo(
 				db, issueRepoInst, replyRepo, attachmentRepo,
 			),
 		},
 		helper: issueHelper{
-			bucket: bCtx.MustGetBucket(ctx),
+			storage: bCtx.MustGetStorage(ctx),
 		},
 	}
 }
 
-// ==================== Bucket Helper Methods ====================
+// ==================== Storage Helper Methods ====================
 
 // cacheVerifyFile 将缓存态文件确认为永久态。
 func (l *IssueLogic) cacheVerifyFile(ctx context.Context, fileId string) {
-	_, err := l.helper.bucket.Normal.CacheVerify(ctx, &bBucketApi.CacheVerifyRequest{
-		FileId: fileId,
-	})
-	if err != nil {
+	if err := l.helper.storage.CacheVerify(ctx, fileId); err != nil {
 		l.log.Warn(ctx, fmt.Sprintf("CacheVerify 调用失败，文件可能仍为缓存态: %v", err))
 	}
 }
 
-// resolveAttachmentURL 通过 beacon-bucket SDK 的 Get 方法将 FileID 解析为下载链接。
+// resolveAttachmentURL 通过文件存储后端的 Get 方法将 FileID 解析为下载链接。
 func (l *IssueLogic) resolveAttachmentURL(ctx context.Context, fileID int64) (string, *xError.Error) {
 	fileIdStr := strconv.FormatInt(fileID, 10)
-	resp, err := l.helper.bucket.Normal.Get(ctx, &bBucketApi.GetRequest{
-		FileId: fileIdStr,
-	})
+	info, err := l.helper.storage.Get(ctx, fileIdStr)
 	if err != nil {
 		return "", xError.NewError(ctx, xError.ServerInternalError, "获取附件文件信息失败", true, err)
 	}
-	if resp.GetObj() == nil {
-		return "", xError.NewError(ctx, xError.ServerInternalError, "附件文件元数据缺失", true)
-	}
-	link := resp.GetObj().GetLink()
-	if link == "" {
+	if info.Link == "" {
 		return "", xError.NewError(ctx, xError.ServerInternalError, "附件下载链接为空", true)
 	}
-	return link, nil
+	return info.Link, nil
 }
 
-// resolveAttachmentURLsBatch 通过 beacon-bucket SDK 的 GetByList 接口批量解析附件下载链接。
+// resolveAttachmentURLsBatch 通过文件存储后端的 GetByList 接口批量解析附件下载链接。
 func (l *IssueLogic) resolveAttachmentURLsBatch(ctx context.Context, fileIDs []int64) (map[int64]string, *xError.Error) {
 	if len(fileIDs) == 0 {
 		return make(map[int64]string), nil
@@ -158,25 +151,18 @@ func (l *IssueLogic) resolveAttachmentURLsBatch(ctx context.Context, fileIDs []i
 		return make(map[int64]string), nil
 	}
 
-	resp, err := l.helper.bucket.Normal.GetByList(ctx, &bBucketApi.GetByListRequest{
-		FileIdList: fileIDList,
-	})
+	fileInfoList, err := l.helper.storage.GetByList(ctx, fileIDList)
 	if err != nil {
 		return nil, xError.NewError(ctx, xError.ServerInternalError, "批量获取附件文件信息失败", true, err)
 	}
 
-	fileInfoList := resp.GetFileInfoList()
 	if len(fileInfoList) != len(fileIDList) {
 		return nil, xError.NewError(ctx, xError.ServerInternalError, "批量获取附件文件信息返回数量不匹配", true)
 	}
 
 	result := make(map[int64]string, len(fileInfoList))
 	for i, info := range fileInfoList {
-		if info.GetObj() == nil {
-			return nil, xError.NewError(ctx, xError.ServerInternalError,
-				xError.ErrMessage(fmt.Sprintf("附件文件元数据缺失(fileId=%s)", fileIDList[i])), true)
-		}
-		link := info.GetObj().GetLink()
+		link := info.Link
 		if link == "" {
 			return nil, xError.NewError(ctx, xError.ServerInternalError,
 				xError.ErrMessage(fmt.Sprintf("附件下载链接为空(fileId=%s)", fileIDList[i])), true)
@@ -191,15 +177,14 @@ func (l *IssueLogic) resolveAttachmentURLsBatch(ctx context.Context, fileIDs []i
 	return result, nil
 }
 
-// deleteBucketFile 从对象存储中删除指定文件。
+// deleteBucketFile 从文件存储后端中删除指定文件。
 func (l *IssueLogic) deleteBucketFile(ctx context.Context, fileID int64) {
 	fileId := strconv.FormatInt(fileID, 10)
-	_, err := l.helper.bucket.Normal.Delete(ctx, &bBucketApi.DeleteRequest{
-		FileId: fileId,
-	})
-	if err != nil {
+	if err := l.helper.storage.Delete(ctx, fileId); err != nil {
 		l.log.Warn(ctx, fmt.Sprintf("Bucket 文件删除失败(fileId=%s)，存在残留风险: %v", fileId, err))
+		return
 	}
+	unregisterStorageFile(ctx, l.repo.storageRepo, l.log, fileID)
 }
 
 // decodeBase64Attachment 解码 Base64 编码的附件数据。
@@ -842,26 +827,16 @@ func (l *IssueLogic) UploadAttachment(
 		return nil, xError.NewError(ctx, xError.ParameterError, xError.ErrMessage("不支持的文件类型: "+mimeType), true)
 	}
 
-	// 校验 Issue 附件 Bucket 配置
-	issueBucketId := xEnv.GetEnvString(bConst.EnvBucketIssueBucketId, "")
-	issuePathId := xEnv.GetEnvString(bConst.EnvBucketIssuePathId, "")
-	if issueBucketId == "" || issuePathId == "" {
-		return nil, xError.NewError(ctx, xError.ServerInternalError, "Issue 附件存储配置缺失，请联系管理员", true)
-	}
-
-	uploadResp, err := l.helper.bucket.Normal.Upload(ctx, &bBucketApi.UploadRequest{
-		BucketId:      issueBucketId,
-		PathId:        issuePathId,
-		ContentBase64: content,
-	})
+	uploadResp, err := l.helper.storage.Upload(ctx, bStorage.CategoryIssue, content)
 	if err != nil {
-		return nil, mapBucketError(ctx, "上传附件失败", err)
+		return nil, mapStorageError(ctx, "上传附件失败", err)
 	}
 
-	fileID, err := strconv.ParseInt(uploadResp.FileId, 10, 64)
+	fileID, err := strconv.ParseInt(uploadResp.FileID, 10, 64)
 	if err != nil {
 		return nil, xError.NewError(ctx, xError.ServerInternalError, "解析文件 ID 失败", true, err)
 	}
+	registerStorageFile(ctx, l.repo.storageRepo, l.log, entityType.StorageFileSourceIssueAttachment, fileID)
 
 	attachment := &entity.IssueAttachment{
 		IssueID:  issueID,
@@ -876,11 +851,11 @@ func (l *IssueLogic) UploadAttachment(
 		return nil, xErr
 	}
 
-	l.cacheVerifyFile(ctx, uploadResp.FileId)
+	l.cacheVerifyFile(ctx, uploadResp.FileID)
 
 	var fileURL string
-	if uploadResp.GetObj() != nil && uploadResp.GetObj().GetLink() != "" {
-		fileURL = uploadResp.GetObj().GetLink()
+	if uploadResp.Link != "" {
+		fileURL = uploadResp.Link
 	} else {
 		fileURL, xErr = l.resolveAttachmentURL(ctx, created.FileID)
 		if xErr != nil {
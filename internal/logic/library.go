@@ -1,28 +1,30 @@
 package logic
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"image/png"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
-	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
 	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+	repocache "github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/cache"
 	repotxn "github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/txn"
 	bCtx "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/context"
-	bBucket "github.com/phalanx-labs/beacon-bucket-sdk"
-	bBucketApi "github.com/phalanx-labs/beacon-bucket-sdk/api"
-	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
 )
 
 const (
@@ -30,6 +32,11 @@ const (
 	skinNameMaxLength = 64
 	capeNameMinLength = 1
 	capeNameMaxLength = 64
+
+	galleryCacheTTL = 60 * time.Second // 公开画廊查询结果缓存时长（同时限定点赞/收藏/装备计数排序的滞后）
+
+	libraryTextureMaxSize      = 1 << 20 // 上传纹理解码后的大小上限（1 MiB）
+	libraryTextureMaxDimension = 1024    // 纹理宽度上限（64 像素基准的 16 倍高清纹理）
 )
 
 // libraryRepo 资源库数据访问适配器。
@@ -37,20 +44,34 @@ const (
 // 聚合资源库（皮肤/披风）相关的各仓储实例，包括皮肤仓储、披风仓储、配额仓储，
 // 用户关联仓储，以及事务协调仓储（TxnRepo），供 LibraryLogic 统一调用。
 type libraryRepo struct {
-	skinRepo     *repository.SkinLibraryRepo     // 皮肤库仓储
-	capeRepo     *repository.CapeLibraryRepo     // 披风库仓储
-	quotaRepo    *repository.LibraryQuotaRepo    // 资源库配额仓储
-	userSkinRepo *repository.UserSkinLibraryRepo // 用户皮肤关联仓储
-	userCapeRepo *repository.UserCapeLibraryRepo // 用户披风关联仓储
-	txn          *repotxn.LibraryTxnRepo         // 资源库事务协调仓储
+	skinRepo     *repository.SkinLibraryRepo           // 皮肤库仓储
+	capeRepo     *repository.CapeLibraryRepo           // 披风库仓储
+	quotaRepo    *repository.LibraryQuotaRepo          // 资源库配额仓储
+	userSkinRepo *repository.UserSkinLibraryRepo       // 用户皮肤关联仓储
+	userCapeRepo *repository.UserCapeLibraryRepo       // 用户披风关联仓储
+	tagRepo      *repository.LibraryTagRepo            // 资源库标签仓储
+	likeRepo     *repository.LibraryLikeRepo           // 资源库点赞仓储
+	reportRepo   *repository.LibraryReportRepo         // 资源库举报仓储
+	versionRepo  *repository.LibraryTextureVersionRepo // 资源库纹理版本仓储
+	campaignRepo *repository.LibraryCampaignRepo       // 资源发放活动仓储
+	redeemRepo   *repository.RedeemCodeRepo            // 兑换码仓储
+	quotaLogRepo *repository.LibraryQuotaLogRepo       // 资源库配额日志仓储
+	shareRepo    *repository.LibraryShareRepo          // 资源私有分享仓储
+	storageRepo  *repository.StorageFileRepo           // 对象存储文件登记仓储
+	userRepo     *repository.UserRepo                  // 用户仓储（兑换码角色限制校验）
+	galleryCache *repocache.GalleryCache               // 公开画廊分页缓存
+	redeemLimit  *repocache.RedeemRateLimitCache       // 兑换码兑换频率计数
+	txn          *repotxn.LibraryTxnRepo               // 资源库事务协调仓储
 }
 
 // libraryHelper 资源库外部服务辅助器。
 //
-// 封装对象存储（Bucket）客户端等外部依赖，用于处理纹理文件上传等
+// 封装文件存储后端等外部依赖，用于处理纹理文件上传等
 // 不属于数据库事务范围的外部服务调用。
 type libraryHelper struct {
-	bucket *bBucket.BucketClient // 对象存储客户端
+	storage      bStorage.Storage // 文件存储后端
+	httpClient   *http.Client     // HTTP 客户端（用于查询 Mojang API）
+	importClient *http.Client     // HTTP 客户端（用于导入皮肤时下载外部纹理，限制重定向目标）
 }
 
 // LibraryLogic 资源库业务逻辑处理者。
@@ -81,7 +102,7 @@ type LibraryLogic struct {
 // 返回值:
 //   - *LibraryLogic: 初始化完成的资源库业务逻辑实例指针。
 //
-// 注意: 该函数依赖于 `xCtxUtil.MustGetDB`、`xCtxUtil.MustGetRDB` 和 `bCtx.MustGetBucket`。
+// 注意: 该函数依赖于 `xCtxUtil.MustGetDB`、`xCtxUtil.MustGetRDB` 和 `bCtx.MustGetStorage`。
 // 如果上下文中缺少必要的依赖，这些辅助函数会触发 panic。请确保上下文已通过中间件正确注入了这些资源。
 func NewLibraryLogic(ctx context.Context) *LibraryLogic {
 	db := xCtxUtil.MustGetDB(ctx)
@@ -92,6 +113,14 @@ func NewLibraryLogic(ctx context.Context) *LibraryLogic {
 	quotaRepo := repository.NewLibraryQuotaRepo(db)
 	userSkinRepo := repository.NewUserSkinLibraryRepo(db)
 	userCapeRepo := repository.NewUserCapeLibraryRepo(db)
+	tagRepo := repository.NewLibraryTagRepo(db)
+	likeRepo := repository.NewLibraryLikeRepo(db)
+	reportRepo := repository.NewLibraryReportRepo(db)
+	versionRepo := repository.NewLibraryTextureVersionRepo(db)
+	campaignRepo := repository.NewLibraryCampaignRepo(db)
+	redeemRepo := repository.NewRedeemCodeRepo(db)
+	quotaLogRepo := repository.NewLibraryQuotaLogRepo(db)
+	shareRepo := repository.NewLibraryShareRepo(db)
 
 	return &LibraryLogic{
 		logic: logic{
@@ -104,20 +133,45 @@ func NewLibraryLogic(ctx context.Context) *LibraryLogic {
 			quotaRepo:    quotaRepo,
 			userSkinRepo: userSkinRepo,
 			userCapeRepo: userCapeRepo,
+			tagRepo:      tagRepo,
+			likeRepo:     likeRepo,
+			reportRepo:   reportRepo,
+			versionRepo:  versionRepo,
+			campaignRepo: campaignRepo,
+			redeemRepo:   redeemRepo,
+			quotaLogRepo: quotaLogRepo,
+			shareRepo:    shareRepo,
+			storageRepo:  repository.NewStorageFileRepo(db),
+			userRepo:     repository.NewUserRepo(db, rdb),
+			galleryCache: &repocache.GalleryCache{RDB: rdb, TTL: galleryCacheTTL},
+			redeemLimit:  &repocache.RedeemRateLimitCache{RDB: rdb, TTL: redeemRateLimitWindow},
 			txn: repotxn.NewLibraryTxnRepo(
 				db, skinRepo, capeRepo, quotaRepo,
 				userSkinRepo, userCapeRepo,
+				likeRepo, tagRepo,
+				repository.NewGameProfileRepo(db),
+				reportRepo,
+				versionRepo,
+				campaignRepo,
+				redeemRepo,
+				repository.NewGameProfileQuotaRepo(db),
+				quotaLogRepo,
+				shareRepo,
 			),
 		},
 		helper: libraryHelper{
-			bucket: bCtx.MustGetBucket(ctx),
+			storage: bCtx.MustGetStorage(ctx),
+			httpClient: &http.Client{
+				Timeout: textureFetchTimeout,
+			},
+			importClient: newSkinImportClient(),
 		},
 	}
 }
 
 // ==================== Texture URL 解析 ====================
 
-// resolveTextureURL 通过 beacon-bucket SDK 的 Get 方法将 Texture ID 解析为下载链接。
+// resolveTextureURL 通过文件存储后端的 Get 方法将 Texture ID 解析为下载链接。
 //
 // 单条解析入口，批量场景请使用 resolveTextureURLsBatch。
 //
@@ -130,23 +184,17 @@ func NewLibraryLogic(ctx context.Context) *LibraryLogic {
 //   - *xError.Error: 当 Bucket 服务不可用或文件不存在时返回错误
 func (l *LibraryLogic) resolveTextureURL(ctx context.Context, textureID int64) (string, *xError.Error) {
 	fileID := strconv.FormatInt(textureID, 10)
-	resp, err := l.helper.bucket.Normal.Get(ctx, &bBucketApi.GetRequest{
-		FileId: fileID,
-	})
+	info, err := l.helper.storage.Get(ctx, fileID)
 	if err != nil {
 		return "", xError.NewError(ctx, xError.ServerInternalError, "获取纹理文件信息失败", true, err)
 	}
-	if resp.GetObj() == nil {
-		return "", xError.NewError(ctx, xError.ServerInternalError, "纹理文件元数据缺失", true)
-	}
-	link := resp.GetObj().GetLink()
-	if link == "" {
+	if info.Link == "" {
 		return "", xError.NewError(ctx, xError.ServerInternalError, "纹理文件下载链接为空", true)
 	}
-	return link, nil
+	return info.Link, nil
 }
 
-// resolveTextureURLsBatch 通过 beacon-bucket SDK 的 GetByList 接口批量解析纹理下载链接。
+// resolveTextureURLsBatch 通过文件存储后端的 GetByList 接口批量解析纹理下载链接。
 //
 // 与 resolveTextureURL（单条）的区别：
 //   - 单次 RPC 获取 N 条文件信息，避免 N+1 问题
@@ -178,25 +226,18 @@ func (l *LibraryLogic) resolveTextureURLsBatch(ctx context.Context, textureIDs [
 		return make(map[int64]string), nil
 	}
 
-	resp, err := l.helper.bucket.Normal.GetByList(ctx, &bBucketApi.GetByListRequest{
-		FileIdList: fileIDList,
-	})
+	fileInfoList, err := l.helper.storage.GetByList(ctx, fileIDList)
 	if err != nil {
 		return nil, xError.NewError(ctx, xError.ServerInternalError, "批量获取纹理文件信息失败", true, err)
 	}
 
-	fileInfoList := resp.GetFileInfoList()
 	if len(fileInfoList) != len(fileIDList) {
 		return nil, xError.NewError(ctx, xError.ServerInternalError, "批量获取纹理文件信息返回数量不匹配", true)
 	}
 
 	result := make(map[int64]string, len(fileInfoList))
 	for i, info := range fileInfoList {
-		if info.GetObj() == nil {
-			return nil, xError.NewError(ctx, xError.ServerInternalError,
-				xError.ErrMessage(fmt.Sprintf("纹理文件元数据缺失(fileId=%s)", fileIDList[i])), true)
-		}
-		link := info.GetObj().GetLink()
+		link := info.Link
 		if link == "" {
 			return nil, xError.NewError(ctx, xError.ServerInternalError,
 				xError.ErrMessage(fmt.Sprintf("纹理文件下载链接为空(fileId=%s)", fileIDList[i])), true)
@@ -221,26 +262,22 @@ func (l *LibraryLogic) ResolveTextureURLsBatchForAdmin(ctx context.Context, text
 // 必须在数据库事务成功后调用。失败仅记录日志不返回错误，
 // 因为 CacheVerify 是幂等接口，已确认的文件重复调用不会产生副作用。
 func (l *LibraryLogic) cacheVerifyFile(ctx context.Context, fileId string) {
-	_, err := l.helper.bucket.Normal.CacheVerify(ctx, &bBucketApi.CacheVerifyRequest{
-		FileId: fileId,
-	})
-	if err != nil {
+	if err := l.helper.storage.CacheVerify(ctx, fileId); err != nil {
 		l.log.Warn(ctx, fmt.Sprintf("CacheVerify 调用失败，文件可能仍为缓存态: %v", err))
 	}
 }
 
-// deleteBucketFile 从对象存储中删除指定文件。
+// deleteBucketFile 从文件存储后端中删除指定文件。
 //
 // 用于业务数据删除后同步清理对应文件。删除失败仅记录日志，
-// 不影响业务流程（可通过后续补偿任务清理残留）。
+// 不影响业务流程，残留文件保留登记，由存储对账任务发现并清理。
 func (l *LibraryLogic) deleteBucketFile(ctx context.Context, textureID int64) {
 	fileId := strconv.FormatInt(textureID, 10)
-	_, err := l.helper.bucket.Normal.Delete(ctx, &bBucketApi.DeleteRequest{
-		FileId: fileId,
-	})
-	if err != nil {
+	if err := l.helper.storage.Delete(ctx, fileId); err != nil {
 		l.log.Warn(ctx, fmt.Sprintf("Bucket 文件删除失败(fileId=%s)，存在残留风险: %v", fileId, err))
+		return
 	}
+	unregisterStorageFile(ctx, l.repo.storageRepo, l.log, textureID)
 }
 
 // buildSkinDTO 将 SkinLibrary 实体转换为 SkinDTO。
@@ -253,14 +290,27 @@ func (l *LibraryLogic) buildSkinDTO(ctx context.Context, skin *entity.SkinLibrar
 		return nil, xErr
 	}
 	return &models.SkinDTO{
-		ID:          skin.ID,
-		UserID:      skin.UserID,
-		Name:        skin.Name,
-		TextureURL:  url,
-		TextureHash: skin.TextureHash,
-		Model:       skin.Model,
-		IsPublic:    skin.IsPublic,
-		UpdatedAt:   skin.UpdatedAt,
+		ID:                    skin.ID,
+		UserID:                skin.UserID,
+		Name:                  skin.Name,
+		TextureURL:            url,
+		TextureHash:           skin.TextureHash,
+		TextureVersion:        skin.TextureVersion,
+		PendingTextureVersion: skin.PendingTextureVersion,
+		Model:                 skin.Model,
+		IsPublic:              skin.IsPublic,
+		UpdatedAt:             skin.UpdatedAt,
+		LikeCount:             skin.LikeCount,
+		CollectCount:          skin.CollectCount,
+		ReviewStatus:          skin.ReviewStatus,
+		ReviewReason:          skin.ReviewReason,
+		ReportCount:           skin.ReportCount,
+		IsHidden:              skin.IsHidden,
+		IsDefault:             skin.IsDefault,
+		IsDefaultEquip:        skin.IsDefaultEquip,
+		IsRetired:             skin.IsRetired,
+		SimilarToID:           skin.SimilarToID,
+		Tags:                  libraryTagNames(skin.Tags),
 	}, nil
 }
 
@@ -271,13 +321,25 @@ func (l *LibraryLogic) buildCapeDTO(ctx context.Context, cape *entity.CapeLibrar
 		return nil, xErr
 	}
 	return &models.CapeDTO{
-		ID:          cape.ID,
-		UserID:      cape.UserID,
-		Name:        cape.Name,
-		TextureURL:  url,
-		TextureHash: cape.TextureHash,
-		IsPublic:    cape.IsPublic,
-		UpdatedAt:   cape.UpdatedAt,
+		ID:                    cape.ID,
+		UserID:                cape.UserID,
+		Name:                  cape.Name,
+		TextureURL:            url,
+		TextureHash:           cape.TextureHash,
+		TextureVersion:        cape.TextureVersion,
+		PendingTextureVersion: cape.PendingTextureVersion,
+		IsPublic:              cape.IsPublic,
+		UpdatedAt:             cape.UpdatedAt,
+		LikeCount:             cape.LikeCount,
+		CollectCount:          cape.CollectCount,
+		ReviewStatus:          cape.ReviewStatus,
+		ReviewReason:          cape.ReviewReason,
+		ReportCount:           cape.ReportCount,
+		IsHidden:              cape.IsHidden,
+		IsDefault:             cape.IsDefault,
+		IsRetired:             cape.IsRetired,
+		SimilarToID:           cape.SimilarToID,
+		Tags:                  libraryTagNames(cape.Tags),
 	}, nil
 }
 
@@ -304,14 +366,27 @@ func (l *LibraryLogic) buildSkinDTOs(ctx context.Context, skins []entity.SkinLib
 	for i, skin := range skins {
 		url, _ := urlMap[skin.Texture]
 		responses[i] = models.SkinDTO{
-			ID:          skin.ID,
-			UserID:      skin.UserID,
-			Name:        skin.Name,
-			TextureURL:  url,
-			TextureHash: skin.TextureHash,
-			Model:       skin.Model,
-			IsPublic:    skin.IsPublic,
-			UpdatedAt:   skin.UpdatedAt,
+			ID:                    skin.ID,
+			UserID:                skin.UserID,
+			Name:                  skin.Name,
+			TextureURL:            url,
+			TextureHash:           skin.TextureHash,
+			TextureVersion:        skin.TextureVersion,
+			PendingTextureVersion: skin.PendingTextureVersion,
+			Model:                 skin.Model,
+			IsPublic:              skin.IsPublic,
+			UpdatedAt:             skin.UpdatedAt,
+			LikeCount:             skin.LikeCount,
+			CollectCount:          skin.CollectCount,
+			ReviewStatus:          skin.ReviewStatus,
+			ReviewReason:          skin.ReviewReason,
+			ReportCount:           skin.ReportCount,
+			IsHidden:              skin.IsHidden,
+			IsDefault:             skin.IsDefault,
+			IsDefaultEquip:        skin.IsDefaultEquip,
+			IsRetired:             skin.IsRetired,
+			SimilarToID:           skin.SimilarToID,
+			Tags:                  libraryTagNames(skin.Tags),
 		}
 	}
 	return responses, nil
@@ -339,13 +414,25 @@ func (l *LibraryLogic) buildCapeDTOs(ctx context.Context, capes []entity.CapeLib
 	for i, cape := range capes {
 		url, _ := urlMap[cape.Texture]
 		responses[i] = models.CapeDTO{
-			ID:          cape.ID,
-			UserID:      cape.UserID,
-			Name:        cape.Name,
-			TextureURL:  url,
-			TextureHash: cape.TextureHash,
-			IsPublic:    cape.IsPublic,
-			UpdatedAt:   cape.UpdatedAt,
+			ID:                    cape.ID,
+			UserID:                cape.UserID,
+			Name:                  cape.Name,
+			TextureURL:            url,
+			TextureHash:           cape.TextureHash,
+			TextureVersion:        cape.TextureVersion,
+			PendingTextureVersion: cape.PendingTextureVersion,
+			IsPublic:              cape.IsPublic,
+			UpdatedAt:             cape.UpdatedAt,
+			LikeCount:             cape.LikeCount,
+			CollectCount:          cape.CollectCount,
+			ReviewStatus:          cape.ReviewStatus,
+			ReviewReason:          cape.ReviewReason,
+			ReportCount:           cape.ReportCount,
+			IsHidden:              cape.IsHidden,
+			IsDefault:             cape.IsDefault,
+			IsRetired:             cape.IsRetired,
+			SimilarToID:           cape.SimilarToID,
+			Tags:                  libraryTagNames(cape.Tags),
 		}
 	}
 	return responses, nil
@@ -408,7 +495,7 @@ func (l *LibraryLogic) buildUserSkinAssociationDTOs(ctx context.Context, associa
 
 	responses := make([]models.SkinDTO, len(associations))
 	for i, assoc := range associations {
-		resp := models.SkinDTO{AssignmentType: assoc.AssignmentType}
+		resp := models.SkinDTO{AssignmentType: assoc.AssignmentType, ExpiresAt: assoc.ExpiresAt, ExpiresIn: giftExpiresIn(assoc.ExpiresAt)}
 		if assoc.SkinLibrary != nil {
 			url, _ := urlMap[assoc.SkinLibrary.Texture]
 			resp.ID = assoc.SkinLibrary.ID
@@ -416,9 +503,19 @@ func (l *LibraryLogic) buildUserSkinAssociationDTOs(ctx context.Context, associa
 			resp.Name = assoc.SkinLibrary.Name
 			resp.TextureURL = url
 			resp.TextureHash = assoc.SkinLibrary.TextureHash
+			resp.TextureVersion = assoc.SkinLibrary.TextureVersion
 			resp.Model = assoc.SkinLibrary.Model
 			resp.IsPublic = assoc.SkinLibrary.IsPublic
 			resp.UpdatedAt = assoc.SkinLibrary.UpdatedAt
+			resp.LikeCount = assoc.SkinLibrary.LikeCount
+			resp.CollectCount = assoc.SkinLibrary.CollectCount
+			resp.ReviewStatus = assoc.SkinLibrary.ReviewStatus
+			resp.ReviewReason = assoc.SkinLibrary.ReviewReason
+			resp.ReportCount = assoc.SkinLibrary.ReportCount
+			resp.IsHidden = assoc.SkinLibrary.IsHidden
+			resp.IsDefault = assoc.SkinLibrary.IsDefault
+			resp.IsDefaultEquip = assoc.SkinLibrary.IsDefaultEquip
+			resp.IsRetired = assoc.SkinLibrary.IsRetired
 		}
 		responses[i] = resp
 	}
@@ -453,7 +550,7 @@ func (l *LibraryLogic) buildUserCapeAssociationDTOs(ctx context.Context, associa
 
 	responses := make([]models.CapeDTO, len(associations))
 	for i, assoc := range associations {
-		resp := models.CapeDTO{AssignmentType: assoc.AssignmentType}
+		resp := models.CapeDTO{AssignmentType: assoc.AssignmentType, ExpiresAt: assoc.ExpiresAt, ExpiresIn: giftExpiresIn(assoc.ExpiresAt)}
 		if assoc.CapeLibrary != nil {
 			url, _ := urlMap[assoc.CapeLibrary.Texture]
 			resp.ID = assoc.CapeLibrary.ID
@@ -461,8 +558,17 @@ func (l *LibraryLogic) buildUserCapeAssociationDTOs(ctx context.Context, associa
 			resp.Name = assoc.CapeLibrary.Name
 			resp.TextureURL = url
 			resp.TextureHash = assoc.CapeLibrary.TextureHash
+			resp.TextureVersion = assoc.CapeLibrary.TextureVersion
 			resp.IsPublic = assoc.CapeLibrary.IsPublic
 			resp.UpdatedAt = assoc.CapeLibrary.UpdatedAt
+			resp.LikeCount = assoc.CapeLibrary.LikeCount
+			resp.CollectCount = assoc.CapeLibrary.CollectCount
+			resp.ReviewStatus = assoc.CapeLibrary.ReviewStatus
+			resp.ReviewReason = assoc.CapeLibrary.ReviewReason
+			resp.ReportCount = assoc.CapeLibrary.ReportCount
+			resp.IsHidden = assoc.CapeLibrary.IsHidden
+			resp.IsDefault = assoc.CapeLibrary.IsDefault
+			resp.IsRetired = assoc.CapeLibrary.IsRetired
 		}
 		responses[i] = resp
 	}
@@ -478,9 +584,10 @@ func (l *LibraryLogic) buildUserCapeAssociationDTOs(ctx context.Context, associa
 //  2. 校验并规范化皮肤名称
 //  3. 校验模型类型合法性（Classic / Slim）
 //  4. 解码 Base64 纹理数据
-//  5. 计算 SHA256 纹理哈希（用于去重）
+//  5. 计算 SHA256 纹理哈希（用于去重）与感知哈希（用于近似重复检测）
 //  6. 上传纹理到对象存储（事务外执行）
 //  7. 委托 Repository 层在事务内完成：配额检查 → 哈希去重 → 记录创建 → 关联创建 → 配额扣减
+//  8. 公开皮肤检测是否与已公开皮肤近似重复，供审核参考
 func (l *LibraryLogic) CreateSkin(ctx context.Context, userID xSnowflake.SnowflakeID, name string, modelType uint8, texture string, isPublic *bool) (*models.SkinDTO, *xError.Error) {
 	l.log.Info(ctx, "CreateSkin - 创建皮肤")
 
@@ -503,37 +610,37 @@ func (l *LibraryLogic) CreateSkin(ctx context.Context, userID xSnowflake.Snowfla
 		return nil, xErr
 	}
 	textureHash := l.calculateTextureHash(textureData)
+	perceptualHash := calculatePerceptualHash(textureData)
 
 	// 上传到对象存储（事务外执行，避免长事务占用连接）
-	skinBucketId := xEnv.GetEnvString(bConst.EnvBucketSkinBucketId, "")
-	skinPathId := xEnv.GetEnvString(bConst.EnvBucketSkinPathId, "")
-	if skinBucketId == "" || skinPathId == "" {
-		return nil, xError.NewError(ctx, xError.ServerInternalError, "皮肤纹理存储配置缺失，请联系管理员", true)
-	}
-	uploadResp, err := l.helper.bucket.Normal.Upload(ctx, &bBucketApi.UploadRequest{
-		BucketId:      skinBucketId,
-		PathId:        skinPathId,
-		ContentBase64: texture,
-	})
+	uploadResp, err := l.helper.storage.Upload(ctx, bStorage.CategorySkin, texture)
 	if err != nil {
-		return nil, mapBucketError(ctx, "上传皮肤纹理失败", err)
+		return nil, mapStorageError(ctx, "上传皮肤纹理失败", err)
 	}
 
-	skinId, err := strconv.ParseInt(uploadResp.FileId, 10, 64)
+	skinId, err := strconv.ParseInt(uploadResp.FileID, 10, 64)
 	if err != nil {
 		return nil, xError.NewError(ctx, xError.ServerInternalError, "解析纹理文件 ID 失败", true, err)
 	}
+	registerStorageFile(ctx, l.repo.storageRepo, l.log, entityType.StorageFileSourceSkinTexture, skinId)
 	isPublicVal := false
 	if isPublic != nil {
 		isPublicVal = *isPublic
 	}
+	// 公开资源需经管理员审核后才对其他玩家可见
+	reviewStatus := entityType.ReviewStatusApproved
+	if isPublicVal {
+		reviewStatus = entityType.ReviewStatusPending
+	}
 	skin := &entity.SkinLibrary{
-		UserID:      &userID,
-		Name:        validatedName,
-		Texture:     skinId,
-		TextureHash: textureHash,
-		Model:       model,
-		IsPublic:    isPublicVal,
+		UserID:         &userID,
+		Name:           validatedName,
+		Texture:        skinId,
+		TextureHash:    textureHash,
+		PerceptualHash: perceptualHash,
+		Model:          model,
+		IsPublic:       isPublicVal,
+		ReviewStatus:   reviewStatus,
 	}
 
 	// 委托 Repository 层在事务内完成创建、关联与配额操作
@@ -541,22 +648,28 @@ func (l *LibraryLogic) CreateSkin(ctx context.Context, userID xSnowflake.Snowfla
 	if xErr != nil {
 		return nil, xErr
 	}
+	if createdSkin.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+	l.flagSimilarSkin(ctx, createdSkin)
 
 	// 事务成功后确认文件转为永久态（必须在 DB 写入成功后调用）
-	l.cacheVerifyFile(ctx, uploadResp.FileId)
+	l.cacheVerifyFile(ctx, uploadResp.FileID)
 
-	// 直接从 UploadResponse 构建 DTO，复用上传返回的下载链接，避免冗余 Get 调用
+	// 直接复用上传返回的下载链接构建 DTO，避免冗余 Get 调用
 	var skinDTO *models.SkinDTO
-	if uploadResp.GetObj() != nil && uploadResp.GetObj().GetLink() != "" {
+	if uploadResp.Link != "" {
 		skinDTO = &models.SkinDTO{
-			ID:          createdSkin.ID,
-			UserID:      createdSkin.UserID,
-			Name:        createdSkin.Name,
-			TextureURL:  uploadResp.GetObj().GetLink(),
-			TextureHash: createdSkin.TextureHash,
-			Model:       createdSkin.Model,
-			IsPublic:    createdSkin.IsPublic,
-			UpdatedAt:   createdSkin.UpdatedAt,
+			ID:             createdSkin.ID,
+			UserID:         createdSkin.UserID,
+			Name:           createdSkin.Name,
+			TextureURL:     uploadResp.Link,
+			TextureHash:    createdSkin.TextureHash,
+			TextureVersion: createdSkin.TextureVersion,
+			Model:          createdSkin.Model,
+			IsPublic:       createdSkin.IsPublic,
+			UpdatedAt:      createdSkin.UpdatedAt,
+			SimilarToID:    createdSkin.SimilarToID,
 		}
 	} else {
 		skinDTO, xErr = l.buildSkinDTO(ctx, createdSkin)
@@ -627,6 +740,12 @@ func (l *LibraryLogic) UpdateSkin(ctx context.Context, userID xSnowflake.Snowfla
 	if xErr != nil {
 		return nil, xErr
 	}
+	if skin.IsPublic || updatedSkin.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+	if !skin.IsPublic {
+		l.flagSimilarSkin(ctx, updatedSkin)
+	}
 	return l.buildSkinDTO(ctx, updatedSkin)
 }
 
@@ -666,9 +785,18 @@ func (l *LibraryLogic) DeleteSkin(ctx context.Context, userID xSnowflake.Snowfla
 	if xErr != nil {
 		return xErr
 	}
+	if skin.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+
+	// 仍被其他用户（赠送/收藏）引用时记录保留，此时不能清理纹理文件
+	_, stillExists, xErr := l.repo.skinRepo.GetByID(ctx, nil, skinID)
+	if xErr != nil || stillExists {
+		return nil
+	}
 
-	// DB 删除成功后同步清理 Bucket 中的文件
-	l.deleteBucketFile(ctx, skin.Texture)
+	// DB 删除成功后同步清理纹理版本及不再被引用的 Bucket 文件
+	l.releaseLibraryTextures(ctx, entityType.LibraryKindSkin, skinID, skin.Texture, skin.TextureHash)
 	return nil
 }
 
@@ -737,9 +865,10 @@ func (l *LibraryLogic) ListMySkinsSimple(ctx context.Context, userID xSnowflake.
 //  1. 校验用户 ID 有效性
 //  2. 校验并规范化披风名称
 //  3. 解码 Base64 纹理数据
-//  4. 计算 SHA256 纹理哈希（用于去重）
+//  4. 计算 SHA256 纹理哈希（用于去重）与感知哈希（用于近似重复检测）
 //  5. 上传纹理到对象存储（事务外执行）
 //  6. 委托 Repository 层在事务内完成：配额检查 → 哈希去重 → 记录创建 → 关联创建 → 配额扣减
+//  7. 公开披风检测是否与已公开披风近似重复，供审核参考
 func (l *LibraryLogic) CreateCape(ctx context.Context, userID xSnowflake.SnowflakeID, name string, texture string, isPublic *bool) (*models.CapeDTO, *xError.Error) {
 	l.log.Info(ctx, "CreateCape - 创建披风")
 
@@ -757,36 +886,36 @@ func (l *LibraryLogic) CreateCape(ctx context.Context, userID xSnowflake.Snowfla
 		return nil, xErr
 	}
 	textureHash := l.calculateTextureHash(textureData)
+	perceptualHash := calculatePerceptualHash(textureData)
 
 	// 上传到对象存储（事务外执行，避免长事务占用连接）
-	capeBucketId := xEnv.GetEnvString(bConst.EnvBucketCapeBucketId, "")
-	capePathId := xEnv.GetEnvString(bConst.EnvBucketCapePathId, "")
-	if capeBucketId == "" || capePathId == "" {
-		return nil, xError.NewError(ctx, xError.ServerInternalError, "披风纹理存储配置缺失，请联系管理员", true)
-	}
-	uploadResp, err := l.helper.bucket.Normal.Upload(ctx, &bBucketApi.UploadRequest{
-		BucketId:      capeBucketId,
-		PathId:        capePathId,
-		ContentBase64: texture,
-	})
+	uploadResp, err := l.helper.storage.Upload(ctx, bStorage.CategoryCape, texture)
 	if err != nil {
-		return nil, mapBucketError(ctx, "上传披风纹理失败", err)
+		return nil, mapStorageError(ctx, "上传披风纹理失败", err)
 	}
 
-	capeId, err := strconv.ParseInt(uploadResp.FileId, 10, 64)
+	capeId, err := strconv.ParseInt(uploadResp.FileID, 10, 64)
 	if err != nil {
 		return nil, xError.NewError(ctx, xError.ServerInternalError, "解析纹理文件 ID 失败", true, err)
 	}
+	registerStorageFile(ctx, l.repo.storageRepo, l.log, entityType.StorageFileSourceCapeTexture, capeId)
 	isPublicVal := false
 	if isPublic != nil {
 		isPublicVal = *isPublic
 	}
+	// 公开资源需经管理员审核后才对其他玩家可见
+	reviewStatus := entityType.ReviewStatusApproved
+	if isPublicVal {
+		reviewStatus = entityType.ReviewStatusPending
+	}
 	cape := &entity.CapeLibrary{
-		UserID:      &userID,
-		Name:        validatedName,
-		Texture:     capeId,
-		TextureHash: textureHash,
-		IsPublic:    isPublicVal,
+		UserID:         &userID,
+		Name:           validatedName,
+		Texture:        capeId,
+		TextureHash:    textureHash,
+		PerceptualHash: perceptualHash,
+		IsPublic:       isPublicVal,
+		ReviewStatus:   reviewStatus,
 	}
 
 	// 委托 Repository 层在事务内完成创建、关联与配额操作
@@ -794,21 +923,27 @@ func (l *LibraryLogic) CreateCape(ctx context.Context, userID xSnowflake.Snowfla
 	if xErr != nil {
 		return nil, xErr
 	}
+	if createdCape.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
+	l.flagSimilarCape(ctx, createdCape)
 
 	// 事务成功后确认文件转为永久态（必须在 DB 写入成功后调用）
-	l.cacheVerifyFile(ctx, uploadResp.FileId)
+	l.cacheVerifyFile(ctx, uploadResp.FileID)
 
-	// 直接从 UploadResponse 构建 DTO，复用上传返回的下载链接，避免冗余 Get 调用
+	// 直接复用上传返回的下载链接构建 DTO，避免冗余 Get 调用
 	var capeDTO *models.CapeDTO
-	if uploadResp.GetObj() != nil && uploadResp.GetObj().GetLink() != "" {
+	if uploadResp.Link != "" {
 		capeDTO = &models.CapeDTO{
-			ID:          createdCape.ID,
-			UserID:      createdCape.UserID,
-			Name:        createdCape.Name,
-			TextureURL:  uploadResp.GetObj().GetLink(),
-			TextureHash: createdCape.TextureHash,
-			IsPublic:    createdCape.IsPublic,
-			UpdatedAt:   createdCape.UpdatedAt,
+			ID:             createdCape.ID,
+			UserID:         createdCape.UserID,
+			Name:           createdCape.Name,
+			TextureURL:     uploadResp.Link,
+			TextureHash:    createdCape.TextureHash,
+			TextureVersion: createdCape.TextureVersion,
+			IsPublic:       createdCape.IsPublic,
+			UpdatedAt:      createdCape.UpdatedAt,
+			SimilarToID:    createdCape.SimilarToID,
 		}
 	} else {
 		capeDTO, xErr = l.buildCapeDTO(ctx, createdCape)
@@ -879,6 +1014,12 @@ func (l *LibraryLogic) UpdateCape(ctx context.Context, userID xSnowflake.Snowfla
 	if xErr != nil {
 		return nil, xErr
 	}
+	if cape.IsPublic || updatedCape.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
+	if !cape.IsPublic {
+		l.flagSimilarCape(ctx, updatedCape)
+	}
 	return l.buildCapeDTO(ctx, updatedCape)
 }
 
@@ -918,9 +1059,18 @@ func (l *LibraryLogic) DeleteCape(ctx context.Context, userID xSnowflake.Snowfla
 	if xErr != nil {
 		return xErr
 	}
+	if cape.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
 
-	// DB 删除成功后同步清理 Bucket 中的文件
-	l.deleteBucketFile(ctx, cape.Texture)
+	// 仍被其他用户（赠送/收藏）引用时记录保留，此时不能清理纹理文件
+	_, stillExists, xErr := l.repo.capeRepo.GetByID(ctx, nil, capeID)
+	if xErr != nil || stillExists {
+		return nil
+	}
+
+	// DB 删除成功后同步清理纹理版本及不再被引用的 Bucket 文件
+	l.releaseLibraryTextures(ctx, entityType.LibraryKindCape, capeID, cape.Texture, cape.TextureHash)
 	return nil
 }
 
@@ -1003,7 +1153,9 @@ func (l *LibraryLogic) GetQuota(ctx context.Context, userID xSnowflake.Snowflake
 // ==================== Admin Logic ====================
 
 // GiftSkin 管理员向用户赠送皮肤。
-func (l *LibraryLogic) GiftSkin(ctx context.Context, operatorID xSnowflake.SnowflakeID, targetUserID xSnowflake.SnowflakeID, skinLibraryID xSnowflake.SnowflakeID, assignmentType entityType.AssignmentType) (*models.SkinDTO, *xError.Error) {
+//
+// expiresAt 非空时为限时赠送，到期后由定时任务自动收回并卸下装备。
+func (l *LibraryLogic) GiftSkin(ctx context.Context, operatorID xSnowflake.SnowflakeID, targetUserID xSnowflake.SnowflakeID, skinLibraryID xSnowflake.SnowflakeID, assignmentType entityType.AssignmentType, expiresAt *time.Time) (*models.SkinDTO, *xError.Error) {
 	l.log.Info(ctx, "GiftSkin - 管理员赠送皮肤")
 
 	if !assignmentType.IsValid() {
@@ -1012,11 +1164,18 @@ func (l *LibraryLogic) GiftSkin(ctx context.Context, operatorID xSnowflake.Snowf
 	if assignmentType == entityType.AssignmentTypeNormal {
 		return nil, xError.NewError(ctx, xError.ParameterError, "管理员赠送不能使用 Normal 类型", true)
 	}
+	if assignmentType == entityType.AssignmentTypeCollect || assignmentType == entityType.AssignmentTypeShare {
+		return nil, xError.NewError(ctx, xError.ParameterError, "管理员赠送不能使用 Collect / Share 类型", true)
+	}
 	if operatorID == targetUserID {
 		return nil, xError.NewError(ctx, xError.ParameterError, "不能向自己赠送资源", true)
 	}
 
-	result, xErr := l.repo.txn.GiftSkinToUser(ctx, targetUserID, skinLibraryID, assignmentType)
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, xError.NewError(ctx, xError.ParameterError, "到期时间必须晚于当前时间", true)
+	}
+
+	result, xErr := l.repo.txn.GiftSkinToUser(ctx, targetUserID, skinLibraryID, assignmentType, expiresAt)
 	if xErr != nil {
 		return nil, xErr
 	}
@@ -1035,6 +1194,8 @@ func (l *LibraryLogic) GiftSkin(ctx context.Context, operatorID xSnowflake.Snowf
 		return nil, xErr
 	}
 	skinResp.AssignmentType = result.AssignmentType
+	skinResp.ExpiresAt = result.ExpiresAt
+	skinResp.ExpiresIn = giftExpiresIn(result.ExpiresAt)
 	return skinResp, nil
 }
 
@@ -1046,7 +1207,9 @@ func (l *LibraryLogic) RevokeSkin(ctx context.Context, targetUserID xSnowflake.S
 }
 
 // GiftCape 管理员向用户赠送披风。
-func (l *LibraryLogic) GiftCape(ctx context.Context, operatorID xSnowflake.SnowflakeID, targetUserID xSnowflake.SnowflakeID, capeLibraryID xSnowflake.SnowflakeID, assignmentType entityType.AssignmentType) (*models.CapeDTO, *xError.Error) {
+//
+// expiresAt 非空时为限时赠送，到期后由定时任务自动收回并卸下装备。
+func (l *LibraryLogic) GiftCape(ctx context.Context, operatorID xSnowflake.SnowflakeID, targetUserID xSnowflake.SnowflakeID, capeLibraryID xSnowflake.SnowflakeID, assignmentType entityType.AssignmentType, expiresAt *time.Time) (*models.CapeDTO, *xError.Error) {
 	l.log.Info(ctx, "GiftCape - 管理员赠送披风")
 
 	if !assignmentType.IsValid() {
@@ -1055,11 +1218,18 @@ func (l *LibraryLogic) GiftCape(ctx context.Context, operatorID xSnowflake.Snowf
 	if assignmentType == entityType.AssignmentTypeNormal {
 		return nil, xError.NewError(ctx, xError.ParameterError, "管理员赠送不能使用 Normal 类型", true)
 	}
+	if assignmentType == entityType.AssignmentTypeCollect || assignmentType == entityType.AssignmentTypeShare {
+		return nil, xError.NewError(ctx, xError.ParameterError, "管理员赠送不能使用 Collect / Share 类型", true)
+	}
 	if operatorID == targetUserID {
 		return nil, xError.NewError(ctx, xError.ParameterError, "不能向自己赠送资源", true)
 	}
 
-	result, xErr := l.repo.txn.GiftCapeToUser(ctx, targetUserID, capeLibraryID, assignmentType)
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, xError.NewError(ctx, xError.ParameterError, "到期时间必须晚于当前时间", true)
+	}
+
+	result, xErr := l.repo.txn.GiftCapeToUser(ctx, targetUserID, capeLibraryID, assignmentType, expiresAt)
 	if xErr != nil {
 		return nil, xErr
 	}
@@ -1078,6 +1248,8 @@ func (l *LibraryLogic) GiftCape(ctx context.Context, operatorID xSnowflake.Snowf
 		return nil, xErr
 	}
 	capeResp.AssignmentType = result.AssignmentType
+	capeResp.ExpiresAt = result.ExpiresAt
+	capeResp.ExpiresIn = giftExpiresIn(result.ExpiresAt)
 	return capeResp, nil
 }
 
@@ -1088,14 +1260,13 @@ func (l *LibraryLogic) RevokeCape(ctx context.Context, targetUserID xSnowflake.S
 	return l.repo.txn.RevokeCapeFromUser(ctx, targetUserID, capeLibraryID)
 }
 
-// RecalculateQuota 重新计算用户配额的 Used 字段。
-func (l *LibraryLogic) RecalculateQuota(ctx context.Context, userID xSnowflake.SnowflakeID) *xError.Error {
+// RecalculateQuota 重新计算用户配额的 Used 字段，与实际数量存在偏差的分项会写入配额日志。
+func (l *LibraryLogic) RecalculateQuota(ctx context.Context, operatorID xSnowflake.SnowflakeID, userID xSnowflake.SnowflakeID) *xError.Error {
 	l.log.Info(ctx, "RecalculateQuota - 重算配额")
 
-	return l.repo.txn.RecalculateQuota(ctx, userID)
+	return l.repo.txn.RecalculateQuota(ctx, userID, &operatorID)
 }
 
-
 // ListUserSkins 查询指定用户的皮肤关联列表（管理员视角）。
 func (l *LibraryLogic) ListUserSkins(ctx context.Context, userID xSnowflake.SnowflakeID, page int, pageSize int) ([]models.SkinDTO, int64, *xError.Error) {
 	l.log.Info(ctx, "ListUserSkins - 查询用户皮肤关联")
@@ -1150,7 +1321,9 @@ func (l *LibraryLogic) validateCapeName(ctx context.Context, name string) (strin
 	return trimmedName, nil
 }
 
-// decodeBase64Texture 解码 Base64 编码的纹理数据。
+// decodeBase64Texture 解码 Base64 编码的纹理数据，并校验其为尺寸合法的 PNG 纹理。
+//
+// 解码前按编码长度估算大小，超过 libraryTextureMaxSize 时直接拒绝，避免为超大输入分配内存。
 func (l *LibraryLogic) decodeBase64Texture(ctx context.Context, texture string) ([]byte, *xError.Error) {
 	base64Data := texture
 	if strings.HasPrefix(texture, "data:") {
@@ -1161,15 +1334,62 @@ func (l *LibraryLogic) decodeBase64Texture(ctx context.Context, texture string)
 		base64Data = texture[idx+len("base64,"):]
 	}
 
+	if base64.StdEncoding.DecodedLen(len(base64Data)) > libraryTextureMaxSize+2 {
+		return nil, xError.NewError(ctx, xError.ParameterError, "纹理文件不能超过 1 MiB", true)
+	}
 	data, err := base64.StdEncoding.DecodeString(base64Data)
 	if err != nil {
 		return nil, xError.NewError(ctx, xError.ParameterError, "无效的 base64 纹理数据", true, err)
 	}
+	if len(data) > libraryTextureMaxSize {
+		return nil, xError.NewError(ctx, xError.ParameterError, "纹理文件不能超过 1 MiB", true)
+	}
+
+	if xErr := validateTextureImage(ctx, data); xErr != nil {
+		return nil, xErr
+	}
 	return data, nil
 }
 
+// validateTextureImage 仅解析 PNG 头部校验纹理尺寸，不解码像素数据。
+func validateTextureImage(ctx context.Context, data []byte) *xError.Error {
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return xError.NewError(ctx, xError.ParameterError, "无法解析纹理 PNG", true, err)
+	}
+	if !isTextureSizeAllowed(config.Width, config.Height) {
+		return xError.NewError(ctx, xError.ParameterError, xError.ErrMessage(fmt.Sprintf("无效纹理尺寸 %dx%d：需为 64x64、64x32 或其整数倍，宽度不超过 %d", config.Width, config.Height, libraryTextureMaxDimension)), true)
+	}
+	return nil
+}
+
+// isTextureSizeAllowed 判断纹理尺寸是否合法：宽度为 64 的整数倍且不超过 libraryTextureMaxDimension，
+// 高度等于宽度（皮肤）或宽度的一半（旧版皮肤与披风）。
+func isTextureSizeAllowed(width int, height int) bool {
+	if width < 64 || width > libraryTextureMaxDimension || width%64 != 0 {
+		return false
+	}
+	return height == width || height*2 == width
+}
+
 // calculateTextureHash 计算纹理数据的 SHA256 哈希值。
 func (l *LibraryLogic) calculateTextureHash(data []byte) string {
 	hash := sha256.Sum256(data)
 	return hex.EncodeToString(hash[:])
 }
+
+// libraryTagNames 提取标签名称列表，未预加载时返回空切片。
+func libraryTagNames(tags []entity.LibraryTag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// invalidateGallery 使指定资源种类的画廊缓存失效，失败仅记录警告不阻断主流程。
+func (l *LibraryLogic) invalidateGallery(ctx context.Context, kind entityType.LibraryKind) {
+	if err := l.repo.galleryCache.Invalidate(ctx, strings.ToLower(kind.String())); err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("画廊缓存失效失败: %v", err))
+	}
+}
@@ -0,0 +1,344 @@
+package logic
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	apiLibrary "github.com/frontleaves-mc/frontleaves-yggleaf/api/library"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
+)
+
+const (
+	libraryArchiveMaxSize       = 32 << 20         // 导入压缩包大小上限（32 MiB）
+	libraryArchiveMaxEntries    = 100              // 单次导入 PNG 条目数量上限
+	libraryArchiveMaxEntrySize  = 1 << 20          // 单个 PNG 大小上限（1 MiB，导入解压与导出读取共用）
+	libraryArchiveManifestName  = "manifest.json"  // 清单文件名（位于压缩包根目录）
+	libraryArchiveManifestLimit = 1 << 20          // 清单文件大小上限（1 MiB）
+	libraryArchiveVersion       = 1                // 当前清单格式版本
+	textureFetchTimeout         = 30 * time.Second // 从外部下载单个纹理文件的超时时间
+)
+
+// pngSignature PNG 文件头魔数。
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+// ImportArchive 从 zip 压缩包批量导入皮肤与披风。
+//
+// 压缩包根目录可选携带 manifest.json 指定每个 PNG 的种类、名称、模型与公开状态；
+// 未在清单中声明的 PNG 按目录推断种类（capes/ 下为披风，其余为皮肤），以文件名作为名称，
+// 默认经典模型、私有。每个条目独立走 CreateSkin / CreateCape 的去重与配额流程，
+// 单条失败不影响其他条目，返回逐条处理结果。
+func (l *LibraryLogic) ImportArchive(ctx context.Context, userID xSnowflake.SnowflakeID, reader io.ReaderAt, size int64) ([]models.LibraryImportResultDTO, *xError.Error) {
+	l.log.Info(ctx, "ImportArchive - 导入衣柜压缩包")
+
+	if size <= 0 || size > libraryArchiveMaxSize {
+		return nil, xError.NewError(ctx, xError.ParameterError, "压缩包大小必须在 1 字节到 32 MiB 之间", true)
+	}
+
+	zr, err := zip.NewReader(reader, size)
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效的 zip 压缩包", true, err)
+	}
+
+	manifest, xErr := l.readArchiveManifest(ctx, zr)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	pngFiles := make([]*zip.File, 0, len(zr.File))
+	for _, file := range zr.File {
+		if isArchiveTextureFile(file) {
+			pngFiles = append(pngFiles, file)
+		}
+	}
+	if len(pngFiles) == 0 {
+		return nil, xError.NewError(ctx, xError.ParameterError, "压缩包中没有 PNG 文件", true)
+	}
+	if len(pngFiles) > libraryArchiveMaxEntries {
+		return nil, xError.NewError(ctx, xError.ParameterError, "压缩包中的 PNG 文件数量不能超过 100 个", true)
+	}
+
+	results := make([]models.LibraryImportResultDTO, 0, len(pngFiles))
+	seen := make(map[string]bool, len(pngFiles))
+	for _, file := range pngFiles {
+		seen[file.Name] = true
+		entry, ok := manifest[file.Name]
+		if !ok {
+			entry = defaultArchiveEntry(file.Name)
+		}
+		results = append(results, l.importArchiveEntry(ctx, userID, file, entry))
+	}
+
+	// 清单中声明但压缩包内不存在的文件同样计入结果，便于用户排查
+	for name, entry := range manifest {
+		if !seen[name] {
+			results = append(results, models.LibraryImportResultDTO{
+				File:    name,
+				Kind:    entry.Kind,
+				Status:  models.LibraryImportStatusFailed,
+				Message: "压缩包中不存在该文件",
+			})
+		}
+	}
+	return results, nil
+}
+
+// ListArchiveExportEntries 列出用户可导出的衣柜条目（自主上传、赠送与系统分配，不含收藏与分享）。
+//
+// 在写出压缩包前完成全部数据库查询，确保错误能以普通响应返回；纹理内容在写出时通过存储接口读取。
+func (l *LibraryLogic) ListArchiveExportEntries(ctx context.Context, userID xSnowflake.SnowflakeID) ([]models.LibraryExportEntryDTO, *xError.Error) {
+	l.log.Info(ctx, "ListArchiveExportEntries - 列出衣柜导出条目")
+
+	skinAssocs, xErr := l.repo.userSkinRepo.ListAllByUserID(ctx, nil, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	capeAssocs, xErr := l.repo.userCapeRepo.ListAllByUserID(ctx, nil, userID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	entries := make([]models.LibraryExportEntryDTO, 0, len(skinAssocs)+len(capeAssocs))
+	for _, assoc := range skinAssocs {
+		if assoc.SkinLibrary == nil || assoc.AssignmentType == entityType.AssignmentTypeCollect || assoc.AssignmentType == entityType.AssignmentTypeShare {
+			continue
+		}
+		skin := assoc.SkinLibrary
+		entries = append(entries, models.LibraryExportEntryDTO{
+			File:           "skins/" + skin.ID.String() + ".png",
+			Kind:           entityType.LibraryKindSkin,
+			Name:           skin.Name,
+			Model:          skin.Model,
+			IsPublic:       skin.IsPublic,
+			AssignmentType: assoc.AssignmentType,
+			Texture:        skin.Texture,
+		})
+	}
+	for _, assoc := range capeAssocs {
+		if assoc.CapeLibrary == nil || assoc.AssignmentType == entityType.AssignmentTypeCollect || assoc.AssignmentType == entityType.AssignmentTypeShare {
+			continue
+		}
+		cape := assoc.CapeLibrary
+		entries = append(entries, models.LibraryExportEntryDTO{
+			File:           "capes/" + cape.ID.String() + ".png",
+			Kind:           entityType.LibraryKindCape,
+			Name:           cape.Name,
+			IsPublic:       cape.IsPublic,
+			AssignmentType: assoc.AssignmentType,
+			Texture:        cape.Texture,
+		})
+	}
+
+	return entries, nil
+}
+
+// WriteArchive 将导出条目以 zip 格式流式写出，清单文件写在最后。
+//
+// 读取失败的纹理会被跳过并从清单中剔除；返回的 error 仅用于记录日志，
+// 因为响应体此时已经开始写出，无法再改为错误响应。
+func (l *LibraryLogic) WriteArchive(ctx context.Context, entries []models.LibraryExportEntryDTO, writer io.Writer) error {
+	l.log.Info(ctx, "WriteArchive - 写出衣柜压缩包")
+
+	zw := zip.NewWriter(writer)
+	manifest := apiLibrary.ArchiveManifest{
+		Version: libraryArchiveVersion,
+		Entries: make([]apiLibrary.ArchiveManifestEntry, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		if entry.Texture == 0 {
+			continue
+		}
+		data, err := l.fetchTexture(ctx, entry.Texture)
+		if err != nil {
+			l.log.Warn(ctx, fmt.Sprintf("导出纹理读取失败，已跳过(file=%s): %v", entry.File, err))
+			continue
+		}
+
+		fw, err := zw.Create(entry.File)
+		if err != nil {
+			return fmt.Errorf("创建压缩包条目失败: %w", err)
+		}
+		if _, err := fw.Write(data); err != nil {
+			return fmt.Errorf("写入压缩包条目失败: %w", err)
+		}
+
+		manifest.Entries = append(manifest.Entries, apiLibrary.ArchiveManifestEntry{
+			File:           entry.File,
+			Kind:           entry.Kind,
+			Name:           entry.Name,
+			Model:          uint8(entry.Model),
+			IsPublic:       entry.IsPublic,
+			AssignmentType: entry.AssignmentType,
+		})
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化清单失败: %w", err)
+	}
+	fw, err := zw.Create(libraryArchiveManifestName)
+	if err != nil {
+		return fmt.Errorf("创建清单条目失败: %w", err)
+	}
+	if _, err := fw.Write(manifestData); err != nil {
+		return fmt.Errorf("写入清单失败: %w", err)
+	}
+	return zw.Close()
+}
+
+// importArchiveEntry 导入单个 PNG 条目，任何失败都转换为该条目的失败结果。
+func (l *LibraryLogic) importArchiveEntry(ctx context.Context, userID xSnowflake.SnowflakeID, file *zip.File, entry apiLibrary.ArchiveManifestEntry) models.LibraryImportResultDTO {
+	result := models.LibraryImportResultDTO{
+		File:   file.Name,
+		Kind:   entry.Kind,
+		Status: models.LibraryImportStatusFailed,
+	}
+	if !entry.Kind.IsValid() {
+		result.Message = "无效资源种类"
+		return result
+	}
+
+	data, err := readArchiveFile(file, libraryArchiveMaxEntrySize)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	if !bytes.HasPrefix(data, pngSignature) {
+		result.Message = "不是有效的 PNG 文件"
+		return result
+	}
+	texture := base64.StdEncoding.EncodeToString(data)
+	isPublic := entry.IsPublic
+
+	var id xSnowflake.SnowflakeID
+	var xErr *xError.Error
+	if entry.Kind == entityType.LibraryKindCape {
+		var cape *models.CapeDTO
+		cape, xErr = l.CreateCape(ctx, userID, entry.Name, texture, &isPublic)
+		if xErr == nil {
+			id = cape.ID
+		}
+	} else {
+		var skin *models.SkinDTO
+		skin, xErr = l.CreateSkin(ctx, userID, entry.Name, entry.Model, texture, &isPublic)
+		if xErr == nil {
+			id = skin.ID
+		}
+	}
+	if xErr != nil {
+		result.Message = string(xErr.ErrorMessage)
+		return result
+	}
+
+	result.Status = models.LibraryImportStatusCreated
+	result.ID = &id
+	return result
+}
+
+// readArchiveManifest 读取并解析压缩包根目录的清单文件，不存在时返回空清单。
+func (l *LibraryLogic) readArchiveManifest(ctx context.Context, zr *zip.Reader) (map[string]apiLibrary.ArchiveManifestEntry, *xError.Error) {
+	entries := make(map[string]apiLibrary.ArchiveManifestEntry)
+	for _, file := range zr.File {
+		if file.Name != libraryArchiveManifestName {
+			continue
+		}
+
+		data, err := readArchiveFile(file, libraryArchiveManifestLimit)
+		if err != nil {
+			return nil, xError.NewError(ctx, xError.ParameterError, "读取 manifest.json 失败", true, err)
+		}
+		var manifest apiLibrary.ArchiveManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, xError.NewError(ctx, xError.ParameterError, "无效的 manifest.json 格式", true, err)
+		}
+		for _, entry := range manifest.Entries {
+			if entry.Kind == 0 {
+				entry.Kind = defaultArchiveEntry(entry.File).Kind
+			}
+			if entry.Kind == entityType.LibraryKindSkin && entry.Model == 0 {
+				entry.Model = uint8(entity.ModelTypeClassic)
+			}
+			if strings.TrimSpace(entry.Name) == "" {
+				entry.Name = defaultArchiveEntry(entry.File).Name
+			}
+			entries[entry.File] = entry
+		}
+		break
+	}
+	if len(entries) > libraryArchiveMaxEntries {
+		return nil, xError.NewError(ctx, xError.ParameterError, "manifest.json 条目数量不能超过 100 个", true)
+	}
+	return entries, nil
+}
+
+// fetchTexture 通过存储接口读取纹理文件内容，超过单个 PNG 大小上限时返回错误（该条目被跳过）。
+func (l *LibraryLogic) fetchTexture(ctx context.Context, texture int64) ([]byte, error) {
+	data, err := l.helper.storage.Read(ctx, strconv.FormatInt(texture, 10), libraryArchiveMaxEntrySize)
+	if errors.Is(err, bStorage.ErrTooLarge) {
+		return nil, fmt.Errorf("纹理文件超过 %d 字节上限", libraryArchiveMaxEntrySize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取纹理文件失败: %w", err)
+	}
+	return data, nil
+}
+
+// defaultArchiveEntry 为未在清单中声明的 PNG 推断默认导入参数。
+func defaultArchiveEntry(name string) apiLibrary.ArchiveManifestEntry {
+	kind := entityType.LibraryKindSkin
+	if strings.HasPrefix(strings.ToLower(name), "capes/") {
+		kind = entityType.LibraryKindCape
+	}
+	return apiLibrary.ArchiveManifestEntry{
+		File:  name,
+		Kind:  kind,
+		Name:  strings.TrimSuffix(path.Base(name), path.Ext(name)),
+		Model: uint8(entity.ModelTypeClassic),
+	}
+}
+
+// isArchiveTextureFile 判断压缩包条目是否为需要导入的 PNG 文件（忽略目录、隐藏文件与 macOS 元数据）。
+func isArchiveTextureFile(file *zip.File) bool {
+	if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") {
+		return false
+	}
+	base := path.Base(file.Name)
+	return !strings.HasPrefix(base, ".") && strings.EqualFold(path.Ext(base), ".png")
+}
+
+// readArchiveFile 读取压缩包条目内容，超过 limit 字节时返回错误以防御压缩炸弹。
+func readArchiveFile(file *zip.File, limit int64) ([]byte, error) {
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("文件大小超过 %d 字节上限", limit)
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("打开压缩包条目失败: %w", err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("读取压缩包条目失败: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("文件大小超过 %d 字节上限", limit)
+	}
+	return data, nil
+}
@@ -0,0 +1,440 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
+	xAsync "github.com/bamboo-services/bamboo-base-go/plugins/async"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+)
+
+const (
+	campaignNameMaxLength   = 64               // 活动名称最大长度（字符）
+	campaignUserIDsMaxCount = 10000            // 指定用户 ID 列表最大数量
+	campaignBatchSize       = 100              // 后台任务每批处理的用户数
+	campaignStaleAfter      = 10 * time.Minute // 执行中的活动超过该时长无进度即视为中断，可重新启动
+	campaignErrorMaxLength  = 255              // 中断/失败原因最大长度（字符）
+)
+
+// CreateCampaign 创建资源发放活动并立即在后台开始执行（管理员专用）。
+//
+// 活动按筛选条件匹配用户，逐个以 (活动, 用户) 为幂等键发放指定皮肤/披风；
+// 已拥有该资源的用户记为跳过，单个用户发放失败不会中断整个活动。
+func (l *LibraryLogic) CreateCampaign(
+	ctx context.Context,
+	operatorID xSnowflake.SnowflakeID,
+	name string,
+	kind entityType.LibraryKind,
+	libraryID xSnowflake.SnowflakeID,
+	assignmentType entityType.AssignmentType,
+	filter models.LibraryCampaignFilterDTO,
+) (*models.LibraryCampaignDTO, *xError.Error) {
+	l.log.Info(ctx, "CreateCampaign - 创建资源发放活动")
+
+	trimmedName := strings.TrimSpace(name)
+	if trimmedName == "" || utf8.RuneCountInString(trimmedName) > campaignNameMaxLength {
+		return nil, xError.NewError(ctx, xError.ParameterError, "活动名称长度需在 1-64 个字符之间", true)
+	}
+	if !kind.IsValid() {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效的资源种类", true)
+	}
+	if assignmentType != entityType.AssignmentTypeGift && assignmentType != entityType.AssignmentTypeAdmin {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效的分配类型，仅支持 2=gift 或 3=admin", true)
+	}
+	if filter.RegisteredAfter != nil && filter.RegisteredBefore != nil && filter.RegisteredAfter.After(*filter.RegisteredBefore) {
+		return nil, xError.NewError(ctx, xError.ParameterError, "注册时间下限不能晚于上限", true)
+	}
+	if len(filter.UserIDs) > campaignUserIDsMaxCount {
+		return nil, xError.NewError(ctx, xError.ParameterError, "指定用户数量不能超过 10000", true)
+	}
+	if filter.RoleName != nil {
+		roleName := strings.TrimSpace(*filter.RoleName)
+		if roleName == "" {
+			filter.RoleName = nil
+		} else {
+			filter.RoleName = &roleName
+		}
+	}
+
+	// 校验发放资源存在且未停用
+	if xErr := l.checkCampaignTarget(ctx, kind, libraryID); xErr != nil {
+		return nil, xErr
+	}
+
+	campaign, xErr := l.repo.campaignRepo.Create(ctx, nil, &entity.LibraryCampaign{
+		Name:                   trimmedName,
+		Kind:                   kind,
+		LibraryID:              libraryID,
+		AssignmentType:         assignmentType,
+		Status:                 entityType.CampaignStatusPending,
+		FilterRoleName:         filter.RoleName,
+		FilterRegisteredAfter:  filter.RegisteredAfter,
+		FilterRegisteredBefore: filter.RegisteredBefore,
+		FilterHasProfile:       filter.HasProfile,
+		FilterUserIDs:          filter.UserIDs,
+		CreatedBy:              operatorID,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	if xErr := l.startCampaign(ctx, campaign.ID); xErr != nil {
+		return nil, xErr
+	}
+	return l.GetCampaign(ctx, campaign.ID)
+}
+
+// ResumeCampaign 恢复执行已中断的资源发放活动（管理员专用）。
+//
+// 仅待执行、已中断，或执行中但超过 10 分钟无进度的活动可以恢复；
+// 后台任务从上次处理到的用户之后继续，已处理过的用户不会重复发放。
+func (l *LibraryLogic) ResumeCampaign(ctx context.Context, campaignID xSnowflake.SnowflakeID) (*models.LibraryCampaignDTO, *xError.Error) {
+	l.log.Info(ctx, "ResumeCampaign - 恢复资源发放活动")
+
+	if _, xErr := l.getCampaign(ctx, campaignID); xErr != nil {
+		return nil, xErr
+	}
+	if xErr := l.startCampaign(ctx, campaignID); xErr != nil {
+		return nil, xErr
+	}
+	return l.GetCampaign(ctx, campaignID)
+}
+
+// RevokeCampaign 撤销资源发放活动，在后台收回本活动发放出去的资源（管理员专用）。
+//
+// 仅已完成或已中断的活动可以撤销；撤销中断后可再次调用继续收回。
+// 用户在活动后自行上传或收藏的同一资源不会被收回。
+func (l *LibraryLogic) RevokeCampaign(ctx context.Context, operatorID xSnowflake.SnowflakeID, campaignID xSnowflake.SnowflakeID) (*models.LibraryCampaignDTO, *xError.Error) {
+	l.log.Info(ctx, "RevokeCampaign - 撤销资源发放活动")
+
+	if _, xErr := l.getCampaign(ctx, campaignID); xErr != nil {
+		return nil, xErr
+	}
+
+	started, xErr := l.repo.campaignRepo.TryStartRevoke(ctx, nil, campaignID, operatorID, time.Now().Add(-campaignStaleAfter))
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !started {
+		return nil, xError.NewError(ctx, xError.DataConflict, "活动当前状态不可撤销，请等待发放完成后再试", true)
+	}
+
+	xAsync.Async(ctx, func(asyncCtx context.Context) {
+		l.runCampaignRevoke(asyncCtx, campaignID)
+	})
+	return l.GetCampaign(ctx, campaignID)
+}
+
+// GetCampaign 获取资源发放活动详情及进度（管理员专用）。
+func (l *LibraryLogic) GetCampaign(ctx context.Context, campaignID xSnowflake.SnowflakeID) (*models.LibraryCampaignDTO, *xError.Error) {
+	l.log.Info(ctx, "GetCampaign - 获取资源发放活动")
+
+	campaign, xErr := l.getCampaign(ctx, campaignID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	dto := buildLibraryCampaignDTO(campaign)
+	return &dto, nil
+}
+
+// ListCampaigns 分页获取资源发放活动列表（管理员专用）。
+func (l *LibraryLogic) ListCampaigns(ctx context.Context, page int, pageSize int) ([]models.LibraryCampaignDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListCampaigns - 获取资源发放活动列表")
+
+	campaigns, total, xErr := l.repo.campaignRepo.List(ctx, nil, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	dtos := make([]models.LibraryCampaignDTO, 0, len(campaigns))
+	for i := range campaigns {
+		dtos = append(dtos, buildLibraryCampaignDTO(&campaigns[i]))
+	}
+	return dtos, total, nil
+}
+
+// ListCampaignGrants 分页获取资源发放活动的用户处理记录（管理员专用），status 为空时不限状态。
+func (l *LibraryLogic) ListCampaignGrants(ctx context.Context, campaignID xSnowflake.SnowflakeID, status *entityType.CampaignGrantStatus, page int, pageSize int) ([]models.LibraryCampaignGrantDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListCampaignGrants - 获取活动用户处理记录")
+
+	if status != nil && !status.IsValid() {
+		return nil, 0, xError.NewError(ctx, xError.ParameterError, "无效的处理结果状态", true)
+	}
+	if _, xErr := l.getCampaign(ctx, campaignID); xErr != nil {
+		return nil, 0, xErr
+	}
+
+	grants, total, xErr := l.repo.campaignRepo.ListGrants(ctx, nil, campaignID, status, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	dtos := make([]models.LibraryCampaignGrantDTO, 0, len(grants))
+	for _, grant := range grants {
+		dtos = append(dtos, models.LibraryCampaignGrantDTO{
+			UserID:    grant.UserID,
+			Status:    grant.Status,
+			Message:   grant.Message,
+			UpdatedAt: grant.UpdatedAt,
+		})
+	}
+	return dtos, total, nil
+}
+
+// ==================== 内部方法 ====================
+
+// getCampaign 查询资源发放活动，不存在时返回 ResourceNotFound。
+func (l *LibraryLogic) getCampaign(ctx context.Context, campaignID xSnowflake.SnowflakeID) (*entity.LibraryCampaign, *xError.Error) {
+	campaign, found, xErr := l.repo.campaignRepo.GetByID(ctx, nil, campaignID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "发放活动不存在", true)
+	}
+	return campaign, nil
+}
+
+// checkCampaignTarget 校验活动发放的资源存在且未停用。
+func (l *LibraryLogic) checkCampaignTarget(ctx context.Context, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) *xError.Error {
+	if kind == entityType.LibraryKindCape {
+		cape, found, xErr := l.repo.capeRepo.GetByID(ctx, nil, libraryID)
+		if xErr != nil {
+			return xErr
+		}
+		if !found {
+			return xError.NewError(ctx, xError.ResourceNotFound, "披风资源不存在", true)
+		}
+		if cape.IsRetired {
+			return xError.NewError(ctx, xError.PermissionDenied, "已停用的系统披风不能再发放", true)
+		}
+		return nil
+	}
+
+	skin, found, xErr := l.repo.skinRepo.GetByID(ctx, nil, libraryID)
+	if xErr != nil {
+		return xErr
+	}
+	if !found {
+		return xError.NewError(ctx, xError.ResourceNotFound, "皮肤资源不存在", true)
+	}
+	if skin.IsRetired {
+		return xError.NewError(ctx, xError.PermissionDenied, "已停用的系统皮肤不能再发放", true)
+	}
+	return nil
+}
+
+// startCampaign 原子地将活动切换为执行中，并启动后台发放任务。
+func (l *LibraryLogic) startCampaign(ctx context.Context, campaignID xSnowflake.SnowflakeID) *xError.Error {
+	started, xErr := l.repo.campaignRepo.TryStart(ctx, nil, campaignID, time.Now().Add(-campaignStaleAfter))
+	if xErr != nil {
+		return xErr
+	}
+	if !started {
+		return xError.NewError(ctx, xError.DataConflict, "活动正在执行或已结束，无法启动", true)
+	}
+
+	xAsync.Async(ctx, func(asyncCtx context.Context) {
+		l.runCampaign(asyncCtx, campaignID)
+	})
+	return nil
+}
+
+// runCampaign 后台发放任务：按用户 ID 升序分批处理匹配用户，每批结束后推进游标与计数。
+//
+// 单个用户发放失败会记录为失败并继续；查询或进度写入失败时中断任务并记录原因，可通过恢复接口继续。
+func (l *LibraryLogic) runCampaign(ctx context.Context, campaignID xSnowflake.SnowflakeID) {
+	campaign, found, xErr := l.repo.campaignRepo.GetByID(ctx, nil, campaignID)
+	if xErr != nil || !found {
+		l.log.Warn(ctx, fmt.Sprintf("发放活动后台任务读取活动失败(campaignID=%d)", campaignID))
+		return
+	}
+
+	userRepo := repository.NewUserRepo(xCtxUtil.MustGetDB(ctx), xCtxUtil.MustGetRDB(ctx))
+	filter := repository.CampaignUserFilter{
+		RoleName:         campaign.FilterRoleName,
+		RegisteredAfter:  campaign.FilterRegisteredAfter,
+		RegisteredBefore: campaign.FilterRegisteredBefore,
+		HasProfile:       campaign.FilterHasProfile,
+		UserIDs:          campaign.FilterUserIDs,
+	}
+
+	// 首次执行时记录匹配用户数
+	if campaign.Cursor == 0 {
+		matched, xErr := userRepo.CountForCampaign(ctx, filter)
+		if xErr != nil {
+			l.failCampaign(ctx, campaignID, entityType.CampaignStatusFailed, xErr)
+			return
+		}
+		if xErr := l.repo.campaignRepo.SetMatchedCount(ctx, nil, campaignID, matched); xErr != nil {
+			l.failCampaign(ctx, campaignID, entityType.CampaignStatusFailed, xErr)
+			return
+		}
+	}
+
+	cursor := campaign.Cursor
+	for {
+		userIDs, xErr := userRepo.ListIDsForCampaign(ctx, filter, cursor, campaignBatchSize)
+		if xErr != nil {
+			l.failCampaign(ctx, campaignID, entityType.CampaignStatusFailed, xErr)
+			return
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+
+		var granted, skipped, failed int64
+		for _, userID := range userIDs {
+			status, processed, xErr := l.repo.txn.GrantCampaignItem(ctx, campaign, userID)
+			if xErr != nil {
+				if l.recordCampaignFailure(ctx, campaignID, userID, xErr) {
+					failed++
+				}
+				continue
+			}
+			if !processed {
+				continue
+			}
+			switch status {
+			case entityType.CampaignGrantStatusGranted:
+				granted++
+			case entityType.CampaignGrantStatusSkipped:
+				skipped++
+			}
+		}
+
+		cursor = userIDs[len(userIDs)-1]
+		if xErr := l.repo.campaignRepo.AdvanceProgress(ctx, nil, campaignID, cursor, granted, skipped, failed); xErr != nil {
+			l.failCampaign(ctx, campaignID, entityType.CampaignStatusFailed, xErr)
+			return
+		}
+		if len(userIDs) < campaignBatchSize {
+			break
+		}
+	}
+
+	if xErr := l.repo.campaignRepo.Finish(ctx, nil, campaignID, entityType.CampaignStatusCompleted, nil); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("发放活动标记完成失败(campaignID=%d): %s", campaignID, xErr.ErrorMessage))
+	}
+}
+
+// runCampaignRevoke 后台撤销任务：分批收回仍处于已发放状态的记录，全部收回后标记为已撤销。
+//
+// 任一记录收回失败即中断并记录原因，活动保持撤销中状态，可再次调用撤销接口继续。
+func (l *LibraryLogic) runCampaignRevoke(ctx context.Context, campaignID xSnowflake.SnowflakeID) {
+	campaign, found, xErr := l.repo.campaignRepo.GetByID(ctx, nil, campaignID)
+	if xErr != nil || !found {
+		l.log.Warn(ctx, fmt.Sprintf("撤销活动后台任务读取活动失败(campaignID=%d)", campaignID))
+		return
+	}
+
+	for {
+		grants, xErr := l.repo.campaignRepo.ListGrantedBatch(ctx, nil, campaignID, campaignBatchSize)
+		if xErr != nil {
+			l.failCampaign(ctx, campaignID, entityType.CampaignStatusRevoking, xErr)
+			return
+		}
+		if len(grants) == 0 {
+			break
+		}
+
+		var revoked int64
+		var revokeErr *xError.Error
+		for i := range grants {
+			if xErr := l.repo.txn.RevokeCampaignGrant(ctx, campaign, &grants[i]); xErr != nil {
+				revokeErr = xErr
+				break
+			}
+			revoked++
+		}
+		if revoked > 0 {
+			if xErr := l.repo.campaignRepo.AddRevoked(ctx, nil, campaignID, revoked); xErr != nil {
+				l.failCampaign(ctx, campaignID, entityType.CampaignStatusRevoking, xErr)
+				return
+			}
+		}
+		if revokeErr != nil {
+			l.failCampaign(ctx, campaignID, entityType.CampaignStatusRevoking, revokeErr)
+			return
+		}
+	}
+
+	if xErr := l.repo.campaignRepo.Finish(ctx, nil, campaignID, entityType.CampaignStatusRevoked, nil); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("发放活动标记已撤销失败(campaignID=%d): %s", campaignID, xErr.ErrorMessage))
+	}
+}
+
+// recordCampaignFailure 记录单个用户的发放失败，返回是否新写入了失败记录。
+func (l *LibraryLogic) recordCampaignFailure(ctx context.Context, campaignID xSnowflake.SnowflakeID, userID xSnowflake.SnowflakeID, cause *xError.Error) bool {
+	message := truncateCampaignError(string(cause.ErrorMessage))
+	inserted, xErr := l.repo.campaignRepo.CreateGrantIfAbsent(ctx, nil, &entity.LibraryCampaignGrant{
+		CampaignID: campaignID,
+		UserID:     userID,
+		Status:     entityType.CampaignGrantStatusFailed,
+		Message:    &message,
+	})
+	if xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("记录活动发放失败(campaignID=%d, userID=%d): %s", campaignID, userID, xErr.ErrorMessage))
+		return false
+	}
+	return inserted
+}
+
+// failCampaign 中断后台任务并记录原因。
+func (l *LibraryLogic) failCampaign(ctx context.Context, campaignID xSnowflake.SnowflakeID, status entityType.CampaignStatus, cause *xError.Error) {
+	message := truncateCampaignError(string(cause.ErrorMessage))
+	l.log.Warn(ctx, fmt.Sprintf("发放活动后台任务中断(campaignID=%d): %s", campaignID, message))
+	if xErr := l.repo.campaignRepo.Finish(ctx, nil, campaignID, status, &message); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("记录发放活动中断原因失败(campaignID=%d): %s", campaignID, xErr.ErrorMessage))
+	}
+}
+
+// truncateCampaignError 截断错误信息以适配数据库字段长度。
+func truncateCampaignError(message string) string {
+	runes := []rune(message)
+	if len(runes) > campaignErrorMaxLength {
+		return string(runes[:campaignErrorMaxLength])
+	}
+	return message
+}
+
+// buildLibraryCampaignDTO 将发放活动实体转换为 DTO。
+func buildLibraryCampaignDTO(campaign *entity.LibraryCampaign) models.LibraryCampaignDTO {
+	return models.LibraryCampaignDTO{
+		ID:             campaign.ID,
+		Name:           campaign.Name,
+		Kind:           campaign.Kind,
+		LibraryID:      campaign.LibraryID,
+		AssignmentType: campaign.AssignmentType,
+		Status:         campaign.Status,
+		Filter: models.LibraryCampaignFilterDTO{
+			RoleName:         campaign.FilterRoleName,
+			RegisteredAfter:  campaign.FilterRegisteredAfter,
+			RegisteredBefore: campaign.FilterRegisteredBefore,
+			HasProfile:       campaign.FilterHasProfile,
+			UserIDs:          campaign.FilterUserIDs,
+		},
+		MatchedCount: campaign.MatchedCount,
+		GrantedCount: campaign.GrantedCount,
+		SkippedCount: campaign.SkippedCount,
+		FailedCount:  campaign.FailedCount,
+		RevokedCount: campaign.RevokedCount,
+		LastError:    campaign.LastError,
+		CreatedBy:    campaign.CreatedBy,
+		StartedAt:    campaign.StartedAt,
+		FinishedAt:   campaign.FinishedAt,
+		RevokedBy:    campaign.RevokedBy,
+		RevokedAt:    campaign.RevokedAt,
+		CreatedAt:    campaign.CreatedAt,
+		UpdatedAt:    campaign.UpdatedAt,
+	}
+}
@@ -0,0 +1,85 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+)
+
+const (
+	giftExpiryBatchSize = 100 // 每批收回的到期关联数
+)
+
+// RevokeExpiredGifts 收回全部已到期的限时赠送皮肤/披风（由定时任务调用）。
+//
+// 逐条在独立事务内删除到期关联并卸下对应游戏档案装备；单条失败仅记录日志，
+// 留待下一轮继续处理。游戏档案纹理在查询时实时组装，卸下装备后即刻生效，无需额外清理缓存。
+// 返回本轮收回的皮肤数与披风数。
+func (l *LibraryLogic) RevokeExpiredGifts(ctx context.Context) (int64, int64, *xError.Error) {
+	l.log.Info(ctx, "RevokeExpiredGifts - 收回到期的限时赠送")
+
+	now := time.Now()
+
+	var skinCount int64
+	for {
+		associations, xErr := l.repo.userSkinRepo.ListExpired(ctx, nil, now, giftExpiryBatchSize)
+		if xErr != nil {
+			return skinCount, 0, xErr
+		}
+		var revokedInBatch int64
+		for i := range associations {
+			revoked, xErr := l.repo.txn.RevokeExpiredSkinGift(ctx, &associations[i], now)
+			if xErr != nil {
+				l.log.Warn(ctx, fmt.Sprintf("收回到期皮肤失败(associationID=%d): %s", associations[i].ID, xErr.ErrorMessage))
+				continue
+			}
+			if revoked {
+				revokedInBatch++
+			}
+		}
+		skinCount += revokedInBatch
+		// 整批均未能收回时停止，避免对失败记录反复重试
+		if len(associations) < giftExpiryBatchSize || revokedInBatch == 0 {
+			break
+		}
+	}
+
+	var capeCount int64
+	for {
+		associations, xErr := l.repo.userCapeRepo.ListExpired(ctx, nil, now, giftExpiryBatchSize)
+		if xErr != nil {
+			return skinCount, capeCount, xErr
+		}
+		var revokedInBatch int64
+		for i := range associations {
+			revoked, xErr := l.repo.txn.RevokeExpiredCapeGift(ctx, &associations[i], now)
+			if xErr != nil {
+				l.log.Warn(ctx, fmt.Sprintf("收回到期披风失败(associationID=%d): %s", associations[i].ID, xErr.ErrorMessage))
+				continue
+			}
+			if revoked {
+				revokedInBatch++
+			}
+		}
+		capeCount += revokedInBatch
+		if len(associations) < giftExpiryBatchSize || revokedInBatch == 0 {
+			break
+		}
+	}
+
+	return skinCount, capeCount, nil
+}
+
+// giftExpiresIn 计算限时赠送距到期的剩余秒数，永久关联返回 nil，已到期返回 0。
+func giftExpiresIn(expiresAt *time.Time) *int64 {
+	if expiresAt == nil {
+		return nil
+	}
+	remaining := int64(time.Until(*expiresAt).Seconds())
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &remaining
+}
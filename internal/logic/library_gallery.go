@@ -0,0 +1,434 @@
+package logic
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+)
+
+const (
+	libraryTagMaxCount  = 10 // 单个资源最多标签数
+	libraryTagMinLength = 1  // 标签最小长度（字符）
+	libraryTagMaxLength = 32 // 标签最大长度（字符）
+
+	galleryKeywordMaxLength = 64 // 画廊搜索关键字最大长度（字符）
+	popularTagDefaultLimit  = 20 // 热门标签默认返回数量
+	popularTagMaxLimit      = 100
+)
+
+// libraryTagPattern 标签合法字符：字母（含中文）、数字、下划线与连字符。
+var libraryTagPattern = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
+// galleryCacheEntry 画廊分页缓存内容。
+type galleryCacheEntry[T any] struct {
+	Total int64 `json:"total"`
+	Items []T   `json:"items"`
+}
+
+// ListSkinGallery 公开皮肤画廊查询。
+//
+// 支持名称搜索、上传者过滤、模型过滤、标签过滤，以及最新/装备最多/收藏最多/点赞最多四种排序。
+// 查询结果按查询条件摘要缓存于 Redis，影响结果集或展示内容的写操作通过版本号整体失效；
+// 点赞、收藏、装备等计数变化不主动失效，计数排序由缓存 TTL 在短时间内收敛。
+func (l *LibraryLogic) ListSkinGallery(ctx context.Context, query models.LibraryGalleryQuery) ([]models.SkinDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListSkinGallery - 查询公开皮肤画廊")
+
+	filter, xErr := l.normalizeGalleryQuery(ctx, &query)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	kind := strings.ToLower(entityType.LibraryKindSkin.String())
+	queryKey := galleryQueryKey(query)
+	if entry, hit := getGalleryCache[models.SkinDTO](ctx, l, kind, queryKey); hit {
+		return entry.Items, entry.Total, nil
+	}
+
+	skins, total, xErr := l.repo.skinRepo.SearchGallery(ctx, nil, filter, query.Page, query.PageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	items, xErr := l.buildSkinDTOs(ctx, skins)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	setGalleryCache(ctx, l, kind, queryKey, galleryCacheEntry[models.SkinDTO]{Total: total, Items: items})
+	return items, total, nil
+}
+
+// ListCapeGallery 公开披风画廊查询。
+//
+// 与 ListSkinGallery 一致，但忽略模型过滤条件。
+func (l *LibraryLogic) ListCapeGallery(ctx context.Context, query models.LibraryGalleryQuery) ([]models.CapeDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListCapeGallery - 查询公开披风画廊")
+
+	query.Model = nil
+	filter, xErr := l.normalizeGalleryQuery(ctx, &query)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	kind := strings.ToLower(entityType.LibraryKindCape.String())
+	queryKey := galleryQueryKey(query)
+	if entry, hit := getGalleryCache[models.CapeDTO](ctx, l, kind, queryKey); hit {
+		return entry.Items, entry.Total, nil
+	}
+
+	capes, total, xErr := l.repo.capeRepo.SearchGallery(ctx, nil, filter, query.Page, query.PageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	items, xErr := l.buildCapeDTOs(ctx, capes)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	setGalleryCache(ctx, l, kind, queryKey, galleryCacheEntry[models.CapeDTO]{Total: total, Items: items})
+	return items, total, nil
+}
+
+// LikeSkin 点赞公开皮肤。
+func (l *LibraryLogic) LikeSkin(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) (*models.LibraryLikeDTO, *xError.Error) {
+	l.log.Info(ctx, "LikeSkin - 点赞皮肤")
+	return l.toggleLike(ctx, userID, entityType.LibraryKindSkin, skinID, true)
+}
+
+// UnlikeSkin 取消点赞皮肤。
+func (l *LibraryLogic) UnlikeSkin(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) (*models.LibraryLikeDTO, *xError.Error) {
+	l.log.Info(ctx, "UnlikeSkin - 取消点赞皮肤")
+	return l.toggleLike(ctx, userID, entityType.LibraryKindSkin, skinID, false)
+}
+
+// LikeCape 点赞公开披风。
+func (l *LibraryLogic) LikeCape(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) (*models.LibraryLikeDTO, *xError.Error) {
+	l.log.Info(ctx, "LikeCape - 点赞披风")
+	return l.toggleLike(ctx, userID, entityType.LibraryKindCape, capeID, true)
+}
+
+// UnlikeCape 取消点赞披风。
+func (l *LibraryLogic) UnlikeCape(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) (*models.LibraryLikeDTO, *xError.Error) {
+	l.log.Info(ctx, "UnlikeCape - 取消点赞披风")
+	return l.toggleLike(ctx, userID, entityType.LibraryKindCape, capeID, false)
+}
+
+// SetSkinTags 整体替换皮肤标签，仅资源创建者可操作。
+func (l *LibraryLogic) SetSkinTags(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID, tags []string) (*models.SkinDTO, *xError.Error) {
+	l.log.Info(ctx, "SetSkinTags - 设置皮肤标签")
+
+	skin, found, xErr := l.repo.skinRepo.GetByID(ctx, nil, skinID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "皮肤不存在", true)
+	}
+	if skin.UserID == nil || *skin.UserID != userID {
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "只有资源创建者可以设置标签", true)
+	}
+
+	names, xErr := l.normalizeLibraryTags(ctx, tags)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if xErr := l.repo.txn.SetLibraryTags(ctx, entityType.LibraryKindSkin, skinID, names); xErr != nil {
+		return nil, xErr
+	}
+	if skin.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+
+	dto, xErr := l.buildSkinDTO(ctx, skin)
+	if xErr != nil {
+		return nil, xErr
+	}
+	dto.Tags = names
+	return dto, nil
+}
+
+// SetCapeTags 整体替换披风标签，仅资源创建者可操作。
+func (l *LibraryLogic) SetCapeTags(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID, tags []string) (*models.CapeDTO, *xError.Error) {
+	l.log.Info(ctx, "SetCapeTags - 设置披风标签")
+
+	cape, found, xErr := l.repo.capeRepo.GetByID(ctx, nil, capeID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "披风不存在", true)
+	}
+	if cape.UserID == nil || *cape.UserID != userID {
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "只有资源创建者可以设置标签", true)
+	}
+
+	names, xErr := l.normalizeLibraryTags(ctx, tags)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if xErr := l.repo.txn.SetLibraryTags(ctx, entityType.LibraryKindCape, capeID, names); xErr != nil {
+		return nil, xErr
+	}
+	if cape.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
+
+	dto, xErr := l.buildCapeDTO(ctx, cape)
+	if xErr != nil {
+		return nil, xErr
+	}
+	dto.Tags = names
+	return dto, nil
+}
+
+// CollectSkin 收藏公开皮肤到当前用户的资源库。
+//
+// 收藏以 AssignmentTypeCollect 关联记录，不计入配额；皮肤被上传者转为私有或删除后不再可装备。
+func (l *LibraryLogic) CollectSkin(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) (*models.SkinDTO, *xError.Error) {
+	l.log.Info(ctx, "CollectSkin - 收藏皮肤")
+
+	result, xErr := l.repo.txn.CollectSkin(ctx, userID, skinID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	skinEntity, found, xErr := l.repo.skinRepo.GetByID(ctx, nil, skinID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "皮肤资源不存在", true)
+	}
+
+	dto, xErr := l.buildSkinDTO(ctx, skinEntity)
+	if xErr != nil {
+		return nil, xErr
+	}
+	dto.AssignmentType = result.AssignmentType
+	return dto, nil
+}
+
+// UncollectSkin 取消收藏皮肤，同时卸下当前用户档案上的该皮肤。
+func (l *LibraryLogic) UncollectSkin(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) *xError.Error {
+	l.log.Info(ctx, "UncollectSkin - 取消收藏皮肤")
+
+	if xErr := l.repo.txn.UncollectSkin(ctx, userID, skinID); xErr != nil {
+		return xErr
+	}
+	return nil
+}
+
+// CollectCape 收藏公开披风到当前用户的资源库。
+//
+// 收藏以 AssignmentTypeCollect 关联记录，不计入配额；披风被上传者转为私有或删除后不再可装备。
+func (l *LibraryLogic) CollectCape(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) (*models.CapeDTO, *xError.Error) {
+	l.log.Info(ctx, "CollectCape - 收藏披风")
+
+	result, xErr := l.repo.txn.CollectCape(ctx, userID, capeID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	capeEntity, found, xErr := l.repo.capeRepo.GetByID(ctx, nil, capeID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "披风资源不存在", true)
+	}
+
+	dto, xErr := l.buildCapeDTO(ctx, capeEntity)
+	if xErr != nil {
+		return nil, xErr
+	}
+	dto.AssignmentType = result.AssignmentType
+	return dto, nil
+}
+
+// UncollectCape 取消收藏披风，同时卸下当前用户档案上的该披风。
+func (l *LibraryLogic) UncollectCape(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) *xError.Error {
+	l.log.Info(ctx, "UncollectCape - 取消收藏披风")
+
+	if xErr := l.repo.txn.UncollectCape(ctx, userID, capeID); xErr != nil {
+		return xErr
+	}
+	return nil
+}
+
+// ListPopularTags 按引用次数列出热门标签。
+func (l *LibraryLogic) ListPopularTags(ctx context.Context, limit int) ([]models.LibraryTagDTO, *xError.Error) {
+	l.log.Info(ctx, "ListPopularTags - 查询热门标签")
+
+	if limit <= 0 {
+		limit = popularTagDefaultLimit
+	}
+	if limit > popularTagMaxLimit {
+		limit = popularTagMaxLimit
+	}
+
+	rows, xErr := l.repo.tagRepo.ListPopular(ctx, nil, limit)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	dtos := make([]models.LibraryTagDTO, len(rows))
+	for i, row := range rows {
+		dtos[i] = models.LibraryTagDTO{Name: row.Name, UsageCount: row.UsageCount}
+	}
+	return dtos, nil
+}
+
+// ==================== Gallery Helper Methods ====================
+
+// toggleLike 执行点赞/取消点赞并返回最新点赞数。
+//
+// 点赞数变化不使画廊缓存失效，避免高频点赞使缓存持续失效，点赞排序由缓存 TTL 收敛。
+func (l *LibraryLogic) toggleLike(ctx context.Context, userID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, like bool) (*models.LibraryLikeDTO, *xError.Error) {
+	var xErr *xError.Error
+	if like {
+		xErr = l.repo.txn.LikeLibrary(ctx, userID, kind, libraryID)
+	} else {
+		xErr = l.repo.txn.UnlikeLibrary(ctx, userID, kind, libraryID)
+	}
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	var likeCount int64
+	switch kind {
+	case entityType.LibraryKindSkin:
+		skin, found, xErr := l.repo.skinRepo.GetByID(ctx, nil, libraryID)
+		if xErr != nil {
+			return nil, xErr
+		}
+		if found {
+			likeCount = skin.LikeCount
+		}
+	case entityType.LibraryKindCape:
+		cape, found, xErr := l.repo.capeRepo.GetByID(ctx, nil, libraryID)
+		if xErr != nil {
+			return nil, xErr
+		}
+		if found {
+			likeCount = cape.LikeCount
+		}
+	}
+
+	return &models.LibraryLikeDTO{Liked: like, LikeCount: likeCount}, nil
+}
+
+// normalizeGalleryQuery 校验并规范化画廊查询参数，返回仓储层过滤条件。
+func (l *LibraryLogic) normalizeGalleryQuery(ctx context.Context, query *models.LibraryGalleryQuery) (repository.LibraryGalleryFilter, *xError.Error) {
+	query.Keyword = strings.TrimSpace(query.Keyword)
+	if utf8.RuneCountInString(query.Keyword) > galleryKeywordMaxLength {
+		return repository.LibraryGalleryFilter{}, xError.NewError(ctx, xError.ParameterError, "搜索关键字过长", true)
+	}
+
+	query.Tag = strings.ToLower(strings.TrimSpace(query.Tag))
+
+	query.Sort = strings.ToLower(strings.TrimSpace(query.Sort))
+	if query.Sort == "" {
+		query.Sort = string(repository.GallerySortNewest)
+	}
+	sort := repository.GallerySort(query.Sort)
+	if !sort.IsValid() {
+		return repository.LibraryGalleryFilter{}, xError.NewError(ctx, xError.ParameterError, "无效的排序方式：仅支持 newest/equipped/collected/liked", true)
+	}
+
+	if query.Model != nil && *query.Model != entity.ModelTypeClassic && *query.Model != entity.ModelTypeSlim {
+		return repository.LibraryGalleryFilter{}, xError.NewError(ctx, xError.ParameterError, "无效的皮肤模型", true)
+	}
+
+	return repository.LibraryGalleryFilter{
+		Keyword:    query.Keyword,
+		UploaderID: query.UploaderID,
+		Model:      query.Model,
+		Tag:        query.Tag,
+		Sort:       sort,
+	}, nil
+}
+
+// normalizeLibraryTags 校验并规范化标签列表（去空白、转小写、去重）。
+func (l *LibraryLogic) normalizeLibraryTags(ctx context.Context, tags []string) ([]string, *xError.Error) {
+	seen := make(map[string]struct{}, len(tags))
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		name := strings.ToLower(strings.TrimSpace(tag))
+		length := utf8.RuneCountInString(name)
+		if length < libraryTagMinLength || length > libraryTagMaxLength {
+			return nil, xError.NewError(ctx, xError.ParameterError, "无效标签长度：必须在 1-32 个字符之间", true)
+		}
+		if !libraryTagPattern.MatchString(name) {
+			return nil, xError.NewError(ctx, xError.ParameterError, "标签仅允许字母、数字、下划线与连字符", true)
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	if len(names) > libraryTagMaxCount {
+		return nil, xError.NewError(ctx, xError.ParameterError, "单个资源最多设置 10 个标签", true)
+	}
+	return names, nil
+}
+
+// galleryQueryKey 计算规范化后查询条件的摘要，作为缓存键的一部分。
+func galleryQueryKey(query models.LibraryGalleryQuery) string {
+	uploader := ""
+	if query.UploaderID != nil {
+		uploader = query.UploaderID.String()
+	}
+	model := ""
+	if query.Model != nil {
+		model = fmt.Sprintf("%d", *query.Model)
+	}
+	raw := strings.Join([]string{
+		query.Keyword, uploader, model, query.Tag, query.Sort,
+		fmt.Sprintf("%d", query.Page), fmt.Sprintf("%d", query.PageSize),
+	}, "\x00")
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:16])
+}
+
+// getGalleryCache 读取画廊缓存，读取或反序列化失败时视为未命中。
+func getGalleryCache[T any](ctx context.Context, l *LibraryLogic, kind string, queryKey string) (*galleryCacheEntry[T], bool) {
+	data, hit, err := l.repo.galleryCache.Get(ctx, kind, queryKey)
+	if err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("读取画廊缓存失败(可忽略): %v", err))
+		return nil, false
+	}
+	if !hit {
+		return nil, false
+	}
+
+	var entry galleryCacheEntry[T]
+	if err := json.Unmarshal(data, &entry); err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("画廊缓存反序列化失败(可忽略): %v", err))
+		return nil, false
+	}
+	return &entry, true
+}
+
+// setGalleryCache 写入画廊缓存，失败仅记录警告。
+func setGalleryCache[T any](ctx context.Context, l *LibraryLogic, kind string, queryKey string, entry galleryCacheEntry[T]) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("画廊缓存序列化失败: %v", err))
+		return
+	}
+	if err := l.repo.galleryCache.Set(ctx, kind, queryKey, data); err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("写入画廊缓存失败: %v", err))
+	}
+}
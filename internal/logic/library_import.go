@@ -0,0 +1,213 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/logic/yggdrasil"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+const (
+	skinImportMaxSize      = 1 << 20 // 导入皮肤纹理大小上限（1 MiB）
+	skinImportMaxRedirects = 3       // 下载纹理时允许的最大重定向次数
+	skinImportDefaultName  = "Imported Skin"
+)
+
+// ImportSkin 从正版玩家或外部纹理链接导入皮肤到当前用户的资源库。
+//
+// username 与 textureURL 必须且只能提供一个：
+//   - username: 实时查询 Mojang API 获取该正版玩家当前皮肤，模型取自纹理元数据
+//   - textureURL: 仅接受 HTTPS 且域名在 skinDomains 白名单内的链接，模型由纹理像素推断
+//
+// 下载的纹理需为合法的皮肤 PNG，随后交由 CreateSkin 完成去重、配额扣减与入库；
+// 显式传入的 name / model 优先于推断值。
+func (l *LibraryLogic) ImportSkin(ctx context.Context, userID xSnowflake.SnowflakeID, username *string, textureURL *string, name *string, modelType *uint8, isPublic *bool) (*models.SkinDTO, *xError.Error) {
+	l.log.Info(ctx, "ImportSkin - 导入皮肤")
+
+	hasUsername := username != nil && strings.TrimSpace(*username) != ""
+	hasURL := textureURL != nil && strings.TrimSpace(*textureURL) != ""
+	if hasUsername == hasURL {
+		return nil, xError.NewError(ctx, xError.ParameterError, "username 与 url 必须且只能提供一个", true)
+	}
+
+	var sourceURL, defaultName string
+	var sourceModel *entity.ModelType
+	if hasUsername {
+		playerName := strings.TrimSpace(*username)
+		if len(playerName) < gameProfileNameMinLength || len(playerName) > gameProfileNameMaxLength || !gameProfileNameRegex.MatchString(playerName) {
+			return nil, xError.NewError(ctx, xError.ParameterError, "无效正版玩家名称：必须为 3-16 位字母、数字或下划线", true)
+		}
+		mojangSkin, err := yggdrasil.FetchMojangSkin(ctx, l.helper.httpClient, playerName)
+		if errors.Is(err, yggdrasil.ErrMojangProfileNotFound) {
+			return nil, xError.NewError(ctx, xError.ResourceNotFound, "正版玩家不存在", true)
+		}
+		if err != nil {
+			return nil, xError.NewError(ctx, xError.ServiceUnavailable, "查询正版皮肤失败", true, err)
+		}
+		if mojangSkin == nil {
+			return nil, xError.NewError(ctx, xError.ResourceNotFound, "该正版玩家未设置皮肤", true)
+		}
+		// Mojang 返回的纹理链接为 http 协议，统一升级为 https 下载
+		sourceURL = strings.Replace(mojangSkin.URL, "http://", "https://", 1)
+		defaultName = playerName
+		sourceModel = &mojangSkin.Model
+	} else {
+		sourceURL = strings.TrimSpace(*textureURL)
+	}
+
+	parsedURL, xErr := validateSkinImportURL(ctx, sourceURL)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if defaultName == "" {
+		defaultName = strings.TrimSuffix(path.Base(parsedURL.Path), path.Ext(parsedURL.Path))
+	}
+
+	data, xErr := l.fetchImportTexture(ctx, parsedURL.String())
+	if xErr != nil {
+		return nil, xErr
+	}
+	config, xErr := validateSkinImage(ctx, data)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	model := detectSkinModel(data, config)
+	if sourceModel != nil {
+		model = *sourceModel
+	}
+	if modelType != nil {
+		model = entity.ModelType(*modelType)
+	}
+
+	skinName := defaultName
+	if name != nil && strings.TrimSpace(*name) != "" {
+		skinName = *name
+	} else if skinName == "" || skinName == "." || skinName == "/" || len(skinName) > skinNameMaxLength {
+		skinName = skinImportDefaultName
+	}
+
+	texture := base64.StdEncoding.EncodeToString(data)
+	return l.CreateSkin(ctx, userID, skinName, uint8(model), texture, isPublic)
+}
+
+// fetchImportTexture 下载待导入的纹理文件，超过 skinImportMaxSize 时拒绝。
+//
+// 使用独立的导入客户端，重定向目标同样必须满足 HTTPS 与域名白名单。
+func (l *LibraryLogic) fetchImportTexture(ctx context.Context, textureURL string) ([]byte, *xError.Error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, textureURL, nil)
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效纹理链接", true, err)
+	}
+
+	resp, err := l.helper.importClient.Do(req)
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.ParameterError, "下载纹理失败", true, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, xError.NewError(ctx, xError.ParameterError, fmt.Sprintf("下载纹理失败：返回状态码 %d", resp.StatusCode), true)
+	}
+	if resp.ContentLength > skinImportMaxSize {
+		return nil, xError.NewError(ctx, xError.ParameterError, "纹理文件不能超过 1 MiB", true)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, skinImportMaxSize+1))
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.ParameterError, "下载纹理失败", true, err)
+	}
+	if len(data) > skinImportMaxSize {
+		return nil, xError.NewError(ctx, xError.ParameterError, "纹理文件不能超过 1 MiB", true)
+	}
+	return data, nil
+}
+
+// newSkinImportClient 创建导入皮肤专用的 HTTP 客户端，逐跳校验重定向目标。
+func newSkinImportClient() *http.Client {
+	return &http.Client{
+		Timeout: textureFetchTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= skinImportMaxRedirects {
+				return errors.New("纹理链接重定向次数过多")
+			}
+			if req.URL.Scheme != "https" || !yggdrasil.IsSkinDomainAllowed(req.URL.Hostname()) {
+				return fmt.Errorf("纹理链接重定向到不允许的域名: %s", req.URL.Host)
+			}
+			return nil
+		},
+	}
+}
+
+// validateSkinImportURL 校验导入链接为 HTTPS 且域名在 skinDomains 白名单内。
+func validateSkinImportURL(ctx context.Context, rawURL string) (*url.URL, *xError.Error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Host == "" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效纹理链接", true, err)
+	}
+	if parsedURL.Scheme != "https" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "纹理链接必须使用 HTTPS", true)
+	}
+	if parsedURL.User != nil {
+		return nil, xError.NewError(ctx, xError.ParameterError, "纹理链接不能包含认证信息", true)
+	}
+	if !yggdrasil.IsSkinDomainAllowed(parsedURL.Hostname()) {
+		return nil, xError.NewError(ctx, xError.ParameterError, "纹理链接域名不在允许列表内", true)
+	}
+	return parsedURL, nil
+}
+
+// validateSkinImage 校验数据为皮肤 PNG：宽度为 64 的整数倍且不超过 libraryTextureMaxDimension，
+// 高度等于宽度或宽度的一半（旧版格式）。仅解析 PNG 头部，尺寸不合法时不做完整解码。
+func validateSkinImage(ctx context.Context, data []byte) (image.Config, *xError.Error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return image.Config{}, xError.NewError(ctx, xError.ParameterError, "纹理文件不是 PNG 图片", true)
+	}
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return image.Config{}, xError.NewError(ctx, xError.ParameterError, "无法解析纹理 PNG", true, err)
+	}
+	if !isTextureSizeAllowed(config.Width, config.Height) {
+		return image.Config{}, xError.NewError(ctx, xError.ParameterError, fmt.Sprintf("无效皮肤尺寸 %dx%d：需为 64x64、64x32 或其整数倍，宽度不超过 %d", config.Width, config.Height, libraryTextureMaxDimension), true)
+	}
+	return config, nil
+}
+
+// detectSkinModel 根据手臂区域像素推断皮肤模型。
+//
+// slim 模型手臂宽 3 像素，64x64 布局中右臂背面最右两列（x=54..55, y=20..31）完全透明；
+// 旧版 64x32 纹理没有 slim 变体，始终为 classic。尺寸超出纹理规格或无法解码时回退为 classic。
+func detectSkinModel(data []byte, config image.Config) entity.ModelType {
+	if config.Height != config.Width || !isTextureSizeAllowed(config.Width, config.Height) {
+		return entity.ModelTypeClassic
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return entity.ModelTypeClassic
+	}
+
+	scale := config.Width / 64
+	bounds := img.Bounds()
+	for y := 20 * scale; y < 32*scale; y++ {
+		for x := 54 * scale; x < 56*scale; x++ {
+			if _, _, _, alpha := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA(); alpha != 0 {
+				return entity.ModelTypeClassic
+			}
+		}
+	}
+	return entity.ModelTypeSlim
+}
@@ -0,0 +1,81 @@
+package logic
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+)
+
+// slimPixel 返回 64 像素基准的皮肤图案，transparentArm 为 true 时右臂背面最右两列透明（slim 模型）。
+func slimPixel(scale int, transparentArm bool) func(x, y int) color.NRGBA {
+	return func(x, y int) color.NRGBA {
+		if transparentArm && x >= 54*scale && x < 56*scale && y >= 20*scale && y < 32*scale {
+			return color.NRGBA{}
+		}
+		return color.NRGBA{R: 120, G: 80, B: 40, A: 255}
+	}
+}
+
+// TestValidateSkinImage 验证皮肤 PNG 的格式与尺寸校验，超出尺寸上限的纹理在完整解码前被拒绝。
+func TestValidateSkinImage(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "64x64 皮肤", data: encodeTestTexture(t, 64, 64, slimPixel(1, false))},
+		{name: "旧版 64x32 皮肤", data: encodeTestTexture(t, 64, 32, slimPixel(1, false))},
+		{name: "1024x1024 高清皮肤", data: encodeTestTexture(t, 1024, 1024, slimPixel(16, false))},
+		{name: "非 PNG 数据", data: []byte("GIF89a"), wantErr: true},
+		{name: "PNG 签名后数据损坏", data: append(append([]byte(nil), pngSignature...), 0x00, 0x01), wantErr: true},
+		{name: "宽度不是 64 的整数倍", data: encodeTestTexture(t, 100, 100, slimPixel(1, false)), wantErr: true},
+		{name: "宽高比不合法", data: encodeTestTexture(t, 64, 128, slimPixel(1, false)), wantErr: true},
+		{name: "宽度超过 1024", data: encodeTestTexture(t, 2048, 2048, slimPixel(32, false)), wantErr: true},
+		{name: "旧版格式宽度超过 1024", data: encodeTestTexture(t, 2048, 1024, slimPixel(32, false)), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, xErr := validateSkinImage(context.Background(), tc.data)
+			if tc.wantErr {
+				if xErr == nil {
+					t.Errorf("validateSkinImage 期望返回错误，实际通过校验（%dx%d）", config.Width, config.Height)
+				}
+				return
+			}
+			if xErr != nil {
+				t.Errorf("validateSkinImage 期望通过校验，实际返回错误: %s", xErr.ErrorMessage)
+			}
+		})
+	}
+}
+
+// TestDetectSkinModel 验证根据手臂区域透明度推断皮肤模型，尺寸超出规格时不解码直接回退为 classic。
+func TestDetectSkinModel(t *testing.T) {
+	cases := []struct {
+		name   string
+		width  int
+		height int
+		data   []byte
+		want   entity.ModelType
+	}{
+		{name: "64x64 classic", width: 64, height: 64, data: encodeTestTexture(t, 64, 64, slimPixel(1, false)), want: entity.ModelTypeClassic},
+		{name: "64x64 slim", width: 64, height: 64, data: encodeTestTexture(t, 64, 64, slimPixel(1, true)), want: entity.ModelTypeSlim},
+		{name: "128x128 高清 slim", width: 128, height: 128, data: encodeTestTexture(t, 128, 128, slimPixel(2, true)), want: entity.ModelTypeSlim},
+		{name: "旧版 64x32 始终为 classic", width: 64, height: 32, data: encodeTestTexture(t, 64, 32, slimPixel(1, true)), want: entity.ModelTypeClassic},
+		{name: "超过尺寸上限回退为 classic", width: 2048, height: 2048, data: encodeTestTexture(t, 2048, 2048, slimPixel(32, true)), want: entity.ModelTypeClassic},
+		{name: "无法解码回退为 classic", width: 64, height: 64, data: []byte("not a png"), want: entity.ModelTypeClassic},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := image.Config{Width: tc.width, Height: tc.height}
+			if got := detectSkinModel(tc.data, config); got != tc.want {
+				t.Errorf("detectSkinModel() = %v, 期望 %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+// AdjustLibraryQuotaAdmin 管理员调整指定用户单个资源库配额分项的总额度。
+//
+// 调整后的总额度不能小于 0，也不能小于该分项的已使用额度；调整会写入配额日志并记录操作管理员。
+// requestID 由调用方生成，同一 requestID 重复提交时不会重复调整。
+func (l *LibraryLogic) AdjustLibraryQuotaAdmin(
+	ctx context.Context,
+	operatorID xSnowflake.SnowflakeID,
+	targetUserID xSnowflake.SnowflakeID,
+	bucket entityType.LibraryQuotaBucket,
+	delta int32,
+	requestID string,
+	remark string,
+) (*entity.LibraryQuota, *xError.Error) {
+	l.log.Info(ctx, "AdjustLibraryQuotaAdmin - 管理员调整资源库配额")
+
+	if targetUserID.IsZero() {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效目标用户 ID：不能为 0", true)
+	}
+	if !bucket.IsValid() {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效的配额分项", true)
+	}
+	if delta == 0 {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效变化量：delta 不能为 0", true)
+	}
+	requestID = strings.TrimSpace(requestID)
+	if requestID == "" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "请求标识不能为空", true)
+	}
+
+	finalRemark := fmt.Sprintf("管理员(ID=%s)调整配额", operatorID.String())
+	if remark != "" {
+		finalRemark = fmt.Sprintf("%s - %s", finalRemark, remark)
+	}
+
+	return l.repo.txn.AdjustLibraryQuotaAdmin(ctx, targetUserID, bucket, delta, operatorID, requestID, &finalRemark)
+}
+
+// ListLibraryQuotaLogs 按时间倒序分页查询用户的资源库配额变更日志，bucket 为空时返回全部分项。
+func (l *LibraryLogic) ListLibraryQuotaLogs(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	bucket *entityType.LibraryQuotaBucket,
+	page int,
+	pageSize int,
+) ([]models.LibraryQuotaLogDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListLibraryQuotaLogs - 查询资源库配额日志")
+
+	if userID.IsZero() {
+		return nil, 0, xError.NewError(ctx, xError.ParameterError, "无效用户 ID：不能为 0", true)
+	}
+	if bucket != nil && !bucket.IsValid() {
+		return nil, 0, xError.NewError(ctx, xError.ParameterError, "无效的配额分项", true)
+	}
+
+	logs, total, xErr := l.repo.quotaLogRepo.ListByUser(ctx, nil, userID, bucket, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	dtos := make([]models.LibraryQuotaLogDTO, len(logs))
+	for i, quotaLog := range logs {
+		dtos[i] = models.LibraryQuotaLogDTO{
+			ID:           quotaLog.ID,
+			OpType:       quotaLog.OpType,
+			Bucket:       quotaLog.Bucket,
+			Delta:        quotaLog.Delta,
+			BeforeUsed:   quotaLog.BeforeUsed,
+			AfterUsed:    quotaLog.AfterUsed,
+			BeforeTotal:  quotaLog.BeforeTotal,
+			AfterTotal:   quotaLog.AfterTotal,
+			RefLibraryID: quotaLog.RefLibraryID,
+			OperatorID:   quotaLog.OperatorID,
+			Remark:       quotaLog.Remark,
+			CreatedAt:    quotaLog.CreatedAt,
+		}
+	}
+	return dtos, total, nil
+}
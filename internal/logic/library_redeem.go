@@ -0,0 +1,306 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+const (
+	redeemCodeLength       = 16                                 // 兑换码长度（不含分隔符）
+	redeemCodeAlphabet     = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // 兑换码字符集（去除易混淆的 I、O、0、1）
+	redeemBatchMaxCount    = 1000                               // 单批次最多生成的兑换码数量
+	redeemMaxUsesLimit     = 100000                             // 单个兑换码最大兑换次数上限
+	redeemQuotaDeltaLimit  = 100                                // 单项配额增量上限
+	redeemRemarkMaxLength  = 255                                // 备注最大长度（字符）
+	redeemRateLimitMax     = 5                                  // 频率窗口内允许的兑换尝试次数
+	redeemRateLimitWindow  = 60 * time.Second                   // 兑换尝试频率统计窗口
+	redeemGenerateAttempts = 3                                  // 兑换码冲突时的最大重新生成次数
+)
+
+// GenerateRedeemCodes 批量生成兑换码（管理员专用）。
+//
+// 同一批次的兑换码共享载荷与限制配置，载荷至少包含皮肤、披风、资源库配额增量或游戏档案配额增量之一。
+// 兑换码由 crypto/rand 生成，极小概率发生的唯一索引冲突会整批重新生成。
+func (l *LibraryLogic) GenerateRedeemCodes(
+	ctx context.Context,
+	operatorID xSnowflake.SnowflakeID,
+	payload models.RedeemCodePayloadDTO,
+	count int,
+	remark *string,
+) (*models.RedeemCodeBatchDTO, *xError.Error) {
+	l.log.Info(ctx, "GenerateRedeemCodes - 批量生成兑换码")
+
+	if count <= 0 || count > redeemBatchMaxCount {
+		return nil, xError.NewError(ctx, xError.ParameterError, "生成数量需在 1-1000 之间", true)
+	}
+	if xErr := l.validateRedeemPayload(ctx, &payload); xErr != nil {
+		return nil, xErr
+	}
+	if remark != nil {
+		trimmed := strings.TrimSpace(*remark)
+		if utf8.RuneCountInString(trimmed) > redeemRemarkMaxLength {
+			return nil, xError.NewError(ctx, xError.ParameterError, "备注不能超过 255 个字符", true)
+		}
+		if trimmed == "" {
+			remark = nil
+		} else {
+			remark = &trimmed
+		}
+	}
+
+	var lastErr *xError.Error
+	for attempt := 0; attempt < redeemGenerateAttempts; attempt++ {
+		codes, err := buildRedeemCodes(payload, count)
+		if err != nil {
+			return nil, xError.NewError(ctx, xError.ServerInternalError, "生成兑换码失败", true, err)
+		}
+
+		batch := &entity.RedeemCodeBatch{
+			Count:     int32(count),
+			Remark:    remark,
+			CreatedBy: operatorID,
+		}
+		if xErr := l.repo.txn.CreateRedeemCodeBatch(ctx, batch, codes); xErr != nil {
+			lastErr = xErr
+			l.log.Warn(ctx, fmt.Sprintf("批量写入兑换码失败，重新生成(attempt=%d): %s", attempt+1, xErr.ErrorMessage))
+			continue
+		}
+
+		dto := buildRedeemCodeBatchDTO(batch, codes)
+		return &dto, nil
+	}
+	return nil, lastErr
+}
+
+// ListRedeemCodes 分页查询兑换码（管理员专用），batchID 为空时不限批次。
+func (l *LibraryLogic) ListRedeemCodes(ctx context.Context, batchID *xSnowflake.SnowflakeID, page int, pageSize int) ([]models.RedeemCodeDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListRedeemCodes - 查询兑换码列表")
+
+	codes, total, xErr := l.repo.redeemRepo.List(ctx, nil, batchID, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	dtos := make([]models.RedeemCodeDTO, 0, len(codes))
+	for i := range codes {
+		dtos = append(dtos, buildRedeemCodeDTO(&codes[i]))
+	}
+	return dtos, total, nil
+}
+
+// DisableRedeemCodeBatch 停用整批兑换码（管理员专用），返回本次被停用的兑换码数量。
+//
+// 已兑换获得的资源与配额不会被收回。
+func (l *LibraryLogic) DisableRedeemCodeBatch(ctx context.Context, batchID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	l.log.Info(ctx, "DisableRedeemCodeBatch - 停用兑换码批次")
+
+	_, found, xErr := l.repo.redeemRepo.GetBatchByID(ctx, nil, batchID)
+	if xErr != nil {
+		return 0, xErr
+	}
+	if !found {
+		return 0, xError.NewError(ctx, xError.ResourceNotFound, "兑换码批次不存在", true)
+	}
+	return l.repo.redeemRepo.DisableBatch(ctx, nil, batchID)
+}
+
+// RedeemCode 用户兑换兑换码。
+//
+// 兑换码忽略大小写及分隔符（- 与空格）。每个用户在 60 秒内最多尝试 5 次，
+// 超出后拒绝；计数依赖 Redis，Redis 不可用时放行以免影响正常兑换。
+func (l *LibraryLogic) RedeemCode(ctx context.Context, userID xSnowflake.SnowflakeID, code string) (*models.RedeemResultDTO, *xError.Error) {
+	l.log.Info(ctx, "RedeemCode - 兑换兑换码")
+
+	normalized := normalizeRedeemCode(code)
+	if normalized == "" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "兑换码不能为空", true)
+	}
+
+	count, err := l.repo.redeemLimit.Hit(ctx, userID.String())
+	if err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("兑换频率计数失败（已放行）: %v", err))
+	} else if count > redeemRateLimitMax {
+		return nil, xError.NewError(ctx, xError.ResourceExhausted, "兑换尝试过于频繁，请稍后再试", true)
+	}
+
+	user, found, xErr := l.repo.userRepo.Get(ctx, userID.String())
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "用户不存在", true)
+	}
+
+	redeemed, xErr := l.repo.txn.RedeemCode(ctx, userID, user.RoleName, normalized)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	return &models.RedeemResultDTO{
+		Code:              redeemed.Code,
+		SkinLibraryID:     redeemed.SkinLibraryID,
+		CapeLibraryID:     redeemed.CapeLibraryID,
+		SkinsPrivateDelta: redeemed.SkinsPrivateDelta,
+		SkinsPublicDelta:  redeemed.SkinsPublicDelta,
+		CapesPrivateDelta: redeemed.CapesPrivateDelta,
+		CapesPublicDelta:  redeemed.CapesPublicDelta,
+		ProfileQuotaDelta: redeemed.ProfileQuotaDelta,
+	}, nil
+}
+
+// ==================== 内部方法 ====================
+
+// validateRedeemPayload 校验并规范化兑换码载荷配置。
+func (l *LibraryLogic) validateRedeemPayload(ctx context.Context, payload *models.RedeemCodePayloadDTO) *xError.Error {
+	deltas := []int32{
+		payload.SkinsPrivateDelta, payload.SkinsPublicDelta,
+		payload.CapesPrivateDelta, payload.CapesPublicDelta,
+		payload.ProfileQuotaDelta,
+	}
+	hasDelta := false
+	for _, delta := range deltas {
+		if delta < 0 || delta > redeemQuotaDeltaLimit {
+			return xError.NewError(ctx, xError.ParameterError, "配额增量需在 0-100 之间", true)
+		}
+		if delta > 0 {
+			hasDelta = true
+		}
+	}
+	if !hasDelta && payload.SkinLibraryID == nil && payload.CapeLibraryID == nil {
+		return xError.NewError(ctx, xError.ParameterError, "兑换码至少需要包含皮肤、披风或配额增量之一", true)
+	}
+	if payload.MaxUses <= 0 || payload.MaxUses > redeemMaxUsesLimit {
+		return xError.NewError(ctx, xError.ParameterError, "最大兑换次数需在 1-100000 之间", true)
+	}
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		return xError.NewError(ctx, xError.ParameterError, "过期时间必须晚于当前时间", true)
+	}
+
+	if payload.SkinLibraryID != nil {
+		if xErr := l.checkCampaignTarget(ctx, entityType.LibraryKindSkin, *payload.SkinLibraryID); xErr != nil {
+			return xErr
+		}
+	}
+	if payload.CapeLibraryID != nil {
+		if xErr := l.checkCampaignTarget(ctx, entityType.LibraryKindCape, *payload.CapeLibraryID); xErr != nil {
+			return xErr
+		}
+	}
+
+	roles := make([]string, 0, len(payload.AllowedRoles))
+	seen := make(map[string]struct{}, len(payload.AllowedRoles))
+	for _, role := range payload.AllowedRoles {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			continue
+		}
+		if _, ok := seen[role]; ok {
+			continue
+		}
+		seen[role] = struct{}{}
+		roles = append(roles, role)
+	}
+	payload.AllowedRoles = roles
+	return nil
+}
+
+// buildRedeemCodes 按载荷配置生成指定数量的兑换码实体（批次内不重复）。
+func buildRedeemCodes(payload models.RedeemCodePayloadDTO, count int) ([]entity.RedeemCode, error) {
+	codes := make([]entity.RedeemCode, 0, count)
+	seen := make(map[string]struct{}, count)
+	for len(codes) < count {
+		code, err := randomRedeemCode()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[code]; ok {
+			continue
+		}
+		seen[code] = struct{}{}
+		codes = append(codes, entity.RedeemCode{
+			Code:              code,
+			SkinLibraryID:     payload.SkinLibraryID,
+			CapeLibraryID:     payload.CapeLibraryID,
+			SkinsPrivateDelta: payload.SkinsPrivateDelta,
+			SkinsPublicDelta:  payload.SkinsPublicDelta,
+			CapesPrivateDelta: payload.CapesPrivateDelta,
+			CapesPublicDelta:  payload.CapesPublicDelta,
+			ProfileQuotaDelta: payload.ProfileQuotaDelta,
+			MaxUses:           payload.MaxUses,
+			ExpiresAt:         payload.ExpiresAt,
+			AllowedRoles:      payload.AllowedRoles,
+		})
+	}
+	return codes, nil
+}
+
+// randomRedeemCode 使用 crypto/rand 生成一个兑换码。
+func randomRedeemCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(redeemCodeAlphabet)))
+	var builder strings.Builder
+	builder.Grow(redeemCodeLength)
+	for i := 0; i < redeemCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("生成随机数失败: %w", err)
+		}
+		builder.WriteByte(redeemCodeAlphabet[n.Int64()])
+	}
+	return builder.String(), nil
+}
+
+// normalizeRedeemCode 规范化用户输入的兑换码：去除分隔符与空白并转为大写。
+func normalizeRedeemCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "", "\t", "").Replace(strings.TrimSpace(code)))
+}
+
+// buildRedeemCodeDTO 将兑换码实体转换为 DTO。
+func buildRedeemCodeDTO(code *entity.RedeemCode) models.RedeemCodeDTO {
+	return models.RedeemCodeDTO{
+		ID:      code.ID,
+		Code:    code.Code,
+		BatchID: code.BatchID,
+		Payload: models.RedeemCodePayloadDTO{
+			SkinLibraryID:     code.SkinLibraryID,
+			CapeLibraryID:     code.CapeLibraryID,
+			SkinsPrivateDelta: code.SkinsPrivateDelta,
+			SkinsPublicDelta:  code.SkinsPublicDelta,
+			CapesPrivateDelta: code.CapesPrivateDelta,
+			CapesPublicDelta:  code.CapesPublicDelta,
+			ProfileQuotaDelta: code.ProfileQuotaDelta,
+			MaxUses:           code.MaxUses,
+			ExpiresAt:         code.ExpiresAt,
+			AllowedRoles:      code.AllowedRoles,
+		},
+		UsedCount:  code.UsedCount,
+		IsDisabled: code.IsDisabled,
+		CreatedAt:  code.CreatedAt,
+	}
+}
+
+// buildRedeemCodeBatchDTO 将兑换码批次及其兑换码转换为 DTO。
+func buildRedeemCodeBatchDTO(batch *entity.RedeemCodeBatch, codes []entity.RedeemCode) models.RedeemCodeBatchDTO {
+	dtos := make([]models.RedeemCodeDTO, 0, len(codes))
+	for i := range codes {
+		dtos = append(dtos, buildRedeemCodeDTO(&codes[i]))
+	}
+	return models.RedeemCodeBatchDTO{
+		ID:        batch.ID,
+		Count:     batch.Count,
+		Remark:    batch.Remark,
+		CreatedBy: batch.CreatedBy,
+		CreatedAt: batch.CreatedAt,
+		Codes:     dtos,
+	}
+}
@@ -0,0 +1,198 @@
+package logic
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+const (
+	reportDescriptionMaxLength = 255 // 举报说明最大长度（字符）
+	defaultReportHideThreshold = 5   // 默认举报自动隐藏阈值
+)
+
+// ReportSkin 举报公开皮肤。
+//
+// 同一用户对同一皮肤仅能存在一条待处理举报；待处理举报数达到阈值后皮肤自动从公开列表隐藏。
+func (l *LibraryLogic) ReportSkin(ctx context.Context, reporterID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID, reason entityType.ReportReason, description string) *xError.Error {
+	l.log.Info(ctx, "ReportSkin - 举报皮肤")
+	return l.reportLibrary(ctx, reporterID, entityType.LibraryKindSkin, skinID, reason, description)
+}
+
+// ReportCape 举报公开披风。
+//
+// 同构于 ReportSkin，Skin → Cape。
+func (l *LibraryLogic) ReportCape(ctx context.Context, reporterID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID, reason entityType.ReportReason, description string) *xError.Error {
+	l.log.Info(ctx, "ReportCape - 举报披风")
+	return l.reportLibrary(ctx, reporterID, entityType.LibraryKindCape, capeID, reason, description)
+}
+
+// ListReportedSkins 分页获取存在待处理举报的皮肤（管理员举报收件箱）。
+func (l *LibraryLogic) ListReportedSkins(ctx context.Context, page int, pageSize int) ([]models.SkinDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListReportedSkins - 获取被举报皮肤列表")
+
+	skins, total, xErr := l.repo.skinRepo.ListReported(ctx, nil, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	responses, xErr := l.buildSkinDTOs(ctx, skins)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	return responses, total, nil
+}
+
+// ListReportedCapes 分页获取存在待处理举报的披风（管理员举报收件箱）。
+func (l *LibraryLogic) ListReportedCapes(ctx context.Context, page int, pageSize int) ([]models.CapeDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListReportedCapes - 获取被举报披风列表")
+
+	capes, total, xErr := l.repo.capeRepo.ListReported(ctx, nil, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	responses, xErr := l.buildCapeDTOs(ctx, capes)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	return responses, total, nil
+}
+
+// ListSkinReports 获取指定皮肤的全部待处理举报明细。
+func (l *LibraryLogic) ListSkinReports(ctx context.Context, skinID xSnowflake.SnowflakeID) ([]models.LibraryReportDTO, *xError.Error) {
+	l.log.Info(ctx, "ListSkinReports - 获取皮肤举报明细")
+	return l.listPendingReports(ctx, entityType.LibraryKindSkin, skinID)
+}
+
+// ListCapeReports 获取指定披风的全部待处理举报明细。
+func (l *LibraryLogic) ListCapeReports(ctx context.Context, capeID xSnowflake.SnowflakeID) ([]models.LibraryReportDTO, *xError.Error) {
+	l.log.Info(ctx, "ListCapeReports - 获取披风举报明细")
+	return l.listPendingReports(ctx, entityType.LibraryKindCape, capeID)
+}
+
+// DismissSkinReports 驳回指定皮肤的全部待处理举报，皮肤恢复在公开列表中展示。
+func (l *LibraryLogic) DismissSkinReports(ctx context.Context, handlerID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	l.log.Info(ctx, "DismissSkinReports - 驳回皮肤举报")
+
+	count, xErr := l.repo.txn.DismissReports(ctx, entityType.LibraryKindSkin, skinID, handlerID)
+	if xErr != nil {
+		return 0, xErr
+	}
+	l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	return count, nil
+}
+
+// DismissCapeReports 驳回指定披风的全部待处理举报，披风恢复在公开列表中展示。
+func (l *LibraryLogic) DismissCapeReports(ctx context.Context, handlerID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	l.log.Info(ctx, "DismissCapeReports - 驳回披风举报")
+
+	count, xErr := l.repo.txn.DismissReports(ctx, entityType.LibraryKindCape, capeID, handlerID)
+	if xErr != nil {
+		return 0, xErr
+	}
+	l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	return count, nil
+}
+
+// TakedownSkin 下架皮肤：从所有用户撤销、卸下全部游戏档案上的装备并删除纹理文件。
+//
+// 返回被标记为已下架的举报条数。
+func (l *LibraryLogic) TakedownSkin(ctx context.Context, handlerID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	l.log.Info(ctx, "TakedownSkin - 下架皮肤")
+
+	skin, resolved, xErr := l.repo.txn.TakedownSkin(ctx, skinID, handlerID)
+	if xErr != nil {
+		return 0, xErr
+	}
+	l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+
+	// DB 删除成功后同步清理纹理版本及不再被引用的 Bucket 文件
+	l.releaseLibraryTextures(ctx, entityType.LibraryKindSkin, skinID, skin.Texture, skin.TextureHash)
+	return resolved, nil
+}
+
+// TakedownCape 下架披风：从所有用户撤销、卸下全部游戏档案上的装备并删除纹理文件。
+//
+// 返回被标记为已下架的举报条数。
+func (l *LibraryLogic) TakedownCape(ctx context.Context, handlerID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	l.log.Info(ctx, "TakedownCape - 下架披风")
+
+	cape, resolved, xErr := l.repo.txn.TakedownCape(ctx, capeID, handlerID)
+	if xErr != nil {
+		return 0, xErr
+	}
+	l.invalidateGallery(ctx, entityType.LibraryKindCape)
+
+	// DB 删除成功后同步清理纹理版本及不再被引用的 Bucket 文件
+	l.releaseLibraryTextures(ctx, entityType.LibraryKindCape, capeID, cape.Texture, cape.TextureHash)
+	return resolved, nil
+}
+
+// reportLibrary 校验举报参数并委托事务仓储提交举报，资源因此被隐藏时失效画廊缓存。
+func (l *LibraryLogic) reportLibrary(ctx context.Context, reporterID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, reason entityType.ReportReason, description string) *xError.Error {
+	if !reason.IsValid() {
+		return xError.NewError(ctx, xError.ParameterError, "无效的举报原因", true)
+	}
+
+	var descPtr *string
+	trimmed := strings.TrimSpace(description)
+	if utf8.RuneCountInString(trimmed) > reportDescriptionMaxLength {
+		return xError.NewError(ctx, xError.ParameterError, "举报说明不能超过 255 个字符", true)
+	}
+	if trimmed != "" {
+		descPtr = &trimmed
+	}
+	if reason == entityType.ReportReasonOther && descPtr == nil {
+		return xError.NewError(ctx, xError.ParameterError, "选择其他原因时必须填写举报说明", true)
+	}
+
+	threshold := int64(xEnv.GetEnvInt(bConst.EnvLibraryReportHideThreshold, defaultReportHideThreshold))
+	if threshold < 1 {
+		threshold = defaultReportHideThreshold
+	}
+
+	becameHidden, xErr := l.repo.txn.ReportLibrary(ctx, reporterID, kind, libraryID, reason, descPtr, threshold)
+	if xErr != nil {
+		return xErr
+	}
+	if becameHidden {
+		l.invalidateGallery(ctx, kind)
+	}
+	return nil
+}
+
+// listPendingReports 查询资源的待处理举报并转换为 DTO。
+func (l *LibraryLogic) listPendingReports(ctx context.Context, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) ([]models.LibraryReportDTO, *xError.Error) {
+	reports, xErr := l.repo.reportRepo.ListPendingByTarget(ctx, nil, kind, libraryID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	return buildLibraryReportDTOs(reports), nil
+}
+
+// buildLibraryReportDTOs 将 LibraryReport 实体列表转换为 DTO 列表。
+func buildLibraryReportDTOs(reports []entity.LibraryReport) []models.LibraryReportDTO {
+	items := make([]models.LibraryReportDTO, len(reports))
+	for i, report := range reports {
+		items[i] = models.LibraryReportDTO{
+			ID:          report.ID,
+			ReporterID:  report.ReporterID,
+			Kind:        report.Kind,
+			LibraryID:   report.LibraryID,
+			Reason:      report.Reason,
+			Description: report.Description,
+			Status:      report.Status,
+			CreatedAt:   report.CreatedAt,
+		}
+	}
+	return items
+}
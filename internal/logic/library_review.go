@@ -0,0 +1,264 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	xAsync "github.com/bamboo-services/bamboo-base-go/plugins/async"
+	xEmail "github.com/bamboo-services/bamboo-base-go/plugins/email"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+)
+
+const (
+	reviewBatchMaxSize    = 100 // 单次批量审核最大数量
+	reviewReasonMaxLength = 255 // 驳回原因最大长度（字符）
+)
+
+// libraryReviewNotice 审核结果通知内容。
+type libraryReviewNotice struct {
+	UserID xSnowflake.SnowflakeID  // 上传者用户 ID
+	Name   string                  // 资源名称
+	Status entityType.ReviewStatus // 审核结果
+}
+
+// ListPendingSkins 分页获取待审核的公开皮肤（先提交先审核）。
+//
+// 包含已通过审核但存在待审核纹理变更的皮肤，此时 PendingTextureURL 为待审核纹理的下载链接。
+func (l *LibraryLogic) ListPendingSkins(ctx context.Context, page int, pageSize int) ([]models.SkinDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListPendingSkins - 获取待审核皮肤列表")
+
+	skins, total, xErr := l.repo.skinRepo.ListPendingReview(ctx, nil, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	responses, xErr := l.buildSkinDTOs(ctx, skins)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	pending := make(map[xSnowflake.SnowflakeID]int32)
+	for _, skin := range skins {
+		if skin.PendingTextureVersion != nil {
+			pending[skin.ID] = *skin.PendingTextureVersion
+		}
+	}
+	urlMap, xErr := l.resolvePendingTextureURLs(ctx, entityType.LibraryKindSkin, pending)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	for i := range responses {
+		responses[i].PendingTextureURL = urlMap[responses[i].ID]
+	}
+	return responses, total, nil
+}
+
+// ListPendingCapes 分页获取待审核的公开披风（先提交先审核）。
+//
+// 同构于 ListPendingSkins，Skin → Cape。
+func (l *LibraryLogic) ListPendingCapes(ctx context.Context, page int, pageSize int) ([]models.CapeDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListPendingCapes - 获取待审核披风列表")
+
+	capes, total, xErr := l.repo.capeRepo.ListPendingReview(ctx, nil, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	responses, xErr := l.buildCapeDTOs(ctx, capes)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+
+	pending := make(map[xSnowflake.SnowflakeID]int32)
+	for _, cape := range capes {
+		if cape.PendingTextureVersion != nil {
+			pending[cape.ID] = *cape.PendingTextureVersion
+		}
+	}
+	urlMap, xErr := l.resolvePendingTextureURLs(ctx, entityType.LibraryKindCape, pending)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	for i := range responses {
+		responses[i].PendingTextureURL = urlMap[responses[i].ID]
+	}
+	return responses, total, nil
+}
+
+// resolvePendingTextureURLs 解析资源待审核纹理版本的下载链接，返回资源 ID -> 下载链接的映射。
+func (l *LibraryLogic) resolvePendingTextureURLs(ctx context.Context, kind entityType.LibraryKind, pending map[xSnowflake.SnowflakeID]int32) (map[xSnowflake.SnowflakeID]string, *xError.Error) {
+	result := make(map[xSnowflake.SnowflakeID]string, len(pending))
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	textures := make(map[xSnowflake.SnowflakeID]int64, len(pending))
+	textureIDs := make([]int64, 0, len(pending))
+	for libraryID, version := range pending {
+		target, found, xErr := l.repo.versionRepo.GetByTargetAndVersion(ctx, nil, kind, libraryID, version)
+		if xErr != nil {
+			return nil, xErr
+		}
+		if !found {
+			continue
+		}
+		textures[libraryID] = target.Texture
+		textureIDs = append(textureIDs, target.Texture)
+	}
+
+	urlMap, xErr := l.resolveTextureURLsBatch(ctx, textureIDs)
+	if xErr != nil {
+		return nil, xErr
+	}
+	for libraryID, texture := range textures {
+		result[libraryID] = urlMap[texture]
+	}
+	return result, nil
+}
+
+// ReviewSkins 管理员批量审核皮肤。
+//
+// approve 为 false 时必须提供驳回原因，驳回的皮肤退回私有并由 Repository 层同步调整配额。
+// 返回实际完成审核的数量，非待审核状态的 ID 会被跳过。审核完成后异步邮件通知上传者。
+func (l *LibraryLogic) ReviewSkins(ctx context.Context, reviewerID xSnowflake.SnowflakeID, skinIDs []xSnowflake.SnowflakeID, approve bool, reason string) (int, *xError.Error) {
+	l.log.Info(ctx, "ReviewSkins - 批量审核皮肤")
+
+	reasonPtr, xErr := l.validateReviewInput(ctx, len(skinIDs), approve, reason)
+	if xErr != nil {
+		return 0, xErr
+	}
+
+	reviewed, xErr := l.repo.txn.ReviewSkins(ctx, skinIDs, approve, reasonPtr, reviewerID)
+	if xErr != nil {
+		return 0, xErr
+	}
+	if len(reviewed) == 0 {
+		return 0, nil
+	}
+	if approve {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+
+	notices := make([]libraryReviewNotice, 0, len(reviewed))
+	for _, skin := range reviewed {
+		if skin.UserID != nil {
+			notices = append(notices, libraryReviewNotice{UserID: *skin.UserID, Name: skin.Name, Status: reviewResultStatus(approve)})
+		}
+	}
+	l.notifyLibraryReview(ctx, "皮肤", notices, reasonPtr)
+	return len(reviewed), nil
+}
+
+// ReviewCapes 管理员批量审核披风。
+//
+// 同构于 ReviewSkins，Skin → Cape。
+func (l *LibraryLogic) ReviewCapes(ctx context.Context, reviewerID xSnowflake.SnowflakeID, capeIDs []xSnowflake.SnowflakeID, approve bool, reason string) (int, *xError.Error) {
+	l.log.Info(ctx, "ReviewCapes - 批量审核披风")
+
+	reasonPtr, xErr := l.validateReviewInput(ctx, len(capeIDs), approve, reason)
+	if xErr != nil {
+		return 0, xErr
+	}
+
+	reviewed, xErr := l.repo.txn.ReviewCapes(ctx, capeIDs, approve, reasonPtr, reviewerID)
+	if xErr != nil {
+		return 0, xErr
+	}
+	if len(reviewed) == 0 {
+		return 0, nil
+	}
+	if approve {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
+
+	notices := make([]libraryReviewNotice, 0, len(reviewed))
+	for _, cape := range reviewed {
+		if cape.UserID != nil {
+			notices = append(notices, libraryReviewNotice{UserID: *cape.UserID, Name: cape.Name, Status: reviewResultStatus(approve)})
+		}
+	}
+	l.notifyLibraryReview(ctx, "披风", notices, reasonPtr)
+	return len(reviewed), nil
+}
+
+// reviewResultStatus 返回通知上传者的审核结果。
+//
+// 仅驳回纹理变更时资源仍保持通过状态，因此按本次审核动作而非资源审核状态决定通知结果。
+func reviewResultStatus(approve bool) entityType.ReviewStatus {
+	if approve {
+		return entityType.ReviewStatusApproved
+	}
+	return entityType.ReviewStatusRejected
+}
+
+// validateReviewInput 校验批量审核参数，返回规范化后的驳回原因（通过时为 nil）。
+func (l *LibraryLogic) validateReviewInput(ctx context.Context, count int, approve bool, reason string) (*string, *xError.Error) {
+	if count == 0 || count > reviewBatchMaxSize {
+		return nil, xError.NewError(ctx, xError.ParameterError, "批量审核数量必须在 1-100 之间", true)
+	}
+	if approve {
+		return nil, nil
+	}
+
+	trimmed := strings.TrimSpace(reason)
+	if trimmed == "" {
+		return nil, xError.NewError(ctx, xError.ParameterError, "驳回时必须填写原因", true)
+	}
+	if utf8.RuneCountInString(trimmed) > reviewReasonMaxLength {
+		return nil, xError.NewError(ctx, xError.ParameterError, "驳回原因不能超过 255 个字符", true)
+	}
+	return &trimmed, nil
+}
+
+// notifyLibraryReview 异步邮件通知上传者审核结果。
+func (l *LibraryLogic) notifyLibraryReview(ctx context.Context, kindName string, notices []libraryReviewNotice, reason *string) {
+	if len(notices) == 0 {
+		return
+	}
+
+	xAsync.Async(ctx, func(asyncCtx context.Context) {
+		db := xCtxUtil.MustGetDB(asyncCtx)
+		rdb := xCtxUtil.MustGetRDB(asyncCtx)
+		userRepo := repository.NewUserRepo(db, rdb)
+		emailClient := xCtxUtil.MustGetEmailClient(asyncCtx)
+
+		reasonText := ""
+		if reason != nil {
+			reasonText = *reason
+		}
+		frontendURL := xEnv.GetEnvString(bConst.EnvFrontendURL, "")
+		libraryURL := frontendURL + "/user/library"
+
+		for _, notice := range notices {
+			user, found, _ := userRepo.Get(asyncCtx, notice.UserID.String())
+			if !found || user.Email == nil {
+				continue
+			}
+
+			err := emailClient.SendTemplate(asyncCtx, &xEmail.Message{
+				To:       []string{*user.Email},
+				Subject:  kindName + "审核结果: " + notice.Name,
+				Template: "library_review",
+				TemplateData: map[string]string{
+					"Kind":       kindName,
+					"Name":       notice.Name,
+					"Status":     notice.Status.ChineseName(),
+					"Reason":     reasonText,
+					"LibraryURL": libraryURL,
+				},
+			})
+			if err != nil {
+				l.log.Warn(asyncCtx, fmt.Sprintf("通知%s审核结果失败(userID=%d): %v", kindName, notice.UserID, err))
+			}
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package logic
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+const (
+	shareDefaultHours = 72      // 分享链接默认有效期（小时）
+	shareMaxHours     = 24 * 30 // 分享链接最长有效期（小时）
+	shareTokenBytes   = 24      // 分享令牌随机字节数（URL 安全 Base64 编码后 32 个字符）
+)
+
+// CreateSkinShare 为当前用户上传的私有皮肤创建分享链接。
+//
+// 仅资源创建者可以分享，已公开且审核通过的皮肤可直接在画廊收藏，无需分享。
+// expiresInHours 为 0 时使用默认有效期。
+func (l *LibraryLogic) CreateSkinShare(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID, expiresInHours int) (*models.LibraryShareDTO, *xError.Error) {
+	l.log.Info(ctx, "CreateSkinShare - 创建皮肤分享链接")
+
+	skin, xErr := l.getShareableSkin(ctx, userID, skinID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if skin.IsPublic && skin.ReviewStatus == entityType.ReviewStatusApproved {
+		return nil, xError.NewError(ctx, xError.ParameterError, "公开皮肤可直接在画廊收藏，无需创建分享链接", true)
+	}
+	return l.createShare(ctx, userID, entityType.LibraryKindSkin, skin.ID, expiresInHours)
+}
+
+// CreateCapeShare 为当前用户上传的私有披风创建分享链接。
+//
+// 同构于 CreateSkinShare，Skin → Cape。
+func (l *LibraryLogic) CreateCapeShare(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID, expiresInHours int) (*models.LibraryShareDTO, *xError.Error) {
+	l.log.Info(ctx, "CreateCapeShare - 创建披风分享链接")
+
+	cape, xErr := l.getShareableCape(ctx, userID, capeID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if cape.IsPublic && cape.ReviewStatus == entityType.ReviewStatusApproved {
+		return nil, xError.NewError(ctx, xError.ParameterError, "公开披风可直接在画廊收藏，无需创建分享链接", true)
+	}
+	return l.createShare(ctx, userID, entityType.LibraryKindCape, cape.ID, expiresInHours)
+}
+
+// ListSkinShares 列出当前用户为指定皮肤创建的全部分享链接（含已撤销与已到期）。
+func (l *LibraryLogic) ListSkinShares(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) ([]models.LibraryShareDTO, *xError.Error) {
+	l.log.Info(ctx, "ListSkinShares - 查询皮肤分享链接")
+
+	if _, xErr := l.getShareableSkin(ctx, userID, skinID); xErr != nil {
+		return nil, xErr
+	}
+	return l.listShares(ctx, userID, entityType.LibraryKindSkin, skinID)
+}
+
+// ListCapeShares 列出当前用户为指定披风创建的全部分享链接（含已撤销与已到期）。
+func (l *LibraryLogic) ListCapeShares(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) ([]models.LibraryShareDTO, *xError.Error) {
+	l.log.Info(ctx, "ListCapeShares - 查询披风分享链接")
+
+	if _, xErr := l.getShareableCape(ctx, userID, capeID); xErr != nil {
+		return nil, xErr
+	}
+	return l.listShares(ctx, userID, entityType.LibraryKindCape, capeID)
+}
+
+// RevokeShare 撤销分享链接，并移除尚未装备该资源的接收者关联。
+//
+// 分享必须属于当前用户且指向 kind + libraryID 对应的资源。
+// 返回撤销后的分享链接与被移除的接收者关联数。
+func (l *LibraryLogic) RevokeShare(ctx context.Context, userID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, shareID xSnowflake.SnowflakeID) (*models.LibraryShareDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "RevokeShare - 撤销分享链接")
+
+	share, found, xErr := l.repo.shareRepo.GetByID(ctx, nil, shareID)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	if !found || share.OwnerID != userID || share.Kind != kind || share.LibraryID != libraryID {
+		return nil, 0, xError.NewError(ctx, xError.ResourceNotFound, "分享链接不存在", true)
+	}
+	if share.RevokedAt != nil {
+		return nil, 0, xError.NewError(ctx, xError.DataConflict, "分享链接已被撤销", true)
+	}
+
+	now := time.Now()
+	detached, xErr := l.repo.txn.RevokeShare(ctx, share, now)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	share.RevokedAt = &now
+
+	dto := buildLibraryShareDTO(share)
+	return &dto, detached, nil
+}
+
+// GetSharePreview 通过分享令牌获取资源预览（无需登录）。
+//
+// 分享已撤销、已到期或资源已删除时统一返回资源不存在，避免泄露令牌状态。
+func (l *LibraryLogic) GetSharePreview(ctx context.Context, token string) (*models.LibrarySharePreviewDTO, *xError.Error) {
+	l.log.Info(ctx, "GetSharePreview - 获取分享资源预览")
+
+	share, found, xErr := l.repo.shareRepo.GetByToken(ctx, nil, token, false)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found || !share.IsActive(time.Now()) {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "分享链接不存在或已失效", true)
+	}
+	return l.buildSharePreview(ctx, share)
+}
+
+// ClaimShare 通过分享令牌将资源添加到当前用户的资源库。
+//
+// 分享关联以 AssignmentTypeShare 记录，不计入配额，可正常装备；
+// 分享被撤销时，若该资源未装备在任何游戏档案上，关联会被移除。
+func (l *LibraryLogic) ClaimShare(ctx context.Context, userID xSnowflake.SnowflakeID, token string) (*models.LibrarySharePreviewDTO, *xError.Error) {
+	l.log.Info(ctx, "ClaimShare - 领取分享资源")
+
+	share, xErr := l.repo.txn.ClaimShare(ctx, token, userID, time.Now())
+	if xErr != nil {
+		return nil, xErr
+	}
+	return l.buildSharePreview(ctx, share)
+}
+
+// getShareableSkin 查询皮肤并校验当前用户为其创建者。
+func (l *LibraryLogic) getShareableSkin(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) (*entity.SkinLibrary, *xError.Error) {
+	skin, found, xErr := l.repo.skinRepo.GetByID(ctx, nil, skinID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "皮肤不存在", true)
+	}
+	if skin.UserID == nil || *skin.UserID != userID {
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "只有资源创建者可以分享资源", true)
+	}
+	return skin, nil
+}
+
+// getShareableCape 查询披风并校验当前用户为其创建者。
+func (l *LibraryLogic) getShareableCape(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) (*entity.CapeLibrary, *xError.Error) {
+	cape, found, xErr := l.repo.capeRepo.GetByID(ctx, nil, capeID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "披风不存在", true)
+	}
+	if cape.UserID == nil || *cape.UserID != userID {
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "只有资源创建者可以分享资源", true)
+	}
+	return cape, nil
+}
+
+// createShare 生成分享令牌并创建分享链接记录。
+func (l *LibraryLogic) createShare(ctx context.Context, userID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, expiresInHours int) (*models.LibraryShareDTO, *xError.Error) {
+	if expiresInHours == 0 {
+		expiresInHours = shareDefaultHours
+	}
+	if expiresInHours < 1 || expiresInHours > shareMaxHours {
+		return nil, xError.NewError(ctx, xError.ParameterError, fmt.Sprintf("无效有效期：expires_in_hours 需在 1-%d 之间", shareMaxHours), true)
+	}
+
+	token, err := randomShareToken()
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.ServerInternalError, "生成分享令牌失败", true, err)
+	}
+
+	share, xErr := l.repo.shareRepo.Create(ctx, nil, &entity.LibraryShare{
+		OwnerID:   userID,
+		Kind:      kind,
+		LibraryID: libraryID,
+		Token:     token,
+		ExpiresAt: time.Now().Add(time.Duration(expiresInHours) * time.Hour),
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	dto := buildLibraryShareDTO(share)
+	return &dto, nil
+}
+
+// listShares 查询分享者对指定资源创建的分享链接并转换为 DTO。
+func (l *LibraryLogic) listShares(ctx context.Context, userID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) ([]models.LibraryShareDTO, *xError.Error) {
+	shares, xErr := l.repo.shareRepo.ListByTarget(ctx, nil, userID, kind, libraryID)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	dtos := make([]models.LibraryShareDTO, len(shares))
+	for i := range shares {
+		dtos[i] = buildLibraryShareDTO(&shares[i])
+	}
+	return dtos, nil
+}
+
+// buildSharePreview 加载分享指向的资源并构建公开预览 DTO。
+func (l *LibraryLogic) buildSharePreview(ctx context.Context, share *entity.LibraryShare) (*models.LibrarySharePreviewDTO, *xError.Error) {
+	preview := &models.LibrarySharePreviewDTO{
+		Kind:      share.Kind,
+		LibraryID: share.LibraryID,
+		ExpiresAt: share.ExpiresAt,
+	}
+
+	var textureID int64
+	if share.Kind == entityType.LibraryKindCape {
+		cape, found, xErr := l.repo.capeRepo.GetByID(ctx, nil, share.LibraryID)
+		if xErr != nil {
+			return nil, xErr
+		}
+		if !found {
+			return nil, xError.NewError(ctx, xError.ResourceNotFound, "分享链接不存在或已失效", true)
+		}
+		preview.Name = cape.Name
+		textureID = cape.Texture
+	} else {
+		skin, found, xErr := l.repo.skinRepo.GetByID(ctx, nil, share.LibraryID)
+		if xErr != nil {
+			return nil, xErr
+		}
+		if !found {
+			return nil, xError.NewError(ctx, xError.ResourceNotFound, "分享链接不存在或已失效", true)
+		}
+		preview.Name = skin.Name
+		preview.Model = &skin.Model
+		textureID = skin.Texture
+	}
+
+	url, xErr := l.resolveTextureURL(ctx, textureID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	preview.TextureURL = url
+	return preview, nil
+}
+
+// buildLibraryShareDTO 将分享链接实体转换为 DTO，预览链接指向前端分享页。
+func buildLibraryShareDTO(share *entity.LibraryShare) models.LibraryShareDTO {
+	frontendURL := xEnv.GetEnvString(bConst.EnvFrontendURL, "")
+	return models.LibraryShareDTO{
+		ID:         share.ID,
+		Kind:       share.Kind,
+		LibraryID:  share.LibraryID,
+		Token:      share.Token,
+		PreviewURL: frontendURL + "/share/" + share.Token,
+		ExpiresAt:  share.ExpiresAt,
+		ClaimCount: share.ClaimCount,
+		RevokedAt:  share.RevokedAt,
+		CreatedAt:  share.CreatedAt,
+	}
+}
+
+// randomShareToken 使用 crypto/rand 生成 URL 安全的分享令牌。
+func randomShareToken() (string, error) {
+	buf := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
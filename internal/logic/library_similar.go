@@ -0,0 +1,422 @@
+package logic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"image/png"
+	"math"
+	"math/bits"
+	"sort"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+)
+
+const (
+	perceptualHashSampleSize = 32 // 计算感知哈希前将纹理归一化到的边长
+	perceptualHashLowFreq    = 8  // 参与感知哈希的低频 DCT 系数边长（8x8 = 64 位）
+
+	similarFlagMaxDistance       = 6  // 待审核资源判定为近似重复的最大汉明距离
+	similarLookupMaxDistance     = 20 // 管理员相似纹理查询允许的最大汉明距离
+	similarLookupDefaultDistance = 10 // 管理员相似纹理查询默认的汉明距离
+	similarLookupMaxResults      = 50 // 管理员相似纹理查询返回数量上限
+
+	perceptualHashBackfillBatchSize = 100 // 存量感知哈希回填每批处理的资源数
+)
+
+// perceptualHashDCT 归一化采样点到低频 DCT 系数的余弦系数表。
+var perceptualHashDCT = func() [perceptualHashLowFreq][perceptualHashSampleSize]float64 {
+	var table [perceptualHashLowFreq][perceptualHashSampleSize]float64
+	for k := 0; k < perceptualHashLowFreq; k++ {
+		for n := 0; n < perceptualHashSampleSize; n++ {
+			table[k][n] = math.Cos(math.Pi * float64(2*n+1) * float64(k) / float64(2*perceptualHashSampleSize))
+		}
+	}
+	return table
+}()
+
+// ListSimilarSkins 管理员查询与指定皮肤纹理相似的皮肤（含私有与待审核），用于处理盗用投诉。
+//
+// 按感知哈希汉明距离升序、创建时间升序返回，距离相同时先上传者在前；maxDistance 为 0 时使用默认值。
+func (l *LibraryLogic) ListSimilarSkins(ctx context.Context, skinID xSnowflake.SnowflakeID, maxDistance int) ([]models.SimilarSkinDTO, *xError.Error) {
+	l.log.Info(ctx, "ListSimilarSkins - 查询相似皮肤")
+
+	maxDistance, xErr := normalizeSimilarDistance(ctx, maxDistance)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	skin, found, xErr := l.repo.skinRepo.GetByID(ctx, nil, skinID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "皮肤不存在", true)
+	}
+	if skin.PerceptualHash == nil {
+		return nil, xError.NewError(ctx, xError.ParameterError, "该皮肤纹理暂无感知哈希，无法查询相似纹理", true)
+	}
+
+	skins, xErr := l.repo.skinRepo.ListSimilar(ctx, nil, repository.LibrarySimilarFilter{
+		PerceptualHash: *skin.PerceptualHash,
+		ExcludeID:      skin.ID,
+		MaxDistance:    maxDistance,
+		Limit:          similarLookupMaxResults,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	dtos, xErr := l.buildSkinDTOs(ctx, skins)
+	if xErr != nil {
+		return nil, xErr
+	}
+	results := make([]models.SimilarSkinDTO, len(skins))
+	for i := range skins {
+		results[i] = models.SimilarSkinDTO{
+			Skin:     dtos[i],
+			Distance: perceptualHashDistance(*skin.PerceptualHash, *skins[i].PerceptualHash),
+		}
+	}
+	return results, nil
+}
+
+// ListSimilarCapes 管理员查询与指定披风纹理相似的披风。
+//
+// 同构于 ListSimilarSkins，Skin → Cape。
+func (l *LibraryLogic) ListSimilarCapes(ctx context.Context, capeID xSnowflake.SnowflakeID, maxDistance int) ([]models.SimilarCapeDTO, *xError.Error) {
+	l.log.Info(ctx, "ListSimilarCapes - 查询相似披风")
+
+	maxDistance, xErr := normalizeSimilarDistance(ctx, maxDistance)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	cape, found, xErr := l.repo.capeRepo.GetByID(ctx, nil, capeID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "披风不存在", true)
+	}
+	if cape.PerceptualHash == nil {
+		return nil, xError.NewError(ctx, xError.ParameterError, "该披风纹理暂无感知哈希，无法查询相似纹理", true)
+	}
+
+	capes, xErr := l.repo.capeRepo.ListSimilar(ctx, nil, repository.LibrarySimilarFilter{
+		PerceptualHash: *cape.PerceptualHash,
+		ExcludeID:      cape.ID,
+		MaxDistance:    maxDistance,
+		Limit:          similarLookupMaxResults,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	dtos, xErr := l.buildCapeDTOs(ctx, capes)
+	if xErr != nil {
+		return nil, xErr
+	}
+	results := make([]models.SimilarCapeDTO, len(capes))
+	for i := range capes {
+		results[i] = models.SimilarCapeDTO{
+			Cape:     dtos[i],
+			Distance: perceptualHashDistance(*cape.PerceptualHash, *capes[i].PerceptualHash),
+		}
+	}
+	return results, nil
+}
+
+// flagSimilarSkin 检测进入待审核的公开皮肤是否与已公开皮肤近似重复，并记录最相近的皮肤 ID。
+//
+// 仅作为审核提示，检测失败只记录警告不阻断主流程；皮肤不处于待审核状态且没有待审核纹理时不做处理。
+func (l *LibraryLogic) flagSimilarSkin(ctx context.Context, skin *entity.SkinLibrary) {
+	if !skin.IsPublic || (skin.ReviewStatus != entityType.ReviewStatusPending && skin.PendingTextureVersion == nil) {
+		return
+	}
+
+	// 存在待审核纹理版本时检测待审核纹理，而非当前生效的纹理
+	perceptualHash := skin.PerceptualHash
+	if skin.PendingTextureVersion != nil {
+		pending, found, xErr := l.repo.versionRepo.GetByTargetAndVersion(ctx, nil, entityType.LibraryKindSkin, skin.ID, *skin.PendingTextureVersion)
+		if xErr != nil {
+			l.log.Warn(ctx, fmt.Sprintf("查询待审核皮肤纹理失败(skinID=%d): %v", skin.ID, xErr.ErrorMessage))
+			return
+		}
+		if found {
+			perceptualHash = pending.PerceptualHash
+		}
+	}
+
+	var similarToID *xSnowflake.SnowflakeID
+	if perceptualHash != nil {
+		similar, xErr := l.repo.skinRepo.ListSimilar(ctx, nil, repository.LibrarySimilarFilter{
+			PerceptualHash: *perceptualHash,
+			ExcludeID:      skin.ID,
+			MaxDistance:    similarFlagMaxDistance,
+			ApprovedOnly:   true,
+			Limit:          1,
+		})
+		if xErr != nil {
+			l.log.Warn(ctx, fmt.Sprintf("检测近似重复皮肤失败(skinID=%d): %v", skin.ID, xErr.ErrorMessage))
+			return
+		}
+		if len(similar) > 0 {
+			similarToID = &similar[0].ID
+			l.log.Info(ctx, fmt.Sprintf("皮肤疑似近似重复(skinID=%d, similarTo=%d)", skin.ID, similar[0].ID))
+		}
+	}
+	if similarToID == nil && skin.SimilarToID == nil {
+		return
+	}
+
+	if xErr := l.repo.skinRepo.UpdateSimilarTo(ctx, nil, skin.ID, similarToID); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("记录近似重复皮肤失败(skinID=%d): %v", skin.ID, xErr.ErrorMessage))
+		return
+	}
+	skin.SimilarToID = similarToID
+}
+
+// flagSimilarCape 检测进入待审核的公开披风是否与已公开披风近似重复。
+//
+// 同构于 flagSimilarSkin，Skin → Cape。
+func (l *LibraryLogic) flagSimilarCape(ctx context.Context, cape *entity.CapeLibrary) {
+	if !cape.IsPublic || (cape.ReviewStatus != entityType.ReviewStatusPending && cape.PendingTextureVersion == nil) {
+		return
+	}
+
+	// 存在待审核纹理版本时检测待审核纹理，而非当前生效的纹理
+	perceptualHash := cape.PerceptualHash
+	if cape.PendingTextureVersion != nil {
+		pending, found, xErr := l.repo.versionRepo.GetByTargetAndVersion(ctx, nil, entityType.LibraryKindCape, cape.ID, *cape.PendingTextureVersion)
+		if xErr != nil {
+			l.log.Warn(ctx, fmt.Sprintf("查询待审核披风纹理失败(capeID=%d): %v", cape.ID, xErr.ErrorMessage))
+			return
+		}
+		if found {
+			perceptualHash = pending.PerceptualHash
+		}
+	}
+
+	var similarToID *xSnowflake.SnowflakeID
+	if perceptualHash != nil {
+		similar, xErr := l.repo.capeRepo.ListSimilar(ctx, nil, repository.LibrarySimilarFilter{
+			PerceptualHash: *perceptualHash,
+			ExcludeID:      cape.ID,
+			MaxDistance:    similarFlagMaxDistance,
+			ApprovedOnly:   true,
+			Limit:          1,
+		})
+		if xErr != nil {
+			l.log.Warn(ctx, fmt.Sprintf("检测近似重复披风失败(capeID=%d): %v", cape.ID, xErr.ErrorMessage))
+			return
+		}
+		if len(similar) > 0 {
+			similarToID = &similar[0].ID
+			l.log.Info(ctx, fmt.Sprintf("披风疑似近似重复(capeID=%d, similarTo=%d)", cape.ID, similar[0].ID))
+		}
+	}
+	if similarToID == nil && cape.SimilarToID == nil {
+		return
+	}
+
+	if xErr := l.repo.capeRepo.UpdateSimilarTo(ctx, nil, cape.ID, similarToID); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("记录近似重复披风失败(capeID=%d): %v", cape.ID, xErr.ErrorMessage))
+		return
+	}
+	cape.SimilarToID = similarToID
+}
+
+// BackfillPerceptualHashes 为缺少感知哈希的存量皮肤/披风回填哈希（启动时由后台任务调用一次）。
+//
+// 按 ID 升序分批读取纹理并计算哈希，读取大小受 fetchTexture 上限约束；单条读取或计算失败仅记录日志并跳过，
+// 留待下次启动重试。返回本轮回填的皮肤数与披风数。
+func (l *LibraryLogic) BackfillPerceptualHashes(ctx context.Context) (int64, int64, *xError.Error) {
+	l.log.Info(ctx, "BackfillPerceptualHashes - 回填存量纹理感知哈希")
+
+	var skinCount int64
+	var cursor xSnowflake.SnowflakeID
+	for {
+		skins, xErr := l.repo.skinRepo.ListMissingPerceptualHash(ctx, nil, cursor, perceptualHashBackfillBatchSize)
+		if xErr != nil {
+			return skinCount, 0, xErr
+		}
+		if len(skins) == 0 {
+			break
+		}
+
+		for i := range skins {
+			hash := l.fetchPerceptualHash(ctx, skins[i].ID, skins[i].Texture)
+			if hash == nil {
+				continue
+			}
+			if xErr := l.repo.skinRepo.UpdatePerceptualHash(ctx, nil, skins[i].ID, skins[i].Texture, *hash); xErr != nil {
+				l.log.Warn(ctx, fmt.Sprintf("回填皮肤感知哈希失败(skinID=%d): %s", skins[i].ID, xErr.ErrorMessage))
+				continue
+			}
+			skinCount++
+		}
+
+		cursor = skins[len(skins)-1].ID
+		if len(skins) < perceptualHashBackfillBatchSize {
+			break
+		}
+	}
+
+	var capeCount int64
+	cursor = 0
+	for {
+		capes, xErr := l.repo.capeRepo.ListMissingPerceptualHash(ctx, nil, cursor, perceptualHashBackfillBatchSize)
+		if xErr != nil {
+			return skinCount, capeCount, xErr
+		}
+		if len(capes) == 0 {
+			break
+		}
+
+		for i := range capes {
+			hash := l.fetchPerceptualHash(ctx, capes[i].ID, capes[i].Texture)
+			if hash == nil {
+				continue
+			}
+			if xErr := l.repo.capeRepo.UpdatePerceptualHash(ctx, nil, capes[i].ID, capes[i].Texture, *hash); xErr != nil {
+				l.log.Warn(ctx, fmt.Sprintf("回填披风感知哈希失败(capeID=%d): %s", capes[i].ID, xErr.ErrorMessage))
+				continue
+			}
+			capeCount++
+		}
+
+		cursor = capes[len(capes)-1].ID
+		if len(capes) < perceptualHashBackfillBatchSize {
+			break
+		}
+	}
+	return skinCount, capeCount, nil
+}
+
+// fetchPerceptualHash 读取纹理并计算感知哈希，读取失败或纹理无法计算哈希时记录日志并返回 nil。
+func (l *LibraryLogic) fetchPerceptualHash(ctx context.Context, libraryID xSnowflake.SnowflakeID, texture int64) *int64 {
+	if texture == 0 {
+		l.log.Warn(ctx, fmt.Sprintf("回填感知哈希跳过(libraryID=%d): 纹理文件 ID 为空", libraryID))
+		return nil
+	}
+	data, err := l.fetchTexture(ctx, texture)
+	if err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("回填感知哈希跳过(libraryID=%d): %v", libraryID, err))
+		return nil
+	}
+	hash := calculatePerceptualHash(data)
+	if hash == nil {
+		l.log.Warn(ctx, fmt.Sprintf("回填感知哈希跳过(libraryID=%d): 纹理无法计算感知哈希", libraryID))
+	}
+	return hash
+}
+
+// normalizeSimilarDistance 校验相似纹理查询的最大汉明距离，0 表示使用默认值。
+func normalizeSimilarDistance(ctx context.Context, maxDistance int) (int, *xError.Error) {
+	if maxDistance == 0 {
+		return similarLookupDefaultDistance, nil
+	}
+	if maxDistance < 0 || maxDistance > similarLookupMaxDistance {
+		return 0, xError.NewError(ctx, xError.ParameterError, xError.ErrMessage(fmt.Sprintf("最大汉明距离必须在 1-%d 之间", similarLookupMaxDistance)), true)
+	}
+	return maxDistance, nil
+}
+
+// calculatePerceptualHash 计算纹理的 64 位感知哈希（pHash）。
+//
+// 归一化流程：解码 PNG → 将透明像素合成到中灰背景（忽略透明区域残留的颜色数据）→ 转为亮度 →
+// 按区域平均缩放到 32x32 → 二维 DCT 取左上 8x8 低频系数 → 与系数中位数比较得到 64 位哈希。
+// 重新编码、改动少量像素或等比放大的高清纹理与原纹理的汉明距离很小。
+// 完整解码前先限制数据大小并通过 PNG 头部校验尺寸，超出纹理规格的数据不做解码。
+// 纹理无法按 PNG 解码、尺寸不合法或内容完全均匀时返回 nil，不参与近似重复检测。
+func calculatePerceptualHash(data []byte) *int64 {
+	if len(data) > libraryTextureMaxSize {
+		return nil
+	}
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil || !isTextureSizeAllowed(config.Width, config.Height) {
+		return nil
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil
+	}
+
+	// 1. 区域平均缩放到 32x32 亮度矩阵
+	var samples [perceptualHashSampleSize][perceptualHashSampleSize]float64
+	for sy := 0; sy < perceptualHashSampleSize; sy++ {
+		y0 := sy * height / perceptualHashSampleSize
+		y1 := max((sy+1)*height/perceptualHashSampleSize, y0+1)
+		for sx := 0; sx < perceptualHashSampleSize; sx++ {
+			x0 := sx * width / perceptualHashSampleSize
+			x1 := max((sx+1)*width/perceptualHashSampleSize, x0+1)
+
+			var sum float64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+					luma := 0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)
+					alpha := float64(c.A) / 255
+					sum += luma*alpha + 128*(1-alpha)
+				}
+			}
+			samples[sy][sx] = sum / float64((y1-y0)*(x1-x0))
+		}
+	}
+
+	// 2. 可分离二维 DCT，仅计算左上 8x8 低频系数
+	var rows [perceptualHashSampleSize][perceptualHashLowFreq]float64
+	for y := 0; y < perceptualHashSampleSize; y++ {
+		for u := 0; u < perceptualHashLowFreq; u++ {
+			var sum float64
+			for x := 0; x < perceptualHashSampleSize; x++ {
+				sum += samples[y][x] * perceptualHashDCT[u][x]
+			}
+			rows[y][u] = sum
+		}
+	}
+	coeffs := make([]float64, 0, perceptualHashLowFreq*perceptualHashLowFreq)
+	for v := 0; v < perceptualHashLowFreq; v++ {
+		for u := 0; u < perceptualHashLowFreq; u++ {
+			var sum float64
+			for y := 0; y < perceptualHashSampleSize; y++ {
+				sum += rows[y][u] * perceptualHashDCT[v][y]
+			}
+			coeffs = append(coeffs, sum)
+		}
+	}
+
+	// 3. 与中位数比较生成哈希（跳过直流分量计算中位数，避免整体亮度影响）
+	sorted := append([]float64(nil), coeffs[1:]...)
+	sort.Float64s(sorted)
+	if sorted[len(sorted)-1]-sorted[0] < 1e-6 {
+		return nil
+	}
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var hash uint64
+	for i, coeff := range coeffs {
+		if coeff > median {
+			hash |= 1 << uint(i)
+		}
+	}
+	result := int64(hash)
+	return &result
+}
+
+// perceptualHashDistance 计算两个感知哈希的汉明距离。
+func perceptualHashDistance(a int64, b int64) int {
+	return bits.OnesCount64(uint64(a ^ b))
+}
@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// encodeTestTexture 按给定尺寸与像素函数生成 PNG 纹理数据。
+func encodeTestTexture(t *testing.T, width int, height int, pixel func(x, y int) color.NRGBA) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, pixel(x, y))
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("编码测试纹理失败: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// checkerPixel 返回以 cell 像素为边长的黑白棋盘格图案。
+func checkerPixel(cell int) func(x, y int) color.NRGBA {
+	return func(x, y int) color.NRGBA {
+		if (x/cell+y/cell)%2 == 0 {
+			return color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+		}
+		return color.NRGBA{A: 255}
+	}
+}
+
+// gradientPixel 返回左暗右亮的水平渐变图案，scale 为相对 64 像素基准的放大倍数。
+func gradientPixel(scale int) func(x, y int) color.NRGBA {
+	return func(x, y int) color.NRGBA {
+		v := uint8(x / scale * 4)
+		return color.NRGBA{R: v, G: v, B: v, A: 255}
+	}
+}
+
+// TestPerceptualHashDistance 验证感知哈希汉明距离计算。
+func TestPerceptualHashDistance(t *testing.T) {
+	cases := []struct {
+		name string
+		a    int64
+		b    int64
+		want int
+	}{
+		{name: "相同哈希", a: 0x1234, b: 0x1234, want: 0},
+		{name: "单比特差异", a: 0b0001, b: 0b0011, want: 1},
+		{name: "低 8 位互补", a: 0x0F, b: 0xF0, want: 8},
+		{name: "全部比特不同", a: 0, b: -1, want: 64},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := perceptualHashDistance(tc.a, tc.b); got != tc.want {
+				t.Errorf("perceptualHashDistance(%#x, %#x) = %d, 期望 %d", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestCalculatePerceptualHash 验证感知哈希对非法输入返回 nil，且对等比放大的纹理保持稳定。
+func TestCalculatePerceptualHash(t *testing.T) {
+	gradient := encodeTestTexture(t, 64, 64, gradientPixel(1))
+	gradientHD := encodeTestTexture(t, 128, 128, gradientPixel(2))
+	checker := encodeTestTexture(t, 64, 64, checkerPixel(8))
+
+	nilCases := []struct {
+		name string
+		data []byte
+	}{
+		{name: "非 PNG 数据", data: []byte("not a png")},
+		{name: "内容完全均匀", data: encodeTestTexture(t, 64, 64, func(x, y int) color.NRGBA { return color.NRGBA{R: 10, G: 20, B: 30, A: 255} })},
+		{name: "宽度不是 64 的整数倍", data: encodeTestTexture(t, 100, 100, checkerPixel(10))},
+		{name: "宽高比不合法", data: encodeTestTexture(t, 64, 128, checkerPixel(8))},
+		{name: "尺寸超过上限", data: encodeTestTexture(t, 2048, 2048, checkerPixel(256))},
+		{name: "数据超过大小上限", data: append(append([]byte(nil), checker...), make([]byte, libraryTextureMaxSize)...)},
+	}
+	for _, tc := range nilCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if hash := calculatePerceptualHash(tc.data); hash != nil {
+				t.Errorf("calculatePerceptualHash 期望返回 nil，实际为 %#x", *hash)
+			}
+		})
+	}
+
+	hashCases := []struct {
+		name        string
+		a           []byte
+		b           []byte
+		maxDistance int
+		minDistance int
+	}{
+		{name: "相同纹理", a: gradient, b: gradient, maxDistance: 0},
+		{name: "等比放大的高清纹理", a: gradient, b: gradientHD, maxDistance: similarFlagMaxDistance},
+		{name: "不同纹理", a: gradient, b: checker, minDistance: similarFlagMaxDistance + 1, maxDistance: 64},
+	}
+	for _, tc := range hashCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hashA := calculatePerceptualHash(tc.a)
+			hashB := calculatePerceptualHash(tc.b)
+			if hashA == nil || hashB == nil {
+				t.Fatalf("calculatePerceptualHash 期望返回哈希，实际为 nil")
+			}
+			distance := perceptualHashDistance(*hashA, *hashB)
+			if distance < tc.minDistance || distance > tc.maxDistance {
+				t.Errorf("汉明距离 = %d, 期望在 %d-%d 之间", distance, tc.minDistance, tc.maxDistance)
+			}
+		})
+	}
+}
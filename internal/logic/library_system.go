@@ -0,0 +1,212 @@
+package logic
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+)
+
+// CreateSystemSkin 创建系统内置皮肤（管理员专用）。
+//
+// 系统皮肤不归属任何用户、不占用配额，创建后直接视为审核通过。
+// isDefault 为 true 时新注册用户自动以 Admin 关联获得该皮肤；isDefaultEquip 为 true 时新游戏档案自动装备该皮肤（隐含 isDefault）。
+func (l *LibraryLogic) CreateSystemSkin(ctx context.Context, name string, modelType uint8, texture string, isPublic bool, isDefault bool, isDefaultEquip bool) (*models.SkinDTO, *xError.Error) {
+	l.log.Info(ctx, "CreateSystemSkin - 创建系统皮肤")
+
+	validatedName, xErr := l.validateSkinName(ctx, name)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	model := entity.ModelType(modelType)
+	if model != entity.ModelTypeClassic && model != entity.ModelTypeSlim {
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效皮肤模型类型", true)
+	}
+
+	textureData, xErr := l.decodeBase64Texture(ctx, texture)
+	if xErr != nil {
+		return nil, xErr
+	}
+	textureHash := l.calculateTextureHash(textureData)
+	perceptualHash := calculatePerceptualHash(textureData)
+
+	// 上传到对象存储（事务外执行，避免长事务占用连接）
+	uploadResp, fileID, xErr := l.uploadTexture(ctx, entityType.LibraryKindSkin, texture)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	createdSkin, xErr := l.repo.txn.CreateSystemSkin(ctx, &entity.SkinLibrary{
+		Name:           validatedName,
+		Texture:        fileID,
+		TextureHash:    textureHash,
+		PerceptualHash: perceptualHash,
+		Model:          model,
+		IsPublic:       isPublic,
+		ReviewStatus:   entityType.ReviewStatusApproved,
+		IsDefault:      isDefault || isDefaultEquip,
+		IsDefaultEquip: isDefaultEquip,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	// 事务成功后确认文件转为永久态
+	l.cacheVerifyFile(ctx, uploadResp.FileID)
+	if createdSkin.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+	return l.buildSkinDTO(ctx, createdSkin)
+}
+
+// ListSystemSkins 分页查询系统内置皮肤目录（管理员专用）。
+func (l *LibraryLogic) ListSystemSkins(ctx context.Context, includeRetired bool, page int, pageSize int) ([]models.SkinDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListSystemSkins - 查询系统皮肤目录")
+
+	skins, total, xErr := l.repo.skinRepo.ListSystem(ctx, nil, includeRetired, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	dtos, xErr := l.buildSkinDTOs(ctx, skins)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	return dtos, total, nil
+}
+
+// UpdateSystemSkin 更新系统皮肤的名称、公开状态与默认标记（管理员专用），空指针表示保持原值。
+func (l *LibraryLogic) UpdateSystemSkin(ctx context.Context, skinID xSnowflake.SnowflakeID, name *string, isPublic *bool, isDefault *bool, isDefaultEquip *bool) (*models.SkinDTO, *xError.Error) {
+	l.log.Info(ctx, "UpdateSystemSkin - 更新系统皮肤")
+
+	if name != nil {
+		validatedName, xErr := l.validateSkinName(ctx, *name)
+		if xErr != nil {
+			return nil, xErr
+		}
+		name = &validatedName
+	}
+
+	updatedSkin, xErr := l.repo.txn.UpdateSystemSkin(ctx, skinID, name, isPublic, isDefault, isDefaultEquip)
+	if xErr != nil {
+		return nil, xErr
+	}
+	l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	return l.buildSkinDTO(ctx, updatedSkin)
+}
+
+// RetireSystemSkin 停用系统皮肤（管理员专用）。
+//
+// 停用后皮肤不再公开、不再发放给新用户或被新游戏档案默认装备，已持有该皮肤的用户不受影响。
+func (l *LibraryLogic) RetireSystemSkin(ctx context.Context, skinID xSnowflake.SnowflakeID) *xError.Error {
+	l.log.Info(ctx, "RetireSystemSkin - 停用系统皮肤")
+
+	skin, xErr := l.repo.txn.RetireSystemSkin(ctx, skinID)
+	if xErr != nil {
+		return xErr
+	}
+	if skin.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+	return nil
+}
+
+// CreateSystemCape 创建系统内置披风（管理员专用）。
+//
+// 系统披风不归属任何用户、不占用配额，创建后直接视为审核通过。
+// isDefault 为 true 时新注册用户自动以 Admin 关联获得该披风。
+func (l *LibraryLogic) CreateSystemCape(ctx context.Context, name string, texture string, isPublic bool, isDefault bool) (*models.CapeDTO, *xError.Error) {
+	l.log.Info(ctx, "CreateSystemCape - 创建系统披风")
+
+	validatedName, xErr := l.validateCapeName(ctx, name)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	textureData, xErr := l.decodeBase64Texture(ctx, texture)
+	if xErr != nil {
+		return nil, xErr
+	}
+	textureHash := l.calculateTextureHash(textureData)
+	perceptualHash := calculatePerceptualHash(textureData)
+
+	// 上传到对象存储（事务外执行，避免长事务占用连接）
+	uploadResp, fileID, xErr := l.uploadTexture(ctx, entityType.LibraryKindCape, texture)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	createdCape, xErr := l.repo.txn.CreateSystemCape(ctx, &entity.CapeLibrary{
+		Name:           validatedName,
+		Texture:        fileID,
+		TextureHash:    textureHash,
+		PerceptualHash: perceptualHash,
+		IsPublic:       isPublic,
+		ReviewStatus:   entityType.ReviewStatusApproved,
+		IsDefault:      isDefault,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	// 事务成功后确认文件转为永久态
+	l.cacheVerifyFile(ctx, uploadResp.FileID)
+	if createdCape.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
+	return l.buildCapeDTO(ctx, createdCape)
+}
+
+// ListSystemCapes 分页查询系统内置披风目录（管理员专用）。
+func (l *LibraryLogic) ListSystemCapes(ctx context.Context, includeRetired bool, page int, pageSize int) ([]models.CapeDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListSystemCapes - 查询系统披风目录")
+
+	capes, total, xErr := l.repo.capeRepo.ListSystem(ctx, nil, includeRetired, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	dtos, xErr := l.buildCapeDTOs(ctx, capes)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	return dtos, total, nil
+}
+
+// UpdateSystemCape 更新系统披风的名称、公开状态与默认标记（管理员专用），空指针表示保持原值。
+func (l *LibraryLogic) UpdateSystemCape(ctx context.Context, capeID xSnowflake.SnowflakeID, name *string, isPublic *bool, isDefault *bool) (*models.CapeDTO, *xError.Error) {
+	l.log.Info(ctx, "UpdateSystemCape - 更新系统披风")
+
+	if name != nil {
+		validatedName, xErr := l.validateCapeName(ctx, *name)
+		if xErr != nil {
+			return nil, xErr
+		}
+		name = &validatedName
+	}
+
+	updatedCape, xErr := l.repo.txn.UpdateSystemCape(ctx, capeID, name, isPublic, isDefault)
+	if xErr != nil {
+		return nil, xErr
+	}
+	l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	return l.buildCapeDTO(ctx, updatedCape)
+}
+
+// RetireSystemCape 停用系统披风（管理员专用）。
+//
+// 停用后披风不再公开、不再发放给新用户，已持有该披风的用户不受影响。
+func (l *LibraryLogic) RetireSystemCape(ctx context.Context, capeID xSnowflake.SnowflakeID) *xError.Error {
+	l.log.Info(ctx, "RetireSystemCape - 停用系统披风")
+
+	cape, xErr := l.repo.txn.RetireSystemCape(ctx, capeID)
+	if xErr != nil {
+		return xErr
+	}
+	if cape.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package logic
+
+import (
+	"context"
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
+)
+
+const maxTextureVersions = 10 // 每个资源保留的纹理版本数量上限
+
+// ReplaceSkinTexture 替换皮肤纹理并保留版本历史。
+//
+// 皮肤 ID、点赞、收藏、标签与装备关系均保持不变；仅上传者可替换。
+// 新纹理与自身历史版本相同时直接切换到该版本。已通过审核的公开皮肤暂存新纹理，
+// 审核通过前继续使用原纹理；其余公开皮肤替换后重新进入待审核。待审核的纹理均检测近似重复。
+func (l *LibraryLogic) ReplaceSkinTexture(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID, texture string) (*models.SkinDTO, *xError.Error) {
+	l.log.Info(ctx, "ReplaceSkinTexture - 替换皮肤纹理")
+
+	textureData, xErr := l.decodeBase64Texture(ctx, texture)
+	if xErr != nil {
+		return nil, xErr
+	}
+	textureHash := l.calculateTextureHash(textureData)
+	perceptualHash := calculatePerceptualHash(textureData)
+
+	// 上传到对象存储（事务外执行，避免长事务占用连接）
+	uploadResp, fileID, xErr := l.uploadTexture(ctx, entityType.LibraryKindSkin, texture)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	skin, pruned, adopted, xErr := l.repo.txn.ReplaceSkinTexture(ctx, userID, skinID, fileID, textureHash, perceptualHash, maxTextureVersions)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	// 仅在新版本采用了本次上传的文件时确认为永久态，否则缓存态文件自然过期
+	if adopted {
+		l.cacheVerifyFile(ctx, uploadResp.FileID)
+	}
+	for _, version := range pruned {
+		l.releaseTextureIfUnreferenced(ctx, entityType.LibraryKindSkin, version.Texture, version.TextureHash)
+	}
+	if skin.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+	l.flagSimilarSkin(ctx, skin)
+	return l.buildSkinDTO(ctx, skin)
+}
+
+// ReplaceCapeTexture 替换披风纹理并保留版本历史。
+//
+// 同构于 ReplaceSkinTexture，Skin → Cape。
+func (l *LibraryLogic) ReplaceCapeTexture(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID, texture string) (*models.CapeDTO, *xError.Error) {
+	l.log.Info(ctx, "ReplaceCapeTexture - 替换披风纹理")
+
+	textureData, xErr := l.decodeBase64Texture(ctx, texture)
+	if xErr != nil {
+		return nil, xErr
+	}
+	textureHash := l.calculateTextureHash(textureData)
+	perceptualHash := calculatePerceptualHash(textureData)
+
+	// 上传到对象存储（事务外执行，避免长事务占用连接）
+	uploadResp, fileID, xErr := l.uploadTexture(ctx, entityType.LibraryKindCape, texture)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	cape, pruned, adopted, xErr := l.repo.txn.ReplaceCapeTexture(ctx, userID, capeID, fileID, textureHash, perceptualHash, maxTextureVersions)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	// 仅在新版本采用了本次上传的文件时确认为永久态，否则缓存态文件自然过期
+	if adopted {
+		l.cacheVerifyFile(ctx, uploadResp.FileID)
+	}
+	for _, version := range pruned {
+		l.releaseTextureIfUnreferenced(ctx, entityType.LibraryKindCape, version.Texture, version.TextureHash)
+	}
+	if cape.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
+	l.flagSimilarCape(ctx, cape)
+	return l.buildCapeDTO(ctx, cape)
+}
+
+// RollbackSkinTexture 将皮肤纹理回滚到指定历史版本。
+//
+// 已通过审核的公开皮肤与替换一致暂存目标版本，回滚到当前版本即撤销待审核的纹理变更。
+func (l *LibraryLogic) RollbackSkinTexture(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID, version int32) (*models.SkinDTO, *xError.Error) {
+	l.log.Info(ctx, "RollbackSkinTexture - 回滚皮肤纹理")
+
+	skin, xErr := l.repo.txn.RollbackSkinTexture(ctx, userID, skinID, version)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if skin.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindSkin)
+	}
+	l.flagSimilarSkin(ctx, skin)
+	return l.buildSkinDTO(ctx, skin)
+}
+
+// RollbackCapeTexture 将披风纹理回滚到指定历史版本。
+//
+// 同构于 RollbackSkinTexture，Skin → Cape。
+func (l *LibraryLogic) RollbackCapeTexture(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID, version int32) (*models.CapeDTO, *xError.Error) {
+	l.log.Info(ctx, "RollbackCapeTexture - 回滚披风纹理")
+
+	cape, xErr := l.repo.txn.RollbackCapeTexture(ctx, userID, capeID, version)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if cape.IsPublic {
+		l.invalidateGallery(ctx, entityType.LibraryKindCape)
+	}
+	l.flagSimilarCape(ctx, cape)
+	return l.buildCapeDTO(ctx, cape)
+}
+
+// ListSkinTextureVersions 获取皮肤的纹理版本历史（仅上传者可查看）。
+func (l *LibraryLogic) ListSkinTextureVersions(ctx context.Context, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) ([]models.LibraryTextureVersionDTO, *xError.Error) {
+	l.log.Info(ctx, "ListSkinTextureVersions - 获取皮肤纹理版本历史")
+
+	skin, found, xErr := l.repo.skinRepo.GetByIDAndUserID(ctx, nil, skinID, userID, false)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "皮肤不存在或不是由当前用户上传", true)
+	}
+
+	current := entity.LibraryTextureVersion{
+		Version:     skin.TextureVersion,
+		Texture:     skin.Texture,
+		TextureHash: skin.TextureHash,
+		CreatedBy:   userID,
+	}
+	current.CreatedAt = skin.CreatedAt
+	return l.listTextureVersions(ctx, entityType.LibraryKindSkin, skinID, current, skin.PendingTextureVersion)
+}
+
+// ListCapeTextureVersions 获取披风的纹理版本历史（仅上传者可查看）。
+func (l *LibraryLogic) ListCapeTextureVersions(ctx context.Context, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) ([]models.LibraryTextureVersionDTO, *xError.Error) {
+	l.log.Info(ctx, "ListCapeTextureVersions - 获取披风纹理版本历史")
+
+	cape, found, xErr := l.repo.capeRepo.GetByIDAndUserID(ctx, nil, capeID, userID, false)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "披风不存在或不是由当前用户上传", true)
+	}
+
+	current := entity.LibraryTextureVersion{
+		Version:     cape.TextureVersion,
+		Texture:     cape.Texture,
+		TextureHash: cape.TextureHash,
+		CreatedBy:   userID,
+	}
+	current.CreatedAt = cape.CreatedAt
+	return l.listTextureVersions(ctx, entityType.LibraryKindCape, capeID, current, cape.PendingTextureVersion)
+}
+
+// listTextureVersions 查询版本历史并解析纹理链接；尚未替换过纹理的资源仅返回当前纹理作为唯一版本。
+//
+// pending 为待审核纹理版本号，对应版本标记为待审核。
+func (l *LibraryLogic) listTextureVersions(ctx context.Context, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, current entity.LibraryTextureVersion, pending *int32) ([]models.LibraryTextureVersionDTO, *xError.Error) {
+	versions, xErr := l.repo.versionRepo.ListByTarget(ctx, nil, kind, libraryID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if len(versions) == 0 {
+		versions = []entity.LibraryTextureVersion{current}
+	}
+
+	textureIDs := make([]int64, len(versions))
+	for i, version := range versions {
+		textureIDs[i] = version.Texture
+	}
+	urlMap, xErr := l.resolveTextureURLsBatch(ctx, textureIDs)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	items := make([]models.LibraryTextureVersionDTO, len(versions))
+	for i, version := range versions {
+		items[i] = models.LibraryTextureVersionDTO{
+			Version:     version.Version,
+			TextureURL:  urlMap[version.Texture],
+			TextureHash: version.TextureHash,
+			IsCurrent:   version.Version == current.Version,
+			IsPending:   pending != nil && version.Version == *pending,
+			CreatedBy:   version.CreatedBy,
+			CreatedAt:   version.CreatedAt,
+		}
+	}
+	return items, nil
+}
+
+// uploadTexture 将 base64 纹理上传到对应种类的存储分类，返回上传结果与解析后的文件 ID。
+func (l *LibraryLogic) uploadTexture(ctx context.Context, kind entityType.LibraryKind, texture string) (*bStorage.FileInfo, int64, *xError.Error) {
+	category := bStorage.CategorySkin
+	kindName := "皮肤"
+	if kind == entityType.LibraryKindCape {
+		category = bStorage.CategoryCape
+		kindName = "披风"
+	}
+
+	uploadResp, err := l.helper.storage.Upload(ctx, category, texture)
+	if err != nil {
+		return nil, 0, mapStorageError(ctx, "上传"+kindName+"纹理失败", err)
+	}
+
+	fileID, err := strconv.ParseInt(uploadResp.FileID, 10, 64)
+	if err != nil {
+		return nil, 0, xError.NewError(ctx, xError.ServerInternalError, "解析纹理文件 ID 失败", true, err)
+	}
+	source := entityType.StorageFileSourceSkinTexture
+	if kind == entityType.LibraryKindCape {
+		source = entityType.StorageFileSourceCapeTexture
+	}
+	registerStorageFile(ctx, l.repo.storageRepo, l.log, source, fileID)
+	return uploadResp, fileID, nil
+}
+
+// releaseLibraryTextures 资源记录删除后清理其全部纹理版本，并释放不再被引用的纹理文件。
+func (l *LibraryLogic) releaseLibraryTextures(ctx context.Context, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, texture int64, textureHash string) {
+	versions, xErr := l.repo.versionRepo.DeleteByTarget(ctx, nil, kind, libraryID)
+	if xErr != nil {
+		versions = nil
+	}
+
+	released := map[int64]bool{texture: true}
+	l.releaseTextureIfUnreferenced(ctx, kind, texture, textureHash)
+	for _, version := range versions {
+		if released[version.Texture] {
+			continue
+		}
+		released[version.Texture] = true
+		l.releaseTextureIfUnreferenced(ctx, kind, version.Texture, version.TextureHash)
+	}
+}
+
+// releaseTextureIfUnreferenced 仅当没有任何资源记录或纹理版本引用该哈希时删除纹理文件。
+func (l *LibraryLogic) releaseTextureIfUnreferenced(ctx context.Context, kind entityType.LibraryKind, texture int64, textureHash string) {
+	var inUse bool
+	var xErr *xError.Error
+	if kind == entityType.LibraryKindCape {
+		_, inUse, xErr = l.repo.capeRepo.GetByTextureHash(ctx, nil, textureHash)
+	} else {
+		_, inUse, xErr = l.repo.skinRepo.GetByTextureHash(ctx, nil, textureHash)
+	}
+	if xErr != nil || inUse {
+		return
+	}
+
+	inUse, xErr = l.repo.versionRepo.ExistsByHash(ctx, nil, kind, textureHash)
+	if xErr != nil || inUse {
+		return
+	}
+	l.deleteBucketFile(ctx, texture)
+}
@@ -0,0 +1,533 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
+	xAsync "github.com/bamboo-services/bamboo-base-go/plugins/async"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/models"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+	bCtx "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/context"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
+)
+
+const (
+	storageReconcileBatchSize      = 100              // 对账每批核对的引用/文件数
+	storageReconcileStaleAfter     = 10 * time.Minute // 执行中的对账超过该时长无进度即视为中断，可重新发起
+	storageOrphanGracePeriod       = time.Hour        // 登记未满该时长的文件不判定为孤儿，避免误删上传中的文件
+	storageReconcileErrorMaxLength = 255              // 中断原因与问题说明的最大长度
+)
+
+// storageReconcileSources 参与引用核对的文件来源。
+var storageReconcileSources = []entityType.StorageFileSource{
+	entityType.StorageFileSourceSkinTexture,
+	entityType.StorageFileSourceCapeTexture,
+	entityType.StorageFileSourceIssueAttachment,
+}
+
+// storageRepo 存储对账数据访问适配器。
+type storageRepo struct {
+	fileRepo      *repository.StorageFileRepo      // 文件登记仓储
+	reconcileRepo *repository.StorageReconcileRepo // 对账报告仓储
+}
+
+// storageHelper 存储对账外部服务辅助器。
+type storageHelper struct {
+	storage bStorage.Storage // 文件存储后端
+}
+
+// storageFileState 对象存储中单个文件的核对状态。
+type storageFileState struct {
+	sha256  string // 文件 SHA256（对象存储未提供时为空）
+	isCache bool   // 是否仍为缓存态
+}
+
+// StorageLogic 存储对账业务逻辑处理者。
+//
+// 负责核对数据库中的文件引用（皮肤纹理、披风纹理、问题附件）与对象存储的一致性。
+// 对象存储不支持列举文件，孤儿文件依赖文件登记表发现：上传时登记、删除成功后移除，
+// 每次对账也会将现存引用补登记。登记表启用前上传且从未被引用的文件无法被发现。
+//
+// 试运行只生成报告；执行模式会确认缓存态引用，并删除超过宽限期且仍未被引用的孤儿文件。
+// 悬空引用与哈希不一致涉及用户数据，始终只记录不处理。
+type StorageLogic struct {
+	logic
+	repo   storageRepo
+	helper storageHelper
+}
+
+// NewStorageLogic 创建存储对账业务逻辑实例。
+func NewStorageLogic(ctx context.Context) *StorageLogic {
+	db := xCtxUtil.MustGetDB(ctx)
+	rdb := xCtxUtil.MustGetRDB(ctx)
+
+	return &StorageLogic{
+		logic: logic{
+			db:  db,
+			rdb: rdb,
+			log: xLog.WithName(xLog.NamedLOGC, "StorageLogic"),
+		},
+		repo: storageRepo{
+			fileRepo:      repository.NewStorageFileRepo(db),
+			reconcileRepo: repository.NewStorageReconcileRepo(db),
+		},
+		helper: storageHelper{
+			storage: bCtx.MustGetStorage(ctx),
+		},
+	}
+}
+
+// StartReconcile 发起一次存储对账，对账在后台执行，返回新建的报告。
+//
+// operatorID 为空表示由定时任务发起；同一时间只允许一个对账任务执行。
+func (l *StorageLogic) StartReconcile(ctx context.Context, operatorID *xSnowflake.SnowflakeID, dryRun bool) (*models.StorageReconcileReportDTO, *xError.Error) {
+	l.log.Info(ctx, "StartReconcile - 发起存储对账")
+
+	active, xErr := l.repo.reconcileRepo.ExistsActive(ctx, nil, time.Now().Add(-storageReconcileStaleAfter))
+	if xErr != nil {
+		return nil, xErr
+	}
+	if active {
+		return nil, xError.NewError(ctx, xError.DataConflict, "已有存储对账任务正在执行，请稍后再试", true)
+	}
+
+	report, xErr := l.repo.reconcileRepo.CreateReport(ctx, nil, &entity.StorageReconcileReport{
+		DryRun:      dryRun,
+		Status:      entityType.StorageReconcileStatusRunning,
+		TriggeredBy: operatorID,
+	})
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	reportID := report.ID
+	xAsync.Async(ctx, func(asyncCtx context.Context) {
+		l.runReconcile(asyncCtx, reportID, dryRun)
+	})
+
+	dto := buildStorageReconcileReportDTO(report)
+	return &dto, nil
+}
+
+// GetReconcileReport 获取存储对账报告详情及进度。
+func (l *StorageLogic) GetReconcileReport(ctx context.Context, reportID xSnowflake.SnowflakeID) (*models.StorageReconcileReportDTO, *xError.Error) {
+	l.log.Info(ctx, "GetReconcileReport - 获取存储对账报告")
+
+	report, xErr := l.getReport(ctx, reportID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	dto := buildStorageReconcileReportDTO(report)
+	return &dto, nil
+}
+
+// ListReconcileReports 分页获取存储对账报告列表。
+func (l *StorageLogic) ListReconcileReports(ctx context.Context, page int, pageSize int) ([]models.StorageReconcileReportDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListReconcileReports - 获取存储对账报告列表")
+
+	reports, total, xErr := l.repo.reconcileRepo.ListReports(ctx, nil, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	dtos := make([]models.StorageReconcileReportDTO, len(reports))
+	for i := range reports {
+		dtos[i] = buildStorageReconcileReportDTO(&reports[i])
+	}
+	return dtos, total, nil
+}
+
+// ListReconcileIssues 分页获取存储对账报告下的问题列表，issueType 为空时返回全部类型。
+func (l *StorageLogic) ListReconcileIssues(
+	ctx context.Context,
+	reportID xSnowflake.SnowflakeID,
+	issueType *entityType.StorageIssueType,
+	page int,
+	pageSize int,
+) ([]models.StorageReconcileIssueDTO, int64, *xError.Error) {
+	l.log.Info(ctx, "ListReconcileIssues - 获取存储对账问题列表")
+
+	if _, xErr := l.getReport(ctx, reportID); xErr != nil {
+		return nil, 0, xErr
+	}
+	issues, total, xErr := l.repo.reconcileRepo.ListIssues(ctx, nil, reportID, issueType, page, pageSize)
+	if xErr != nil {
+		return nil, 0, xErr
+	}
+	dtos := make([]models.StorageReconcileIssueDTO, len(issues))
+	for i := range issues {
+		dtos[i] = buildStorageReconcileIssueDTO(&issues[i])
+	}
+	return dtos, total, nil
+}
+
+// OpenLocalFile 打开本地存储中的文件用于下载，调用方负责关闭返回的文件。
+//
+// 仅在文件存储后端为 local 时可用，其他后端的文件由对象存储自行提供下载，统一返回文件不存在。
+func (l *StorageLogic) OpenLocalFile(ctx context.Context, fileID string) (*os.File, string, time.Time, *xError.Error) {
+	localStorage, ok := l.helper.storage.(*bStorage.LocalStorage)
+	if !ok {
+		return nil, "", time.Time{}, xError.NewError(ctx, xError.ResourceNotFound, "文件不存在", true)
+	}
+
+	file, mimeType, modTime, err := localStorage.Open(fileID)
+	if err != nil {
+		return nil, "", time.Time{}, mapStorageError(ctx, "读取文件失败", err)
+	}
+	return file, mimeType, modTime, nil
+}
+
+// getReport 查询对账报告，不存在时返回 ResourceNotFound。
+func (l *StorageLogic) getReport(ctx context.Context, reportID xSnowflake.SnowflakeID) (*entity.StorageReconcileReport, *xError.Error) {
+	report, found, xErr := l.repo.reconcileRepo.GetReportByID(ctx, nil, reportID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "存储对账报告不存在", true)
+	}
+	return report, nil
+}
+
+// runReconcile 后台对账任务：依次核对各来源的数据库引用，再核对文件登记表中的孤儿文件。
+//
+// 查询或进度写入失败时中断任务并记录原因，已写入的问题与计数保留。
+func (l *StorageLogic) runReconcile(ctx context.Context, reportID xSnowflake.SnowflakeID, dryRun bool) {
+	for _, source := range storageReconcileSources {
+		if xErr := l.reconcileReferences(ctx, reportID, source, dryRun); xErr != nil {
+			l.failReconcile(ctx, reportID, xErr)
+			return
+		}
+	}
+	if xErr := l.reconcileOrphans(ctx, reportID, dryRun); xErr != nil {
+		l.failReconcile(ctx, reportID, xErr)
+		return
+	}
+
+	if xErr := l.repo.reconcileRepo.Finish(ctx, nil, reportID, entityType.StorageReconcileStatusCompleted, nil); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("存储对账标记完成失败(reportID=%d): %s", reportID, xErr.ErrorMessage))
+	}
+}
+
+// reconcileReferences 分批核对指定来源的数据库引用：补登记引用文件，并检查悬空引用、哈希不一致与缓存态文件。
+func (l *StorageLogic) reconcileReferences(ctx context.Context, reportID xSnowflake.SnowflakeID, source entityType.StorageFileSource, dryRun bool) *xError.Error {
+	var cursor xSnowflake.SnowflakeID
+	for {
+		refs, xErr := l.repo.fileRepo.ListReferences(ctx, nil, source, cursor, storageReconcileBatchSize)
+		if xErr != nil {
+			return xErr
+		}
+		if len(refs) == 0 {
+			return nil
+		}
+
+		fileIDs := make([]int64, len(refs))
+		for i, ref := range refs {
+			fileIDs[i] = ref.FileID
+		}
+		if xErr := l.repo.fileRepo.Register(ctx, nil, source, fileIDs); xErr != nil {
+			return xErr
+		}
+		states, xErr := l.lookupFiles(ctx, fileIDs)
+		if xErr != nil {
+			return xErr
+		}
+
+		counts := repository.StorageReconcileCounts{CheckedReferences: int64(len(refs))}
+		var issues []entity.StorageReconcileIssue
+		verified := make(map[int64]*entity.StorageReconcileIssue)
+		for _, ref := range refs {
+			refID := ref.RefID
+			state, ok := states[ref.FileID]
+			if !ok {
+				counts.Dangling++
+				issues = append(issues, newStorageIssue(reportID, entityType.StorageIssueDanglingReference, source, ref.FileID, &refID))
+				continue
+			}
+
+			if ref.Hash != "" && state.sha256 != "" && !strings.EqualFold(ref.Hash, state.sha256) {
+				counts.Mismatch++
+				issue := newStorageIssue(reportID, entityType.StorageIssueHashMismatch, source, ref.FileID, &refID)
+				expected, actual := ref.Hash, state.sha256
+				issue.ExpectedHash, issue.ActualHash = &expected, &actual
+				issues = append(issues, issue)
+			}
+
+			if state.isCache {
+				counts.Unverified++
+				issue := newStorageIssue(reportID, entityType.StorageIssueUnverifiedReference, source, ref.FileID, &refID)
+				// 同一文件被多条记录引用时只确认一次，结果沿用首次确认
+				if first, done := verified[ref.FileID]; done {
+					issue.Action, issue.Message = first.Action, first.Message
+				} else if !dryRun {
+					l.verifyCachedFile(ctx, &issue)
+					verified[ref.FileID] = &issue
+				}
+				switch issue.Action {
+				case entityType.StorageIssueActionFixed:
+					counts.Fixed++
+				case entityType.StorageIssueActionFailed:
+					counts.Failed++
+				}
+				issues = append(issues, issue)
+			}
+		}
+
+		if xErr := l.repo.reconcileRepo.CreateIssues(ctx, nil, issues); xErr != nil {
+			return xErr
+		}
+		if xErr := l.repo.reconcileRepo.AddCounts(ctx, nil, reportID, counts); xErr != nil {
+			return xErr
+		}
+		cursor = refs[len(refs)-1].RefID
+	}
+}
+
+// reconcileOrphans 分批核对文件登记表，登记超过宽限期且未被任何记录引用的文件判定为孤儿文件。
+func (l *StorageLogic) reconcileOrphans(ctx context.Context, reportID xSnowflake.SnowflakeID, dryRun bool) *xError.Error {
+	createdBefore := time.Now().Add(-storageOrphanGracePeriod)
+
+	var cursor xSnowflake.SnowflakeID
+	for {
+		files, xErr := l.repo.fileRepo.ListBefore(ctx, nil, createdBefore, cursor, storageReconcileBatchSize)
+		if xErr != nil {
+			return xErr
+		}
+		if len(files) == 0 {
+			return nil
+		}
+
+		fileIDs := make([]int64, len(files))
+		for i, file := range files {
+			fileIDs[i] = file.FileID
+		}
+		referenced, xErr := l.repo.fileRepo.ReferencedFileIDs(ctx, nil, fileIDs)
+		if xErr != nil {
+			return xErr
+		}
+
+		counts := repository.StorageReconcileCounts{CheckedFiles: int64(len(files))}
+		var issues []entity.StorageReconcileIssue
+		for _, file := range files {
+			if referenced[file.FileID] {
+				continue
+			}
+			counts.Orphan++
+			issue := newStorageIssue(reportID, entityType.StorageIssueOrphanFile, file.Source, file.FileID, nil)
+			if !dryRun {
+				l.removeOrphanFile(ctx, &issue)
+				switch issue.Action {
+				case entityType.StorageIssueActionFixed:
+					counts.Fixed++
+				case entityType.StorageIssueActionFailed:
+					counts.Failed++
+				}
+			}
+			issues = append(issues, issue)
+		}
+
+		if xErr := l.repo.reconcileRepo.CreateIssues(ctx, nil, issues); xErr != nil {
+			return xErr
+		}
+		if xErr := l.repo.reconcileRepo.AddCounts(ctx, nil, reportID, counts); xErr != nil {
+			return xErr
+		}
+		cursor = files[len(files)-1].ID
+	}
+}
+
+// lookupFiles 查询文件在对象存储中的状态，返回结果中缺失的文件 ID 即为对象存储中不存在的文件。
+//
+// 优先使用 GetByList 批量查询；批量接口失败或未返回的文件逐个通过 Get 复核，
+// 仅 NotFound 视为文件不存在，其他错误直接返回以中断对账，避免误报悬空引用。
+func (l *StorageLogic) lookupFiles(ctx context.Context, fileIDs []int64) (map[int64]storageFileState, *xError.Error) {
+	states := make(map[int64]storageFileState, len(fileIDs))
+	seen := make(map[int64]bool, len(fileIDs))
+	fileIDList := make([]string, 0, len(fileIDs))
+	for _, fileID := range fileIDs {
+		if seen[fileID] {
+			continue
+		}
+		seen[fileID] = true
+		fileIDList = append(fileIDList, strconv.FormatInt(fileID, 10))
+	}
+
+	infos, err := l.helper.storage.GetByList(ctx, fileIDList)
+	if err != nil {
+		l.log.Warn(ctx, fmt.Sprintf("批量查询对象存储文件失败，降级为逐个查询: %v", err))
+	} else {
+		for _, info := range infos {
+			fileID, parseErr := strconv.ParseInt(info.FileID, 10, 64)
+			if parseErr != nil {
+				continue
+			}
+			states[fileID] = storageFileState{
+				sha256:  info.Sha256,
+				isCache: info.IsCache,
+			}
+		}
+	}
+
+	for _, fileIdStr := range fileIDList {
+		fileID, _ := strconv.ParseInt(fileIdStr, 10, 64)
+		if _, ok := states[fileID]; ok {
+			continue
+		}
+		info, err := l.helper.storage.Get(ctx, fileIdStr)
+		if err != nil {
+			if isStorageNotFound(err) {
+				continue
+			}
+			return nil, mapStorageError(ctx, "查询对象存储文件失败", err)
+		}
+		states[fileID] = storageFileState{
+			sha256:  info.Sha256,
+			isCache: info.IsCache,
+		}
+	}
+	return states, nil
+}
+
+// verifyCachedFile 将被引用的缓存态文件确认为永久态，并记录处理结果。
+func (l *StorageLogic) verifyCachedFile(ctx context.Context, issue *entity.StorageReconcileIssue) {
+	if err := l.helper.storage.CacheVerify(ctx, strconv.FormatInt(issue.FileID, 10)); err != nil {
+		markStorageIssueFailed(issue, err.Error())
+		return
+	}
+	issue.Action = entityType.StorageIssueActionFixed
+}
+
+// removeOrphanFile 删除孤儿文件并移除其登记，并记录处理结果。
+//
+// 删除前再次确认文件未被引用，避免与并发的引用写入冲突；对象存储中已不存在的文件视为删除成功。
+func (l *StorageLogic) removeOrphanFile(ctx context.Context, issue *entity.StorageReconcileIssue) {
+	referenced, xErr := l.repo.fileRepo.ReferencedFileIDs(ctx, nil, []int64{issue.FileID})
+	if xErr != nil {
+		markStorageIssueFailed(issue, string(xErr.ErrorMessage))
+		return
+	}
+	if referenced[issue.FileID] {
+		markStorageIssueFailed(issue, "文件已被重新引用，跳过删除")
+		return
+	}
+
+	err := l.helper.storage.Delete(ctx, strconv.FormatInt(issue.FileID, 10))
+	if err != nil && !isStorageNotFound(err) {
+		markStorageIssueFailed(issue, err.Error())
+		return
+	}
+	if xErr := l.repo.fileRepo.DeleteByFileID(ctx, nil, issue.FileID); xErr != nil {
+		markStorageIssueFailed(issue, string(xErr.ErrorMessage))
+		return
+	}
+	issue.Action = entityType.StorageIssueActionFixed
+}
+
+// failReconcile 记录对账任务中断原因并标记失败。
+func (l *StorageLogic) failReconcile(ctx context.Context, reportID xSnowflake.SnowflakeID, cause *xError.Error) {
+	message := truncateStorageMessage(string(cause.ErrorMessage))
+	l.log.Warn(ctx, fmt.Sprintf("存储对账后台任务中断(reportID=%d): %s", reportID, message))
+	if xErr := l.repo.reconcileRepo.Finish(ctx, nil, reportID, entityType.StorageReconcileStatusFailed, &message); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("记录存储对账中断原因失败(reportID=%d): %s", reportID, xErr.ErrorMessage))
+	}
+}
+
+// registerStorageFile 登记新上传的对象存储文件，供存储对账发现孤儿文件。
+//
+// 登记失败仅记录日志，不影响上传流程（下次对账会补登记仍被引用的文件）。
+func registerStorageFile(ctx context.Context, repo *repository.StorageFileRepo, log *xLog.LogNamedLogger, source entityType.StorageFileSource, fileID int64) {
+	if xErr := repo.Register(ctx, nil, source, []int64{fileID}); xErr != nil {
+		log.Warn(ctx, fmt.Sprintf("登记对象存储文件失败(fileId=%d): %s", fileID, xErr.ErrorMessage))
+	}
+}
+
+// unregisterStorageFile 移除已删除文件的登记。
+//
+// 移除失败仅记录日志，残留登记会在下次执行模式对账时被清理。
+func unregisterStorageFile(ctx context.Context, repo *repository.StorageFileRepo, log *xLog.LogNamedLogger, fileID int64) {
+	if xErr := repo.DeleteByFileID(ctx, nil, fileID); xErr != nil {
+		log.Warn(ctx, fmt.Sprintf("移除对象存储文件登记失败(fileId=%d): %s", fileID, xErr.ErrorMessage))
+	}
+}
+
+// newStorageIssue 构造一条待处理的对账问题。
+func newStorageIssue(
+	reportID xSnowflake.SnowflakeID,
+	issueType entityType.StorageIssueType,
+	source entityType.StorageFileSource,
+	fileID int64,
+	refID *xSnowflake.SnowflakeID,
+) entity.StorageReconcileIssue {
+	return entity.StorageReconcileIssue{
+		ReportID: reportID,
+		Type:     issueType,
+		Source:   source,
+		FileID:   fileID,
+		RefID:    refID,
+		Action:   entityType.StorageIssueActionNone,
+	}
+}
+
+// markStorageIssueFailed 将对账问题标记为处理失败并记录原因。
+func markStorageIssueFailed(issue *entity.StorageReconcileIssue, message string) {
+	message = truncateStorageMessage(message)
+	issue.Action = entityType.StorageIssueActionFailed
+	issue.Message = &message
+}
+
+// truncateStorageMessage 截断错误信息以适配数据库字段长度。
+func truncateStorageMessage(message string) string {
+	runes := []rune(message)
+	if len(runes) > storageReconcileErrorMaxLength {
+		return string(runes[:storageReconcileErrorMaxLength])
+	}
+	return message
+}
+
+// buildStorageReconcileReportDTO 将对账报告实体转换为 DTO。
+func buildStorageReconcileReportDTO(report *entity.StorageReconcileReport) models.StorageReconcileReportDTO {
+	return models.StorageReconcileReportDTO{
+		ID:                report.ID,
+		DryRun:            report.DryRun,
+		Status:            report.Status,
+		TriggeredBy:       report.TriggeredBy,
+		CheckedReferences: report.CheckedReferences,
+		CheckedFiles:      report.CheckedFiles,
+		OrphanCount:       report.OrphanCount,
+		DanglingCount:     report.DanglingCount,
+		MismatchCount:     report.MismatchCount,
+		UnverifiedCount:   report.UnverifiedCount,
+		FixedCount:        report.FixedCount,
+		FailedCount:       report.FailedCount,
+		LastError:         report.LastError,
+		FinishedAt:        report.FinishedAt,
+		CreatedAt:         report.CreatedAt,
+		UpdatedAt:         report.UpdatedAt,
+	}
+}
+
+// buildStorageReconcileIssueDTO 将对账问题实体转换为 DTO。
+func buildStorageReconcileIssueDTO(issue *entity.StorageReconcileIssue) models.StorageReconcileIssueDTO {
+	return models.StorageReconcileIssueDTO{
+		ID:           issue.ID,
+		ReportID:     issue.ReportID,
+		Type:         issue.Type,
+		Source:       issue.Source,
+		FileID:       issue.FileID,
+		RefID:        issue.RefID,
+		ExpectedHash: issue.ExpectedHash,
+		ActualHash:   issue.ActualHash,
+		Action:       issue.Action,
+		Message:      issue.Message,
+		CreatedAt:    issue.CreatedAt,
+	}
+}
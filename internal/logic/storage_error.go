@@ -2,19 +2,29 @@ package logic
 
 import (
 	"context"
+	"errors"
 	"strings"
 
-	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	"connectrpc.com/connect"
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
 )
 
-// mapBucketError 将 beacon-bucket SDK 返回的 connect-go 错误动态映射为对应的业务错误码。
+// mapStorageError 将文件存储后端返回的错误动态映射为对应的业务错误码。
 //
-// 客户端类错误（invalid_argument、not_found、permission_denied 等）映射到 4xx，
+// 存储包定义的错误（ErrNotFound、ErrInvalidContent）优先匹配；beacon-bucket SDK 透传的
+// connect-go 错误中，客户端类错误（invalid_argument、not_found、permission_denied 等）映射到 4xx，
 // 服务端类错误（internal、unavailable 等）映射到 5xx。
-func mapBucketError(ctx context.Context, message string, err error) *xError.Error {
+func mapStorageError(ctx context.Context, message string, err error) *xError.Error {
 	msg := xError.ErrMessage(message)
 
+	switch {
+	case errors.Is(err, bStorage.ErrNotFound):
+		return xError.NewError(ctx, xError.ResourceNotFound, msg, true, err)
+	case errors.Is(err, bStorage.ErrInvalidContent):
+		return xError.NewError(ctx, xError.FormatError, msg, true, err)
+	}
+
 	if connectErr := new(connect.Error); errorAs(err, &connectErr) {
 		switch connectErr.Code() {
 		case connect.CodeInvalidArgument:
@@ -87,3 +97,14 @@ func errorAs(err error, target **connect.Error) bool {
 	}
 	return false
 }
+
+// isStorageNotFound 判断文件存储后端返回的错误是否表示文件不存在。
+func isStorageNotFound(err error) bool {
+	if errors.Is(err, bStorage.ErrNotFound) {
+		return true
+	}
+	if connectErr := new(connect.Error); errorAs(err, &connectErr) {
+		return connectErr.Code() == connect.CodeNotFound
+	}
+	return strings.HasPrefix(err.Error(), "not_found")
+}
@@ -13,6 +13,7 @@ import (
 	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+	repotxn "github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/txn"
 	apiAdmin "github.com/frontleaves-mc/frontleaves-yggleaf/api/admin"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/api/user"
 	bSdkModels "github.com/phalanx-labs/beacon-sso-sdk/models"
@@ -22,6 +23,9 @@ import (
 type userRepo struct {
 	user             *repository.UserRepo
 	libraryQuotaRepo *repository.LibraryQuotaRepo
+	quotaTierRepo    *repository.RoleQuotaTierRepo
+	quotaTierTxn     *repotxn.QuotaTierTxnRepo
+	userTxn          *repotxn.UserTxnRepo
 }
 
 // UserLogic 用户业务逻辑处理者
@@ -50,6 +54,8 @@ type UserLogic struct {
 func NewUserLogic(ctx context.Context) *UserLogic {
 	db := xCtxUtil.MustGetDB(ctx)
 	rdb := xCtxUtil.MustGetRDB(ctx)
+	userRepository := repository.NewUserRepo(db, rdb)
+	libraryQuotaRepo := repository.NewLibraryQuotaRepo(db)
 	return &UserLogic{
 		logic: logic{
 			db:  db,
@@ -57,8 +63,18 @@ func NewUserLogic(ctx context.Context) *UserLogic {
 			log: xLog.WithName(xLog.NamedLOGC, "UserLogic"),
 		},
 		repo: userRepo{
-			user:             repository.NewUserRepo(db, rdb),
-			libraryQuotaRepo: repository.NewLibraryQuotaRepo(db),
+			user:             userRepository,
+			libraryQuotaRepo: libraryQuotaRepo,
+			quotaTierRepo:    repository.NewRoleQuotaTierRepo(db),
+			quotaTierTxn:     repotxn.NewQuotaTierTxnRepo(db, repository.NewGameProfileQuotaRepo(db), libraryQuotaRepo, repository.NewLibraryQuotaLogRepo(db)),
+			userTxn: repotxn.NewUserTxnRepo(
+				db,
+				userRepository,
+				repository.NewSkinLibraryRepo(db),
+				repository.NewCapeLibraryRepo(db),
+				repository.NewUserSkinLibraryRepo(db),
+				repository.NewUserCapeLibraryRepo(db),
+			),
 		},
 	}
 }
@@ -133,7 +149,8 @@ func (l *UserLogic) TakeUser(ctx context.Context, userinfo *bSdkModels.OAuthUser
 		RoleName: xUtil.Ptr(entity.RolePlayer.String()),
 	}
 
-	user, xErr := l.repo.user.Set(ctx, newUser)
+	// 在事务内创建用户并发放系统目录中的默认皮肤与披风
+	user, xErr := l.repo.userTxn.CreateUserWithDefaultLibrary(ctx, newUser)
 	if xErr != nil {
 		return nil, xErr
 	}
@@ -143,6 +160,9 @@ func (l *UserLogic) TakeUser(ctx context.Context, userinfo *bSdkModels.OAuthUser
 		l.log.Warn(ctx, string("创建用户资源库配额失败: "+quotaErr.ErrorMessage))
 	}
 
+	// 按角色配额档位初始化配额总额度（不阻断主流程，仅记录警告日志）
+	l.applyRoleQuotaTier(ctx, snowflakeID, entity.RolePlayer, false)
+
 	return user, nil
 }
 
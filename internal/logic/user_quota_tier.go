@@ -0,0 +1,223 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xAsync "github.com/bamboo-services/bamboo-base-go/plugins/async"
+	apiAdmin "github.com/frontleaves-mc/frontleaves-yggleaf/api/admin"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+)
+
+const quotaTierReapplyBatchSize = 100 // 重新应用档位时每批处理的用户数
+
+// ListQuotaTiers 获取全部角色配额档位（管理员专用）。
+func (l *UserLogic) ListQuotaTiers(ctx context.Context) (*apiAdmin.QuotaTierListResponse, *xError.Error) {
+	l.log.Info(ctx, "ListQuotaTiers - 获取角色配额档位列表")
+
+	tiers, xErr := l.repo.quotaTierRepo.List(ctx, nil)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	items := make([]apiAdmin.QuotaTierResponse, len(tiers))
+	for i := range tiers {
+		items[i] = buildQuotaTierResponse(&tiers[i])
+	}
+	return &apiAdmin.QuotaTierListResponse{Items: items}, nil
+}
+
+// UpdateQuotaTier 创建或修改角色配额档位（管理员专用）。
+//
+// 修改仅影响之后创建或变更为该角色的用户；req.Reapply 为 true 时在后台将档位重新应用到
+// 该角色的现有用户，只提升低于档位的总额度，不会降低管理员手动调整或兑换获得的额度。
+func (l *UserLogic) UpdateQuotaTier(
+	ctx context.Context,
+	operatorID xSnowflake.SnowflakeID,
+	roleName string,
+	req *apiAdmin.UpdateQuotaTierRequest,
+) (*apiAdmin.UpdateQuotaTierResponse, *xError.Error) {
+	l.log.Info(ctx, "UpdateQuotaTier - 修改角色配额档位")
+
+	role, xErr := l.checkRoleExists(ctx, roleName)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	tier := &entity.RoleQuotaTier{
+		RoleName:          role,
+		ProfileTotal:      req.ProfileTotal,
+		SkinsPublicTotal:  req.SkinsPublicTotal,
+		SkinsPrivateTotal: req.SkinsPrivateTotal,
+		CapesPublicTotal:  req.CapesPublicTotal,
+		CapesPrivateTotal: req.CapesPrivateTotal,
+		UpdatedBy:         &operatorID,
+	}
+	if existing, found, xErr := l.repo.quotaTierRepo.GetByRole(ctx, nil, role); xErr != nil {
+		return nil, xErr
+	} else if found {
+		tier.CreatedAt = existing.CreatedAt
+	}
+	if xErr := l.repo.quotaTierRepo.Save(ctx, nil, tier); xErr != nil {
+		return nil, xErr
+	}
+
+	if req.Reapply {
+		saved := *tier
+		xAsync.Async(ctx, func(asyncCtx context.Context) {
+			l.reapplyQuotaTier(asyncCtx, &saved)
+		})
+	}
+	return &apiAdmin.UpdateQuotaTierResponse{
+		Tier:           buildQuotaTierResponse(tier),
+		ReapplyStarted: req.Reapply,
+	}, nil
+}
+
+// ChangeUserRole 变更用户角色并应用目标角色的配额档位（管理员专用）。
+//
+// 档位仅提升低于档位的总额度，降级角色时不会收回已有额度。
+// 角色保存成功但档位应用失败时返回错误，重复调用是安全的。
+func (l *UserLogic) ChangeUserRole(
+	ctx context.Context,
+	operatorID xSnowflake.SnowflakeID,
+	targetUserID xSnowflake.SnowflakeID,
+	roleName string,
+) (*apiAdmin.AdminUserItem, *xError.Error) {
+	l.log.Info(ctx, "ChangeUserRole - 变更用户角色")
+
+	if operatorID == targetUserID {
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "不能变更自己的角色", true)
+	}
+	role, xErr := l.checkRoleExists(ctx, roleName)
+	if xErr != nil {
+		return nil, xErr
+	}
+
+	user, found, xErr := l.repo.user.Get(ctx, targetUserID.String())
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "用户不存在", true)
+	}
+
+	if user.RoleName == nil || *user.RoleName != role.String() {
+		roleValue := role.String()
+		user.RoleName = &roleValue
+		if user, xErr = l.repo.user.Set(ctx, user); xErr != nil {
+			return nil, xErr
+		}
+	}
+
+	tier, found, xErr := l.repo.quotaTierRepo.GetByRole(ctx, nil, role)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if found {
+		if _, xErr := l.repo.quotaTierTxn.ApplyTier(ctx, targetUserID, tier, true); xErr != nil {
+			return nil, xErr
+		}
+	}
+
+	return &apiAdmin.AdminUserItem{
+		ID:        user.ID.String(),
+		Username:  user.Username,
+		Email:     user.Email,
+		RoleName:  user.RoleName,
+		HasBan:    user.HasBan,
+		UpdatedAt: user.UpdatedAt,
+	}, nil
+}
+
+// ==================== 内部方法 ====================
+
+// applyRoleQuotaTier 将角色配额档位应用到用户，失败或未配置档位时仅记录日志。
+func (l *UserLogic) applyRoleQuotaTier(ctx context.Context, userID xSnowflake.SnowflakeID, role entity.RoleName, raiseOnly bool) {
+	tier, found, xErr := l.repo.quotaTierRepo.GetByRole(ctx, nil, role)
+	if xErr != nil {
+		l.log.Warn(ctx, string("查询角色配额档位失败: "+xErr.ErrorMessage))
+		return
+	}
+	if !found {
+		return
+	}
+	if _, xErr := l.repo.quotaTierTxn.ApplyTier(ctx, userID, tier, raiseOnly); xErr != nil {
+		l.log.Warn(ctx, string("应用角色配额档位失败: "+xErr.ErrorMessage))
+	}
+}
+
+// reapplyQuotaTier 后台任务：按用户 ID 升序分批将档位重新应用到该角色的全部用户。
+//
+// 单个用户失败仅记录日志并继续，结束时输出汇总。
+func (l *UserLogic) reapplyQuotaTier(ctx context.Context, tier *entity.RoleQuotaTier) {
+	roleName := tier.RoleName.String()
+	filter := repository.CampaignUserFilter{RoleName: &roleName}
+
+	var cursor xSnowflake.SnowflakeID
+	var changed, failed int64
+	for {
+		userIDs, xErr := l.repo.user.ListIDsForCampaign(ctx, filter, cursor, quotaTierReapplyBatchSize)
+		if xErr != nil {
+			l.log.Warn(ctx, fmt.Sprintf("重新应用角色配额档位中断(role=%s): %s", roleName, xErr.ErrorMessage))
+			return
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+
+		for _, userID := range userIDs {
+			applied, xErr := l.repo.quotaTierTxn.ApplyTier(ctx, userID, tier, true)
+			if xErr != nil {
+				failed++
+				l.log.Warn(ctx, fmt.Sprintf("重新应用角色配额档位失败(role=%s, userID=%d): %s", roleName, userID, xErr.ErrorMessage))
+				continue
+			}
+			if applied {
+				changed++
+			}
+		}
+
+		cursor = userIDs[len(userIDs)-1]
+		if len(userIDs) < quotaTierReapplyBatchSize {
+			break
+		}
+	}
+	l.log.Info(ctx, fmt.Sprintf("重新应用角色配额档位完成(role=%s): 调整 %d 个用户，失败 %d 个", roleName, changed, failed))
+}
+
+// checkRoleExists 规范化角色名称并校验角色存在。
+func (l *UserLogic) checkRoleExists(ctx context.Context, roleName string) (entity.RoleName, *xError.Error) {
+	role := entity.RoleName(strings.ToUpper(strings.TrimSpace(roleName)))
+	exists, xErr := l.repo.quotaTierRepo.RoleExists(ctx, nil, role)
+	if xErr != nil {
+		return "", xErr
+	}
+	if !exists {
+		return "", xError.NewError(ctx, xError.ResourceNotFound, "角色不存在", true)
+	}
+	return role, nil
+}
+
+// buildQuotaTierResponse 将角色配额档位实体转换为响应 DTO。
+func buildQuotaTierResponse(tier *entity.RoleQuotaTier) apiAdmin.QuotaTierResponse {
+	var updatedBy *string
+	if tier.UpdatedBy != nil {
+		id := tier.UpdatedBy.String()
+		updatedBy = &id
+	}
+	return apiAdmin.QuotaTierResponse{
+		RoleName:          tier.RoleName.String(),
+		ProfileTotal:      tier.ProfileTotal,
+		SkinsPublicTotal:  tier.SkinsPublicTotal,
+		SkinsPrivateTotal: tier.SkinsPrivateTotal,
+		CapesPublicTotal:  tier.CapesPublicTotal,
+		CapesPrivateTotal: tier.CapesPrivateTotal,
+		UpdatedBy:         updatedBy,
+		UpdatedAt:         tier.UpdatedAt,
+	}
+}
@@ -52,6 +52,7 @@ func (l *YggdrasilLogic) ValidateGameToken(ctx context.Context, accessToken stri
 //
 // 支持邮箱或手机号作为登录凭证，验证密码后生成游戏令牌。
 // 单角色时自动绑定到令牌并返回 selectedProfile，多角色时通过 refresh 选择。
+// 被管理员锁定的角色不出现在可用角色列表中，也不会被自动绑定。
 //
 // 参数:
 //   - ctx: 上下文对象
@@ -103,11 +104,17 @@ func (l *YggdrasilLogic) AuthenticateUser(ctx context.Context, username string,
 		return "", "", nil, nil, nil, xErr
 	}
 
-	// 5. 查询用户的游戏档案列表
-	profiles, xErr := l.repo.profileRepo.ListByUserIDWithTextures(ctx, nil, user.ID)
+	// 5. 查询用户的游戏档案列表（排除被管理员锁定的角色）
+	allProfiles, xErr := l.repo.profileRepo.ListByUserIDWithTextures(ctx, nil, user.ID)
 	if xErr != nil {
 		return "", "", nil, nil, nil, xErr
 	}
+	profiles := make([]entity.GameProfile, 0, len(allProfiles))
+	for i := range allProfiles {
+		if !allProfiles[i].IsLocked() {
+			profiles = append(profiles, allProfiles[i])
+		}
+	}
 
 	// 6. 单角色时绑定并返回 selectedProfile（绑定成功后才设置）
 	var selectedProfile *entity.GameProfile
@@ -139,6 +146,7 @@ func (l *YggdrasilLogic) AuthenticateUser(ctx context.Context, username string,
 //
 // 吊销原令牌并颁发新令牌。若携带 selectedProfile 则为角色选择操作。
 // 暂时失效状态的令牌也可以执行刷新。新令牌的 clientToken 与原令牌相同。
+// 不能选择被管理员锁定的角色；原令牌绑定的角色已被锁定时，新令牌不再继承该绑定。
 //
 // 参数:
 //   - ctx: 上下文对象
@@ -225,12 +233,26 @@ func (l *YggdrasilLogic) RefreshToken(ctx context.Context, accessToken string, c
 		if !found {
 			return "", "", nil, nil, xError.NewError(ctx, xError.ParameterError, "Invalid token.", true)
 		}
+		if profile.IsLocked() {
+			return "", "", nil, nil, xError.NewError(ctx, xError.ParameterError, "Profile has been locked.", true)
+		}
 
 		bindProfileID = &profile.ID
 		selectedProfile = profile
 	} else if oldToken.BoundProfileID != nil {
-		// 继承原令牌的角色绑定（在事务内原子执行）
-		bindProfileID = oldToken.BoundProfileID
+		// 继承原令牌的角色绑定（在事务内原子执行），角色已被锁定时解除绑定
+		boundProfile, boundFound, boundErr := l.repo.profileRepo.GetByIDWithTextures(ctx, nil, *oldToken.BoundProfileID)
+		if boundErr != nil {
+			return "", "", nil, nil, boundErr
+		}
+		if boundFound && boundProfile.IsLocked() {
+			l.log.Info(ctx, fmt.Sprintf("原令牌绑定的角色(ID=%s)已被锁定，新令牌不再绑定角色", boundProfile.ID.String()))
+		} else {
+			bindProfileID = oldToken.BoundProfileID
+			if boundFound {
+				selectedProfile = boundProfile
+			}
+		}
 	}
 
 	// 在事务内原子执行：吊销旧令牌 + 创建新令牌 + （可选）绑定角色
@@ -239,16 +261,6 @@ func (l *YggdrasilLogic) RefreshToken(ctx context.Context, accessToken string, c
 		return "", "", nil, nil, xErr
 	}
 
-	// 继承原令牌角色时，需查询绑定的角色信息用于响应
-	if selectedProfile == nil && bindProfileID != nil {
-		boundProfile, boundFound, boundErr := l.repo.profileRepo.GetByIDWithTextures(ctx, nil, *bindProfileID)
-		if boundErr != nil {
-			l.log.Warn(ctx, fmt.Sprintf("查询绑定角色失败: %s", boundErr.ErrorMessage))
-		} else if boundFound {
-			selectedProfile = boundProfile
-		}
-	}
-
 	// 构建 user 信息
 	var userResp *entity.User
 	if requestUser {
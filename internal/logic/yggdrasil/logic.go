@@ -13,7 +13,7 @@
 // 功能模块:
 //   - auth.go: 认证服务（登录、刷新、验证、吊销、登出）
 //   - session.go: 会话管理（加入服务器、验证加入）
-//   - profile.go: 角色查询（单查、批查）
+//   - profile.go: 角色查询（单查、批查、名称历史）
 //   - texture.go: 材质管理（上传、删除）
 //   - signing.go: 数字签名、UUID 转换、材质 JSON 组装
 package yggdrasil
@@ -31,7 +31,7 @@ import (
 	bCtx "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/context"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/cache"
-	bBucket "github.com/phalanx-labs/beacon-bucket-sdk"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/txn"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
@@ -62,6 +62,8 @@ type yggdrasilRepo struct {
 	profileRepo        *repository.GameProfileYggRepo  // Yggdrasil 角色查询仓储
 	sessionCache       *cache.SessionCache             // 会话缓存
 	onlineProfileRepo  *repository.GameOnlineProfileRepo // 正版档案缓存仓储
+	joinLogRepo        *repository.GameProfileJoinLogRepo // 进服记录仓储
+	nameHistoryRepo    *repository.GameProfileNameHistoryRepo // 角色名称历史仓储
 }
 
 // YggdrasilLogic Yggdrasil 协议业务逻辑处理者。
@@ -77,7 +79,7 @@ type YggdrasilLogic struct {
 	repo       yggdrasilRepo
 	privKey    *rsa.PrivateKey          // RSA 私钥（用于 textures 属性签名）
 	pubKeyPEM  string                   // RSA 公钥 PEM 字符串（用于 API 元数据响应）
-	bucket     *bBucket.BucketClient    // 对象存储客户端（用于解析纹理下载链接）
+	storage    bStorage.Storage         // 文件存储后端（用于解析纹理下载链接）
 	httpClient *http.Client             // HTTP 客户端（用于 Mojang API 调用）
 }
 
@@ -121,10 +123,12 @@ func NewYggdrasilLogic(ctx context.Context) *YggdrasilLogic {
 			profileRepo:       repository.NewGameProfileYggRepo(db),
 			sessionCache:      &cache.SessionCache{RDB: rdb},
 			onlineProfileRepo: repository.NewGameOnlineProfileRepo(db),
+			joinLogRepo:       repository.NewGameProfileJoinLogRepo(db),
+			nameHistoryRepo:   repository.NewGameProfileNameHistoryRepo(db),
 		},
 		privKey:   privKey,
 		pubKeyPEM: pubKeyPEM,
-		bucket:    bCtx.MustGetBucket(ctx),
+		storage:   bCtx.MustGetStorage(ctx),
 		httpClient: &http.Client{
 			Timeout: time.Duration(bConst.MojangAPITimeoutSec) * time.Second,
 		},
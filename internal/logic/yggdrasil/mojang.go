@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -15,6 +16,15 @@ import (
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
 )
 
+// ErrMojangProfileNotFound Mojang 中不存在指定名称的正版玩家。
+var ErrMojangProfileNotFound = errors.New("mojang profile not found")
+
+// MojangSkin 正版玩家当前使用的皮肤。
+type MojangSkin struct {
+	URL   string           // 皮肤纹理下载链接
+	Model entity.ModelType // 皮肤模型类型
+}
+
 // mojangLookupResponse Mojang 玩家名称查找 API 的响应结构。
 type mojangLookupResponse struct {
 	ID   string `json:"id"`   // 无连字符 UUID
@@ -102,7 +112,7 @@ func (l *YggdrasilLogic) fetchMojangProfile(ctx context.Context, profileName str
 	expiresAt := time.Now().Add(time.Duration(bConst.OnlineProfileCacheDurationMin) * time.Minute)
 
 	// Step 1: 查正版 UUID
-	onlineUUID, err := l.lookupMojangUUID(ctx, profileName)
+	onlineUUID, err := lookupMojangUUID(ctx, l.httpClient, profileName)
 	if err != nil {
 		// 玩家不存在或查询失败 → 缓存非正版记录
 		l.log.Warn(ctx, fmt.Sprintf("Mojang 查找玩家 UUID 失败(%s)，缓存为非正版用户: %v", profileName, err))
@@ -119,7 +129,7 @@ func (l *YggdrasilLogic) fetchMojangProfile(ctx context.Context, profileName str
 	}
 
 	// Step 2: 查皮肤/披风
-	textures, err := l.fetchMojangSessionProfile(ctx, onlineUUID)
+	textures, err := fetchMojangSessionProfile(ctx, l.httpClient, onlineUUID)
 	if err != nil {
 		// UUID 存在但纹理查询失败 → 仍缓存为正版（有 UUID 但纹理暂时不可用）
 		l.log.Warn(ctx, fmt.Sprintf("Mojang 查询纹理失败(%s)，缓存为正版无纹理: %v", profileName, err))
@@ -149,13 +159,8 @@ func (l *YggdrasilLogic) fetchMojangProfile(ctx context.Context, profileName str
 	if textures.Textures.SKIN != nil {
 		skinURL := textures.Textures.SKIN.URL
 		onlineProfile.SkinURL = &skinURL
-		if textures.Textures.SKIN.Metadata != nil && textures.Textures.SKIN.Metadata.Model == "slim" {
-			model := entity.ModelTypeSlim
-			onlineProfile.SkinModel = &model
-		} else {
-			model := entity.ModelTypeClassic
-			onlineProfile.SkinModel = &model
-		}
+		model := textures.skinModel()
+		onlineProfile.SkinModel = &model
 	}
 	if textures.Textures.CAPE != nil {
 		capeURL := textures.Textures.CAPE.URL
@@ -170,8 +175,38 @@ func (l *YggdrasilLogic) fetchMojangProfile(ctx context.Context, profileName str
 	return result, nil
 }
 
+// FetchMojangSkin 按玩家名称直接查询 Mojang API 获取正版皮肤，不读写在线档案缓存。
+//
+// 在线档案缓存以本平台游戏档案为键，无法按任意玩家名称命中，因此该方法总是实时查询。
+// 玩家不存在时返回 ErrMojangProfileNotFound；玩家存在但未设置皮肤时返回 nil, nil。
+func FetchMojangSkin(ctx context.Context, client *http.Client, name string) (*MojangSkin, error) {
+	onlineUUID, err := lookupMojangUUID(ctx, client, name)
+	if err != nil {
+		return nil, err
+	}
+	textures, err := fetchMojangSessionProfile(ctx, client, onlineUUID)
+	if err != nil {
+		return nil, err
+	}
+	if textures.Textures.SKIN == nil || textures.Textures.SKIN.URL == "" {
+		return nil, nil
+	}
+	return &MojangSkin{
+		URL:   textures.Textures.SKIN.URL,
+		Model: textures.skinModel(),
+	}, nil
+}
+
+// skinModel 解析纹理元数据中的皮肤模型，未声明时为 classic。
+func (p *mojangTexturesPayload) skinModel() entity.ModelType {
+	if p.Textures.SKIN != nil && p.Textures.SKIN.Metadata != nil && p.Textures.SKIN.Metadata.Model == "slim" {
+		return entity.ModelTypeSlim
+	}
+	return entity.ModelTypeClassic
+}
+
 // lookupMojangUUID 调用 Mojang 名称查找 API 获取正版 UUID。
-func (l *YggdrasilLogic) lookupMojangUUID(ctx context.Context, name string) (string, error) {
+func lookupMojangUUID(ctx context.Context, client *http.Client, name string) (string, error) {
 	url := bConst.MojangAPIProfileLookupURL + name
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -179,14 +214,14 @@ func (l *YggdrasilLogic) lookupMojangUUID(ctx context.Context, name string) (str
 		return "", fmt.Errorf("创建 Mojang lookup 请求失败: %w", err)
 	}
 
-	resp, err := l.httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("Mojang lookup 请求失败: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		return "", fmt.Errorf("玩家 %s 不存在于 Mojang", name)
+		return "", fmt.Errorf("玩家 %s 不存在于 Mojang: %w", name, ErrMojangProfileNotFound)
 	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("Mojang lookup 返回异常状态码: %d", resp.StatusCode)
@@ -200,7 +235,7 @@ func (l *YggdrasilLogic) lookupMojangUUID(ctx context.Context, name string) (str
 }
 
 // fetchMojangSessionProfile 调用 Mojang 会话 API 获取纹理信息。
-func (l *YggdrasilLogic) fetchMojangSessionProfile(ctx context.Context, onlineUUID string) (*mojangTexturesPayload, error) {
+func fetchMojangSessionProfile(ctx context.Context, client *http.Client, onlineUUID string) (*mojangTexturesPayload, error) {
 	url := bConst.MojangAPISessionProfileURL + onlineUUID
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -208,7 +243,7 @@ func (l *YggdrasilLogic) fetchMojangSessionProfile(ctx context.Context, onlineUU
 		return nil, fmt.Errorf("创建 Mojang session 请求失败: %w", err)
 	}
 
-	resp, err := l.httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("Mojang session 请求失败: %w", err)
 	}
@@ -93,3 +93,48 @@ func (l *YggdrasilLogic) GetProfileByUUID(ctx context.Context, unsignedUUID stri
 
 	return l.repo.profileRepo.GetByUUIDUnsigned(ctx, nil, unsignedUUID)
 }
+
+// QueryNameHistory 根据无符号 UUID 查询角色的名称历史。
+//
+// 响应格式与 Mojang `/user/profiles/{uuid}/names` 接口一致：按时间升序排列，
+// 首个名称不含 changedToAt，其余名称的 changedToAt 为改名时间（毫秒）。
+// 尚无历史记录的角色仅返回当前名称。
+//
+// 参数:
+//   - ctx: 上下文对象
+//   - unsignedUUID: 角色的无符号 UUID（32 位十六进制字符串）
+//
+// 返回值:
+//   - []apiYgg.NameHistoryItem: 名称历史列表
+//   - bool: 是否找到角色
+//   - *xError.Error: 查询过程中的错误
+func (l *YggdrasilLogic) QueryNameHistory(ctx context.Context, unsignedUUID string) ([]apiYgg.NameHistoryItem, bool, *xError.Error) {
+	l.log.Info(ctx, "QueryNameHistory - 查询角色名称历史")
+
+	profile, found, xErr := l.repo.profileRepo.GetByUUIDUnsigned(ctx, nil, unsignedUUID)
+	if xErr != nil {
+		return nil, false, xErr
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	histories, xErr := l.repo.nameHistoryRepo.ListByProfileID(ctx, nil, profile.ID)
+	if xErr != nil {
+		return nil, false, xErr
+	}
+	if len(histories) == 0 {
+		return []apiYgg.NameHistoryItem{{Name: profile.Name}}, true, nil
+	}
+
+	items := make([]apiYgg.NameHistoryItem, 0, len(histories))
+	for i, h := range histories {
+		item := apiYgg.NameHistoryItem{Name: h.Name}
+		if i > 0 {
+			changedToAt := h.ChangedAt.UnixMilli()
+			item.ChangedToAt = &changedToAt
+		}
+		items = append(items, item)
+	}
+	return items, true, nil
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	apiYgg "github.com/frontleaves-mc/frontleaves-yggleaf/api/yggdrasil"
@@ -17,22 +19,25 @@ import (
 // 该方法用于 Minecraft 服务端验证客户端的进服请求。流程如下：
 //  1. 从 Redis 中查询 serverId 对应的会话记录
 //  2. 根据 profileUUID 查询角色信息（含关联皮肤和披风）
-//  3. 验证 username 与令牌绑定角色的名称一致
-//  4. 可选验证客户端 IP（当 ip 参数不为空时）
+//  3. 拒绝被管理员锁定的角色
+//  4. 验证 username 与令牌绑定角色的名称一致
+//  5. 可选验证客户端 IP（当 ip 参数不为空时）
 //
-// 验证通过后删除会话缓存（一次性使用），并返回包含纹理属性和数字签名的角色信息。
+// 验证通过后删除会话缓存（一次性使用），记录进服历史并刷新档案最近进服时间，
+// 最后返回包含纹理属性和数字签名的角色信息。
 //
 // 参数:
 //   - ctx: 上下文对象
 //   - username: 角色名称
 //   - serverId: 服务端生成的随机字符串
 //   - ip: 客户端 IP 地址（可选，用于防止代理连接）
+//   - serverAddress: 发起验证请求的服务端地址（可选，未知时传空字符串）
 //
 // 返回值:
 //   - *apiYgg.ProfileResponse: 验证通过的角色信息（含签名）
 //   - bool: 是否验证通过
 //   - *xError.Error: 验证过程中的错误
-func (l *YggdrasilLogic) HasJoined(ctx context.Context, username string, serverId string, ip string) (*apiYgg.ProfileResponse, bool, *xError.Error) {
+func (l *YggdrasilLogic) HasJoined(ctx context.Context, username string, serverId string, ip string, serverAddress string) (*apiYgg.ProfileResponse, bool, *xError.Error) {
 	l.log.Info(ctx, "HasJoined - 验证客户端加入服务器")
 
 	// 从 Redis 中查询会话记录
@@ -55,8 +60,13 @@ func (l *YggdrasilLogic) HasJoined(ctx context.Context, username string, serverI
 		return nil, false, nil
 	}
 
-	// 验证 username 与角色名称一致
-	if profile.Name != username {
+	// 被管理员锁定的角色不允许进入服务器
+	if profile.IsLocked() {
+		return nil, false, nil
+	}
+
+	// 验证 username 与角色名称一致（与 Minecraft 一致，不区分大小写）
+	if !strings.EqualFold(profile.Name, username) {
 		return nil, false, nil
 	}
 
@@ -78,11 +88,46 @@ func (l *YggdrasilLogic) HasJoined(ctx context.Context, username string, serverI
 		l.log.Warn(ctx, fmt.Sprintf("删除会话缓存失败（TTL 兜底仍生效）: %v", delErr))
 	}
 
+	// 记录进服历史（失败仅记录日志，不阻断玩家进服）
+	clientIP := sessionData.ClientIP
+	if clientIP == "" {
+		clientIP = ip
+	}
+	l.recordJoin(ctx, profile, clientIP, serverAddress)
+
 	// hasJoined 必须包含签名（unsigned=false）
 	resp := l.BuildProfileResponse(ctx, profile, false)
 	return resp, true, nil
 }
 
+// recordJoin 写入进服记录并刷新游戏档案的最近进服时间。
+//
+// 两次写入互不依赖，任一失败仅记录 Warn 日志：进服记录属于统计数据，
+// 不应因数据库临时故障影响玩家进入服务器。
+func (l *YggdrasilLogic) recordJoin(ctx context.Context, profile *entity.GameProfile, clientIP string, serverAddress string) {
+	now := time.Now()
+
+	joinLog := &entity.GameProfileJoinLog{
+		GameProfileID: profile.ID,
+		UserID:        profile.UserID,
+		ProfileName:   profile.Name,
+		JoinedAt:      now,
+	}
+	if clientIP != "" {
+		joinLog.ClientIP = &clientIP
+	}
+	if serverAddress != "" {
+		joinLog.ServerAddress = &serverAddress
+	}
+	if _, xErr := l.repo.joinLogRepo.Create(ctx, nil, joinLog); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("写入进服记录失败(可忽略): %v", xErr.ErrorMessage))
+	}
+
+	if xErr := l.repo.profileRepo.UpdateLastSeenAt(ctx, nil, profile.ID, now); xErr != nil {
+		l.log.Warn(ctx, fmt.Sprintf("更新最近进服时间失败(可忽略): %v", xErr.ErrorMessage))
+	}
+}
+
 // JoinServer 记录客户端加入服务器的会话信息。
 //
 // 该方法由 Minecraft 客户端调用，将 serverId、accessToken 和 profileUUID 的
@@ -136,7 +181,7 @@ func (l *YggdrasilLogic) JoinServer(ctx context.Context, accessToken string, pro
 func (l *YggdrasilLogic) BuildProfileResponse(ctx context.Context, profile *entity.GameProfile, unsigned bool) *apiYgg.ProfileResponse {
 	profileID := EncodeUnsignedUUID(profile.UUID)
 
-	// 通过 beacon-bucket SDK 解析纹理下载链接（使用 Texture ID 而非 Hash）
+	// 通过文件存储后端解析纹理下载链接（使用 Texture ID 而非 Hash）
 	var skinURL string
 	var skinModel entity.ModelType
 	var capeURL string
@@ -13,7 +13,6 @@ import (
 
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
 	yggdrasilAPI "github.com/frontleaves-mc/frontleaves-yggleaf/api/yggdrasil"
-	bBucketApi "github.com/phalanx-labs/beacon-bucket-sdk/api"
 	"github.com/google/uuid"
 )
 
@@ -109,10 +108,10 @@ func (l *YggdrasilLogic) BuildTexturesPayload(
 	return payload
 }
 
-// resolveTextureURL 通过 beacon-bucket SDK 的 Get 方法将 Texture ID 解析为下载链接。
+// resolveTextureURL 通过文件存储后端的 Get 方法将 Texture ID 解析为下载链接。
 //
 // 与 LibraryLogic.resolveTextureURL 保持一致的实现：使用数据库中存储的
-// int64 纹理文件 ID（即 beacon-bucket 的 FileId）调用 bucket.Normal.Get()
+// int64 纹理文件 ID（即存储后端的 FileID）调用 storage.Get()
 // 获取真实的可访问下载链接。
 //
 // 参数:
@@ -122,23 +121,21 @@ func (l *YggdrasilLogic) BuildTexturesPayload(
 // 返回值:
 //   - string: 文件下载链接，获取失败时返回空字符串
 func (l *YggdrasilLogic) resolveTextureURL(ctx context.Context, textureID int64) string {
-	if l.bucket == nil {
-		l.log.Warn(ctx, "BucketClient 未初始化，无法解析纹理 URL")
+	if l.storage == nil {
+		l.log.Warn(ctx, "文件存储后端未初始化，无法解析纹理 URL")
 		return ""
 	}
 	fileID := strconv.FormatInt(textureID, 10)
-	resp, err := l.bucket.Normal.Get(ctx, &bBucketApi.GetRequest{
-		FileId: fileID,
-	})
+	info, err := l.storage.Get(ctx, fileID)
 	if err != nil {
 		l.log.Warn(ctx, fmt.Sprintf("获取纹理文件信息失败(fileId=%s): %v", fileID, err))
 		return ""
 	}
-	if resp.GetObj() == nil || resp.GetObj().GetLink() == "" {
-		l.log.Warn(ctx, fmt.Sprintf("纹理文件元数据或下载链接为空(fileId=%s)", fileID))
+	if info.Link == "" {
+		l.log.Warn(ctx, fmt.Sprintf("纹理文件下载链接为空(fileId=%s)", fileID))
 		return ""
 	}
-	return resp.GetObj().GetLink()
+	return info.Link
 }
 
 // EncodeUnsignedUUID 将标准 UUID 字符串转换为无连字符格式。
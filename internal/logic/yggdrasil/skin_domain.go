@@ -0,0 +1,52 @@
+package yggdrasil
+
+import (
+	"strings"
+
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// SkinDomains 构建 skinDomains 白名单列表。
+//
+// 基础域名来自常量配置（主域名 + 后缀通配），额外域名通过环境变量
+// YGGDRASIL_SKIN_DOMAINS_EXTRA（逗号分隔）追加。
+// 这允许 beacon-bucket 等 CDN 返回的纹理 URL 域名动态加入白名单，
+// 解决 Minecraft 游戏客户端严格校验 skinDomains 导致皮肤不显示的问题。
+func SkinDomains() []string {
+	domains := []string{
+		bConst.YggdrasilSkinDomainSuffix,
+		"textures.minecraft.net", // Mojang 正版纹理域名（在线档案回退使用）
+	}
+	if extra := xEnv.GetEnvString(bConst.EnvYggdrasilSkinDomainsExtra, ""); extra != "" {
+		for _, d := range strings.Split(extra, ",") {
+			if trimmed := strings.TrimSpace(d); trimmed != "" {
+				domains = append(domains, trimmed)
+			}
+		}
+	}
+	return domains
+}
+
+// IsSkinDomainAllowed 按 skinDomains 规则判断主机名是否在白名单内。
+//
+// 以 "." 开头的条目匹配其所有子域名，其余条目要求完全相同（忽略大小写）。
+func IsSkinDomainAllowed(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return false
+	}
+	for _, domain := range SkinDomains() {
+		domain = strings.ToLower(domain)
+		if strings.HasPrefix(domain, ".") {
+			if strings.HasSuffix(host, domain) {
+				return true
+			}
+			continue
+		}
+		if host == domain {
+			return true
+		}
+	}
+	return false
+}
@@ -11,13 +11,94 @@ import (
 // 由 Logic 层构建，包含已解析的纹理链接。
 // Handler 层负责将此 DTO 转换为 api/user.GameProfileResponse。
 type GameProfileDTO struct {
-	ID            xSnowflake.SnowflakeID  // 档案 ID
-	UserID        xSnowflake.SnowflakeID  // 关联用户 ID
-	UUID          string                  // UUIDv7 标识
-	Name          string                  // 档案用户名
-	SkinLibraryID *xSnowflake.SnowflakeID // 装备的皮肤库 ID
-	CapeLibraryID *xSnowflake.SnowflakeID // 装备的披风库 ID
+	ID                    xSnowflake.SnowflakeID  // 档案 ID
+	UserID                xSnowflake.SnowflakeID  // 关联用户 ID
+	UUID                  string                  // UUIDv7 标识
+	Name                  string                  // 档案用户名
+	SkinLibraryID         *xSnowflake.SnowflakeID // 装备的皮肤库 ID
+	CapeLibraryID         *xSnowflake.SnowflakeID // 装备的披风库 ID
+	UpdatedAt             time.Time               // 更新时间
+	LastSeenAt            *time.Time              // 最近进服时间
+	NameChangedAt         *time.Time              // 最近一次改名时间
+	NameChangeAvailableAt *time.Time              // 改名冷却结束时间（nil 表示当前可改名）
+	LockedAt              *time.Time              // 管理员锁定时间（nil 表示未锁定）
+	LockReason            *string                 // 锁定原因
+	Skin                  *SkinDTO                // 装备的皮肤信息（含 texture_url）
+	Cape                  *CapeDTO                // 装备的披风信息（含 texture_url）
+}
+
+// GameProfileDeletionDTO 游戏档案删除结果数据传输对象。
+type GameProfileDeletionDTO struct {
+	ProfileID         xSnowflake.SnowflakeID // 被删除的档案 ID
+	UserID            xSnowflake.SnowflakeID // 原持有者用户 ID
+	UUID              string                 // 被删除的档案 UUID
+	Name              string                 // 被删除的档案用户名
+	RevokedTokens     int64                  // 被吊销的绑定令牌数量
+	NameReservedUntil *time.Time             // 名称保留到期时间（nil 表示名称已立即释放）
+}
+
+// GameProfileJoinDTO 游戏档案进服记录数据传输对象。
+type GameProfileJoinDTO struct {
+	ID            xSnowflake.SnowflakeID // 记录 ID
+	GameProfileID xSnowflake.SnowflakeID // 游戏档案 ID
+	UserID        xSnowflake.SnowflakeID // 关联用户 ID
+	ProfileName   string                 // 进服时的游戏内用户名
+	ServerAddress *string                // 发起验证的服务端地址
+	ClientIP      *string                // 客户端 IP
+	JoinedAt      time.Time              // 进服时间
+}
+
+// DailyActiveDTO 每日活跃玩家统计数据传输对象。
+type DailyActiveDTO struct {
+	Day            string // 统计日期（YYYY-MM-DD）
+	ActiveProfiles int64  // 活跃游戏档案数
+	ActiveUsers    int64  // 活跃用户数
+	JoinCount      int64  // 进服次数
+}
+
+// GameProfileOutfitDTO 游戏档案外观预设数据传输对象。
+type GameProfileOutfitDTO struct {
+	ID            xSnowflake.SnowflakeID  // 预设 ID
+	Name          string                  // 预设名称
+	SkinLibraryID *xSnowflake.SnowflakeID // 皮肤库 ID（nil 表示应用时卸下皮肤）
+	CapeLibraryID *xSnowflake.SnowflakeID // 披风库 ID（nil 表示应用时卸下披风）
+	CreatedAt     time.Time               // 创建时间
 	UpdatedAt     time.Time               // 更新时间
-	Skin          *SkinDTO                // 装备的皮肤信息（含 texture_url）
-	Cape          *CapeDTO                // 装备的披风信息（含 texture_url）
+	Skin          *SkinDTO                // 预设的皮肤信息（含 texture_url）
+	Cape          *CapeDTO                // 预设的披风信息（含 texture_url）
+}
+
+// GameProfileNameHistoryDTO 游戏档案名称历史数据传输对象。
+type GameProfileNameHistoryDTO struct {
+	Name       string                  // 游戏内用户名
+	Source     string                  // 名称来源（create / rename / admin）
+	UserID     xSnowflake.SnowflakeID  // 名称生效时的持有者用户 ID
+	OperatorID *xSnowflake.SnowflakeID // 代为操作的管理员用户 ID
+	ChangedAt  time.Time               // 名称生效时间
+}
+
+// GameProfileNameConflictGroupDTO 仅大小写不同的重复用户名分组数据传输对象。
+type GameProfileNameConflictGroupDTO struct {
+	Key      string           // 归一化名称（小写）
+	Profiles []GameProfileDTO // 冲突档案（按创建时间升序）
+}
+
+// GameProfileNameConflictReportDTO 用户名大小写冲突报告数据传输对象。
+type GameProfileNameConflictReportDTO struct {
+	Enforced bool                              // 不区分大小写唯一索引是否已建立
+	Groups   []GameProfileNameConflictGroupDTO // 冲突分组（按归一化名称升序）
+}
+
+// GameProfileAuditLogDTO 游戏档案管理员操作审计记录数据传输对象。
+type GameProfileAuditLogDTO struct {
+	ID          xSnowflake.SnowflakeID // 记录 ID
+	ProfileID   xSnowflake.SnowflakeID // 游戏档案 ID
+	ProfileUUID string                 // 游戏档案 UUID
+	ProfileName string                 // 操作时的游戏内用户名
+	UserID      xSnowflake.SnowflakeID // 操作时的持有者用户 ID
+	OperatorID  xSnowflake.SnowflakeID // 操作管理员用户 ID
+	Action      string                 // 操作类型（rename / clear_skin / lock / unlock / delete）
+	Reason      string                 // 操作原因
+	Detail      *string                // 变更详情
+	CreatedAt   time.Time              // 操作时间
 }
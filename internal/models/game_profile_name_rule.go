@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+)
+
+// GameProfileNameRuleInput 管理员创建或更新名称规则的输入参数。
+//
+// 由 Handler 层从请求体构建，Logic 层负责校验与解析枚举。
+type GameProfileNameRuleInput struct {
+	Kind          string                  // 规则类别（reserved / blocked）
+	MatchType     string                  // 匹配方式（exact / prefix / contains / regex）
+	Pattern       string                  // 匹配内容
+	AllowedUserID *xSnowflake.SnowflakeID // 允许使用保留名称的用户 ID（仅保留规则）
+	Reason        string                  // 规则说明
+	IsEnabled     bool                    // 是否启用
+}
+
+// GameProfileNameRuleDTO 游戏档案名称规则数据传输对象。
+type GameProfileNameRuleDTO struct {
+	ID            xSnowflake.SnowflakeID  // 规则 ID
+	Kind          string                  // 规则类别（reserved / blocked）
+	MatchType     string                  // 匹配方式（exact / prefix / contains / regex）
+	Pattern       string                  // 匹配内容
+	AllowedUserID *xSnowflake.SnowflakeID // 允许使用保留名称的用户 ID
+	Reason        string                  // 规则说明
+	IsEnabled     bool                    // 是否启用
+	CreatedAt     time.Time               // 创建时间
+	UpdatedAt     time.Time               // 更新时间
+}
+
+// GameProfileNameAvailabilityDTO 游戏档案名称可用性检查结果数据传输对象。
+type GameProfileNameAvailabilityDTO struct {
+	Name      string // 规范化后的名称
+	Available bool   // 当前用户是否可以使用该名称
+	Reason    string // 不可用原因（invalid / blocked / reserved / taken / held），可用时为空
+	Message   string // 面向用户的提示信息，可用时为空
+}
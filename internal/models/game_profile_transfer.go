@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+)
+
+// GameProfileTransferDTO 游戏档案转移请求数据传输对象。
+type GameProfileTransferDTO struct {
+	ID          xSnowflake.SnowflakeID // 转移请求 ID
+	ProfileID   xSnowflake.SnowflakeID // 游戏档案 ID
+	ProfileUUID string                 // 游戏档案 UUID
+	ProfileName string                 // 发起时的档案用户名
+	FromUserID  xSnowflake.SnowflakeID // 发起方用户 ID
+	ToUserID    xSnowflake.SnowflakeID // 接收方用户 ID
+	Direction   string                 // 相对当前用户的方向（outgoing / incoming）
+	Status      string                 // 请求状态（pending / accepted / rejected / cancelled / expired）
+	Remark      *string                // 发起方附言
+	ExpiresAt   time.Time              // 到期时间
+	RespondedAt *time.Time             // 处理时间
+	CreatedAt   time.Time              // 发起时间
+}
+
+// GameProfileTransferAcceptDTO 接受游戏档案转移结果数据传输对象。
+type GameProfileTransferAcceptDTO struct {
+	Transfer      GameProfileTransferDTO // 已接受的转移请求
+	Profile       *GameProfileDTO        // 转移后的游戏档案（接收方视角）
+	SkinUnbound   bool                   // 是否因接收方未拥有而卸下了皮肤
+	CapeUnbound   bool                   // 是否因接收方未拥有而卸下了披风
+	RevokedTokens int64                  // 被吊销的发起方绑定令牌数量
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// 衣柜导入条目处理状态。
+const (
+	LibraryImportStatusCreated = "created" // 创建成功
+	LibraryImportStatusFailed  = "failed"  // 创建失败
+)
+
+// LibraryImportResultDTO 衣柜导入单条结果数据传输对象。
+type LibraryImportResultDTO struct {
+	File    string                  // 压缩包内 PNG 路径
+	Kind    entityType.LibraryKind  // 资源种类
+	Status  string                  // 处理状态
+	ID      *xSnowflake.SnowflakeID // 创建成功时的资源 ID
+	Message string                  // 失败原因
+}
+
+// LibraryExportEntryDTO 衣柜导出条目数据传输对象。
+type LibraryExportEntryDTO struct {
+	File           string                    // 压缩包内 PNG 路径
+	Kind           entityType.LibraryKind    // 资源种类
+	Name           string                    // 资源名称
+	Model          entity.ModelType          // 皮肤模型（披风为 0）
+	IsPublic       bool                      // 是否公开
+	AssignmentType entityType.AssignmentType // 关联类型
+	Texture        int64                     // 纹理文件 ID
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// LibraryCampaignFilterDTO 资源发放活动的目标用户筛选条件，各条件之间为“且”关系。
+type LibraryCampaignFilterDTO struct {
+	RoleName         *string                  // 用户角色名称
+	RegisteredAfter  *time.Time               // 注册时间下限（含）
+	RegisteredBefore *time.Time               // 注册时间上限（含）
+	HasProfile       *bool                    // 是否拥有游戏档案
+	UserIDs          []xSnowflake.SnowflakeID // 指定用户 ID 列表
+}
+
+// LibraryCampaignDTO 资源发放活动数据传输对象。
+type LibraryCampaignDTO struct {
+	ID             xSnowflake.SnowflakeID    // 活动 ID
+	Name           string                    // 活动名称
+	Kind           entityType.LibraryKind    // 资源种类
+	LibraryID      xSnowflake.SnowflakeID    // 发放的资源 ID
+	AssignmentType entityType.AssignmentType // 发放后的关联类型
+	Status         entityType.CampaignStatus // 活动状态
+	Filter         LibraryCampaignFilterDTO  // 目标用户筛选条件
+	MatchedCount   int64                     // 开始执行时匹配的用户数
+	GrantedCount   int64                     // 已发放数
+	SkippedCount   int64                     // 已跳过数
+	FailedCount    int64                     // 失败数
+	RevokedCount   int64                     // 已收回数
+	LastError      *string                   // 最近一次中断原因
+	CreatedBy      xSnowflake.SnowflakeID    // 创建者
+	StartedAt      *time.Time                // 开始执行时间
+	FinishedAt     *time.Time                // 完成时间
+	RevokedBy      *xSnowflake.SnowflakeID   // 撤销者
+	RevokedAt      *time.Time                // 撤销时间
+	CreatedAt      time.Time                 // 创建时间
+	UpdatedAt      time.Time                 // 最近更新时间
+}
+
+// LibraryCampaignGrantDTO 资源发放活动的单个用户处理记录。
+type LibraryCampaignGrantDTO struct {
+	UserID    xSnowflake.SnowflakeID         // 用户 ID
+	Status    entityType.CampaignGrantStatus // 处理结果
+	Message   *string                        // 跳过或失败原因
+	UpdatedAt time.Time                      // 最近处理时间
+}
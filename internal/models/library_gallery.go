@@ -0,0 +1,31 @@
+package models
+
+import (
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+)
+
+// LibraryGalleryQuery 公开画廊查询参数。
+//
+// 由 Handler 层从查询字符串构建，Logic 层负责校验与规范化。
+type LibraryGalleryQuery struct {
+	Keyword    string                  // 名称关键字（可选）
+	UploaderID *xSnowflake.SnowflakeID // 上传者用户 ID（可选）
+	Model      *entity.ModelType       // 皮肤模型（可选，仅皮肤）
+	Tag        string                  // 标签名称（可选）
+	Sort       string                  // 排序方式：newest / equipped / collected / liked
+	Page       int                     // 页码
+	PageSize   int                     // 每页数量
+}
+
+// LibraryTagDTO 资源库标签数据传输对象。
+type LibraryTagDTO struct {
+	Name       string // 标签名称
+	UsageCount int64  // 被引用次数
+}
+
+// LibraryLikeDTO 点赞操作结果数据传输对象。
+type LibraryLikeDTO struct {
+	Liked     bool  // 当前用户是否已点赞
+	LikeCount int64 // 最新点赞数
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// LibraryQuotaLogDTO 资源库配额变更日志数据传输对象。
+type LibraryQuotaLogDTO struct {
+	ID           xSnowflake.SnowflakeID        // 日志 ID
+	OpType       entityType.LibraryQuotaOpType // 操作类型
+	Bucket       entityType.LibraryQuotaBucket // 配额分项
+	Delta        int32                         // 额度变化值（正增负减）
+	BeforeUsed   int32                         // 变更前已使用额度
+	AfterUsed    int32                         // 变更后已使用额度
+	BeforeTotal  int32                         // 变更前总额度
+	AfterTotal   int32                         // 变更后总额度
+	RefLibraryID *xSnowflake.SnowflakeID       // 关联皮肤或披风 ID
+	OperatorID   *xSnowflake.SnowflakeID       // 操作管理员 ID
+	Remark       *string                       // 备注
+	CreatedAt    time.Time                     // 变更时间
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// LibraryReportDTO 资源库举报数据传输对象。
+type LibraryReportDTO struct {
+	ID          xSnowflake.SnowflakeID  // 举报记录 ID
+	ReporterID  xSnowflake.SnowflakeID  // 举报用户 ID
+	Kind        entityType.LibraryKind  // 资源种类
+	LibraryID   xSnowflake.SnowflakeID  // 资源库记录 ID
+	Reason      entityType.ReportReason // 举报原因
+	Description *string                 // 举报说明
+	Status      entityType.ReportStatus // 处理状态
+	CreatedAt   time.Time               // 举报时间
+}
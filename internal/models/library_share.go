@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// LibraryShareDTO 资源私有分享链接数据传输对象（分享者视角）。
+type LibraryShareDTO struct {
+	ID         xSnowflake.SnowflakeID // 分享 ID
+	Kind       entityType.LibraryKind // 资源种类
+	LibraryID  xSnowflake.SnowflakeID // 资源库记录 ID
+	Token      string                 // 分享令牌
+	PreviewURL string                 // 公开预览页面链接
+	ExpiresAt  time.Time              // 到期时间
+	ClaimCount int64                  // 领取人数
+	RevokedAt  *time.Time             // 撤销时间
+	CreatedAt  time.Time              // 创建时间
+}
+
+// LibrarySharePreviewDTO 资源私有分享预览数据传输对象（公开视角）。
+//
+// 仅包含预览所需的最少字段，不暴露资源的审核、举报等内部状态。
+type LibrarySharePreviewDTO struct {
+	Kind       entityType.LibraryKind // 资源种类
+	LibraryID  xSnowflake.SnowflakeID // 资源库记录 ID
+	Name       string                 // 资源名称
+	TextureURL string                 // 纹理文件下载链接
+	Model      *entity.ModelType      // 皮肤模型（仅皮肤）
+	ExpiresAt  time.Time              // 分享到期时间
+}
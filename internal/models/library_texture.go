@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+)
+
+// LibraryTextureVersionDTO 资源库纹理版本数据传输对象。
+type LibraryTextureVersionDTO struct {
+	Version     int32                  // 版本号
+	TextureURL  string                 // 纹理文件下载链接
+	TextureHash string                 // 纹理 SHA256 哈希
+	IsCurrent   bool                   // 是否为当前生效版本
+	IsPending   bool                   // 是否为待审核版本（审核通过后生效）
+	CreatedBy   xSnowflake.SnowflakeID // 上传该版本的用户 ID
+	CreatedAt   time.Time              // 版本创建时间
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+)
+
+// RedeemCodePayloadDTO 兑换码载荷与限制配置，同一批次生成的兑换码共享该配置。
+type RedeemCodePayloadDTO struct {
+	SkinLibraryID     *xSnowflake.SnowflakeID // 兑换获得的皮肤 ID
+	CapeLibraryID     *xSnowflake.SnowflakeID // 兑换获得的披风 ID
+	SkinsPrivateDelta int32                   // 私有皮肤总额度增量
+	SkinsPublicDelta  int32                   // 公开皮肤总额度增量
+	CapesPrivateDelta int32                   // 私有披风总额度增量
+	CapesPublicDelta  int32                   // 公开披风总额度增量
+	ProfileQuotaDelta int32                   // 游戏档案总额度增量
+	MaxUses           int32                   // 每个兑换码的最大兑换次数
+	ExpiresAt         *time.Time              // 过期时间（为空表示永不过期）
+	AllowedRoles      []string                // 允许兑换的角色名称列表（为空表示不限）
+}
+
+// RedeemCodeDTO 兑换码数据传输对象。
+type RedeemCodeDTO struct {
+	ID         xSnowflake.SnowflakeID // 兑换码记录 ID
+	Code       string                 // 兑换码
+	BatchID    xSnowflake.SnowflakeID // 生成批次 ID
+	Payload    RedeemCodePayloadDTO   // 载荷与限制配置
+	UsedCount  int32                  // 已兑换次数
+	IsDisabled bool                   // 是否已停用
+	CreatedAt  time.Time              // 创建时间
+}
+
+// RedeemCodeBatchDTO 兑换码生成批次数据传输对象。
+type RedeemCodeBatchDTO struct {
+	ID        xSnowflake.SnowflakeID // 批次 ID
+	Count     int32                  // 生成数量
+	Remark    *string                // 备注
+	CreatedBy xSnowflake.SnowflakeID // 生成管理员 ID
+	CreatedAt time.Time              // 生成时间
+	Codes     []RedeemCodeDTO        // 本批次生成的兑换码
+}
+
+// RedeemResultDTO 兑换结果，描述本次兑换获得的资源与配额。
+type RedeemResultDTO struct {
+	Code              string                  // 兑换码
+	SkinLibraryID     *xSnowflake.SnowflakeID // 获得的皮肤 ID
+	CapeLibraryID     *xSnowflake.SnowflakeID // 获得的披风 ID
+	SkinsPrivateDelta int32                   // 私有皮肤总额度增量
+	SkinsPublicDelta  int32                   // 公开皮肤总额度增量
+	CapesPrivateDelta int32                   // 私有披风总额度增量
+	CapesPublicDelta  int32                   // 公开披风总额度增量
+	ProfileQuotaDelta int32                   // 游戏档案总额度增量
+}
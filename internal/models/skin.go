@@ -13,15 +13,37 @@ import (
 // 由 Logic 层从 entity.SkinLibrary + bucket 解析后的纹理链接构建。
 // Handler 层负责将此 DTO 转换为 api/library.SkinResponse。
 type SkinDTO struct {
-	ID             xSnowflake.SnowflakeID    // 皮肤库记录 ID
-	UserID         *xSnowflake.SnowflakeID   // 创建者/上传者用户 ID
-	Name           string                    // 皮肤名称
-	TextureURL     string                    // 纹理文件下载链接（由 bucket.Get 解析）
-	TextureHash    string                    // 纹理 SHA256 哈希
-	Model          entity.ModelType          // 皮肤模型 (1=classic, 2=slim)
-	IsPublic       bool                      // 是否公开
-	UpdatedAt      time.Time                 // 更新时间
-	AssignmentType entityType.AssignmentType // 关联类型（mine 模式下返回）
+	ID                    xSnowflake.SnowflakeID    // 皮肤库记录 ID
+	UserID                *xSnowflake.SnowflakeID   // 创建者/上传者用户 ID
+	Name                  string                    // 皮肤名称
+	TextureURL            string                    // 纹理文件下载链接（由 bucket.Get 解析）
+	TextureHash           string                    // 纹理 SHA256 哈希
+	TextureVersion        int32                     // 当前纹理版本号
+	PendingTextureVersion *int32                    // 待审核纹理版本号（已通过审核的公开资源更换纹理后，审核通过前继续使用当前纹理）
+	PendingTextureURL     string                    // 待审核纹理下载链接（仅待审核列表返回）
+	Model                 entity.ModelType          // 皮肤模型 (1=classic, 2=slim)
+	IsPublic              bool                      // 是否公开
+	UpdatedAt             time.Time                 // 更新时间
+	AssignmentType        entityType.AssignmentType // 关联类型（mine 模式下返回）
+	ExpiresAt             *time.Time                // 限时赠送到期时间（mine 模式下返回，为空表示永久）
+	ExpiresIn             *int64                    // 限时赠送剩余秒数（由 ExpiresAt 计算，永久为空，已到期为 0）
+	LikeCount             int64                     // 点赞数
+	CollectCount          int64                     // 收藏数
+	ReviewStatus          entityType.ReviewStatus   // 审核状态
+	ReviewReason          *string                   // 审核驳回原因
+	ReportCount           int64                     // 待处理举报数
+	IsHidden              bool                      // 是否因举报自动隐藏
+	IsDefault             bool                      // 是否为新用户默认发放的系统皮肤
+	IsDefaultEquip        bool                      // 是否为新游戏档案默认装备的系统皮肤
+	IsRetired             bool                      // 系统皮肤是否已停用
+	SimilarToID           *xSnowflake.SnowflakeID   // 疑似近似重复的已公开皮肤 ID（待审核时检测）
+	Tags                  []string                  // 标签名称列表（仅在预加载标签时返回）
+}
+
+// SimilarSkinDTO 相似皮肤数据传输对象。
+type SimilarSkinDTO struct {
+	Skin     SkinDTO // 相似皮肤
+	Distance int     // 与查询皮肤的感知哈希汉明距离（越小越相似）
 }
 
 // SkinSimpleDTO 皮肤精简数据传输对象（仅 ID + Name）。
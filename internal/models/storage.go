@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+)
+
+// StorageReconcileReportDTO 存储对账报告数据传输对象。
+type StorageReconcileReportDTO struct {
+	ID                xSnowflake.SnowflakeID            // 报告 ID
+	DryRun            bool                              // 是否为试运行
+	Status            entityType.StorageReconcileStatus // 任务状态
+	TriggeredBy       *xSnowflake.SnowflakeID           // 触发管理员（为空表示定时任务）
+	CheckedReferences int64                             // 已核对的数据库引用数
+	CheckedFiles      int64                             // 已核对的登记文件数
+	OrphanCount       int64                             // 孤儿文件数
+	DanglingCount     int64                             // 悬空引用数
+	MismatchCount     int64                             // 哈希不一致数
+	UnverifiedCount   int64                             // 缓存态引用数
+	FixedCount        int64                             // 已自动修复数
+	FailedCount       int64                             // 自动修复失败数
+	LastError         *string                           // 任务中断原因
+	FinishedAt        *time.Time                        // 完成时间
+	CreatedAt         time.Time                         // 创建时间
+	UpdatedAt         time.Time                         // 最近更新时间
+}
+
+// StorageReconcileIssueDTO 存储对账问题数据传输对象。
+type StorageReconcileIssueDTO struct {
+	ID           xSnowflake.SnowflakeID        // 问题 ID
+	ReportID     xSnowflake.SnowflakeID        // 所属报告 ID
+	Type         entityType.StorageIssueType   // 问题类型
+	Source       entityType.StorageFileSource  // 文件来源
+	FileID       int64                         // 存储桶文件 ID
+	RefID        *xSnowflake.SnowflakeID       // 引用该文件的记录 ID
+	ExpectedHash *string                       // 数据库记录的纹理哈希
+	ActualHash   *string                       // 对象存储文件的 SHA256
+	Action       entityType.StorageIssueAction // 处理动作
+	Message      *string                       // 处理失败原因
+	CreatedAt    time.Time                     // 发现时间
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+// GameProfileNameRuleCache 游戏档案名称规则缓存管理器
+//
+// 该类型封装了与 Redis 的交互，用于缓存全部已启用的名称规则，
+// 避免名称可用性检查（前端随输入频繁调用）每次都查询数据库。
+// 缓存结构使用 String，键为 game-profile:name-rules，值为 JSON 序列化的规则列表。
+//
+// 失效策略：规则的任何写操作后删除该键，下次读取时从数据库重建。
+type GameProfileNameRuleCache xCache.Cache
+
+// Get 读取已启用的名称规则列表。
+//
+// 返回值:
+//   - []entity.GameProfileNameRule: 缓存的规则列表。
+//   - bool: 是否命中缓存。
+//   - error: 操作过程中发生的错误。
+func (c *GameProfileNameRuleCache) Get(ctx context.Context) ([]entity.GameProfileNameRule, bool, error) {
+	data, err := c.RDB.Get(ctx, bConst.CacheGameProfileNameRules.String()).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("读取名称规则缓存失败: %w", err)
+	}
+
+	var rules []entity.GameProfileNameRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, false, fmt.Errorf("名称规则缓存反序列化失败: %w", err)
+	}
+	return rules, true, nil
+}
+
+// Set 写入已启用的名称规则列表。
+func (c *GameProfileNameRuleCache) Set(ctx context.Context, rules []entity.GameProfileNameRule) error {
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return fmt.Errorf("名称规则缓存序列化失败: %w", err)
+	}
+	if err := c.RDB.Set(ctx, bConst.CacheGameProfileNameRules.String(), data, c.TTL).Err(); err != nil {
+		return fmt.Errorf("写入名称规则缓存失败: %w", err)
+	}
+	return nil
+}
+
+// Invalidate 删除名称规则缓存。
+func (c *GameProfileNameRuleCache) Invalidate(ctx context.Context) error {
+	if err := c.RDB.Del(ctx, bConst.CacheGameProfileNameRules.String()).Err(); err != nil {
+		return fmt.Errorf("删除名称规则缓存失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	"github.com/redis/go-redis/v9"
+)
+
+// GalleryCache 公开画廊分页缓存管理器
+//
+// 该类型封装了与 Redis 的交互，用于缓存画廊查询的分页结果。
+// 缓存结构使用 String，键格式为 library:gallery:<kind>:<version>:<queryKey>，值为调用方序列化后的 JSON。
+//
+// 失效策略：每种资源维护一个版本号键，影响结果集或展示内容的写操作（标签、公开状态、审核、纹理变更等）
+// 递增版本号，旧版本的分页缓存不再被命中并由 TTL 自然回收，避免 SCAN 批量删除。
+// 点赞、收藏、装备等高频计数变化不递增版本号，计数排序的滞后由 TTL 限定。
+type GalleryCache xCache.Cache
+
+// Get 读取指定查询条件的画廊缓存。
+//
+// 参数:
+//   - ctx: 上下文对象。
+//   - kind: 资源种类标识（skin / cape）。
+//   - queryKey: 查询条件摘要。
+//
+// 返回值:
+//   - []byte: 缓存的 JSON 数据。
+//   - bool: 是否命中缓存。
+//   - error: 操作过程中发生的错误。
+func (c *GalleryCache) Get(ctx context.Context, kind string, queryKey string) ([]byte, bool, error) {
+	version, err := c.version(ctx, kind)
+	if err != nil {
+		return nil, false, err
+	}
+
+	data, err := c.RDB.Get(ctx, bConst.CacheLibraryGallery.Get(kind, version, queryKey).String()).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("读取画廊缓存失败: %w", err)
+	}
+	return data, true, nil
+}
+
+// Set 写入指定查询条件的画廊缓存。
+func (c *GalleryCache) Set(ctx context.Context, kind string, queryKey string, data []byte) error {
+	version, err := c.version(ctx, kind)
+	if err != nil {
+		return err
+	}
+
+	if err := c.RDB.Set(ctx, bConst.CacheLibraryGallery.Get(kind, version, queryKey).String(), data, c.TTL).Err(); err != nil {
+		return fmt.Errorf("写入画廊缓存失败: %w", err)
+	}
+	return nil
+}
+
+// Invalidate 递增版本号，使指定资源种类的全部画廊缓存失效。
+func (c *GalleryCache) Invalidate(ctx context.Context, kind string) error {
+	if err := c.RDB.Incr(ctx, bConst.CacheLibraryGalleryVersion.Get(kind).String()).Err(); err != nil {
+		return fmt.Errorf("递增画廊缓存版本失败: %w", err)
+	}
+	return nil
+}
+
+// version 读取资源种类当前的缓存版本号，不存在时视为 0。
+func (c *GalleryCache) version(ctx context.Context, kind string) (int64, error) {
+	version, err := c.RDB.Get(ctx, bConst.CacheLibraryGalleryVersion.Get(kind).String()).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("读取画廊缓存版本失败: %w", err)
+	}
+	return version, nil
+}
@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+)
+
+// RedeemRateLimitCache 兑换码兑换频率计数器
+//
+// 使用 Redis INCR + EXPIRE 实现固定窗口计数，键格式为 library:redeem:ratelimit:<userID>，
+// 窗口时长取自 TTL。无论兑换成功与否均计数，用于防止暴力枚举兑换码。
+type RedeemRateLimitCache xCache.Cache
+
+// Hit 记录一次兑换尝试并返回当前窗口内的累计次数。
+func (c *RedeemRateLimitCache) Hit(ctx context.Context, userID string) (int64, error) {
+	key := bConst.CacheLibraryRedeemRateLimit.Get(userID).String()
+
+	count, err := c.RDB.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("递增兑换频率计数失败: %w", err)
+	}
+	if count == 1 {
+		if err := c.RDB.Expire(ctx, key, c.TTL).Err(); err != nil {
+			return count, fmt.Errorf("设置兑换频率计数过期时间失败: %w", err)
+		}
+	}
+	return count, nil
+}
@@ -49,8 +49,14 @@ func (c *SessionCache) Set(ctx context.Context, serverID string, data *SessionDa
 		return fmt.Errorf("序列化会话数据失败: %w", err)
 	}
 
+	ttl := time.Duration(bConst.YggdrasilSessionExpireSec) * time.Second
 	redisKey := bConst.CacheYggdrasilSession.Get(serverID).String()
-	if err := c.RDB.Set(ctx, redisKey, jsonData, time.Duration(bConst.YggdrasilSessionExpireSec)*time.Second).Err(); err != nil {
+	indexKey := bConst.CacheYggdrasilSessionProfile.Get(data.ProfileUUID).String()
+	pipe := c.RDB.TxPipeline()
+	pipe.Set(ctx, redisKey, jsonData, ttl)
+	pipe.SAdd(ctx, indexKey, serverID)
+	pipe.Expire(ctx, indexKey, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
 		return err
 	}
 	return nil
@@ -99,3 +105,33 @@ func (c *SessionCache) Delete(ctx context.Context, serverID string) error {
 
 	return c.RDB.Del(ctx, bConst.CacheYggdrasilSession.Get(serverID).String()).Err()
 }
+
+// DeleteByProfile 删除指定游戏档案的全部待验证会话。
+//
+// 会话写入时会同步登记到以档案 UUID 为键的索引集合中（与会话同 TTL），
+// 删除时按索引逐个清除会话，最后删除索引本身。
+//
+// 参数:
+//   - ctx: 上下文对象，用于传递请求上下文。
+//   - profileUUID: 游戏档案无符号 UUID。
+//
+// 返回值:
+//   - error: 操作过程中发生的错误。
+func (c *SessionCache) DeleteByProfile(ctx context.Context, profileUUID string) error {
+	if profileUUID == "" {
+		return fmt.Errorf("游戏档案 UUID 为空")
+	}
+
+	indexKey := bConst.CacheYggdrasilSessionProfile.Get(profileUUID).String()
+	serverIDs, err := c.RDB.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(serverIDs)+1)
+	for _, serverID := range serverIDs {
+		keys = append(keys, bConst.CacheYggdrasilSession.Get(serverID).String())
+	}
+	keys = append(keys, indexKey)
+	return c.RDB.Del(ctx, keys...).Err()
+}
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -51,6 +53,24 @@ func (r *CapeLibraryRepo) GetByID(ctx context.Context, tx *gorm.DB, capeID xSnow
 	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询披风库记录失败", true, err)
 }
 
+// GetByIDForUpdate 根据披风 ID 行锁查询披风库记录（SELECT ... FOR UPDATE），需在事务内调用。
+func (r *CapeLibraryRepo) GetByIDForUpdate(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID) (*entity.CapeLibrary, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByIDForUpdate - 根据披风 ID 行锁获取披风库记录")
+
+	var cape entity.CapeLibrary
+	err := r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", capeID).
+		First(&cape).Error
+	if err == nil {
+		return &cape, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询披风库记录失败", true, err)
+}
+
 // GetByIDAndUserID 根据披风 ID 和用户 ID 查询披风库记录。
 func (r *CapeLibraryRepo) GetByIDAndUserID(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, userID xSnowflake.SnowflakeID, forUpdate bool) (*entity.CapeLibrary, bool, *xError.Error) {
 	r.log.Info(ctx, "GetByIDAndUserID - 根据披风 ID 与用户 ID 获取披风库记录")
@@ -118,12 +138,12 @@ func (r *CapeLibraryRepo) DeleteByID(ctx context.Context, tx *gorm.DB, capeID xS
 	return nil
 }
 
-// ListPublic 查询公开的披风库记录列表。
+// ListPublic 查询公开、审核通过且未被隐藏的披风库记录列表。
 func (r *CapeLibraryRepo) ListPublic(ctx context.Context, tx *gorm.DB, page int, pageSize int) ([]entity.CapeLibrary, int64, *xError.Error) {
 	r.log.Info(ctx, "ListPublic - 查询公开披风库记录列表")
 
 	var total int64
-	query := r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).Where("is_public = ?", true)
+	query := r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).Where("is_public = ? AND review_status = ? AND is_hidden = ?", true, entityType.ReviewStatusApproved, false)
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询披风库记录总数失败", true, err)
 	}
@@ -176,6 +196,338 @@ func (r *CapeLibraryRepo) CountPrivateByUserID(ctx context.Context, tx *gorm.DB,
 	return count, nil
 }
 
+// SearchGallery 按画廊条件分页查询公开、审核通过且未被隐藏的披风库记录（预加载标签）。
+func (r *CapeLibraryRepo) SearchGallery(ctx context.Context, tx *gorm.DB, filter LibraryGalleryFilter, page int, pageSize int) ([]entity.CapeLibrary, int64, *xError.Error) {
+	r.log.Info(ctx, "SearchGallery - 按画廊条件查询公开披风库记录")
+
+	query := r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).Where("fyl_cape_library.is_public = ? AND fyl_cape_library.review_status = ? AND fyl_cape_library.is_hidden = ?", true, entityType.ReviewStatusApproved, false)
+	if filter.Keyword != "" {
+		query = query.Where("fyl_cape_library.name ILIKE ?", "%"+escapeLikePattern(filter.Keyword)+"%")
+	}
+	if filter.UploaderID != nil {
+		query = query.Where("fyl_cape_library.user_id = ?", *filter.UploaderID)
+	}
+	if filter.Tag != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM fyl_cape_library_tag JOIN fyl_library_tag ON fyl_library_tag.id = fyl_cape_library_tag.library_tag_id WHERE fyl_cape_library_tag.cape_library_id = fyl_cape_library.id AND fyl_library_tag.name = ?)",
+			filter.Tag,
+		)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询披风库记录总数失败", true, err)
+	}
+
+	switch filter.Sort {
+	case GallerySortEquipped:
+		query = query.Order("(SELECT COUNT(*) FROM fyl_game_profile WHERE fyl_game_profile.cape_library_id = fyl_cape_library.id) DESC")
+	case GallerySortCollected:
+		query = query.Order("fyl_cape_library.collect_count DESC")
+	case GallerySortLiked:
+		query = query.Order("fyl_cape_library.like_count DESC")
+	}
+	query = query.Order("fyl_cape_library.created_at DESC")
+
+	var items []entity.CapeLibrary
+	offset := (page - 1) * pageSize
+	if err := query.Preload("Tags").Offset(offset).Limit(pageSize).Find(&items).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询披风库记录列表失败", true, err)
+	}
+	return items, total, nil
+}
+
+// ReplaceTags 以给定标签集合整体替换披风的标签关联。
+//
+// 直接操作 fyl_cape_library_tag 关联表，避免 GORM Association 回写披风记录触发实体钩子。
+func (r *CapeLibraryRepo) ReplaceTags(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, tags []entity.LibraryTag) *xError.Error {
+	r.log.Info(ctx, "ReplaceTags - 替换披风标签关联")
+
+	db := r.pickDB(ctx, tx)
+	if err := db.Exec("DELETE FROM fyl_cape_library_tag WHERE cape_library_id = ?", capeID).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "清除披风标签失败", true, err)
+	}
+	for _, tag := range tags {
+		if err := db.Exec("INSERT INTO fyl_cape_library_tag (cape_library_id, library_tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING", capeID, tag.ID).Error; err != nil {
+			return xError.NewError(ctx, xError.DatabaseError, "写入披风标签失败", true, err)
+		}
+	}
+	return nil
+}
+
+// IncrementLikeCount 原子增减披风点赞数（delta 可为负数）。
+func (r *CapeLibraryRepo) IncrementLikeCount(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, delta int64) *xError.Error {
+	r.log.Info(ctx, "IncrementLikeCount - 更新披风点赞数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ?", capeID).
+		UpdateColumn("like_count", gorm.Expr("GREATEST(like_count + ?, 0)", delta)).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新披风点赞数失败", true, err)
+	}
+	return nil
+}
+
+// IncrementCollectCount 原子增减披风收藏数（delta 可为负数）。
+func (r *CapeLibraryRepo) IncrementCollectCount(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, delta int64) *xError.Error {
+	r.log.Info(ctx, "IncrementCollectCount - 更新披风收藏数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ?", capeID).
+		UpdateColumn("collect_count", gorm.Expr("GREATEST(collect_count + ?, 0)", delta)).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新披风收藏数失败", true, err)
+	}
+	return nil
+}
+
+// ListPendingReview 分页查询待审核的公开披风库记录（按更新时间正序，先提交先审核）。
+//
+// 待审核包括整条记录处于待审核状态，以及已通过审核但存在待审核纹理版本的记录。
+func (r *CapeLibraryRepo) ListPendingReview(ctx context.Context, tx *gorm.DB, page int, pageSize int) ([]entity.CapeLibrary, int64, *xError.Error) {
+	r.log.Info(ctx, "ListPendingReview - 查询待审核披风库记录")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).
+		Where("is_public = ? AND (review_status = ? OR pending_texture_version IS NOT NULL)", true, entityType.ReviewStatusPending)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询待审核披风总数失败", true, err)
+	}
+
+	var items []entity.CapeLibrary
+	offset := (page - 1) * pageSize
+	if err := query.Order("updated_at ASC").Offset(offset).Limit(pageSize).Find(&items).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询待审核披风列表失败", true, err)
+	}
+	return items, total, nil
+}
+
+// UpdateReviewStatus 更新披风审核状态及公开状态。
+//
+// reviewerID 为空表示重新进入待审核，此时同时清空审核人、审核时间与驳回原因。
+// 使用 UpdateColumns 跳过实体钩子，仅写入审核相关列。
+func (r *CapeLibraryRepo) UpdateReviewStatus(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, status entityType.ReviewStatus, isPublic bool, reason *string, reviewerID *xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "UpdateReviewStatus - 更新披风审核状态")
+
+	updates := map[string]interface{}{
+		"review_status": status,
+		"is_public":     isPublic,
+		"review_reason": reason,
+		"reviewed_by":   reviewerID,
+		"reviewed_at":   nil,
+	}
+	if reviewerID != nil {
+		updates["reviewed_at"] = time.Now()
+	}
+	if err := r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).Where("id = ?", capeID).UpdateColumns(updates).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新披风审核状态失败", true, err)
+	}
+	return nil
+}
+
+// AddReport 原子累加披风待处理举报数，累加后达到 hideThreshold 时同时标记为隐藏。
+func (r *CapeLibraryRepo) AddReport(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, hideThreshold int64) *xError.Error {
+	r.log.Info(ctx, "AddReport - 累加披风举报数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ?", capeID).
+		UpdateColumns(map[string]interface{}{
+			"report_count": gorm.Expr("report_count + 1"),
+			"is_hidden":    gorm.Expr("is_hidden OR report_count + 1 >= ?", hideThreshold),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新披风举报数失败", true, err)
+	}
+	return nil
+}
+
+// ClearReports 清零披风待处理举报数并取消隐藏。
+func (r *CapeLibraryRepo) ClearReports(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearReports - 清除披风举报状态")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ?", capeID).
+		UpdateColumns(map[string]interface{}{
+			"report_count": 0,
+			"is_hidden":    false,
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "清除披风举报状态失败", true, err)
+	}
+	return nil
+}
+
+// ListReported 分页查询存在待处理举报的披风库记录（已隐藏优先，其次按举报数倒序）。
+func (r *CapeLibraryRepo) ListReported(ctx context.Context, tx *gorm.DB, page int, pageSize int) ([]entity.CapeLibrary, int64, *xError.Error) {
+	r.log.Info(ctx, "ListReported - 查询被举报披风库记录")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).Where("report_count > 0")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询被举报披风总数失败", true, err)
+	}
+
+	var items []entity.CapeLibrary
+	offset := (page - 1) * pageSize
+	if err := query.Order("is_hidden DESC").Order("report_count DESC").Order("updated_at ASC").Offset(offset).Limit(pageSize).Find(&items).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询被举报披风列表失败", true, err)
+	}
+	return items, total, nil
+}
+
+// UpdateTexture 更新披风当前纹理文件、哈希、感知哈希与版本号，并清空待审核纹理版本。
+//
+// 使用 UpdateColumns 跳过实体钩子，仅写入纹理相关列。
+func (r *CapeLibraryRepo) UpdateTexture(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, texture int64, textureHash string, perceptualHash *int64, version int32) *xError.Error {
+	r.log.Info(ctx, "UpdateTexture - 更新披风纹理")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ?", capeID).
+		UpdateColumns(map[string]interface{}{
+			"texture":                 texture,
+			"texture_hash":            textureHash,
+			"perceptual_hash":         perceptualHash,
+			"texture_version":         version,
+			"pending_texture_version": nil,
+			"updated_at":              time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新披风纹理失败", true, err)
+	}
+	return nil
+}
+
+// UpdatePendingTextureVersion 设置或清空披风待审核纹理版本号。
+//
+// 暂存新版本时同步刷新更新时间，使审核队列按提交先后排序；使用 UpdateColumns 跳过实体钩子。
+func (r *CapeLibraryRepo) UpdatePendingTextureVersion(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, version *int32) *xError.Error {
+	r.log.Info(ctx, "UpdatePendingTextureVersion - 更新披风待审核纹理版本")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ?", capeID).
+		UpdateColumns(map[string]interface{}{
+			"pending_texture_version": version,
+			"updated_at":              time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新披风待审核纹理版本失败", true, err)
+	}
+	return nil
+}
+
+// ListSimilar 查询感知哈希与目标纹理相近的披风，按汉明距离升序、创建时间升序排列。
+func (r *CapeLibraryRepo) ListSimilar(ctx context.Context, tx *gorm.DB, filter LibrarySimilarFilter) ([]entity.CapeLibrary, *xError.Error) {
+	r.log.Info(ctx, "ListSimilar - 查询相似披风")
+
+	var capes []entity.CapeLibrary
+	query := applySimilarFilter(r.pickDB(ctx, tx), &entity.CapeLibrary{}, filter)
+	if err := query.Find(&capes).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询相似披风失败", true, err)
+	}
+	return capes, nil
+}
+
+// ListMissingPerceptualHash 按 ID 升序查询 afterID 之后尚未计算感知哈希的披风，用于存量数据回填。
+func (r *CapeLibraryRepo) ListMissingPerceptualHash(ctx context.Context, tx *gorm.DB, afterID xSnowflake.SnowflakeID, limit int) ([]entity.CapeLibrary, *xError.Error) {
+	r.log.Info(ctx, "ListMissingPerceptualHash - 查询缺少感知哈希的披风")
+
+	var capes []entity.CapeLibrary
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("perceptual_hash IS NULL AND id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&capes).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询缺少感知哈希的披风失败", true, err)
+	}
+	return capes, nil
+}
+
+// UpdatePerceptualHash 回填披风的感知哈希。
+//
+// 仅当纹理未变且感知哈希仍为空时写入，避免覆盖回填期间替换纹理产生的新哈希。
+func (r *CapeLibraryRepo) UpdatePerceptualHash(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, texture int64, perceptualHash int64) *xError.Error {
+	r.log.Info(ctx, "UpdatePerceptualHash - 回填披风感知哈希")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ? AND texture = ? AND perceptual_hash IS NULL", capeID, texture).
+		UpdateColumn("perceptual_hash", perceptualHash).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "回填披风感知哈希失败", true, err)
+	}
+	return nil
+}
+
+// UpdateSimilarTo 更新披风的疑似近似重复标记，similarToID 为 nil 时清除标记。
+func (r *CapeLibraryRepo) UpdateSimilarTo(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, similarToID *xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "UpdateSimilarTo - 更新披风近似重复标记")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ?", capeID).
+		UpdateColumn("similar_to_id", similarToID).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新披风近似重复标记失败", true, err)
+	}
+	return nil
+}
+
+// ListSystem 分页查询系统内置披风（user_id 为空），includeRetired 为 false 时排除已停用披风。
+func (r *CapeLibraryRepo) ListSystem(ctx context.Context, tx *gorm.DB, includeRetired bool, page int, pageSize int) ([]entity.CapeLibrary, int64, *xError.Error) {
+	r.log.Info(ctx, "ListSystem - 查询系统内置披风列表")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).Where("user_id IS NULL")
+	if !includeRetired {
+		query = query.Where("is_retired = ?", false)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询系统披风总数失败", true, err)
+	}
+
+	var capes []entity.CapeLibrary
+	offset := (page - 1) * pageSize
+	if err := query.Order("is_retired ASC").Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&capes).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询系统披风列表失败", true, err)
+	}
+	return capes, total, nil
+}
+
+// ListDefaults 查询所有未停用且标记为新用户默认发放的系统披风。
+func (r *CapeLibraryRepo) ListDefaults(ctx context.Context, tx *gorm.DB) ([]entity.CapeLibrary, *xError.Error) {
+	r.log.Info(ctx, "ListDefaults - 查询默认发放的系统披风")
+
+	var capes []entity.CapeLibrary
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("user_id IS NULL AND is_default = ? AND is_retired = ?", true, false).
+		Order("created_at ASC").
+		Find(&capes).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询默认发放的系统披风失败", true, err)
+	}
+	return capes, nil
+}
+
+// UpdateCatalog 更新系统披风的名称、公开状态、默认发放与停用标记。
+//
+// 使用 UpdateColumns 跳过实体钩子，仅写入目录相关列。
+func (r *CapeLibraryRepo) UpdateCatalog(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID, name string, isPublic bool, isDefault bool, isRetired bool) *xError.Error {
+	r.log.Info(ctx, "UpdateCatalog - 更新系统披风目录标记")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.CapeLibrary{}).
+		Where("id = ?", capeID).
+		UpdateColumns(map[string]interface{}{
+			"name":       name,
+			"is_public":  isPublic,
+			"is_default": isDefault,
+			"is_retired": isRetired,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新系统披风目录标记失败", true, err)
+	}
+	return nil
+}
+
 func (r *CapeLibraryRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
 	if tx != nil {
 		return tx.WithContext(ctx)
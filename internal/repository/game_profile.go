@@ -3,15 +3,25 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// gameProfileNameLowerIndex 游戏档案用户名不区分大小写唯一索引名称（lower(name)）。
+//
+// 该索引无法由 AutoMigrate 直接创建（存量数据可能存在仅大小写不同的重复名称），
+// 由启动预置流程或管理员在冲突处理完成后通过 CreateNameLowerUniqueIndex 建立。
+const gameProfileNameLowerIndex = "uk_game_profile_name_lower"
+
 // GameProfileRepo 游戏档案仓储，负责游戏档案数据访问。
 type GameProfileRepo struct {
 	db  *gorm.DB
@@ -31,6 +41,9 @@ func (r *GameProfileRepo) Create(ctx context.Context, tx *gorm.DB, profile *enti
 	r.log.Info(ctx, "Create - 创建游戏档案")
 
 	if err := r.pickDB(ctx, tx).Create(profile).Error; err != nil {
+		if isNameLowerUniqueViolation(err) {
+			return nil, xError.NewError(ctx, xError.DataConflict, "用户名已存在", true, err)
+		}
 		return nil, xError.NewError(ctx, xError.DatabaseError, "创建游戏档案失败", true, err)
 	}
 	return profile, nil
@@ -47,12 +60,12 @@ func (r *GameProfileRepo) ExistsByUUID(ctx context.Context, tx *gorm.DB, uuid st
 	return count > 0, nil
 }
 
-// ExistsByNameExceptID 检查用户名是否存在（排除指定档案 ID）。
+// ExistsByNameExceptID 检查用户名是否存在（不区分大小写，排除指定档案 ID）。
 func (r *GameProfileRepo) ExistsByNameExceptID(ctx context.Context, tx *gorm.DB, name string, profileID xSnowflake.SnowflakeID) (bool, *xError.Error) {
 	r.log.Info(ctx, "ExistsByNameExceptID - 检查用户名是否存在")
 
 	var count int64
-	query := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("name = ?", name)
+	query := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("lower(name) = lower(?)", name)
 	if !profileID.IsZero() {
 		query = query.Where("id <> ?", profileID)
 	}
@@ -133,6 +146,33 @@ func (r *GameProfileRepo) UpdateName(ctx context.Context, tx *gorm.DB, profileID
 	r.log.Info(ctx, "UpdateName - 更新游戏档案用户名")
 
 	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("id = ?", profileID).Update("name", name).Error; err != nil {
+		if isNameLowerUniqueViolation(err) {
+			return nil, xError.NewError(ctx, xError.DataConflict, "用户名已存在", true, err)
+		}
+		return nil, xError.NewError(ctx, xError.DatabaseError, "更新游戏档案名称失败", true, err)
+	}
+
+	updatedProfile, found, xErr := r.GetByID(ctx, tx, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	return updatedProfile, nil
+}
+
+// UpdateNameAndChangedAt 更新指定档案的用户名及最近改名时间（改名冷却起点）。
+func (r *GameProfileRepo) UpdateNameAndChangedAt(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID, name string, changedAt time.Time) (*entity.GameProfile, *xError.Error) {
+	r.log.Info(ctx, "UpdateNameAndChangedAt - 更新游戏档案用户名及改名时间")
+
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("id = ?", profileID).Updates(map[string]interface{}{
+		"name":            name,
+		"name_changed_at": changedAt,
+	}).Error; err != nil {
+		if isNameLowerUniqueViolation(err) {
+			return nil, xError.NewError(ctx, xError.DataConflict, "用户名已存在", true, err)
+		}
 		return nil, xError.NewError(ctx, xError.DatabaseError, "更新游戏档案名称失败", true, err)
 	}
 
@@ -146,6 +186,40 @@ func (r *GameProfileRepo) UpdateName(ctx context.Context, tx *gorm.DB, profileID
 	return updatedProfile, nil
 }
 
+// ClearNameChangedAt 清空指定档案的最近改名时间，使其可立即再次改名。
+func (r *GameProfileRepo) ClearNameChangedAt(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearNameChangedAt - 重置游戏档案改名冷却")
+
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("id = ?", profileID).UpdateColumn("name_changed_at", nil).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "重置游戏档案改名冷却失败", true, err)
+	}
+	return nil
+}
+
+// UpdateLock 更新指定档案的锁定状态，lockedAt 为 nil 时解除锁定并清空锁定原因。
+func (r *GameProfileRepo) UpdateLock(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID, lockedAt *time.Time, reason *string) (*entity.GameProfile, *xError.Error) {
+	r.log.Info(ctx, "UpdateLock - 更新游戏档案锁定状态")
+
+	if lockedAt == nil {
+		reason = nil
+	}
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("id = ?", profileID).Updates(map[string]interface{}{
+		"locked_at":   lockedAt,
+		"lock_reason": reason,
+	}).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "更新游戏档案锁定状态失败", true, err)
+	}
+
+	updatedProfile, found, xErr := r.GetByID(ctx, tx, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	return updatedProfile, nil
+}
+
 // UpdateSkinLibraryID 更新指定档案的关联皮肤库 ID。
 func (r *GameProfileRepo) UpdateSkinLibraryID(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID, skinLibraryID *xSnowflake.SnowflakeID) (*entity.GameProfile, *xError.Error) {
 	r.log.Info(ctx, "UpdateSkinLibraryID - 更新游戏档案关联皮肤")
@@ -182,6 +256,187 @@ func (r *GameProfileRepo) UpdateCapeLibraryID(ctx context.Context, tx *gorm.DB,
 	return updatedProfile, nil
 }
 
+// UpdateEquipment 以单条 UPDATE 同时更新指定档案的关联皮肤库 ID 与披风库 ID。
+//
+// 两项绑定在同一语句内写入，读取方不会观察到只换了一半的外观；传 nil 表示卸下对应部位。
+func (r *GameProfileRepo) UpdateEquipment(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID, skinLibraryID *xSnowflake.SnowflakeID, capeLibraryID *xSnowflake.SnowflakeID) (*entity.GameProfile, *xError.Error) {
+	r.log.Info(ctx, "UpdateEquipment - 同时更新游戏档案皮肤与披风")
+
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("id = ?", profileID).Updates(map[string]interface{}{
+		"skin_library_id": skinLibraryID,
+		"cape_library_id": capeLibraryID,
+	}).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "更新游戏档案外观失败", true, err)
+	}
+
+	updatedProfile, found, xErr := r.GetByID(ctx, tx, profileID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+	}
+	return updatedProfile, nil
+}
+
+// ClearSkinLibraryIDByUser 卸下指定用户所有档案上装备的皮肤。
+func (r *GameProfileRepo) ClearSkinLibraryIDByUser(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, skinLibraryID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearSkinLibraryIDByUser - 卸下用户档案上的皮肤")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfile{}).
+		Where("user_id = ? AND skin_library_id = ?", userID, skinLibraryID).
+		UpdateColumn("skin_library_id", nil).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "卸下游戏档案皮肤失败", true, err)
+	}
+	return nil
+}
+
+// ClearSkinLibraryIDForCollectors 卸下所有以收藏方式装备该皮肤的档案。
+func (r *GameProfileRepo) ClearSkinLibraryIDForCollectors(ctx context.Context, tx *gorm.DB, skinLibraryID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearSkinLibraryIDForCollectors - 卸下收藏者档案上的皮肤")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfile{}).
+		Where("skin_library_id = ?", skinLibraryID).
+		Where("user_id IN (SELECT user_id FROM fyl_user_skin_library WHERE skin_library_id = ? AND assignment_type = ?)", skinLibraryID, entityType.AssignmentTypeCollect).
+		UpdateColumn("skin_library_id", nil).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "卸下收藏者档案皮肤失败", true, err)
+	}
+	return nil
+}
+
+// ClearCapeLibraryIDByUser 卸下指定用户所有档案上装备的披风。
+func (r *GameProfileRepo) ClearCapeLibraryIDByUser(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, capeLibraryID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearCapeLibraryIDByUser - 卸下用户档案上的披风")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfile{}).
+		Where("user_id = ? AND cape_library_id = ?", userID, capeLibraryID).
+		UpdateColumn("cape_library_id", nil).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "卸下游戏档案披风失败", true, err)
+	}
+	return nil
+}
+
+// ClearCapeLibraryIDForCollectors 卸下所有以收藏方式装备该披风的档案。
+func (r *GameProfileRepo) ClearCapeLibraryIDForCollectors(ctx context.Context, tx *gorm.DB, capeLibraryID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearCapeLibraryIDForCollectors - 卸下收藏者档案上的披风")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfile{}).
+		Where("cape_library_id = ?", capeLibraryID).
+		Where("user_id IN (SELECT user_id FROM fyl_user_cape_library WHERE cape_library_id = ? AND assignment_type = ?)", capeLibraryID, entityType.AssignmentTypeCollect).
+		UpdateColumn("cape_library_id", nil).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "卸下收藏者档案披风失败", true, err)
+	}
+	return nil
+}
+
+// ClearSkinLibraryIDAll 卸下所有档案上装备的指定皮肤（不区分用户）。
+func (r *GameProfileRepo) ClearSkinLibraryIDAll(ctx context.Context, tx *gorm.DB, skinLibraryID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearSkinLibraryIDAll - 卸下全部档案上的皮肤")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfile{}).
+		Where("skin_library_id = ?", skinLibraryID).
+		UpdateColumn("skin_library_id", nil).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "卸下游戏档案皮肤失败", true, err)
+	}
+	return nil
+}
+
+// ClearCapeLibraryIDAll 卸下所有档案上装备的指定披风（不区分用户）。
+func (r *GameProfileRepo) ClearCapeLibraryIDAll(ctx context.Context, tx *gorm.DB, capeLibraryID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearCapeLibraryIDAll - 卸下全部档案上的披风")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfile{}).
+		Where("cape_library_id = ?", capeLibraryID).
+		UpdateColumn("cape_library_id", nil).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "卸下游戏档案披风失败", true, err)
+	}
+	return nil
+}
+
+// UpdateOwnerAndEquipment 以单条 UPDATE 将档案转移给新持有者并同时写入装备的皮肤与披风。
+//
+// 用于档案转移：接收方未拥有的资源需在同一语句内卸下（传 nil），避免出现归属已变更但仍装备原持有者资源的中间状态。
+func (r *GameProfileRepo) UpdateOwnerAndEquipment(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID, userID xSnowflake.SnowflakeID, skinLibraryID *xSnowflake.SnowflakeID, capeLibraryID *xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "UpdateOwnerAndEquipment - 转移游戏档案持有者")
+
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("id = ?", profileID).Updates(map[string]interface{}{
+		"user_id":         userID,
+		"skin_library_id": skinLibraryID,
+		"cape_library_id": capeLibraryID,
+	}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "转移游戏档案持有者失败", true, err)
+	}
+	return nil
+}
+
+// Delete 删除游戏档案记录，进服记录与正版档案缓存随外键级联删除。
+func (r *GameProfileRepo) Delete(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "Delete - 删除游戏档案")
+
+	if err := r.pickDB(ctx, tx).Where("id = ?", profileID).Delete(&entity.GameProfile{}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "删除游戏档案失败", true, err)
+	}
+	return nil
+}
+
+// ListNameCaseConflicts 查询所有仅大小写不同的重复用户名档案，按 lower(name) 与创建时间升序排列。
+func (r *GameProfileRepo) ListNameCaseConflicts(ctx context.Context, tx *gorm.DB) ([]entity.GameProfile, *xError.Error) {
+	r.log.Info(ctx, "ListNameCaseConflicts - 查询大小写冲突的游戏档案用户名")
+
+	duplicated := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).
+		Select("lower(name)").
+		Group("lower(name)").
+		Having("COUNT(*) > 1")
+
+	var profiles []entity.GameProfile
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).
+		Where("lower(name) IN (?)", duplicated).
+		Order("lower(name) ASC, created_at ASC").
+		Find(&profiles).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询大小写冲突的游戏档案用户名失败", true, err)
+	}
+	return profiles, nil
+}
+
+// HasNameLowerUniqueIndex 检查用户名不区分大小写唯一索引是否已建立。
+func (r *GameProfileRepo) HasNameLowerUniqueIndex(ctx context.Context) bool {
+	return r.db.WithContext(ctx).Migrator().HasIndex(&entity.GameProfile{}, gameProfileNameLowerIndex)
+}
+
+// CreateNameLowerUniqueIndex 建立用户名不区分大小写唯一索引（已存在时不做任何操作）。
+//
+// 存量数据仍有仅大小写不同的重复名称时返回 DataConflict。
+func (r *GameProfileRepo) CreateNameLowerUniqueIndex(ctx context.Context) *xError.Error {
+	r.log.Info(ctx, "CreateNameLowerUniqueIndex - 建立游戏档案用户名不区分大小写唯一索引")
+
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(&entity.GameProfile{}); err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "解析游戏档案表结构失败", true, err)
+	}
+
+	sql := fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (lower(name))", stmt.Quote(gameProfileNameLowerIndex), stmt.Quote(stmt.Schema.Table))
+	if err := r.db.WithContext(ctx).Exec(sql).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return xError.NewError(ctx, xError.DataConflict, "存在仅大小写不同的重复用户名，无法建立唯一索引", true, err)
+		}
+		return xError.NewError(ctx, xError.DatabaseError, "建立游戏档案用户名唯一索引失败", true, err)
+	}
+	return nil
+}
+
+// isNameLowerUniqueViolation 判断错误是否由用户名不区分大小写唯一索引冲突引起（并发创建或改名为同一名称）。
+func isNameLowerUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == gameProfileNameLowerIndex
+}
+
 func (r *GameProfileRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
 	if tx != nil {
 		return tx.WithContext(ctx)
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// GameProfileAuditLogRepo 游戏档案审计记录仓储，负责管理员操作审计记录的数据访问。
+type GameProfileAuditLogRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewGameProfileAuditLogRepo 初始化并返回 GameProfileAuditLogRepo 实例。
+func NewGameProfileAuditLogRepo(db *gorm.DB) *GameProfileAuditLogRepo {
+	return &GameProfileAuditLogRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "GameProfileAuditLogRepo"),
+	}
+}
+
+// Create 创建审计记录。
+func (r *GameProfileAuditLogRepo) Create(ctx context.Context, tx *gorm.DB, auditLog *entity.GameProfileAuditLog) (*entity.GameProfileAuditLog, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建游戏档案审计记录")
+
+	if err := r.pickDB(ctx, tx).Create(auditLog).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建游戏档案审计记录失败", true, err)
+	}
+	return auditLog, nil
+}
+
+// List 分页查询审计记录（按创建时间倒序），profileID / userID 为空时不过滤。
+func (r *GameProfileAuditLogRepo) List(ctx context.Context, tx *gorm.DB, profileID *xSnowflake.SnowflakeID, userID *xSnowflake.SnowflakeID, page int, pageSize int) ([]entity.GameProfileAuditLog, int64, *xError.Error) {
+	r.log.Info(ctx, "List - 分页查询游戏档案审计记录")
+
+	query := r.pickDB(ctx, tx).Model(&entity.GameProfileAuditLog{})
+	if profileID != nil {
+		query = query.Where("profile_id = ?", *profileID)
+	}
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案审计记录总数失败", true, err)
+	}
+
+	var auditLogs []entity.GameProfileAuditLog
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&auditLogs).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案审计记录列表失败", true, err)
+	}
+	return auditLogs, total, nil
+}
+
+func (r *GameProfileAuditLogRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
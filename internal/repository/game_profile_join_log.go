@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// DailyActiveRow 日活聚合行，按自然日统计去重后的活跃档案数与用户数。
+type DailyActiveRow struct {
+	Day            time.Time `gorm:"column:day"`             // 统计日期（当日 00:00）
+	ActiveProfiles int64     `gorm:"column:active_profiles"` // 活跃游戏档案数
+	ActiveUsers    int64     `gorm:"column:active_users"`    // 活跃用户数
+	JoinCount      int64     `gorm:"column:join_count"`      // 进服次数
+}
+
+// GameProfileJoinLogRepo 游戏档案进服记录仓储，负责进服历史数据访问。
+type GameProfileJoinLogRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewGameProfileJoinLogRepo 初始化并返回 GameProfileJoinLogRepo 实例。
+func NewGameProfileJoinLogRepo(db *gorm.DB) *GameProfileJoinLogRepo {
+	return &GameProfileJoinLogRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "GameProfileJoinLogRepo"),
+	}
+}
+
+// Create 创建一条进服记录。
+func (r *GameProfileJoinLogRepo) Create(ctx context.Context, tx *gorm.DB, joinLog *entity.GameProfileJoinLog) (*entity.GameProfileJoinLog, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建进服记录")
+
+	if err := r.pickDB(ctx, tx).Create(joinLog).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建进服记录失败", true, err)
+	}
+	return joinLog, nil
+}
+
+// ListByProfileID 分页查询指定游戏档案的进服记录（按进服时间倒序）。
+func (r *GameProfileJoinLogRepo) ListByProfileID(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID, page int, pageSize int) ([]entity.GameProfileJoinLog, int64, *xError.Error) {
+	r.log.Info(ctx, "ListByProfileID - 查询游戏档案进服记录")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.GameProfileJoinLog{}).Where("game_profile_id = ?", profileID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询进服记录总数失败", true, err)
+	}
+
+	var logs []entity.GameProfileJoinLog
+	offset := (page - 1) * pageSize
+	if err := query.Order("joined_at DESC").Offset(offset).Limit(pageSize).Find(&logs).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询进服记录列表失败", true, err)
+	}
+	return logs, total, nil
+}
+
+// ListSince 分页查询指定时间之后的全部进服记录（按进服时间倒序）。
+func (r *GameProfileJoinLogRepo) ListSince(ctx context.Context, tx *gorm.DB, since time.Time, page int, pageSize int) ([]entity.GameProfileJoinLog, int64, *xError.Error) {
+	r.log.Info(ctx, "ListSince - 查询时间窗口内的进服记录")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.GameProfileJoinLog{}).Where("joined_at >= ?", since)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询进服记录总数失败", true, err)
+	}
+
+	var logs []entity.GameProfileJoinLog
+	offset := (page - 1) * pageSize
+	if err := query.Order("joined_at DESC").Offset(offset).Limit(pageSize).Find(&logs).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询进服记录列表失败", true, err)
+	}
+	return logs, total, nil
+}
+
+// CountDistinctProfilesSince 统计指定时间之后进服过的去重游戏档案数。
+func (r *GameProfileJoinLogRepo) CountDistinctProfilesSince(ctx context.Context, tx *gorm.DB, since time.Time) (int64, *xError.Error) {
+	r.log.Info(ctx, "CountDistinctProfilesSince - 统计时间窗口内的活跃档案数")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfileJoinLog{}).
+		Where("joined_at >= ?", since).
+		Distinct("game_profile_id").
+		Count(&count).Error; err != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "统计活跃档案数失败", true, err)
+	}
+	return count, nil
+}
+
+// AggregateDailyActive 按自然日聚合指定时间之后的活跃档案数、活跃用户数与进服次数（按日期倒序）。
+func (r *GameProfileJoinLogRepo) AggregateDailyActive(ctx context.Context, tx *gorm.DB, since time.Time) ([]DailyActiveRow, *xError.Error) {
+	r.log.Info(ctx, "AggregateDailyActive - 聚合每日活跃玩家")
+
+	var rows []DailyActiveRow
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfileJoinLog{}).
+		Select("date_trunc('day', joined_at) AS day, COUNT(DISTINCT game_profile_id) AS active_profiles, COUNT(DISTINCT user_id) AS active_users, COUNT(*) AS join_count").
+		Where("joined_at >= ?", since).
+		Group("day").
+		Order("day DESC").
+		Scan(&rows).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "聚合每日活跃玩家失败", true, err)
+	}
+	return rows, nil
+}
+
+func (r *GameProfileJoinLogRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
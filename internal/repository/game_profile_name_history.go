@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// GameProfileNameHistoryRepo 游戏档案名称历史仓储，负责名称历史记录的数据访问。
+type GameProfileNameHistoryRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewGameProfileNameHistoryRepo 初始化并返回 GameProfileNameHistoryRepo 实例。
+func NewGameProfileNameHistoryRepo(db *gorm.DB) *GameProfileNameHistoryRepo {
+	return &GameProfileNameHistoryRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "GameProfileNameHistoryRepo"),
+	}
+}
+
+// Create 创建名称历史记录。
+func (r *GameProfileNameHistoryRepo) Create(ctx context.Context, tx *gorm.DB, history *entity.GameProfileNameHistory) (*entity.GameProfileNameHistory, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建游戏档案名称历史")
+
+	if err := r.pickDB(ctx, tx).Create(history).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建游戏档案名称历史失败", true, err)
+	}
+	return history, nil
+}
+
+// ExistsByProfileID 检查档案是否已有名称历史记录。
+func (r *GameProfileNameHistoryRepo) ExistsByProfileID(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsByProfileID - 检查游戏档案是否存在名称历史")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfileNameHistory{}).
+		Where("profile_id = ?", profileID).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案名称历史失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// ListByProfileID 查询档案的全部名称历史（按生效时间升序）。
+func (r *GameProfileNameHistoryRepo) ListByProfileID(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID) ([]entity.GameProfileNameHistory, *xError.Error) {
+	r.log.Info(ctx, "ListByProfileID - 查询游戏档案名称历史")
+
+	var histories []entity.GameProfileNameHistory
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfileNameHistory{}).
+		Where("profile_id = ?", profileID).
+		Order("changed_at ASC, id ASC").
+		Find(&histories).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案名称历史失败", true, err)
+	}
+	return histories, nil
+}
+
+func (r *GameProfileNameHistoryRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// GameProfileNameReservationRepo 游戏档案名称保留仓储，负责名称保留记录的数据访问。
+type GameProfileNameReservationRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewGameProfileNameReservationRepo 初始化并返回 GameProfileNameReservationRepo 实例。
+func NewGameProfileNameReservationRepo(db *gorm.DB) *GameProfileNameReservationRepo {
+	return &GameProfileNameReservationRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "GameProfileNameReservationRepo"),
+	}
+}
+
+// Create 创建名称保留记录。
+func (r *GameProfileNameReservationRepo) Create(ctx context.Context, tx *gorm.DB, reservation *entity.GameProfileNameReservation) (*entity.GameProfileNameReservation, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建游戏档案名称保留")
+
+	if err := r.pickDB(ctx, tx).Create(reservation).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建游戏档案名称保留失败", true, err)
+	}
+	return reservation, nil
+}
+
+// ExistsActiveForOthers 检查名称在 now 时刻是否仍保留给除 userID 以外的用户（不区分大小写）。
+func (r *GameProfileNameReservationRepo) ExistsActiveForOthers(ctx context.Context, tx *gorm.DB, name string, userID xSnowflake.SnowflakeID, now time.Time) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsActiveForOthers - 检查名称是否被他人保留")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfileNameReservation{}).
+		Where("lower(name) = lower(?) AND user_id <> ? AND expires_at > ?", name, userID, now).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案名称保留失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// ReleaseActiveByName 将名称（不区分大小写）在 now 时刻仍有效的保留记录立即置为到期，返回被释放的记录数。
+func (r *GameProfileNameReservationRepo) ReleaseActiveByName(ctx context.Context, tx *gorm.DB, name string, now time.Time) (int64, *xError.Error) {
+	r.log.Info(ctx, "ReleaseActiveByName - 释放名称保留")
+
+	result := r.pickDB(ctx, tx).Model(&entity.GameProfileNameReservation{}).
+		Where("lower(name) = lower(?) AND expires_at > ?", name, now).
+		Update("expires_at", now)
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "释放游戏档案名称保留失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *GameProfileNameReservationRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// GameProfileNameRuleRepo 游戏档案名称规则仓储，负责保留名称与屏蔽规则的数据访问。
+type GameProfileNameRuleRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewGameProfileNameRuleRepo 初始化并返回 GameProfileNameRuleRepo 实例。
+func NewGameProfileNameRuleRepo(db *gorm.DB) *GameProfileNameRuleRepo {
+	return &GameProfileNameRuleRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "GameProfileNameRuleRepo"),
+	}
+}
+
+// Create 创建名称规则。
+func (r *GameProfileNameRuleRepo) Create(ctx context.Context, tx *gorm.DB, rule *entity.GameProfileNameRule) (*entity.GameProfileNameRule, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建游戏档案名称规则")
+
+	if err := r.pickDB(ctx, tx).Create(rule).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建游戏档案名称规则失败", true, err)
+	}
+	return rule, nil
+}
+
+// GetByID 根据 ID 查询名称规则。
+func (r *GameProfileNameRuleRepo) GetByID(ctx context.Context, tx *gorm.DB, id xSnowflake.SnowflakeID) (*entity.GameProfileNameRule, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByID - 根据 ID 查询游戏档案名称规则")
+
+	var rule entity.GameProfileNameRule
+	err := r.pickDB(ctx, tx).Model(&entity.GameProfileNameRule{}).Where("id = ?", id).First(&rule).Error
+	if err == nil {
+		return &rule, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案名称规则失败", true, err)
+}
+
+// List 分页查询名称规则，kind 为 nil 时不按类别过滤。
+func (r *GameProfileNameRuleRepo) List(ctx context.Context, tx *gorm.DB, kind *entity.GameProfileNameRuleKind, page int, pageSize int) ([]entity.GameProfileNameRule, int64, *xError.Error) {
+	r.log.Info(ctx, "List - 分页查询游戏档案名称规则")
+
+	query := r.pickDB(ctx, tx).Model(&entity.GameProfileNameRule{})
+	if kind != nil {
+		query = query.Where("kind = ?", *kind)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案名称规则总数失败", true, err)
+	}
+
+	var rules []entity.GameProfileNameRule
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&rules).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案名称规则列表失败", true, err)
+	}
+	return rules, total, nil
+}
+
+// ListEnabled 查询全部已启用的名称规则。
+func (r *GameProfileNameRuleRepo) ListEnabled(ctx context.Context, tx *gorm.DB) ([]entity.GameProfileNameRule, *xError.Error) {
+	r.log.Info(ctx, "ListEnabled - 查询已启用的游戏档案名称规则")
+
+	var rules []entity.GameProfileNameRule
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfileNameRule{}).
+		Where("is_enabled = ?", true).
+		Order("id ASC").
+		Find(&rules).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询已启用的游戏档案名称规则失败", true, err)
+	}
+	return rules, nil
+}
+
+// Update 更新名称规则。
+func (r *GameProfileNameRuleRepo) Update(ctx context.Context, tx *gorm.DB, rule *entity.GameProfileNameRule) (*entity.GameProfileNameRule, *xError.Error) {
+	r.log.Info(ctx, "Update - 更新游戏档案名称规则")
+
+	if err := r.pickDB(ctx, tx).Save(rule).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "更新游戏档案名称规则失败", true, err)
+	}
+	return rule, nil
+}
+
+// DeleteByID 删除名称规则。
+func (r *GameProfileNameRuleRepo) DeleteByID(ctx context.Context, tx *gorm.DB, id xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "DeleteByID - 删除游戏档案名称规则")
+
+	if err := r.pickDB(ctx, tx).Delete(&entity.GameProfileNameRule{}, id).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "删除游戏档案名称规则失败", true, err)
+	}
+	return nil
+}
+
+func (r *GameProfileNameRuleRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
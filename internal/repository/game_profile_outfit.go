@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// GameProfileOutfitRepo 游戏档案外观预设仓储，负责外观预设数据访问。
+type GameProfileOutfitRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewGameProfileOutfitRepo 初始化并返回 GameProfileOutfitRepo 实例。
+func NewGameProfileOutfitRepo(db *gorm.DB) *GameProfileOutfitRepo {
+	return &GameProfileOutfitRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "GameProfileOutfitRepo"),
+	}
+}
+
+// Create 创建外观预设记录。
+func (r *GameProfileOutfitRepo) Create(ctx context.Context, tx *gorm.DB, outfit *entity.GameProfileOutfit) (*entity.GameProfileOutfit, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建外观预设")
+
+	if err := r.pickDB(ctx, tx).Create(outfit).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建外观预设失败", true, err)
+	}
+	return outfit, nil
+}
+
+// GetByIDAndUserID 根据预设 ID 与用户 ID 查询外观预设（含关联皮肤和披风）。
+func (r *GameProfileOutfitRepo) GetByIDAndUserID(ctx context.Context, tx *gorm.DB, outfitID xSnowflake.SnowflakeID, userID xSnowflake.SnowflakeID) (*entity.GameProfileOutfit, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByIDAndUserID - 查询外观预设")
+
+	var outfit entity.GameProfileOutfit
+	err := r.pickDB(ctx, tx).
+		Preload("SkinLibrary").
+		Preload("CapeLibrary").
+		Where("id = ? AND user_id = ?", outfitID, userID).
+		First(&outfit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询外观预设失败", true, err)
+	}
+	return &outfit, true, nil
+}
+
+// ListByUserID 查询指定用户的全部外观预设（含关联皮肤和披风，按创建时间正序）。
+func (r *GameProfileOutfitRepo) ListByUserID(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID) ([]entity.GameProfileOutfit, *xError.Error) {
+	r.log.Info(ctx, "ListByUserID - 查询用户外观预设列表")
+
+	var outfits []entity.GameProfileOutfit
+	if err := r.pickDB(ctx, tx).
+		Preload("SkinLibrary").
+		Preload("CapeLibrary").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&outfits).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询外观预设列表失败", true, err)
+	}
+	return outfits, nil
+}
+
+// CountByUserID 统计指定用户的外观预设数量。
+func (r *GameProfileOutfitRepo) CountByUserID(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "CountByUserID - 统计用户外观预设数量")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfileOutfit{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "统计外观预设数量失败", true, err)
+	}
+	return count, nil
+}
+
+// ExistsByUserAndNameExceptID 检查用户是否已有同名外观预设（排除指定预设 ID）。
+func (r *GameProfileOutfitRepo) ExistsByUserAndNameExceptID(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, name string, outfitID xSnowflake.SnowflakeID) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsByUserAndNameExceptID - 检查外观预设名称是否重复")
+
+	var count int64
+	query := r.pickDB(ctx, tx).Model(&entity.GameProfileOutfit{}).Where("user_id = ? AND name = ?", userID, name)
+	if !outfitID.IsZero() {
+		query = query.Where("id <> ?", outfitID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询外观预设名称失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// Update 更新外观预设的名称与皮肤、披风组合。
+func (r *GameProfileOutfitRepo) Update(ctx context.Context, tx *gorm.DB, outfit *entity.GameProfileOutfit) *xError.Error {
+	r.log.Info(ctx, "Update - 更新外观预设")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfileOutfit{}).
+		Where("id = ?", outfit.ID).
+		Updates(map[string]interface{}{
+			"name":            outfit.Name,
+			"skin_library_id": outfit.SkinLibraryID,
+			"cape_library_id": outfit.CapeLibraryID,
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新外观预设失败", true, err)
+	}
+	return nil
+}
+
+// DeleteByIDAndUserID 删除指定用户的外观预设，返回是否删除了记录。
+func (r *GameProfileOutfitRepo) DeleteByIDAndUserID(ctx context.Context, tx *gorm.DB, outfitID xSnowflake.SnowflakeID, userID xSnowflake.SnowflakeID) (bool, *xError.Error) {
+	r.log.Info(ctx, "DeleteByIDAndUserID - 删除外观预设")
+
+	result := r.pickDB(ctx, tx).Where("id = ? AND user_id = ?", outfitID, userID).Delete(&entity.GameProfileOutfit{})
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "删除外观预设失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *GameProfileOutfitRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -29,7 +29,7 @@ func NewGameProfileQuotaLogRepo(db *gorm.DB) *GameProfileQuotaLogRepo {
 	}
 }
 
-// Create 创建一条游戏档案配额日志。
+// Create 创建一条游戏档案配额日志，幂等键由操作类型、用户、关联档案与当前时间生成。
 func (r *GameProfileQuotaLogRepo) Create(
 	ctx context.Context,
 	tx *gorm.DB,
@@ -41,7 +41,32 @@ func (r *GameProfileQuotaLogRepo) Create(
 	refProfileID *xSnowflake.SnowflakeID,
 	remark *string,
 ) (*entity.GameProfileQuotaLog, *xError.Error) {
-	r.log.Info(ctx, "Create - 创建游戏档案配额日志")
+	refProfileStr := "0"
+	if refProfileID != nil {
+		refProfileStr = refProfileID.String()
+	}
+
+	idempotencyKey := fmt.Sprintf("%s:%s:%s:%d", opType.String(), userID.String(), refProfileStr, time.Now().UnixNano())
+	return r.CreateWithIdempotencyKey(ctx, tx, userID, opType, delta, beforeUsed, beforeTotal, refProfileID, remark, idempotencyKey)
+}
+
+// CreateWithIdempotencyKey 使用调用方给定的幂等键创建一条游戏档案配额日志。
+//
+// 幂等键受唯一索引约束，重复写入同一业务操作时将返回数据库错误并使所在事务回滚，
+// 用于保证删除档案退还额度等一次性操作不会被重复记账。
+func (r *GameProfileQuotaLogRepo) CreateWithIdempotencyKey(
+	ctx context.Context,
+	tx *gorm.DB,
+	userID xSnowflake.SnowflakeID,
+	opType entityType.ObType,
+	delta int32,
+	beforeUsed int32,
+	beforeTotal int32,
+	refProfileID *xSnowflake.SnowflakeID,
+	remark *string,
+	idempotencyKey string,
+) (*entity.GameProfileQuotaLog, *xError.Error) {
+	r.log.Info(ctx, "CreateWithIdempotencyKey - 创建游戏档案配额日志")
 
 	if !opType.IsValid() {
 		return nil, xError.NewError(ctx, xError.ParameterError, "无效操作类型", false)
@@ -60,12 +85,6 @@ func (r *GameProfileQuotaLogRepo) Create(
 		return nil, xError.NewError(ctx, xError.ParameterError, "无效变化量：变更后已使用额度不能小于 0", false)
 	}
 
-	refProfileStr := "0"
-	if refProfileID != nil {
-		refProfileStr = refProfileID.String()
-	}
-
-	idempotencyKey := fmt.Sprintf("%s:%s:%s:%d", opType.String(), userID.String(), refProfileStr, time.Now().UnixNano())
 	quotaLog := &entity.GameProfileQuotaLog{
 		UserID:         userID,
 		OpType:         opType,
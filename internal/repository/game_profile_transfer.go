@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GameProfileTransferRepo 游戏档案转移请求仓储，负责转移请求的数据访问。
+type GameProfileTransferRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewGameProfileTransferRepo 初始化并返回 GameProfileTransferRepo 实例。
+func NewGameProfileTransferRepo(db *gorm.DB) *GameProfileTransferRepo {
+	return &GameProfileTransferRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "GameProfileTransferRepo"),
+	}
+}
+
+// Create 创建转移请求。
+func (r *GameProfileTransferRepo) Create(ctx context.Context, tx *gorm.DB, transfer *entity.GameProfileTransfer) (*entity.GameProfileTransfer, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建游戏档案转移请求")
+
+	if err := r.pickDB(ctx, tx).Create(transfer).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建游戏档案转移请求失败", true, err)
+	}
+	return transfer, nil
+}
+
+// GetByID 根据请求 ID 查询转移请求，forUpdate 为 true 时加行锁。
+func (r *GameProfileTransferRepo) GetByID(ctx context.Context, tx *gorm.DB, transferID xSnowflake.SnowflakeID, forUpdate bool) (*entity.GameProfileTransfer, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByID - 查询游戏档案转移请求")
+
+	query := r.pickDB(ctx, tx).Model(&entity.GameProfileTransfer{}).Where("id = ?", transferID)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var transfer entity.GameProfileTransfer
+	err := query.First(&transfer).Error
+	if err == nil {
+		return &transfer, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案转移请求失败", true, err)
+}
+
+// ExistsPendingByProfileID 检查档案在 now 时刻是否存在待接收且未到期的转移请求。
+func (r *GameProfileTransferRepo) ExistsPendingByProfileID(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID, now time.Time) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsPendingByProfileID - 检查档案是否存在待接收的转移请求")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfileTransfer{}).
+		Where("profile_id = ? AND status = ? AND expires_at > ?", profileID, entity.GameProfileTransferStatusPending, now).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案转移请求失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// ListByUserID 分页查询用户发起或接收的转移请求（按创建时间倒序）。
+func (r *GameProfileTransferRepo) ListByUserID(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, page int, pageSize int) ([]entity.GameProfileTransfer, int64, *xError.Error) {
+	r.log.Info(ctx, "ListByUserID - 查询用户的游戏档案转移请求")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.GameProfileTransfer{}).Where("from_user_id = ? OR to_user_id = ?", userID, userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案转移请求总数失败", true, err)
+	}
+
+	var transfers []entity.GameProfileTransfer
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&transfers).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询游戏档案转移请求列表失败", true, err)
+	}
+	return transfers, total, nil
+}
+
+// UpdateStatus 更新转移请求状态并记录处理时间。
+func (r *GameProfileTransferRepo) UpdateStatus(ctx context.Context, tx *gorm.DB, transferID xSnowflake.SnowflakeID, status entity.GameProfileTransferStatus, respondedAt time.Time) *xError.Error {
+	r.log.Info(ctx, "UpdateStatus - 更新游戏档案转移请求状态")
+
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfileTransfer{}).
+		Where("id = ?", transferID).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"responded_at": respondedAt,
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新游戏档案转移请求状态失败", true, err)
+	}
+	return nil
+}
+
+func (r *GameProfileTransferRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
@@ -75,12 +77,12 @@ func (r *GameProfileYggRepo) GetByUUIDUnsignedWithTextures(ctx context.Context,
 	return nil, false, xError.NewError(ctx, xError.DatabaseError, "根据 UUID 查询游戏档案详情失败", true, err)
 }
 
-// GetByName 根据用户名查询游戏档案。
+// GetByName 根据用户名查询游戏档案（不区分大小写，返回值保留存储的显示大小写）。
 func (r *GameProfileYggRepo) GetByName(ctx context.Context, tx *gorm.DB, name string) (*entity.GameProfile, bool, *xError.Error) {
 	r.log.Info(ctx, "GetByName - 根据用户名获取游戏档案")
 
 	var profile entity.GameProfile
-	err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("name = ?", name).First(&profile).Error
+	err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("lower(name) = lower(?)", name).First(&profile).Error
 	if err == nil {
 		return &profile, true, nil
 	}
@@ -90,7 +92,7 @@ func (r *GameProfileYggRepo) GetByName(ctx context.Context, tx *gorm.DB, name st
 	return nil, false, xError.NewError(ctx, xError.DatabaseError, "根据用户名查询游戏档案失败", true, err)
 }
 
-// BatchGetByNames 根据用户名列表批量查询游戏档案。
+// BatchGetByNames 根据用户名列表批量查询游戏档案（不区分大小写，返回值保留存储的显示大小写）。
 func (r *GameProfileYggRepo) BatchGetByNames(ctx context.Context, tx *gorm.DB, names []string) ([]entity.GameProfile, *xError.Error) {
 	r.log.Info(ctx, "BatchGetByNames - 根据用户名列表批量获取游戏档案")
 
@@ -98,8 +100,13 @@ func (r *GameProfileYggRepo) BatchGetByNames(ctx context.Context, tx *gorm.DB, n
 		return []entity.GameProfile{}, nil
 	}
 
+	lowerNames := make([]string, 0, len(names))
+	for _, name := range names {
+		lowerNames = append(lowerNames, strings.ToLower(name))
+	}
+
 	var profiles []entity.GameProfile
-	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("name IN ?", names).Find(&profiles).Error; err != nil {
+	if err := r.pickDB(ctx, tx).Model(&entity.GameProfile{}).Where("lower(name) IN ?", lowerNames).Find(&profiles).Error; err != nil {
 		return nil, xError.NewError(ctx, xError.DatabaseError, "批量查询游戏档案失败", true, err)
 	}
 	return profiles, nil
@@ -242,6 +249,19 @@ func (r *GameProfileYggRepo) GetByIDWithTextures(ctx context.Context, tx *gorm.D
 	return nil, false, xError.NewError(ctx, xError.DatabaseError, "根据 ID 查询游戏档案失败", true, err)
 }
 
+// UpdateLastSeenAt 更新游戏档案的最近进服时间。
+func (r *GameProfileYggRepo) UpdateLastSeenAt(ctx context.Context, tx *gorm.DB, id xSnowflake.SnowflakeID, seenAt time.Time) *xError.Error {
+	r.log.Info(ctx, "UpdateLastSeenAt - 更新游戏档案最近进服时间")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.GameProfile{}).
+		Where("id = ?", id).
+		UpdateColumn("last_seen_at", seenAt).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新游戏档案最近进服时间失败", true, err)
+	}
+	return nil
+}
+
 func (r *GameProfileYggRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
 	if tx != nil {
 		return tx.WithContext(ctx)
@@ -151,6 +151,25 @@ func (r *GameTokenRepo) InvalidateByAccessToken(ctx context.Context, tx *gorm.DB
 	return result.RowsAffected, nil
 }
 
+// InvalidateByBoundProfileID 将绑定到指定游戏档案的所有有效或暂时失效令牌设为无效状态。
+//
+// 用于删除或转移游戏档案前吊销相关令牌，需在 BoundProfileID 被外键置空之前调用。
+//
+// 返回值:
+//   - int64: 被吊销的令牌数量
+//   - *xError.Error: 数据库操作异常
+func (r *GameTokenRepo) InvalidateByBoundProfileID(ctx context.Context, tx *gorm.DB, profileID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "InvalidateByBoundProfileID - 吊销绑定指定游戏档案的令牌")
+
+	result := r.pickDB(ctx, tx).Model(&entity.GameToken{}).
+		Where("bound_profile_id = ? AND status IN (?, ?)", profileID, entity.GameTokenStatusValid, entity.GameTokenStatusTempInvalid).
+		Update("status", entity.GameTokenStatusInvalid)
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "吊销游戏档案绑定令牌失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 // CountValidByUserID 统计指定用户的有效且未过期的游戏令牌数量。
 //
 // 用于配额管理（CreateWithQuotaCheck）和 Signout 二次扫描兜底等场景。
@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LibraryCampaignRepo 资源发放活动仓储，负责活动及其用户处理记录的数据访问。
+type LibraryCampaignRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewLibraryCampaignRepo 初始化并返回 LibraryCampaignRepo 实例。
+func NewLibraryCampaignRepo(db *gorm.DB) *LibraryCampaignRepo {
+	return &LibraryCampaignRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "LibraryCampaignRepo"),
+	}
+}
+
+// Create 创建发放活动。
+func (r *LibraryCampaignRepo) Create(ctx context.Context, tx *gorm.DB, campaign *entity.LibraryCampaign) (*entity.LibraryCampaign, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建发放活动")
+
+	if err := r.pickDB(ctx, tx).Create(campaign).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建发放活动失败", true, err)
+	}
+	return campaign, nil
+}
+
+// GetByID 根据活动 ID 查询发放活动。
+func (r *LibraryCampaignRepo) GetByID(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID) (*entity.LibraryCampaign, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByID - 查询发放活动")
+
+	var campaign entity.LibraryCampaign
+	err := r.pickDB(ctx, tx).Model(&entity.LibraryCampaign{}).Where("id = ?", campaignID).First(&campaign).Error
+	if err == nil {
+		return &campaign, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询发放活动失败", true, err)
+}
+
+// List 分页查询发放活动（按创建时间倒序）。
+func (r *LibraryCampaignRepo) List(ctx context.Context, tx *gorm.DB, page int, pageSize int) ([]entity.LibraryCampaign, int64, *xError.Error) {
+	r.log.Info(ctx, "List - 分页查询发放活动")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.LibraryCampaign{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询发放活动总数失败", true, err)
+	}
+
+	var campaigns []entity.LibraryCampaign
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&campaigns).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询发放活动列表失败", true, err)
+	}
+	return campaigns, total, nil
+}
+
+// TryStart 原子地将活动切换为执行中。
+//
+// 仅待执行、已中断，或执行中但心跳（updated_at）早于 staleBefore 的活动可以启动，
+// 返回 false 表示活动当前不可启动（例如已有后台任务在执行）。
+func (r *LibraryCampaignRepo) TryStart(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID, staleBefore time.Time) (bool, *xError.Error) {
+	r.log.Info(ctx, "TryStart - 启动发放活动")
+
+	result := r.pickDB(ctx, tx).
+		Model(&entity.LibraryCampaign{}).
+		Where("id = ?", campaignID).
+		Where("status IN ? OR (status = ? AND updated_at < ?)",
+			[]entityType.CampaignStatus{entityType.CampaignStatusPending, entityType.CampaignStatusFailed},
+			entityType.CampaignStatusRunning, staleBefore).
+		UpdateColumns(map[string]interface{}{
+			"status":     entityType.CampaignStatusRunning,
+			"started_at": gorm.Expr("COALESCE(started_at, ?)", time.Now()),
+			"last_error": nil,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "启动发放活动失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// TryStartRevoke 原子地将活动切换为撤销中。
+//
+// 仅已完成、已中断，或撤销中但心跳早于 staleBefore / 上次撤销中断的活动可以撤销。
+func (r *LibraryCampaignRepo) TryStartRevoke(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID, revokerID xSnowflake.SnowflakeID, staleBefore time.Time) (bool, *xError.Error) {
+	r.log.Info(ctx, "TryStartRevoke - 开始撤销发放活动")
+
+	result := r.pickDB(ctx, tx).
+		Model(&entity.LibraryCampaign{}).
+		Where("id = ?", campaignID).
+		Where("status IN ? OR (status = ? AND (updated_at < ? OR last_error IS NOT NULL))",
+			[]entityType.CampaignStatus{entityType.CampaignStatusCompleted, entityType.CampaignStatusFailed},
+			entityType.CampaignStatusRevoking, staleBefore).
+		UpdateColumns(map[string]interface{}{
+			"status":     entityType.CampaignStatusRevoking,
+			"revoked_by": revokerID,
+			"last_error": nil,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "撤销发放活动失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// SetMatchedCount 记录开始执行时匹配的用户数。
+func (r *LibraryCampaignRepo) SetMatchedCount(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID, matched int64) *xError.Error {
+	r.log.Info(ctx, "SetMatchedCount - 记录活动匹配用户数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryCampaign{}).
+		Where("id = ?", campaignID).
+		UpdateColumns(map[string]interface{}{
+			"matched_count": matched,
+			"updated_at":    time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "记录活动匹配用户数失败", true, err)
+	}
+	return nil
+}
+
+// AdvanceProgress 推进活动游标并累加本批次的处理计数，同时刷新心跳。
+func (r *LibraryCampaignRepo) AdvanceProgress(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID, cursor xSnowflake.SnowflakeID, granted int64, skipped int64, failed int64) *xError.Error {
+	r.log.Info(ctx, "AdvanceProgress - 推进活动进度")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryCampaign{}).
+		Where("id = ?", campaignID).
+		UpdateColumns(map[string]interface{}{
+			"cursor":        cursor,
+			"granted_count": gorm.Expr("granted_count + ?", granted),
+			"skipped_count": gorm.Expr("skipped_count + ?", skipped),
+			"failed_count":  gorm.Expr("failed_count + ?", failed),
+			"updated_at":    time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "推进活动进度失败", true, err)
+	}
+	return nil
+}
+
+// AddRevoked 累加活动已收回数，同时刷新心跳。
+func (r *LibraryCampaignRepo) AddRevoked(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID, revoked int64) *xError.Error {
+	r.log.Info(ctx, "AddRevoked - 累加活动收回数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryCampaign{}).
+		Where("id = ?", campaignID).
+		UpdateColumns(map[string]interface{}{
+			"revoked_count": gorm.Expr("revoked_count + ?", revoked),
+			"updated_at":    time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "累加活动收回数失败", true, err)
+	}
+	return nil
+}
+
+// Finish 结束后台任务：写入最终状态与中断原因，完成或撤销时记录对应时间。
+func (r *LibraryCampaignRepo) Finish(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID, status entityType.CampaignStatus, lastError *string) *xError.Error {
+	r.log.Info(ctx, "Finish - 结束发放活动后台任务")
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     status,
+		"last_error": lastError,
+		"updated_at": now,
+	}
+	switch status {
+	case entityType.CampaignStatusCompleted:
+		updates["finished_at"] = now
+	case entityType.CampaignStatusRevoked:
+		updates["revoked_at"] = now
+	}
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryCampaign{}).
+		Where("id = ?", campaignID).
+		UpdateColumns(updates).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新发放活动状态失败", true, err)
+	}
+	return nil
+}
+
+// CreateGrantIfAbsent 创建用户处理记录，(campaign_id, user_id) 已存在时不做任何修改。
+//
+// 返回 false 表示该用户已被本活动处理过。
+func (r *LibraryCampaignRepo) CreateGrantIfAbsent(ctx context.Context, tx *gorm.DB, grant *entity.LibraryCampaignGrant) (bool, *xError.Error) {
+	r.log.Info(ctx, "CreateGrantIfAbsent - 创建活动用户处理记录")
+
+	result := r.pickDB(ctx, tx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "campaign_id"}, {Name: "user_id"}},
+			DoNothing: true,
+		}).
+		Create(grant)
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "创建活动用户处理记录失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateGrantStatus 更新用户处理记录的状态与说明。
+func (r *LibraryCampaignRepo) UpdateGrantStatus(ctx context.Context, tx *gorm.DB, grantID xSnowflake.SnowflakeID, status entityType.CampaignGrantStatus, message *string) *xError.Error {
+	r.log.Info(ctx, "UpdateGrantStatus - 更新活动用户处理记录")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryCampaignGrant{}).
+		Where("id = ?", grantID).
+		UpdateColumns(map[string]interface{}{
+			"status":     status,
+			"message":    message,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新活动用户处理记录失败", true, err)
+	}
+	return nil
+}
+
+// ListGrants 分页查询活动的用户处理记录，status 为空时不限状态。
+func (r *LibraryCampaignRepo) ListGrants(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID, status *entityType.CampaignGrantStatus, page int, pageSize int) ([]entity.LibraryCampaignGrant, int64, *xError.Error) {
+	r.log.Info(ctx, "ListGrants - 分页查询活动用户处理记录")
+
+	query := r.pickDB(ctx, tx).Model(&entity.LibraryCampaignGrant{}).Where("campaign_id = ?", campaignID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询活动用户处理记录总数失败", true, err)
+	}
+
+	var grants []entity.LibraryCampaignGrant
+	offset := (page - 1) * pageSize
+	if err := query.Order("user_id ASC").Offset(offset).Limit(pageSize).Find(&grants).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询活动用户处理记录列表失败", true, err)
+	}
+	return grants, total, nil
+}
+
+// ListGrantedBatch 按记录 ID 升序查询一批仍处于已发放状态的用户处理记录（撤销时使用）。
+func (r *LibraryCampaignRepo) ListGrantedBatch(ctx context.Context, tx *gorm.DB, campaignID xSnowflake.SnowflakeID, limit int) ([]entity.LibraryCampaignGrant, *xError.Error) {
+	r.log.Info(ctx, "ListGrantedBatch - 查询待收回的活动用户处理记录")
+
+	var grants []entity.LibraryCampaignGrant
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryCampaignGrant{}).
+		Where("campaign_id = ? AND status = ?", campaignID, entityType.CampaignGrantStatusGranted).
+		Order("id ASC").
+		Limit(limit).
+		Find(&grants).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询待收回的活动用户处理记录失败", true, err)
+	}
+	return grants, nil
+}
+
+func (r *LibraryCampaignRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
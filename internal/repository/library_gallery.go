@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"strings"
+
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+)
+
+// GallerySort 公开画廊排序方式。
+type GallerySort string
+
+const (
+	GallerySortNewest    GallerySort = "newest"    // 最新发布
+	GallerySortEquipped  GallerySort = "equipped"  // 装备最多（按 GameProfile 引用数）
+	GallerySortCollected GallerySort = "collected" // 收藏最多
+	GallerySortLiked     GallerySort = "liked"     // 点赞最多
+)
+
+// IsValid 校验排序方式是否为合法值。
+func (s GallerySort) IsValid() bool {
+	switch s {
+	case GallerySortNewest, GallerySortEquipped, GallerySortCollected, GallerySortLiked:
+		return true
+	default:
+		return false
+	}
+}
+
+// LibraryGalleryFilter 公开画廊查询条件。
+//
+// 所有条件均为可选，零值表示不过滤；Model 仅对皮肤生效。
+type LibraryGalleryFilter struct {
+	Keyword    string                  // 名称关键字（模糊匹配，大小写不敏感）
+	UploaderID *xSnowflake.SnowflakeID // 上传者用户 ID
+	Model      *entity.ModelType       // 皮肤模型
+	Tag        string                  // 标签名称（精确匹配，已规范化）
+	Sort       GallerySort             // 排序方式
+}
+
+// escapeLikePattern 转义 LIKE 模式中的通配符，避免用户输入的 % 与 _ 被当作通配符。
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryLikeRepo 资源库点赞仓储，负责点赞关系数据访问。
+type LibraryLikeRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewLibraryLikeRepo 初始化并返回 LibraryLikeRepo 实例。
+func NewLibraryLikeRepo(db *gorm.DB) *LibraryLikeRepo {
+	return &LibraryLikeRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "LibraryLikeRepo"),
+	}
+}
+
+// Create 创建点赞记录。
+func (r *LibraryLikeRepo) Create(ctx context.Context, tx *gorm.DB, like *entity.LibraryLike) (*entity.LibraryLike, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建点赞记录")
+
+	if err := r.pickDB(ctx, tx).Create(like).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建点赞记录失败", true, err)
+	}
+	return like, nil
+}
+
+// ExistsByUserAndTarget 检查用户是否已点赞指定资源。
+func (r *LibraryLikeRepo) ExistsByUserAndTarget(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsByUserAndTarget - 检查点赞记录是否存在")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryLike{}).
+		Where("user_id = ? AND kind = ? AND library_id = ?", userID, kind, libraryID).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询点赞记录失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// DeleteByUserAndTarget 删除用户对指定资源的点赞记录，返回是否实际删除。
+func (r *LibraryLikeRepo) DeleteByUserAndTarget(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) (bool, *xError.Error) {
+	r.log.Info(ctx, "DeleteByUserAndTarget - 删除点赞记录")
+
+	result := r.pickDB(ctx, tx).
+		Where("user_id = ? AND kind = ? AND library_id = ?", userID, kind, libraryID).
+		Delete(&entity.LibraryLike{})
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "删除点赞记录失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteByTarget 删除指定资源的全部点赞记录。
+func (r *LibraryLikeRepo) DeleteByTarget(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "DeleteByTarget - 删除资源的全部点赞记录")
+
+	if err := r.pickDB(ctx, tx).
+		Where("kind = ? AND library_id = ?", kind, libraryID).
+		Delete(&entity.LibraryLike{}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "删除资源点赞记录失败", true, err)
+	}
+	return nil
+}
+
+func (r *LibraryLikeRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
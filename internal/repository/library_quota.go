@@ -136,6 +136,22 @@ func (r *LibraryQuotaRepo) UpdateAllUsed(ctx context.Context, tx *gorm.DB, quota
 	return nil
 }
 
+// UpdateAllTotal 一次性更新配额的全部 4 个 Total 字段。
+func (r *LibraryQuotaRepo) UpdateAllTotal(ctx context.Context, tx *gorm.DB, quotaID xSnowflake.SnowflakeID, skinsPubTotal, skinsPriTotal, capesPubTotal, capesPriTotal int32) *xError.Error {
+	r.log.Info(ctx, "UpdateAllTotal - 一次性更新配额全部 Total 字段")
+
+	updates := map[string]interface{}{
+		"skins_public_total":  skinsPubTotal,
+		"skins_private_total": skinsPriTotal,
+		"capes_public_total":  capesPubTotal,
+		"capes_private_total": capesPriTotal,
+	}
+	if err := r.pickDB(ctx, tx).Model(&entity.LibraryQuota{}).Where("id = ?", quotaID).Updates(updates).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新配额 Total 字段失败", true, err)
+	}
+	return nil
+}
+
 func (r *LibraryQuotaRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
 	if tx != nil {
 		return tx.WithContext(ctx)
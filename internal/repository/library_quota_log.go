@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryQuotaLogRepo 资源库配额日志仓储，负责配额日志数据访问。
+type LibraryQuotaLogRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewLibraryQuotaLogRepo 初始化并返回 LibraryQuotaLogRepo 实例。
+func NewLibraryQuotaLogRepo(db *gorm.DB) *LibraryQuotaLogRepo {
+	return &LibraryQuotaLogRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "LibraryQuotaLogRepo"),
+	}
+}
+
+// Create 创建一条资源库配额日志。
+func (r *LibraryQuotaLogRepo) Create(ctx context.Context, tx *gorm.DB, quotaLog *entity.LibraryQuotaLog) *xError.Error {
+	r.log.Info(ctx, "Create - 创建资源库配额日志")
+
+	if err := r.pickDB(ctx, tx).Create(quotaLog).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "创建资源库配额日志失败", true, err)
+	}
+	return nil
+}
+
+// ExistsByIdempotencyKey 判断指定幂等键的资源库配额日志是否已存在。
+func (r *LibraryQuotaLogRepo) ExistsByIdempotencyKey(ctx context.Context, tx *gorm.DB, idempotencyKey string) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsByIdempotencyKey - 根据幂等键判断资源库配额日志是否存在")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).Model(&entity.LibraryQuotaLog{}).Where("idempotency_key = ?", idempotencyKey).Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询资源库配额日志失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// ListByUser 按时间倒序分页查询指定用户的资源库配额日志，bucket 为空时返回全部分项。
+func (r *LibraryQuotaLogRepo) ListByUser(
+	ctx context.Context,
+	tx *gorm.DB,
+	userID xSnowflake.SnowflakeID,
+	bucket *entityType.LibraryQuotaBucket,
+	page int,
+	pageSize int,
+) ([]entity.LibraryQuotaLog, int64, *xError.Error) {
+	r.log.Info(ctx, "ListByUser - 分页查询资源库配额日志")
+
+	query := r.pickDB(ctx, tx).Model(&entity.LibraryQuotaLog{}).Where("user_id = ?", userID)
+	if bucket != nil {
+		query = query.Where("bucket = ?", *bucket)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询资源库配额日志总数失败", true, err)
+	}
+
+	var logs []entity.LibraryQuotaLog
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC, id DESC").Offset(offset).Limit(pageSize).Find(&logs).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询资源库配额日志列表失败", true, err)
+	}
+	return logs, total, nil
+}
+
+func (r *LibraryQuotaLogRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
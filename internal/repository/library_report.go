@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryReportRepo 资源库举报仓储，负责举报记录数据访问。
+type LibraryReportRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewLibraryReportRepo 初始化并返回 LibraryReportRepo 实例。
+func NewLibraryReportRepo(db *gorm.DB) *LibraryReportRepo {
+	return &LibraryReportRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "LibraryReportRepo"),
+	}
+}
+
+// Create 创建举报记录。
+func (r *LibraryReportRepo) Create(ctx context.Context, tx *gorm.DB, report *entity.LibraryReport) (*entity.LibraryReport, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建举报记录")
+
+	if err := r.pickDB(ctx, tx).Create(report).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建举报记录失败", true, err)
+	}
+	return report, nil
+}
+
+// ExistsPendingByReporter 检查用户是否已对指定资源提交过待处理的举报。
+func (r *LibraryReportRepo) ExistsPendingByReporter(ctx context.Context, tx *gorm.DB, reporterID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsPendingByReporter - 检查待处理举报是否存在")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryReport{}).
+		Where("reporter_id = ? AND kind = ? AND library_id = ? AND status = ?", reporterID, kind, libraryID, entityType.ReportStatusPending).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询举报记录失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// ListPendingByTarget 查询指定资源的全部待处理举报（按举报时间倒序）。
+func (r *LibraryReportRepo) ListPendingByTarget(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) ([]entity.LibraryReport, *xError.Error) {
+	r.log.Info(ctx, "ListPendingByTarget - 查询资源的待处理举报")
+
+	var reports []entity.LibraryReport
+	if err := r.pickDB(ctx, tx).
+		Where("kind = ? AND library_id = ? AND status = ?", kind, libraryID, entityType.ReportStatusPending).
+		Order("created_at DESC").
+		Find(&reports).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询举报记录失败", true, err)
+	}
+	return reports, nil
+}
+
+// ResolvePendingByTarget 将指定资源的全部待处理举报标记为处理结果，返回处理条数。
+func (r *LibraryReportRepo) ResolvePendingByTarget(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, status entityType.ReportStatus, handlerID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "ResolvePendingByTarget - 处理资源的待处理举报")
+
+	result := r.pickDB(ctx, tx).
+		Model(&entity.LibraryReport{}).
+		Where("kind = ? AND library_id = ? AND status = ?", kind, libraryID, entityType.ReportStatusPending).
+		UpdateColumns(map[string]interface{}{
+			"status":     status,
+			"handled_by": handlerID,
+			"handled_at": time.Now(),
+		})
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "更新举报处理状态失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *LibraryReportRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LibraryShareRepo 资源私有分享仓储，负责分享链接及其领取记录的数据访问。
+type LibraryShareRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewLibraryShareRepo 初始化并返回 LibraryShareRepo 实例。
+func NewLibraryShareRepo(db *gorm.DB) *LibraryShareRepo {
+	return &LibraryShareRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "LibraryShareRepo"),
+	}
+}
+
+// Create 创建分享链接。
+func (r *LibraryShareRepo) Create(ctx context.Context, tx *gorm.DB, share *entity.LibraryShare) (*entity.LibraryShare, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建分享链接")
+
+	if err := r.pickDB(ctx, tx).Create(share).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建分享链接失败", true, err)
+	}
+	return share, nil
+}
+
+// GetByID 根据分享 ID 查询分享链接。
+func (r *LibraryShareRepo) GetByID(ctx context.Context, tx *gorm.DB, shareID xSnowflake.SnowflakeID) (*entity.LibraryShare, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByID - 查询分享链接")
+
+	var share entity.LibraryShare
+	err := r.pickDB(ctx, tx).Model(&entity.LibraryShare{}).Where("id = ?", shareID).First(&share).Error
+	if err == nil {
+		return &share, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询分享链接失败", true, err)
+}
+
+// GetByToken 根据分享令牌查询分享链接，forUpdate 为 true 时加行锁。
+func (r *LibraryShareRepo) GetByToken(ctx context.Context, tx *gorm.DB, token string, forUpdate bool) (*entity.LibraryShare, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByToken - 根据令牌查询分享链接")
+
+	query := r.pickDB(ctx, tx).Model(&entity.LibraryShare{}).Where("token = ?", token)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var share entity.LibraryShare
+	err := query.First(&share).Error
+	if err == nil {
+		return &share, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询分享链接失败", true, err)
+}
+
+// ListByTarget 查询指定分享者对某个资源创建的全部分享链接（按创建时间倒序）。
+func (r *LibraryShareRepo) ListByTarget(ctx context.Context, tx *gorm.DB, ownerID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) ([]entity.LibraryShare, *xError.Error) {
+	r.log.Info(ctx, "ListByTarget - 查询资源的分享链接")
+
+	var shares []entity.LibraryShare
+	if err := r.pickDB(ctx, tx).
+		Where("owner_id = ? AND kind = ? AND library_id = ?", ownerID, kind, libraryID).
+		Order("created_at DESC").
+		Find(&shares).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询分享链接列表失败", true, err)
+	}
+	return shares, nil
+}
+
+// MarkRevoked 将未撤销的分享链接标记为已撤销，返回是否实际更新。
+func (r *LibraryShareRepo) MarkRevoked(ctx context.Context, tx *gorm.DB, shareID xSnowflake.SnowflakeID, revokedAt time.Time) (bool, *xError.Error) {
+	r.log.Info(ctx, "MarkRevoked - 撤销分享链接")
+
+	result := r.pickDB(ctx, tx).
+		Model(&entity.LibraryShare{}).
+		Where("id = ? AND revoked_at IS NULL", shareID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "撤销分享链接失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// IncrementClaimCount 增减分享链接的领取人数。
+func (r *LibraryShareRepo) IncrementClaimCount(ctx context.Context, tx *gorm.DB, shareID xSnowflake.SnowflakeID, delta int64) *xError.Error {
+	r.log.Info(ctx, "IncrementClaimCount - 更新分享领取人数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryShare{}).
+		Where("id = ?", shareID).
+		UpdateColumn("claim_count", gorm.Expr("claim_count + ?", delta)).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新分享领取人数失败", true, err)
+	}
+	return nil
+}
+
+// CreateClaim 创建分享领取记录。
+func (r *LibraryShareRepo) CreateClaim(ctx context.Context, tx *gorm.DB, claim *entity.LibraryShareClaim) (*entity.LibraryShareClaim, *xError.Error) {
+	r.log.Info(ctx, "CreateClaim - 创建分享领取记录")
+
+	if err := r.pickDB(ctx, tx).Create(claim).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建分享领取记录失败", true, err)
+	}
+	return claim, nil
+}
+
+func (r *LibraryShareRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// similarScanWindow 相似纹理查询的候选集上限：仅在 ID 最新的若干条带感知哈希的资源中计算汉明距离，
+// 避免逐行计算距离时随资源总量线性增长的全表扫描。
+const similarScanWindow = 5000
+
+// perceptualHashDistanceExpr 计算 perceptual_hash 与给定哈希的汉明距离。
+//
+// 通过 bit(64) 的文本形式统计 1 的个数，不依赖 PostgreSQL 14 才提供的 bit_count。
+const perceptualHashDistanceExpr = "length(replace(CAST(perceptual_hash # ? AS bit(64))::text, '0', ''))"
+
+// LibrarySimilarFilter 相似纹理查询条件。
+type LibrarySimilarFilter struct {
+	PerceptualHash int64                  // 目标纹理感知哈希
+	ExcludeID      xSnowflake.SnowflakeID // 排除的资源 ID（通常为目标资源自身）
+	MaxDistance    int                    // 最大汉明距离（含）
+	ApprovedOnly   bool                   // 仅匹配已审核通过的公开资源
+	Limit          int                    // 返回数量上限
+}
+
+// applySimilarFilter 构建皮肤/披风相似纹理查询，并按汉明距离升序排列。
+//
+// 先按主键倒序取至多 similarScanWindow 条候选资源，再在候选集内计算汉明距离，
+// 查询代价与资源总量无关；超出候选窗口的较早资源不会被匹配。
+func applySimilarFilter(db *gorm.DB, model any, filter LibrarySimilarFilter) *gorm.DB {
+	candidates := db.Model(model).
+		Select("id").
+		Where("perceptual_hash IS NOT NULL AND id <> ?", filter.ExcludeID)
+	if filter.ApprovedOnly {
+		candidates = candidates.Where("is_public = ? AND review_status = ?", true, entityType.ReviewStatusApproved)
+	}
+	candidates = candidates.Order("id DESC").Limit(similarScanWindow)
+
+	return db.Model(model).
+		Where("id IN (?)", candidates).
+		Where(perceptualHashDistanceExpr+" <= ?", filter.PerceptualHash, filter.MaxDistance).
+		Order(gorm.Expr(perceptualHashDistanceExpr+" ASC", filter.PerceptualHash)).
+		Order("created_at ASC").
+		Limit(filter.Limit)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagUsageRow 标签使用次数聚合行。
+type TagUsageRow struct {
+	Name       string `gorm:"column:name"`        // 标签名称
+	UsageCount int64  `gorm:"column:usage_count"` // 被皮肤与披风引用的总次数
+}
+
+// LibraryTagRepo 资源库标签仓储，负责标签数据访问。
+type LibraryTagRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewLibraryTagRepo 初始化并返回 LibraryTagRepo 实例。
+func NewLibraryTagRepo(db *gorm.DB) *LibraryTagRepo {
+	return &LibraryTagRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "LibraryTagRepo"),
+	}
+}
+
+// FindOrCreateByNames 根据名称列表查询标签，不存在的名称自动创建。
+//
+// 调用方需保证 names 已规范化且去重。
+func (r *LibraryTagRepo) FindOrCreateByNames(ctx context.Context, tx *gorm.DB, names []string) ([]entity.LibraryTag, *xError.Error) {
+	r.log.Info(ctx, "FindOrCreateByNames - 查询或创建标签")
+
+	if len(names) == 0 {
+		return []entity.LibraryTag{}, nil
+	}
+
+	newTags := make([]entity.LibraryTag, len(names))
+	for i, name := range names {
+		newTags[i] = entity.LibraryTag{Name: name}
+	}
+	if err := r.pickDB(ctx, tx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).
+		Create(&newTags).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建标签失败", true, err)
+	}
+
+	var tags []entity.LibraryTag
+	if err := r.pickDB(ctx, tx).Model(&entity.LibraryTag{}).Where("name IN ?", names).Find(&tags).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询标签失败", true, err)
+	}
+	return tags, nil
+}
+
+// ListPopular 按皮肤与披风的引用次数倒序列出热门标签。
+func (r *LibraryTagRepo) ListPopular(ctx context.Context, tx *gorm.DB, limit int) ([]TagUsageRow, *xError.Error) {
+	r.log.Info(ctx, "ListPopular - 查询热门标签")
+
+	var rows []TagUsageRow
+	if err := r.pickDB(ctx, tx).Raw(`
+		SELECT fyl_library_tag.name AS name, COUNT(*) AS usage_count
+		FROM fyl_library_tag
+		JOIN (
+			SELECT library_tag_id FROM fyl_skin_library_tag
+			UNION ALL
+			SELECT library_tag_id FROM fyl_cape_library_tag
+		) refs ON refs.library_tag_id = fyl_library_tag.id
+		GROUP BY fyl_library_tag.name
+		ORDER BY usage_count DESC, fyl_library_tag.name ASC
+		LIMIT ?`, limit).Scan(&rows).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询热门标签失败", true, err)
+	}
+	return rows, nil
+}
+
+func (r *LibraryTagRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
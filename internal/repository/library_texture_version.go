@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// LibraryTextureVersionRepo 资源库纹理版本仓储，负责纹理版本历史数据访问。
+type LibraryTextureVersionRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewLibraryTextureVersionRepo 初始化并返回 LibraryTextureVersionRepo 实例。
+func NewLibraryTextureVersionRepo(db *gorm.DB) *LibraryTextureVersionRepo {
+	return &LibraryTextureVersionRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "LibraryTextureVersionRepo"),
+	}
+}
+
+// Create 创建纹理版本记录。
+func (r *LibraryTextureVersionRepo) Create(ctx context.Context, tx *gorm.DB, version *entity.LibraryTextureVersion) (*entity.LibraryTextureVersion, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建纹理版本记录")
+
+	if err := r.pickDB(ctx, tx).Create(version).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建纹理版本记录失败", true, err)
+	}
+	return version, nil
+}
+
+// ListByTarget 查询指定资源的全部纹理版本（按版本号倒序）。
+func (r *LibraryTextureVersionRepo) ListByTarget(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) ([]entity.LibraryTextureVersion, *xError.Error) {
+	r.log.Info(ctx, "ListByTarget - 查询资源纹理版本列表")
+
+	var versions []entity.LibraryTextureVersion
+	if err := r.pickDB(ctx, tx).
+		Where("kind = ? AND library_id = ?", kind, libraryID).
+		Order("version DESC").
+		Find(&versions).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询纹理版本列表失败", true, err)
+	}
+	return versions, nil
+}
+
+// GetByTargetAndVersion 根据资源与版本号查询纹理版本记录。
+func (r *LibraryTextureVersionRepo) GetByTargetAndVersion(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, version int32) (*entity.LibraryTextureVersion, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByTargetAndVersion - 根据版本号获取纹理版本记录")
+
+	var record entity.LibraryTextureVersion
+	err := r.pickDB(ctx, tx).
+		Where("kind = ? AND library_id = ? AND version = ?", kind, libraryID, version).
+		First(&record).Error
+	if err == nil {
+		return &record, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询纹理版本记录失败", true, err)
+}
+
+// GetByTargetAndHash 根据资源与纹理哈希查询纹理版本记录。
+func (r *LibraryTextureVersionRepo) GetByTargetAndHash(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, textureHash string) (*entity.LibraryTextureVersion, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByTargetAndHash - 根据纹理哈希获取纹理版本记录")
+
+	var record entity.LibraryTextureVersion
+	err := r.pickDB(ctx, tx).
+		Where("kind = ? AND library_id = ? AND texture_hash = ?", kind, libraryID, textureHash).
+		Order("version DESC").
+		First(&record).Error
+	if err == nil {
+		return &record, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询纹理版本记录失败", true, err)
+}
+
+// GetMaxVersion 查询指定资源的最大版本号，无版本记录时返回 0。
+func (r *LibraryTextureVersionRepo) GetMaxVersion(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) (int32, *xError.Error) {
+	r.log.Info(ctx, "GetMaxVersion - 查询资源最大纹理版本号")
+
+	var maxVersion int32
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryTextureVersion{}).
+		Where("kind = ? AND library_id = ?", kind, libraryID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&maxVersion).Error; err != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "查询最大纹理版本号失败", true, err)
+	}
+	return maxVersion, nil
+}
+
+// ExistsByHash 检查指定种类下是否仍有版本记录引用该纹理哈希。
+func (r *LibraryTextureVersionRepo) ExistsByHash(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, textureHash string) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsByHash - 检查纹理哈希是否仍被版本引用")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.LibraryTextureVersion{}).
+		Where("kind = ? AND texture_hash = ?", kind, textureHash).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询纹理版本记录失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// DeleteByIDs 根据 ID 列表删除纹理版本记录。
+func (r *LibraryTextureVersionRepo) DeleteByIDs(ctx context.Context, tx *gorm.DB, ids []xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "DeleteByIDs - 删除纹理版本记录")
+
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := r.pickDB(ctx, tx).Where("id IN ?", ids).Delete(&entity.LibraryTextureVersion{}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "删除纹理版本记录失败", true, err)
+	}
+	return nil
+}
+
+// DeleteByTarget 删除指定资源的全部纹理版本记录，返回被删除的记录（供调用方释放纹理文件）。
+func (r *LibraryTextureVersionRepo) DeleteByTarget(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) ([]entity.LibraryTextureVersion, *xError.Error) {
+	r.log.Info(ctx, "DeleteByTarget - 删除资源的全部纹理版本记录")
+
+	versions, xErr := r.ListByTarget(ctx, tx, kind, libraryID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if err := r.pickDB(ctx, tx).
+		Where("kind = ? AND library_id = ?", kind, libraryID).
+		Delete(&entity.LibraryTextureVersion{}).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "删除纹理版本记录失败", true, err)
+	}
+	return versions, nil
+}
+
+func (r *LibraryTextureVersionRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
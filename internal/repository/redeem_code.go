@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RedeemCodeRepo 兑换码仓储，负责兑换码及其兑换记录的数据访问。
+type RedeemCodeRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewRedeemCodeRepo 初始化并返回 RedeemCodeRepo 实例。
+func NewRedeemCodeRepo(db *gorm.DB) *RedeemCodeRepo {
+	return &RedeemCodeRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "RedeemCodeRepo"),
+	}
+}
+
+// CreateBatch 创建兑换码生成批次并批量写入该批次的兑换码。
+//
+// 批次 ID 在写入批次记录时生成，随后回填到每个兑换码；调用方需在事务内调用以保证原子性。
+func (r *RedeemCodeRepo) CreateBatch(ctx context.Context, tx *gorm.DB, batch *entity.RedeemCodeBatch, codes []entity.RedeemCode) *xError.Error {
+	r.log.Info(ctx, "CreateBatch - 批量创建兑换码")
+
+	if err := r.pickDB(ctx, tx).Create(batch).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "创建兑换码批次失败", true, err)
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	for i := range codes {
+		codes[i].BatchID = batch.ID
+	}
+	if err := r.pickDB(ctx, tx).CreateInBatches(&codes, 200).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "批量创建兑换码失败", true, err)
+	}
+	return nil
+}
+
+// GetBatchByID 根据批次 ID 查询兑换码生成批次。
+func (r *RedeemCodeRepo) GetBatchByID(ctx context.Context, tx *gorm.DB, batchID xSnowflake.SnowflakeID) (*entity.RedeemCodeBatch, bool, *xError.Error) {
+	r.log.Info(ctx, "GetBatchByID - 根据 ID 查询兑换码批次")
+
+	var batch entity.RedeemCodeBatch
+	err := r.pickDB(ctx, tx).Model(&entity.RedeemCodeBatch{}).Where("id = ?", batchID).First(&batch).Error
+	if err == nil {
+		return &batch, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询兑换码批次失败", true, err)
+}
+
+// GetByCode 根据兑换码字符串查询兑换码，forUpdate 为 true 时加行锁。
+func (r *RedeemCodeRepo) GetByCode(ctx context.Context, tx *gorm.DB, code string, forUpdate bool) (*entity.RedeemCode, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByCode - 根据兑换码查询")
+
+	query := r.pickDB(ctx, tx).Model(&entity.RedeemCode{}).Where("code = ?", code)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	var redeemCode entity.RedeemCode
+	err := query.First(&redeemCode).Error
+	if err == nil {
+		return &redeemCode, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询兑换码失败", true, err)
+}
+
+// List 分页查询兑换码（按创建时间倒序），batchID 为空时不限批次。
+func (r *RedeemCodeRepo) List(ctx context.Context, tx *gorm.DB, batchID *xSnowflake.SnowflakeID, page int, pageSize int) ([]entity.RedeemCode, int64, *xError.Error) {
+	r.log.Info(ctx, "List - 分页查询兑换码")
+
+	query := r.pickDB(ctx, tx).Model(&entity.RedeemCode{})
+	if batchID != nil {
+		query = query.Where("batch_id = ?", *batchID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询兑换码总数失败", true, err)
+	}
+
+	var codes []entity.RedeemCode
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC, id ASC").Offset(offset).Limit(pageSize).Find(&codes).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询兑换码列表失败", true, err)
+	}
+	return codes, total, nil
+}
+
+// IncrementUsed 兑换次数 +1。
+func (r *RedeemCodeRepo) IncrementUsed(ctx context.Context, tx *gorm.DB, codeID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "IncrementUsed - 累加兑换码兑换次数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.RedeemCode{}).
+		Where("id = ?", codeID).
+		UpdateColumns(map[string]interface{}{
+			"used_count": gorm.Expr("used_count + 1"),
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新兑换码兑换次数失败", true, err)
+	}
+	return nil
+}
+
+// DisableBatch 停用指定批次的全部兑换码，返回受影响条数。
+func (r *RedeemCodeRepo) DisableBatch(ctx context.Context, tx *gorm.DB, batchID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "DisableBatch - 停用兑换码批次")
+
+	result := r.pickDB(ctx, tx).
+		Model(&entity.RedeemCode{}).
+		Where("batch_id = ? AND is_disabled = ?", batchID, false).
+		UpdateColumns(map[string]interface{}{
+			"is_disabled": true,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "停用兑换码批次失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// CreateRedemptionIfAbsent 创建兑换记录，(code_id, user_id) 已存在时不做任何修改。
+//
+// 返回 false 表示该用户已兑换过此兑换码。
+func (r *RedeemCodeRepo) CreateRedemptionIfAbsent(ctx context.Context, tx *gorm.DB, redemption *entity.RedeemCodeRedemption) (bool, *xError.Error) {
+	r.log.Info(ctx, "CreateRedemptionIfAbsent - 创建兑换记录")
+
+	result := r.pickDB(ctx, tx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code_id"}, {Name: "user_id"}},
+			DoNothing: true,
+		}).
+		Create(redemption)
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "创建兑换记录失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *RedeemCodeRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// RoleQuotaTierRepo 角色配额档位仓储，负责角色默认配额档位及角色存在性的数据访问。
+type RoleQuotaTierRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewRoleQuotaTierRepo 初始化并返回 RoleQuotaTierRepo 实例。
+func NewRoleQuotaTierRepo(db *gorm.DB) *RoleQuotaTierRepo {
+	return &RoleQuotaTierRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "RoleQuotaTierRepo"),
+	}
+}
+
+// GetByRole 根据角色名称查询配额档位。
+func (r *RoleQuotaTierRepo) GetByRole(ctx context.Context, tx *gorm.DB, roleName entity.RoleName) (*entity.RoleQuotaTier, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByRole - 根据角色查询配额档位")
+
+	var tier entity.RoleQuotaTier
+	err := r.pickDB(ctx, tx).Model(&entity.RoleQuotaTier{}).Where("role_name = ?", roleName).First(&tier).Error
+	if err == nil {
+		return &tier, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询角色配额档位失败", true, err)
+}
+
+// List 查询全部角色配额档位（按角色名称排序）。
+func (r *RoleQuotaTierRepo) List(ctx context.Context, tx *gorm.DB) ([]entity.RoleQuotaTier, *xError.Error) {
+	r.log.Info(ctx, "List - 查询角色配额档位列表")
+
+	var tiers []entity.RoleQuotaTier
+	if err := r.pickDB(ctx, tx).Model(&entity.RoleQuotaTier{}).Order("role_name ASC").Find(&tiers).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询角色配额档位列表失败", true, err)
+	}
+	return tiers, nil
+}
+
+// Save 创建或更新角色配额档位。
+func (r *RoleQuotaTierRepo) Save(ctx context.Context, tx *gorm.DB, tier *entity.RoleQuotaTier) *xError.Error {
+	r.log.Info(ctx, "Save - 保存角色配额档位")
+
+	if err := r.pickDB(ctx, tx).Save(tier).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "保存角色配额档位失败", true, err)
+	}
+	return nil
+}
+
+// RoleExists 判断角色是否存在。
+func (r *RoleQuotaTierRepo) RoleExists(ctx context.Context, tx *gorm.DB, roleName entity.RoleName) (bool, *xError.Error) {
+	r.log.Info(ctx, "RoleExists - 查询角色是否存在")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).Model(&entity.Role{}).Where("name = ?", roleName).Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询角色失败", true, err)
+	}
+	return count > 0, nil
+}
+
+func (r *RoleQuotaTierRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
 	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -51,6 +53,24 @@ func (r *SkinLibraryRepo) GetByID(ctx context.Context, tx *gorm.DB, skinID xSnow
 	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询皮肤库记录失败", true, err)
 }
 
+// GetByIDForUpdate 根据皮肤 ID 行锁查询皮肤库记录（SELECT ... FOR UPDATE），需在事务内调用。
+func (r *SkinLibraryRepo) GetByIDForUpdate(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID) (*entity.SkinLibrary, bool, *xError.Error) {
+	r.log.Info(ctx, "GetByIDForUpdate - 根据皮肤 ID 行锁获取皮肤库记录")
+
+	var skin entity.SkinLibrary
+	err := r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", skinID).
+		First(&skin).Error
+	if err == nil {
+		return &skin, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询皮肤库记录失败", true, err)
+}
+
 // GetByIDAndUserID 根据皮肤 ID 和用户 ID 查询皮肤库记录。
 func (r *SkinLibraryRepo) GetByIDAndUserID(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, userID xSnowflake.SnowflakeID, forUpdate bool) (*entity.SkinLibrary, bool, *xError.Error) {
 	r.log.Info(ctx, "GetByIDAndUserID - 根据皮肤 ID 与用户 ID 获取皮肤库记录")
@@ -118,12 +138,12 @@ func (r *SkinLibraryRepo) DeleteByID(ctx context.Context, tx *gorm.DB, skinID xS
 	return nil
 }
 
-// ListPublic 查询公开的皮肤库记录列表。
+// ListPublic 查询公开、审核通过且未被隐藏的皮肤库记录列表。
 func (r *SkinLibraryRepo) ListPublic(ctx context.Context, tx *gorm.DB, page int, pageSize int) ([]entity.SkinLibrary, int64, *xError.Error) {
 	r.log.Info(ctx, "ListPublic - 查询公开皮肤库记录列表")
 
 	var total int64
-	query := r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).Where("is_public = ?", true)
+	query := r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).Where("is_public = ? AND review_status = ? AND is_hidden = ?", true, entityType.ReviewStatusApproved, false)
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询皮肤库记录总数失败", true, err)
 	}
@@ -176,6 +196,373 @@ func (r *SkinLibraryRepo) CountPrivateByUserID(ctx context.Context, tx *gorm.DB,
 	return count, nil
 }
 
+// SearchGallery 按画廊条件分页查询公开、审核通过且未被隐藏的皮肤库记录（预加载标签）。
+func (r *SkinLibraryRepo) SearchGallery(ctx context.Context, tx *gorm.DB, filter LibraryGalleryFilter, page int, pageSize int) ([]entity.SkinLibrary, int64, *xError.Error) {
+	r.log.Info(ctx, "SearchGallery - 按画廊条件查询公开皮肤库记录")
+
+	query := r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).Where("fyl_skin_library.is_public = ? AND fyl_skin_library.review_status = ? AND fyl_skin_library.is_hidden = ?", true, entityType.ReviewStatusApproved, false)
+	if filter.Keyword != "" {
+		query = query.Where("fyl_skin_library.name ILIKE ?", "%"+escapeLikePattern(filter.Keyword)+"%")
+	}
+	if filter.UploaderID != nil {
+		query = query.Where("fyl_skin_library.user_id = ?", *filter.UploaderID)
+	}
+	if filter.Model != nil {
+		query = query.Where("fyl_skin_library.model = ?", *filter.Model)
+	}
+	if filter.Tag != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM fyl_skin_library_tag JOIN fyl_library_tag ON fyl_library_tag.id = fyl_skin_library_tag.library_tag_id WHERE fyl_skin_library_tag.skin_library_id = fyl_skin_library.id AND fyl_library_tag.name = ?)",
+			filter.Tag,
+		)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询皮肤库记录总数失败", true, err)
+	}
+
+	switch filter.Sort {
+	case GallerySortEquipped:
+		query = query.Order("(SELECT COUNT(*) FROM fyl_game_profile WHERE fyl_game_profile.skin_library_id = fyl_skin_library.id) DESC")
+	case GallerySortCollected:
+		query = query.Order("fyl_skin_library.collect_count DESC")
+	case GallerySortLiked:
+		query = query.Order("fyl_skin_library.like_count DESC")
+	}
+	query = query.Order("fyl_skin_library.created_at DESC")
+
+	var items []entity.SkinLibrary
+	offset := (page - 1) * pageSize
+	if err := query.Preload("Tags").Offset(offset).Limit(pageSize).Find(&items).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询皮肤库记录列表失败", true, err)
+	}
+	return items, total, nil
+}
+
+// ReplaceTags 以给定标签集合整体替换皮肤的标签关联。
+//
+// 直接操作 fyl_skin_library_tag 关联表，避免 GORM Association 回写皮肤记录触发实体钩子。
+func (r *SkinLibraryRepo) ReplaceTags(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, tags []entity.LibraryTag) *xError.Error {
+	r.log.Info(ctx, "ReplaceTags - 替换皮肤标签关联")
+
+	db := r.pickDB(ctx, tx)
+	if err := db.Exec("DELETE FROM fyl_skin_library_tag WHERE skin_library_id = ?", skinID).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "清除皮肤标签失败", true, err)
+	}
+	for _, tag := range tags {
+		if err := db.Exec("INSERT INTO fyl_skin_library_tag (skin_library_id, library_tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING", skinID, tag.ID).Error; err != nil {
+			return xError.NewError(ctx, xError.DatabaseError, "写入皮肤标签失败", true, err)
+		}
+	}
+	return nil
+}
+
+// IncrementLikeCount 原子增减皮肤点赞数（delta 可为负数）。
+func (r *SkinLibraryRepo) IncrementLikeCount(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, delta int64) *xError.Error {
+	r.log.Info(ctx, "IncrementLikeCount - 更新皮肤点赞数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ?", skinID).
+		UpdateColumn("like_count", gorm.Expr("GREATEST(like_count + ?, 0)", delta)).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新皮肤点赞数失败", true, err)
+	}
+	return nil
+}
+
+// IncrementCollectCount 原子增减皮肤收藏数（delta 可为负数）。
+func (r *SkinLibraryRepo) IncrementCollectCount(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, delta int64) *xError.Error {
+	r.log.Info(ctx, "IncrementCollectCount - 更新皮肤收藏数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ?", skinID).
+		UpdateColumn("collect_count", gorm.Expr("GREATEST(collect_count + ?, 0)", delta)).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新皮肤收藏数失败", true, err)
+	}
+	return nil
+}
+
+// ListPendingReview 分页查询待审核的公开皮肤库记录（按更新时间正序，先提交先审核）。
+//
+// 待审核包括整条记录处于待审核状态，以及已通过审核但存在待审核纹理版本的记录。
+func (r *SkinLibraryRepo) ListPendingReview(ctx context.Context, tx *gorm.DB, page int, pageSize int) ([]entity.SkinLibrary, int64, *xError.Error) {
+	r.log.Info(ctx, "ListPendingReview - 查询待审核皮肤库记录")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).
+		Where("is_public = ? AND (review_status = ? OR pending_texture_version IS NOT NULL)", true, entityType.ReviewStatusPending)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询待审核皮肤总数失败", true, err)
+	}
+
+	var items []entity.SkinLibrary
+	offset := (page - 1) * pageSize
+	if err := query.Order("updated_at ASC").Offset(offset).Limit(pageSize).Find(&items).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询待审核皮肤列表失败", true, err)
+	}
+	return items, total, nil
+}
+
+// UpdateReviewStatus 更新皮肤审核状态及公开状态。
+//
+// reviewerID 为空表示重新进入待审核，此时同时清空审核人、审核时间与驳回原因。
+// 使用 UpdateColumns 跳过实体钩子，仅写入审核相关列。
+func (r *SkinLibraryRepo) UpdateReviewStatus(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, status entityType.ReviewStatus, isPublic bool, reason *string, reviewerID *xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "UpdateReviewStatus - 更新皮肤审核状态")
+
+	updates := map[string]interface{}{
+		"review_status": status,
+		"is_public":     isPublic,
+		"review_reason": reason,
+		"reviewed_by":   reviewerID,
+		"reviewed_at":   nil,
+	}
+	if reviewerID != nil {
+		updates["reviewed_at"] = time.Now()
+	}
+	if err := r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).Where("id = ?", skinID).UpdateColumns(updates).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新皮肤审核状态失败", true, err)
+	}
+	return nil
+}
+
+// AddReport 原子累加皮肤待处理举报数，累加后达到 hideThreshold 时同时标记为隐藏。
+func (r *SkinLibraryRepo) AddReport(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, hideThreshold int64) *xError.Error {
+	r.log.Info(ctx, "AddReport - 累加皮肤举报数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ?", skinID).
+		UpdateColumns(map[string]interface{}{
+			"report_count": gorm.Expr("report_count + 1"),
+			"is_hidden":    gorm.Expr("is_hidden OR report_count + 1 >= ?", hideThreshold),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新皮肤举报数失败", true, err)
+	}
+	return nil
+}
+
+// ClearReports 清零皮肤待处理举报数并取消隐藏。
+func (r *SkinLibraryRepo) ClearReports(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "ClearReports - 清除皮肤举报状态")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ?", skinID).
+		UpdateColumns(map[string]interface{}{
+			"report_count": 0,
+			"is_hidden":    false,
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "清除皮肤举报状态失败", true, err)
+	}
+	return nil
+}
+
+// ListReported 分页查询存在待处理举报的皮肤库记录（已隐藏优先，其次按举报数倒序）。
+func (r *SkinLibraryRepo) ListReported(ctx context.Context, tx *gorm.DB, page int, pageSize int) ([]entity.SkinLibrary, int64, *xError.Error) {
+	r.log.Info(ctx, "ListReported - 查询被举报皮肤库记录")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).Where("report_count > 0")
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询被举报皮肤总数失败", true, err)
+	}
+
+	var items []entity.SkinLibrary
+	offset := (page - 1) * pageSize
+	if err := query.Order("is_hidden DESC").Order("report_count DESC").Order("updated_at ASC").Offset(offset).Limit(pageSize).Find(&items).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询被举报皮肤列表失败", true, err)
+	}
+	return items, total, nil
+}
+
+// UpdateTexture 更新皮肤当前纹理文件、哈希、感知哈希与版本号，并清空待审核纹理版本。
+//
+// 使用 UpdateColumns 跳过实体钩子，仅写入纹理相关列。
+func (r *SkinLibraryRepo) UpdateTexture(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, texture int64, textureHash string, perceptualHash *int64, version int32) *xError.Error {
+	r.log.Info(ctx, "UpdateTexture - 更新皮肤纹理")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ?", skinID).
+		UpdateColumns(map[string]interface{}{
+			"texture":                 texture,
+			"texture_hash":            textureHash,
+			"perceptual_hash":         perceptualHash,
+			"texture_version":         version,
+			"pending_texture_version": nil,
+			"updated_at":              time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新皮肤纹理失败", true, err)
+	}
+	return nil
+}
+
+// UpdatePendingTextureVersion 设置或清空皮肤待审核纹理版本号。
+//
+// 暂存新版本时同步刷新更新时间，使审核队列按提交先后排序；使用 UpdateColumns 跳过实体钩子。
+func (r *SkinLibraryRepo) UpdatePendingTextureVersion(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, version *int32) *xError.Error {
+	r.log.Info(ctx, "UpdatePendingTextureVersion - 更新皮肤待审核纹理版本")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ?", skinID).
+		UpdateColumns(map[string]interface{}{
+			"pending_texture_version": version,
+			"updated_at":              time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新皮肤待审核纹理版本失败", true, err)
+	}
+	return nil
+}
+
+// ListSimilar 查询感知哈希与目标纹理相近的皮肤，按汉明距离升序、创建时间升序排列。
+func (r *SkinLibraryRepo) ListSimilar(ctx context.Context, tx *gorm.DB, filter LibrarySimilarFilter) ([]entity.SkinLibrary, *xError.Error) {
+	r.log.Info(ctx, "ListSimilar - 查询相似皮肤")
+
+	var skins []entity.SkinLibrary
+	query := applySimilarFilter(r.pickDB(ctx, tx), &entity.SkinLibrary{}, filter)
+	if err := query.Find(&skins).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询相似皮肤失败", true, err)
+	}
+	return skins, nil
+}
+
+// ListMissingPerceptualHash 按 ID 升序查询 afterID 之后尚未计算感知哈希的皮肤，用于存量数据回填。
+func (r *SkinLibraryRepo) ListMissingPerceptualHash(ctx context.Context, tx *gorm.DB, afterID xSnowflake.SnowflakeID, limit int) ([]entity.SkinLibrary, *xError.Error) {
+	r.log.Info(ctx, "ListMissingPerceptualHash - 查询缺少感知哈希的皮肤")
+
+	var skins []entity.SkinLibrary
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("perceptual_hash IS NULL AND id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&skins).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询缺少感知哈希的皮肤失败", true, err)
+	}
+	return skins, nil
+}
+
+// UpdatePerceptualHash 回填皮肤的感知哈希。
+//
+// 仅当纹理未变且感知哈希仍为空时写入，避免覆盖回填期间替换纹理产生的新哈希。
+func (r *SkinLibraryRepo) UpdatePerceptualHash(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, texture int64, perceptualHash int64) *xError.Error {
+	r.log.Info(ctx, "UpdatePerceptualHash - 回填皮肤感知哈希")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ? AND texture = ? AND perceptual_hash IS NULL", skinID, texture).
+		UpdateColumn("perceptual_hash", perceptualHash).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "回填皮肤感知哈希失败", true, err)
+	}
+	return nil
+}
+
+// UpdateSimilarTo 更新皮肤的疑似近似重复标记，similarToID 为 nil 时清除标记。
+func (r *SkinLibraryRepo) UpdateSimilarTo(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, similarToID *xSnowflake.SnowflakeID) *xError.Error {
+	r.log.Info(ctx, "UpdateSimilarTo - 更新皮肤近似重复标记")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ?", skinID).
+		UpdateColumn("similar_to_id", similarToID).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新皮肤近似重复标记失败", true, err)
+	}
+	return nil
+}
+
+// ListSystem 分页查询系统内置皮肤（user_id 为空），includeRetired 为 false 时排除已停用皮肤。
+func (r *SkinLibraryRepo) ListSystem(ctx context.Context, tx *gorm.DB, includeRetired bool, page int, pageSize int) ([]entity.SkinLibrary, int64, *xError.Error) {
+	r.log.Info(ctx, "ListSystem - 查询系统内置皮肤列表")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).Where("user_id IS NULL")
+	if !includeRetired {
+		query = query.Where("is_retired = ?", false)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询系统皮肤总数失败", true, err)
+	}
+
+	var skins []entity.SkinLibrary
+	offset := (page - 1) * pageSize
+	if err := query.Order("is_retired ASC").Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&skins).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询系统皮肤列表失败", true, err)
+	}
+	return skins, total, nil
+}
+
+// ListDefaults 查询所有未停用且标记为新用户默认发放的系统皮肤。
+func (r *SkinLibraryRepo) ListDefaults(ctx context.Context, tx *gorm.DB) ([]entity.SkinLibrary, *xError.Error) {
+	r.log.Info(ctx, "ListDefaults - 查询默认发放的系统皮肤")
+
+	var skins []entity.SkinLibrary
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("user_id IS NULL AND is_default = ? AND is_retired = ?", true, false).
+		Order("created_at ASC").
+		Find(&skins).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询默认发放的系统皮肤失败", true, err)
+	}
+	return skins, nil
+}
+
+// GetDefaultEquip 查询新游戏档案默认装备的系统皮肤。
+func (r *SkinLibraryRepo) GetDefaultEquip(ctx context.Context, tx *gorm.DB) (*entity.SkinLibrary, bool, *xError.Error) {
+	r.log.Info(ctx, "GetDefaultEquip - 查询默认装备皮肤")
+
+	var skin entity.SkinLibrary
+	err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("user_id IS NULL AND is_default_equip = ? AND is_retired = ?", true, false).
+		First(&skin).Error
+	if err == nil {
+		return &skin, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询默认装备皮肤失败", true, err)
+}
+
+// ClearDefaultEquip 清除所有系统皮肤的默认装备标记，保证默认装备皮肤全局唯一。
+func (r *SkinLibraryRepo) ClearDefaultEquip(ctx context.Context, tx *gorm.DB) *xError.Error {
+	r.log.Info(ctx, "ClearDefaultEquip - 清除默认装备皮肤标记")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("is_default_equip = ?", true).
+		UpdateColumn("is_default_equip", false).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "清除默认装备皮肤标记失败", true, err)
+	}
+	return nil
+}
+
+// UpdateCatalog 更新系统皮肤的名称、公开状态、默认发放、默认装备与停用标记。
+//
+// 使用 UpdateColumns 跳过实体钩子，仅写入目录相关列。
+func (r *SkinLibraryRepo) UpdateCatalog(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID, name string, isPublic bool, isDefault bool, isDefaultEquip bool, isRetired bool) *xError.Error {
+	r.log.Info(ctx, "UpdateCatalog - 更新系统皮肤目录标记")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.SkinLibrary{}).
+		Where("id = ?", skinID).
+		UpdateColumns(map[string]interface{}{
+			"name":             name,
+			"is_public":        isPublic,
+			"is_default":       isDefault,
+			"is_default_equip": isDefaultEquip,
+			"is_retired":       isRetired,
+			"updated_at":       time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新系统皮肤目录标记失败", true, err)
+	}
+	return nil
+}
+
 func (r *SkinLibraryRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
 	if tx != nil {
 		return tx.WithContext(ctx)
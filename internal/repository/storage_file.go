@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StorageReference 数据库记录对对象存储文件的一条引用。
+type StorageReference struct {
+	RefID  xSnowflake.SnowflakeID // 引用记录 ID（皮肤/披风/附件）
+	FileID int64                  // 存储桶文件 ID
+	Hash   string                 // 数据库记录的纹理哈希（附件为空）
+}
+
+// StorageFileRepo 对象存储文件登记仓储，负责文件登记数据访问及数据库文件引用查询。
+type StorageFileRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewStorageFileRepo 初始化并返回 StorageFileRepo 实例。
+func NewStorageFileRepo(db *gorm.DB) *StorageFileRepo {
+	return &StorageFileRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "StorageFileRepo"),
+	}
+}
+
+// Register 批量登记对象存储文件，已登记的文件 ID 会被跳过。
+func (r *StorageFileRepo) Register(ctx context.Context, tx *gorm.DB, source entityType.StorageFileSource, fileIDs []int64) *xError.Error {
+	r.log.Info(ctx, "Register - 登记对象存储文件")
+
+	if len(fileIDs) == 0 {
+		return nil
+	}
+	files := make([]entity.StorageFile, len(fileIDs))
+	for i, fileID := range fileIDs {
+		files[i] = entity.StorageFile{FileID: fileID, Source: source}
+	}
+
+	if err := r.pickDB(ctx, tx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "file_id"}},
+			DoNothing: true,
+		}).
+		CreateInBatches(files, 200).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "登记对象存储文件失败", true, err)
+	}
+	return nil
+}
+
+// DeleteByFileID 移除对象存储文件登记。
+func (r *StorageFileRepo) DeleteByFileID(ctx context.Context, tx *gorm.DB, fileID int64) *xError.Error {
+	r.log.Info(ctx, "DeleteByFileID - 移除对象存储文件登记")
+
+	if err := r.pickDB(ctx, tx).Where("file_id = ?", fileID).Delete(&entity.StorageFile{}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "移除对象存储文件登记失败", true, err)
+	}
+	return nil
+}
+
+// ListBefore 按 ID 升序分页读取登记时间早于 createdBefore 的文件，cursor 为上一批最后一条的 ID。
+func (r *StorageFileRepo) ListBefore(
+	ctx context.Context,
+	tx *gorm.DB,
+	createdBefore time.Time,
+	cursor xSnowflake.SnowflakeID,
+	limit int,
+) ([]entity.StorageFile, *xError.Error) {
+	r.log.Info(ctx, "ListBefore - 分页读取对象存储文件登记")
+
+	var files []entity.StorageFile
+	if err := r.pickDB(ctx, tx).
+		Where("id > ? AND created_at < ?", cursor, createdBefore).
+		Order("id ASC").
+		Limit(limit).
+		Find(&files).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询对象存储文件登记失败", true, err)
+	}
+	return files, nil
+}
+
+// ListReferences 按记录 ID 升序分页读取指定来源的数据库文件引用，cursor 为上一批最后一条的记录 ID。
+func (r *StorageFileRepo) ListReferences(
+	ctx context.Context,
+	tx *gorm.DB,
+	source entityType.StorageFileSource,
+	cursor xSnowflake.SnowflakeID,
+	limit int,
+) ([]StorageReference, *xError.Error) {
+	r.log.Info(ctx, "ListReferences - 分页读取数据库文件引用")
+
+	var query *gorm.DB
+	switch source {
+	case entityType.StorageFileSourceSkinTexture:
+		query = r.pickDB(ctx, tx).Model(&entity.SkinLibrary{}).Select("id AS ref_id, texture AS file_id, texture_hash AS hash")
+	case entityType.StorageFileSourceCapeTexture:
+		query = r.pickDB(ctx, tx).Model(&entity.CapeLibrary{}).Select("id AS ref_id, texture AS file_id, texture_hash AS hash")
+	case entityType.StorageFileSourceIssueAttachment:
+		query = r.pickDB(ctx, tx).Model(&entity.IssueAttachment{}).Select("id AS ref_id, file_id, '' AS hash")
+	default:
+		return nil, xError.NewError(ctx, xError.ParameterError, "无效的文件来源", true)
+	}
+
+	var refs []StorageReference
+	if err := query.Where("id > ?", cursor).Order("id ASC").Limit(limit).Scan(&refs).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询数据库文件引用失败", true, err)
+	}
+	return refs, nil
+}
+
+// ReferencedFileIDs 返回 fileIDs 中仍被皮肤、披风、纹理历史版本或问题附件引用的文件 ID 集合。
+func (r *StorageFileRepo) ReferencedFileIDs(ctx context.Context, tx *gorm.DB, fileIDs []int64) (map[int64]bool, *xError.Error) {
+	r.log.Info(ctx, "ReferencedFileIDs - 查询仍被引用的文件")
+
+	referenced := make(map[int64]bool)
+	if len(fileIDs) == 0 {
+		return referenced, nil
+	}
+
+	queries := []struct {
+		model  interface{}
+		column string
+	}{
+		{&entity.SkinLibrary{}, "texture"},
+		{&entity.CapeLibrary{}, "texture"},
+		{&entity.LibraryTextureVersion{}, "texture"},
+		{&entity.IssueAttachment{}, "file_id"},
+	}
+	for _, q := range queries {
+		var found []int64
+		if err := r.pickDB(ctx, tx).Model(q.model).
+			Where(q.column+" IN ?", fileIDs).
+			Distinct().
+			Pluck(q.column, &found).Error; err != nil {
+			return nil, xError.NewError(ctx, xError.DatabaseError, "查询文件引用失败", true, err)
+		}
+		for _, fileID := range found {
+			referenced[fileID] = true
+		}
+	}
+	return referenced, nil
+}
+
+func (r *StorageFileRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// StorageReconcileCounts 存储对账单批次累加的计数。
+type StorageReconcileCounts struct {
+	CheckedReferences int64
+	CheckedFiles      int64
+	Orphan            int64
+	Dangling          int64
+	Mismatch          int64
+	Unverified        int64
+	Fixed             int64
+	Failed            int64
+}
+
+// StorageReconcileRepo 存储对账仓储，负责对账报告与对账问题数据访问。
+type StorageReconcileRepo struct {
+	db  *gorm.DB
+	log *xLog.LogNamedLogger
+}
+
+// NewStorageReconcileRepo 初始化并返回 StorageReconcileRepo 实例。
+func NewStorageReconcileRepo(db *gorm.DB) *StorageReconcileRepo {
+	return &StorageReconcileRepo{
+		db:  db,
+		log: xLog.WithName(xLog.NamedREPO, "StorageReconcileRepo"),
+	}
+}
+
+// CreateReport 创建对账报告。
+func (r *StorageReconcileRepo) CreateReport(ctx context.Context, tx *gorm.DB, report *entity.StorageReconcileReport) (*entity.StorageReconcileReport, *xError.Error) {
+	r.log.Info(ctx, "CreateReport - 创建存储对账报告")
+
+	if err := r.pickDB(ctx, tx).Create(report).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建存储对账报告失败", true, err)
+	}
+	return report, nil
+}
+
+// ExistsActive 判断是否存在执行中且心跳（updated_at）不早于 staleBefore 的对账任务。
+func (r *StorageReconcileRepo) ExistsActive(ctx context.Context, tx *gorm.DB, staleBefore time.Time) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsActive - 检查执行中的存储对账任务")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.StorageReconcileReport{}).
+		Where("status = ? AND updated_at >= ?", entityType.StorageReconcileStatusRunning, staleBefore).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询存储对账任务失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// GetReportByID 根据报告 ID 查询对账报告。
+func (r *StorageReconcileRepo) GetReportByID(ctx context.Context, tx *gorm.DB, reportID xSnowflake.SnowflakeID) (*entity.StorageReconcileReport, bool, *xError.Error) {
+	r.log.Info(ctx, "GetReportByID - 查询存储对账报告")
+
+	var report entity.StorageReconcileReport
+	err := r.pickDB(ctx, tx).Model(&entity.StorageReconcileReport{}).Where("id = ?", reportID).First(&report).Error
+	if err == nil {
+		return &report, true, nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	return nil, false, xError.NewError(ctx, xError.DatabaseError, "查询存储对账报告失败", true, err)
+}
+
+// ListReports 分页查询对账报告（按创建时间倒序）。
+func (r *StorageReconcileRepo) ListReports(ctx context.Context, tx *gorm.DB, page int, pageSize int) ([]entity.StorageReconcileReport, int64, *xError.Error) {
+	r.log.Info(ctx, "ListReports - 分页查询存储对账报告")
+
+	var total int64
+	query := r.pickDB(ctx, tx).Model(&entity.StorageReconcileReport{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询存储对账报告总数失败", true, err)
+	}
+
+	var reports []entity.StorageReconcileReport
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&reports).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询存储对账报告列表失败", true, err)
+	}
+	return reports, total, nil
+}
+
+// AddCounts 累加本批次的对账计数，同时刷新心跳。
+func (r *StorageReconcileRepo) AddCounts(ctx context.Context, tx *gorm.DB, reportID xSnowflake.SnowflakeID, counts StorageReconcileCounts) *xError.Error {
+	r.log.Info(ctx, "AddCounts - 累加存储对账计数")
+
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.StorageReconcileReport{}).
+		Where("id = ?", reportID).
+		UpdateColumns(map[string]interface{}{
+			"checked_references": gorm.Expr("checked_references + ?", counts.CheckedReferences),
+			"checked_files":      gorm.Expr("checked_files + ?", counts.CheckedFiles),
+			"orphan_count":       gorm.Expr("orphan_count + ?", counts.Orphan),
+			"dangling_count":     gorm.Expr("dangling_count + ?", counts.Dangling),
+			"mismatch_count":     gorm.Expr("mismatch_count + ?", counts.Mismatch),
+			"unverified_count":   gorm.Expr("unverified_count + ?", counts.Unverified),
+			"fixed_count":        gorm.Expr("fixed_count + ?", counts.Fixed),
+			"failed_count":       gorm.Expr("failed_count + ?", counts.Failed),
+			"updated_at":         time.Now(),
+		}).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "累加存储对账计数失败", true, err)
+	}
+	return nil
+}
+
+// Finish 结束对账任务：写入最终状态与中断原因，完成时记录完成时间。
+func (r *StorageReconcileRepo) Finish(ctx context.Context, tx *gorm.DB, reportID xSnowflake.SnowflakeID, status entityType.StorageReconcileStatus, lastError *string) *xError.Error {
+	r.log.Info(ctx, "Finish - 结束存储对账任务")
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     status,
+		"last_error": lastError,
+		"updated_at": now,
+	}
+	if status == entityType.StorageReconcileStatusCompleted {
+		updates["finished_at"] = now
+	}
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.StorageReconcileReport{}).
+		Where("id = ?", reportID).
+		UpdateColumns(updates).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "更新存储对账报告状态失败", true, err)
+	}
+	return nil
+}
+
+// CreateIssues 批量写入对账问题。
+func (r *StorageReconcileRepo) CreateIssues(ctx context.Context, tx *gorm.DB, issues []entity.StorageReconcileIssue) *xError.Error {
+	r.log.Info(ctx, "CreateIssues - 写入存储对账问题")
+
+	if len(issues) == 0 {
+		return nil
+	}
+	if err := r.pickDB(ctx, tx).CreateInBatches(issues, 200).Error; err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "写入存储对账问题失败", true, err)
+	}
+	return nil
+}
+
+// ListIssues 分页查询对账报告下的问题，issueType 为空时返回全部类型。
+func (r *StorageReconcileRepo) ListIssues(
+	ctx context.Context,
+	tx *gorm.DB,
+	reportID xSnowflake.SnowflakeID,
+	issueType *entityType.StorageIssueType,
+	page int,
+	pageSize int,
+) ([]entity.StorageReconcileIssue, int64, *xError.Error) {
+	r.log.Info(ctx, "ListIssues - 分页查询存储对账问题")
+
+	query := r.pickDB(ctx, tx).Model(&entity.StorageReconcileIssue{}).Where("report_id = ?", reportID)
+	if issueType != nil {
+		query = query.Where("type = ?", *issueType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询存储对账问题总数失败", true, err)
+	}
+
+	var issues []entity.StorageReconcileIssue
+	offset := (page - 1) * pageSize
+	if err := query.Order("id ASC").Offset(offset).Limit(pageSize).Find(&issues).Error; err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询存储对账问题列表失败", true, err)
+	}
+	return issues, total, nil
+}
+
+func (r *StorageReconcileRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -38,11 +38,18 @@ import (
 // 保证操作的原子性。所有事务的开启、提交和回滚均在此层完成，
 // 上层 Logic 无需感知事务细节。
 type GameProfileTxnRepo struct {
-	db       *gorm.DB                           // GORM 数据库实例（用于开启事务）
-	log      *xLog.LogNamedLogger               // 日志实例
-	profile  *repository.GameProfileRepo        // 游戏档案仓储
-	quota    *repository.GameProfileQuotaRepo   // 游戏档案配额仓储
-	quotaLog *repository.GameProfileQuotaLogRepo // 游戏档案配额日志仓储
+	db          *gorm.DB                                   // GORM 数据库实例（用于开启事务）
+	log         *xLog.LogNamedLogger                       // 日志实例
+	profile     *repository.GameProfileRepo                // 游戏档案仓储
+	quota       *repository.GameProfileQuotaRepo           // 游戏档案配额仓储
+	quotaLog    *repository.GameProfileQuotaLogRepo        // 游戏档案配额日志仓储
+	token       *repository.GameTokenRepo                  // 游戏令牌仓储（删除、转移档案时吊销绑定令牌）
+	nameHold    *repository.GameProfileNameReservationRepo // 游戏档案名称保留仓储
+	transfer    *repository.GameProfileTransferRepo        // 游戏档案转移请求仓储
+	userSkinLib *repository.UserSkinLibraryRepo            // 用户皮肤关联仓储（创建档案时补发默认装备皮肤，转移档案时校验接收方是否拥有装备的皮肤）
+	userCapeLib *repository.UserCapeLibraryRepo            // 用户披风关联仓储（转移档案时校验接收方是否拥有装备的披风）
+	nameHistory *repository.GameProfileNameHistoryRepo     // 游戏档案名称历史仓储
+	auditLog    *repository.GameProfileAuditLogRepo        // 游戏档案审计记录仓储（管理员操作）
 }
 
 // NewGameProfileTxnRepo 初始化并返回 GameProfileTxnRepo 实例。
@@ -52,6 +59,13 @@ type GameProfileTxnRepo struct {
 //   - profile: 游戏档案仓储实例。
 //   - quota: 游戏档案配额仓储实例。
 //   - quotaLog: 游戏档案配额日志仓储实例。
+//   - token: 游戏令牌仓储实例。
+//   - nameHold: 游戏档案名称保留仓储实例。
+//   - transfer: 游戏档案转移请求仓储实例。
+//   - userSkinLib: 用户皮肤关联仓储实例。
+//   - userCapeLib: 用户披风关联仓储实例。
+//   - nameHistory: 游戏档案名称历史仓储实例。
+//   - auditLog: 游戏档案审计记录仓储实例。
 //
 // 返回值:
 //   - *GameProfileTxnRepo: 初始化完成的事务协调仓储实例指针。
@@ -60,13 +74,27 @@ func NewGameProfileTxnRepo(
 	profile *repository.GameProfileRepo,
 	quota *repository.GameProfileQuotaRepo,
 	quotaLog *repository.GameProfileQuotaLogRepo,
+	token *repository.GameTokenRepo,
+	nameHold *repository.GameProfileNameReservationRepo,
+	transfer *repository.GameProfileTransferRepo,
+	userSkinLib *repository.UserSkinLibraryRepo,
+	userCapeLib *repository.UserCapeLibraryRepo,
+	nameHistory *repository.GameProfileNameHistoryRepo,
+	auditLog *repository.GameProfileAuditLogRepo,
 ) *GameProfileTxnRepo {
 	return &GameProfileTxnRepo{
-		db:       db,
-		log:      xLog.WithName(xLog.NamedREPO, "GameProfileTxnRepo"),
-		profile:  profile,
-		quota:    quota,
-		quotaLog: quotaLog,
+		db:          db,
+		log:         xLog.WithName(xLog.NamedREPO, "GameProfileTxnRepo"),
+		profile:     profile,
+		quota:       quota,
+		quotaLog:    quotaLog,
+		token:       token,
+		nameHold:    nameHold,
+		transfer:    transfer,
+		userSkinLib: userSkinLib,
+		userCapeLib: userCapeLib,
+		nameHistory: nameHistory,
+		auditLog:    auditLog,
 	}
 }
 
@@ -75,16 +103,18 @@ func NewGameProfileTxnRepo(
 // 该方法执行以下原子操作序列：
 //  1. 行锁查询用户配额记录（SELECT ... FOR UPDATE）
 //  2. 校验配额余额是否充足
-//  3. 校验 UUID 和名称唯一性
-//  4. 创建游戏档案记录
-//  5. 更新配额已用数量 (+1)
-//  6. 写入配额变更日志
+//  3. 校验 UUID 和名称唯一性（含他人的名称保留）
+//  4. 发放默认装备皮肤关联（用户尚未持有时以 Admin 关联补发）
+//  5. 创建游戏档案记录及初始名称历史
+//  6. 更新配额已用数量 (+1)
+//  7. 写入配额变更日志
 //
-// 任一步骤失败将触发整体回滚。
+// 任一步骤失败将触发整体回滚。若用户已持有默认装备皮肤的关联但当前不可装备（如赠送已到期），
+// 档案以无皮肤状态创建，不覆盖管理员设定的关联。
 //
 // 参数:
 //   - ctx: 标准库上下文对象，用于传递请求范围的数据、取消信号和截止时间。
-//   - profile: 待创建的游戏档案实体指针，需已填充 UserID、UUID、Name 字段。
+//   - profile: 待创建的游戏档案实体指针，需已填充 UserID、UUID、Name 字段；SkinLibraryID 可填充默认装备皮肤 ID。
 //
 // 返回值:
 //   - *entity.GameProfile: 创建成功的游戏档案实体指针。
@@ -135,15 +165,63 @@ func (t *GameProfileTxnRepo) AddProfileWithQuota(
 			bizErr = xError.NewError(ctx, xError.DataConflict, "用户名已存在", true)
 			return bizErr
 		}
+		nameReserved, xErr := t.nameHold.ExistsActiveForOthers(ctx, tx, profile.Name, profile.UserID, time.Now())
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if nameReserved {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "用户名处于保留期，暂不可使用", true)
+			return bizErr
+		}
+
+		// 4. 发放默认装备皮肤关联
+		if profile.SkinLibraryID != nil {
+			_, held, xErr := t.userSkinLib.GetByUserAndSkin(ctx, tx, profile.UserID, *profile.SkinLibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if !held {
+				if _, xErr = t.userSkinLib.Create(ctx, tx, &entity.UserSkinLibrary{
+					UserID:         profile.UserID,
+					SkinLibraryID:  *profile.SkinLibraryID,
+					AssignmentType: entityType.AssignmentTypeAdmin,
+				}); xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+			} else {
+				equippable, xErr := t.userSkinLib.ExistsEquippableByUserAndSkin(ctx, tx, profile.UserID, *profile.SkinLibraryID)
+				if xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+				if !equippable {
+					profile.SkinLibraryID = nil
+				}
+			}
+		}
 
-		// 4. 创建档案
+		// 5. 创建档案
 		createdProfile, xErr = t.profile.Create(ctx, tx, profile)
 		if xErr != nil {
 			bizErr = xErr
 			return xErr
 		}
+		_, xErr = t.nameHistory.Create(ctx, tx, &entity.GameProfileNameHistory{
+			ProfileID: createdProfile.ID,
+			UserID:    createdProfile.UserID,
+			Name:      createdProfile.Name,
+			Source:    entity.GameProfileNameSourceCreate,
+			ChangedAt: time.Now(),
+		})
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
 
-		// 5. 更新配额
+		// 6. 更新配额
 		beforeUsed := quota.Used
 		afterUsed := quota.Used + 1
 		xErr = t.quota.UpdateUsed(ctx, tx, quota.ID, afterUsed)
@@ -152,7 +230,7 @@ func (t *GameProfileTxnRepo) AddProfileWithQuota(
 			return xErr
 		}
 
-		// 6. 写入日志
+		// 7. 写入日志
 		_, xErr = t.quotaLog.Create(
 			ctx, tx, profile.UserID,
 			entityType.ObTypeAddGameProfile, 1,
@@ -263,3 +341,128 @@ func (t *GameProfileTxnRepo) AdjustQuotaAdmin(
 	}
 	return updatedQuota, nil
 }
+
+// DeleteProfileWithRefund 在事务内删除游戏档案并退还档案配额。
+//
+// 该方法执行以下原子操作序列：
+//  1. 行锁查询档案（必须属于 userID）与用户配额记录
+//  2. 吊销所有绑定到该档案的令牌（需在外键将 BoundProfileID 置空之前完成）
+//  3. 配额已用数量 -1，并以 "DELETE_GAME_PROFILE:<档案ID>" 为幂等键写入退还日志
+//  4. 若 reserveUntil 非空，为原持有者写入名称保留记录
+//  5. 若 audit 非空（管理员删除），写入审计记录
+//  6. 删除档案（进服记录、正版档案缓存随外键级联删除）
+//
+// 任一步骤失败将触发整体回滚。幂等键以档案 ID 唯一确定，同一档案的退还至多记账一次。
+//
+// 返回值:
+//   - *entity.GameProfile: 被删除的档案（删除前快照）
+//   - int64: 被吊销的令牌数量
+//   - *xError.Error: 业务校验失败或数据库操作错误
+func (t *GameProfileTxnRepo) DeleteProfileWithRefund(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	profileID xSnowflake.SnowflakeID,
+	remark *string,
+	reserveUntil *time.Time,
+	audit *entity.GameProfileAuditLog,
+) (*entity.GameProfile, int64, *xError.Error) {
+	t.log.Info(ctx, "DeleteProfileWithRefund - 事务内删除游戏档案并退还配额")
+
+	var deletedProfile *entity.GameProfile
+	var revokedTokens int64
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询档案与配额
+		profile, found, xErr := t.profile.GetByIDAndUserID(ctx, tx, profileID, userID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+			return bizErr
+		}
+		quota, found, xErr := t.quota.GetByUserID(ctx, tx, userID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "用户游戏档案配额不存在", true)
+			return bizErr
+		}
+
+		// 2. 吊销绑定令牌
+		revokedTokens, xErr = t.token.InvalidateByBoundProfileID(ctx, tx, profile.ID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 3. 退还配额并写入日志（历史数据已用数为 0 时仅记录日志，不再扣减）
+		refund := int32(1)
+		if quota.Used < refund {
+			refund = 0
+		}
+		if refund > 0 {
+			xErr = t.quota.UpdateUsed(ctx, tx, quota.ID, quota.Used-refund)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+		idempotencyKey := fmt.Sprintf("%s:%s", entityType.ObTypeDeleteGameProfile.String(), profile.ID.String())
+		_, xErr = t.quotaLog.CreateWithIdempotencyKey(
+			ctx, tx, userID,
+			entityType.ObTypeDeleteGameProfile, refund,
+			quota.Used, quota.Total,
+			xUtil.Ptr(profile.ID),
+			remark,
+			idempotencyKey,
+		)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 4. 名称保留
+		if reserveUntil != nil {
+			_, xErr = t.nameHold.Create(ctx, tx, &entity.GameProfileNameReservation{
+				Name:        profile.Name,
+				UserID:      userID,
+				ProfileUUID: profile.UUID.String(),
+				ExpiresAt:   *reserveUntil,
+			})
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 5. 管理员审计
+		if audit != nil {
+			if xErr = t.createAuditLog(ctx, tx, audit, profile); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 6. 删除档案
+		xErr = t.profile.Delete(ctx, tx, profile.ID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		deletedProfile = profile
+		return nil
+	})
+	if bizErr != nil {
+		return nil, 0, bizErr
+	}
+	if err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "删除游戏档案失败", true, err)
+	}
+	return deletedProfile, revokedTokens, nil
+}
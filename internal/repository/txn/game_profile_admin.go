@@ -0,0 +1,135 @@
+package txn
+
+import (
+	"context"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// ClearProfileSkinWithAudit 在事务内由管理员清除游戏档案装备的皮肤并写入审计记录。
+//
+// 事务序列：行锁查询档案（必须仍属于 userID）→ 校验当前已装备皮肤 → 清除 SkinLibraryID → 写入审计记录。
+func (t *GameProfileTxnRepo) ClearProfileSkinWithAudit(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	profileID xSnowflake.SnowflakeID,
+	audit *entity.GameProfileAuditLog,
+) (*entity.GameProfile, *xError.Error) {
+	t.log.Info(ctx, "ClearProfileSkinWithAudit - 事务内管理员清除游戏档案皮肤")
+
+	var updatedProfile *entity.GameProfile
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询档案
+		profile, found, xErr := t.profile.GetByIDAndUserID(ctx, tx, profileID, userID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+			return bizErr
+		}
+		if profile.SkinLibraryID == nil {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "游戏档案未装备皮肤", true)
+			return bizErr
+		}
+
+		// 2. 清除皮肤
+		detail := "skin_library_id=" + profile.SkinLibraryID.String()
+		updatedProfile, xErr = t.profile.UpdateSkinLibraryID(ctx, tx, profile.ID, nil)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 3. 审计记录
+		audit.Detail = &detail
+		if xErr = t.createAuditLog(ctx, tx, audit, profile); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "清除游戏档案皮肤失败", true, err)
+	}
+	return updatedProfile, nil
+}
+
+// UpdateProfileLockWithAudit 在事务内由管理员锁定或解锁游戏档案并写入审计记录。
+//
+// lockedAt 非空表示锁定，为 nil 表示解除锁定。档案已处于目标状态时返回 DataConflict，
+// 避免重复操作产生无意义的审计记录。
+func (t *GameProfileTxnRepo) UpdateProfileLockWithAudit(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	profileID xSnowflake.SnowflakeID,
+	lockedAt *time.Time,
+	audit *entity.GameProfileAuditLog,
+) (*entity.GameProfile, *xError.Error) {
+	t.log.Info(ctx, "UpdateProfileLockWithAudit - 事务内管理员更新游戏档案锁定状态")
+
+	var updatedProfile *entity.GameProfile
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询档案
+		profile, found, xErr := t.profile.GetByIDAndUserID(ctx, tx, profileID, userID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+			return bizErr
+		}
+		if lockedAt != nil && profile.IsLocked() {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "游戏档案已处于锁定状态", true)
+			return bizErr
+		}
+		if lockedAt == nil && !profile.IsLocked() {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "游戏档案未被锁定", true)
+			return bizErr
+		}
+
+		// 2. 更新锁定状态（锁定原因与审计原因一致）
+		updatedProfile, xErr = t.profile.UpdateLock(ctx, tx, profile.ID, lockedAt, &audit.Reason)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 3. 审计记录
+		if xErr = t.createAuditLog(ctx, tx, audit, profile); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "更新游戏档案锁定状态失败", true, err)
+	}
+	return updatedProfile, nil
+}
+
+// createAuditLog 以操作前的档案快照补全审计记录的档案字段并写入。
+func (t *GameProfileTxnRepo) createAuditLog(ctx context.Context, tx *gorm.DB, audit *entity.GameProfileAuditLog, profile *entity.GameProfile) *xError.Error {
+	audit.ProfileID = profile.ID
+	audit.ProfileUUID = profile.UUID.String()
+	audit.ProfileName = profile.Name
+	audit.UserID = profile.UserID
+	_, xErr := t.auditLog.Create(ctx, tx, audit)
+	return xErr
+}
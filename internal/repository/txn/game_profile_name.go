@@ -0,0 +1,164 @@
+package txn
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// RenameProfile 在事务内修改游戏档案用户名并记录名称历史。
+//
+// 该方法执行以下原子操作序列：
+//  1. 行锁查询档案（必须属于 userID），名称未变更时直接返回
+//  2. 校验改名冷却：cooldown > 0 且距上次改名不足 cooldown 时拒绝
+//  3. 校验新名称未被其他档案占用，且不处于他人的名称保留期
+//  4. 档案尚无名称历史时补录当前名称作为初始记录（兼容历史数据）
+//  5. 写入新名称的历史记录
+//  6. 若 reserveUntil 非空，为当前持有者保留旧名称（仅大小写不同时不保留）
+//  7. 更新档案名称与最近改名时间
+//  8. 若 audit 非空（管理员强制改名），写入审计记录
+//
+// audit 非空时名称历史来源记为管理员改名并记录操作者。任一步骤失败将触发整体回滚。
+func (t *GameProfileTxnRepo) RenameProfile(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	profileID xSnowflake.SnowflakeID,
+	newName string,
+	now time.Time,
+	cooldown time.Duration,
+	reserveUntil *time.Time,
+	audit *entity.GameProfileAuditLog,
+) (*entity.GameProfile, *xError.Error) {
+	t.log.Info(ctx, "RenameProfile - 事务内修改游戏档案用户名")
+
+	var renamedProfile *entity.GameProfile
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询档案
+		profile, found, xErr := t.profile.GetByIDAndUserID(ctx, tx, profileID, userID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+			return bizErr
+		}
+		if profile.Name == newName {
+			renamedProfile = profile
+			return nil
+		}
+
+		// 2. 改名冷却
+		if cooldown > 0 && profile.NameChangedAt != nil {
+			availableAt := profile.NameChangedAt.Add(cooldown)
+			if now.Before(availableAt) {
+				bizErr = xError.NewError(ctx, xError.OperationDenied, "改名冷却中，请于 "+availableAt.Format(time.DateTime)+" 后再试", true)
+				return bizErr
+			}
+		}
+
+		// 3. 名称唯一性与保留期
+		nameExisted, xErr := t.profile.ExistsByNameExceptID(ctx, tx, newName, profile.ID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if nameExisted {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "用户名已存在", true)
+			return bizErr
+		}
+		nameReserved, xErr := t.nameHold.ExistsActiveForOthers(ctx, tx, newName, userID, now)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if nameReserved {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "用户名处于保留期，暂不可使用", true)
+			return bizErr
+		}
+
+		// 4. 补录初始名称
+		hasHistory, xErr := t.nameHistory.ExistsByProfileID(ctx, tx, profile.ID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !hasHistory {
+			_, xErr = t.nameHistory.Create(ctx, tx, &entity.GameProfileNameHistory{
+				ProfileID: profile.ID,
+				UserID:    profile.UserID,
+				Name:      profile.Name,
+				Source:    entity.GameProfileNameSourceCreate,
+				ChangedAt: profile.CreatedAt,
+			})
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 5. 新名称历史
+		history := &entity.GameProfileNameHistory{
+			ProfileID: profile.ID,
+			UserID:    profile.UserID,
+			Name:      newName,
+			Source:    entity.GameProfileNameSourceRename,
+			ChangedAt: now,
+		}
+		if audit != nil {
+			history.Source = entity.GameProfileNameSourceAdmin
+			history.OperatorID = &audit.OperatorID
+		}
+		_, xErr = t.nameHistory.Create(ctx, tx, history)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 6. 旧名称保留
+		if reserveUntil != nil && !strings.EqualFold(profile.Name, newName) {
+			_, xErr = t.nameHold.Create(ctx, tx, &entity.GameProfileNameReservation{
+				Name:        profile.Name,
+				UserID:      profile.UserID,
+				ProfileUUID: profile.UUID.String(),
+				ExpiresAt:   *reserveUntil,
+			})
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 7. 更新档案
+		renamedProfile, xErr = t.profile.UpdateNameAndChangedAt(ctx, tx, profile.ID, newName, now)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 8. 管理员审计
+		if audit != nil {
+			detail := profile.Name + " -> " + newName
+			audit.Detail = &detail
+			if xErr = t.createAuditLog(ctx, tx, audit, profile); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "修改游戏档案用户名失败", true, err)
+	}
+	return renamedProfile, nil
+}
@@ -0,0 +1,348 @@
+package txn
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xUtil "github.com/bamboo-services/bamboo-base-go/common/utility"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// GameProfileTransferResult 接受档案转移的事务结果。
+type GameProfileTransferResult struct {
+	Transfer      *entity.GameProfileTransfer // 已接受的转移请求
+	Profile       *entity.GameProfile         // 转移后的游戏档案
+	SkinUnbound   bool                        // 是否因接收方未拥有而卸下了皮肤
+	CapeUnbound   bool                        // 是否因接收方未拥有而卸下了披风
+	RevokedTokens int64                       // 被吊销的发起方绑定令牌数量
+}
+
+// CreateTransfer 在事务内发起游戏档案转移请求。
+//
+// 事务序列：行锁查询档案（必须属于发起方且未被锁定）→ 校验该档案不存在有效的待接收请求 → 写入转移请求。
+// 发起时不占用接收方配额，配额在接收方接受时校验并扣减。
+func (t *GameProfileTxnRepo) CreateTransfer(
+	ctx context.Context,
+	transfer *entity.GameProfileTransfer,
+	now time.Time,
+) (*entity.GameProfileTransfer, *xError.Error) {
+	t.log.Info(ctx, "CreateTransfer - 事务内发起游戏档案转移")
+
+	var createdTransfer *entity.GameProfileTransfer
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询档案
+		profile, found, xErr := t.profile.GetByIDAndUserID(ctx, tx, transfer.ProfileID, transfer.FromUserID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "游戏档案不存在", true)
+			return bizErr
+		}
+		if profile.IsLocked() {
+			bizErr = xError.NewError(ctx, xError.OperationDenied, "游戏档案已被管理员锁定，无法转移", true)
+			return bizErr
+		}
+
+		// 2. 同一档案至多一个待接收请求
+		pending, xErr := t.transfer.ExistsPendingByProfileID(ctx, tx, profile.ID, now)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if pending {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该游戏档案已有待接收的转移请求，请先取消", true)
+			return bizErr
+		}
+
+		// 3. 写入请求
+		transfer.ProfileUUID = profile.UUID.String()
+		transfer.ProfileName = profile.Name
+		transfer.Status = entity.GameProfileTransferStatusPending
+		createdTransfer, xErr = t.transfer.Create(ctx, tx, transfer)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "发起游戏档案转移失败", true, err)
+	}
+	return createdTransfer, nil
+}
+
+// AcceptTransfer 在事务内由接收方接受游戏档案转移。
+//
+// 该方法执行以下原子操作序列：
+//  1. 行锁查询转移请求，校验接收方身份、待接收状态与有效期
+//  2. 行锁查询档案，校验其仍属于发起方（否则将请求置为已取消）且未被管理员锁定
+//  3. 按用户 ID 升序行锁双方配额，校验接收方配额余额
+//  4. 校验接收方是否拥有档案当前装备的皮肤与披风，未拥有则卸下
+//  5. 吊销发起方绑定到该档案的令牌
+//  6. 转移档案持有者（UUID 与名称保持不变）
+//  7. 接收方已用 +1、发起方已用 -1，并以转移请求 ID 为幂等键为双方各写入一条配额日志
+//  8. 将请求置为已接受
+//
+// 任一步骤失败将触发整体回滚。待验证会话存放于 Redis，无法随事务提交，
+// 由调用方在提交后按返回的档案 UUID 清理（见 GameProfileLogic.AcceptTransfer）。
+func (t *GameProfileTxnRepo) AcceptTransfer(
+	ctx context.Context,
+	transferID xSnowflake.SnowflakeID,
+	userID xSnowflake.SnowflakeID,
+	now time.Time,
+) (*GameProfileTransferResult, *xError.Error) {
+	t.log.Info(ctx, "AcceptTransfer - 事务内接受游戏档案转移")
+
+	var result *GameProfileTransferResult
+	var bizErr *xError.Error
+	// 档案已不属于发起方时需提交"已取消"状态，而不是回滚
+	var staleTransfer bool
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询转移请求
+		transfer, found, xErr := t.transfer.GetByID(ctx, tx, transferID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found || transfer.ToUserID != userID {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "转移请求不存在", true)
+			return bizErr
+		}
+		if !transfer.IsPending(now) {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "转移请求已处理或已过期", true)
+			return bizErr
+		}
+
+		// 2. 行锁查询档案
+		profile, found, xErr := t.profile.GetByIDAndUserID(ctx, tx, transfer.ProfileID, transfer.FromUserID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			if xErr = t.transfer.UpdateStatus(ctx, tx, transfer.ID, entity.GameProfileTransferStatusCancelled, now); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			staleTransfer = true
+			return nil
+		}
+		if profile.IsLocked() {
+			bizErr = xError.NewError(ctx, xError.OperationDenied, "游戏档案已被管理员锁定，暂无法接受转移", true)
+			return bizErr
+		}
+
+		// 3. 按用户 ID 升序锁定双方配额，避免相向转移时死锁
+		firstUserID, secondUserID := transfer.FromUserID, transfer.ToUserID
+		if secondUserID < firstUserID {
+			firstUserID, secondUserID = secondUserID, firstUserID
+		}
+		quotas := make(map[xSnowflake.SnowflakeID]*entity.GameProfileQuota, 2)
+		for _, lockUserID := range []xSnowflake.SnowflakeID{firstUserID, secondUserID} {
+			quota, found, xErr := t.quota.GetByUserID(ctx, tx, lockUserID, true)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if !found {
+				bizErr = xError.NewError(ctx, xError.ResourceNotFound, "用户游戏档案配额不存在", true)
+				return bizErr
+			}
+			quotas[lockUserID] = quota
+		}
+		toQuota := quotas[transfer.ToUserID]
+		fromQuota := quotas[transfer.FromUserID]
+		if toQuota.Used >= toQuota.Total {
+			bizErr = xError.NewError(ctx, xError.ResourceExhausted, "游戏档案配额不足，无法接收该档案", true)
+			return bizErr
+		}
+
+		// 4. 接收方未拥有的资源需卸下
+		skinLibraryID := profile.SkinLibraryID
+		capeLibraryID := profile.CapeLibraryID
+		var skinUnbound, capeUnbound bool
+		if skinLibraryID != nil {
+			hasSkin, xErr := t.userSkinLib.ExistsEquippableByUserAndSkin(ctx, tx, transfer.ToUserID, *skinLibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if !hasSkin {
+				skinLibraryID = nil
+				skinUnbound = true
+			}
+		}
+		if capeLibraryID != nil {
+			hasCape, xErr := t.userCapeLib.ExistsEquippableByUserAndCape(ctx, tx, transfer.ToUserID, *capeLibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if !hasCape {
+				capeLibraryID = nil
+				capeUnbound = true
+			}
+		}
+
+		// 5. 吊销发起方绑定令牌
+		revokedTokens, xErr := t.token.InvalidateByBoundProfileID(ctx, tx, profile.ID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 6. 转移持有者
+		xErr = t.profile.UpdateOwnerAndEquipment(ctx, tx, profile.ID, transfer.ToUserID, skinLibraryID, capeLibraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 7. 双方配额与日志（发起方历史数据已用数为 0 时仅记录日志，不再扣减）
+		xErr = t.quota.UpdateUsed(ctx, tx, toQuota.ID, toQuota.Used+1)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		inRemark := fmt.Sprintf("接收用户 %s 转移的游戏档案 %s", transfer.FromUserID.String(), profile.Name)
+		_, xErr = t.quotaLog.CreateWithIdempotencyKey(
+			ctx, tx, transfer.ToUserID,
+			entityType.ObTypeTransferProfileIn, 1,
+			toQuota.Used, toQuota.Total,
+			xUtil.Ptr(profile.ID),
+			&inRemark,
+			fmt.Sprintf("%s:%s", entityType.ObTypeTransferProfileIn.String(), transfer.ID.String()),
+		)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		refund := int32(1)
+		if fromQuota.Used < refund {
+			refund = 0
+		}
+		if refund > 0 {
+			xErr = t.quota.UpdateUsed(ctx, tx, fromQuota.ID, fromQuota.Used-refund)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+		outRemark := fmt.Sprintf("游戏档案 %s 转移给用户 %s", profile.Name, transfer.ToUserID.String())
+		_, xErr = t.quotaLog.CreateWithIdempotencyKey(
+			ctx, tx, transfer.FromUserID,
+			entityType.ObTypeTransferProfileOut, refund,
+			fromQuota.Used, fromQuota.Total,
+			xUtil.Ptr(profile.ID),
+			&outRemark,
+			fmt.Sprintf("%s:%s", entityType.ObTypeTransferProfileOut.String(), transfer.ID.String()),
+		)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 8. 请求置为已接受
+		xErr = t.transfer.UpdateStatus(ctx, tx, transfer.ID, entity.GameProfileTransferStatusAccepted, now)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		transfer.Status = entity.GameProfileTransferStatusAccepted
+		transfer.RespondedAt = &now
+
+		profile.UserID = transfer.ToUserID
+		profile.SkinLibraryID = skinLibraryID
+		profile.CapeLibraryID = capeLibraryID
+		result = &GameProfileTransferResult{
+			Transfer:      transfer,
+			Profile:       profile,
+			SkinUnbound:   skinUnbound,
+			CapeUnbound:   capeUnbound,
+			RevokedTokens: revokedTokens,
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "接受游戏档案转移失败", true, err)
+	}
+	if staleTransfer {
+		return nil, xError.NewError(ctx, xError.DataConflict, "游戏档案已不属于发起方，转移请求已取消", true)
+	}
+	return result, nil
+}
+
+// CloseTransfer 在事务内关闭待接收的转移请求。
+//
+// status 为 GameProfileTransferStatusRejected 时仅接收方可操作，
+// 为 GameProfileTransferStatusCancelled 时仅发起方可操作。不涉及配额变更。
+func (t *GameProfileTxnRepo) CloseTransfer(
+	ctx context.Context,
+	transferID xSnowflake.SnowflakeID,
+	userID xSnowflake.SnowflakeID,
+	status entity.GameProfileTransferStatus,
+	now time.Time,
+) (*entity.GameProfileTransfer, *xError.Error) {
+	t.log.Info(ctx, "CloseTransfer - 事务内关闭游戏档案转移请求")
+
+	var closedTransfer *entity.GameProfileTransfer
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		transfer, found, xErr := t.transfer.GetByID(ctx, tx, transferID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "转移请求不存在", true)
+			return bizErr
+		}
+		actorID := transfer.FromUserID
+		if status == entity.GameProfileTransferStatusRejected {
+			actorID = transfer.ToUserID
+		}
+		if actorID != userID {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "转移请求不存在", true)
+			return bizErr
+		}
+		if !transfer.IsPending(now) {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "转移请求已处理或已过期", true)
+			return bizErr
+		}
+
+		xErr = t.transfer.UpdateStatus(ctx, tx, transfer.ID, status, now)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		transfer.Status = status
+		transfer.RespondedAt = &now
+		closedTransfer = transfer
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "关闭游戏档案转移请求失败", true, err)
+	}
+	return closedTransfer, nil
+}
@@ -2,6 +2,7 @@ package txn
 
 import (
 	"context"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
@@ -22,13 +23,23 @@ import (
 // 注意：对象存储（Bucket）上传不在事务范围内，由 Logic 层在上传成功后
 // 将文件 ID 填入实体再调用本仓储的方法，避免长事务占用数据库连接。
 type LibraryTxnRepo struct {
-	db           *gorm.DB                       // GORM 数据库实例（用于开启事务）
-	log          *xLog.LogNamedLogger            // 日志实例
-	skinRepo     *repository.SkinLibraryRepo     // 皮肤库仓储
-	capeRepo     *repository.CapeLibraryRepo     // 披风库仓储
-	quotaRepo    *repository.LibraryQuotaRepo    // 资源库配额仓储
-	userSkinRepo *repository.UserSkinLibraryRepo // 用户皮肤关联仓储
-	userCapeRepo *repository.UserCapeLibraryRepo // 用户披风关联仓储
+	db           *gorm.DB                              // GORM 数据库实例（用于开启事务）
+	log          *xLog.LogNamedLogger                  // 日志实例
+	skinRepo     *repository.SkinLibraryRepo           // 皮肤库仓储
+	capeRepo     *repository.CapeLibraryRepo           // 披风库仓储
+	quotaRepo    *repository.LibraryQuotaRepo          // 资源库配额仓储
+	userSkinRepo *repository.UserSkinLibraryRepo       // 用户皮肤关联仓储
+	userCapeRepo *repository.UserCapeLibraryRepo       // 用户披风关联仓储
+	likeRepo     *repository.LibraryLikeRepo           // 资源库点赞仓储
+	tagRepo      *repository.LibraryTagRepo            // 资源库标签仓储
+	profileRepo  *repository.GameProfileRepo           // 游戏档案仓储（收藏失效时卸下装备）
+	reportRepo   *repository.LibraryReportRepo         // 资源库举报仓储
+	versionRepo  *repository.LibraryTextureVersionRepo // 资源库纹理版本仓储
+	campaignRepo *repository.LibraryCampaignRepo       // 资源发放活动仓储
+	redeemRepo   *repository.RedeemCodeRepo            // 兑换码仓储
+	profileQuota *repository.GameProfileQuotaRepo      // 游戏档案配额仓储（兑换码增加档案额度）
+	quotaLogRepo *repository.LibraryQuotaLogRepo       // 资源库配额日志仓储
+	shareRepo    *repository.LibraryShareRepo          // 资源私有分享仓储
 }
 
 // NewLibraryTxnRepo 初始化并返回 LibraryTxnRepo 实例。
@@ -39,6 +50,16 @@ func NewLibraryTxnRepo(
 	quotaRepo *repository.LibraryQuotaRepo,
 	userSkinRepo *repository.UserSkinLibraryRepo,
 	userCapeRepo *repository.UserCapeLibraryRepo,
+	likeRepo *repository.LibraryLikeRepo,
+	tagRepo *repository.LibraryTagRepo,
+	profileRepo *repository.GameProfileRepo,
+	reportRepo *repository.LibraryReportRepo,
+	versionRepo *repository.LibraryTextureVersionRepo,
+	campaignRepo *repository.LibraryCampaignRepo,
+	redeemRepo *repository.RedeemCodeRepo,
+	profileQuota *repository.GameProfileQuotaRepo,
+	quotaLogRepo *repository.LibraryQuotaLogRepo,
+	shareRepo *repository.LibraryShareRepo,
 ) *LibraryTxnRepo {
 	return &LibraryTxnRepo{
 		db:           db,
@@ -48,6 +69,16 @@ func NewLibraryTxnRepo(
 		quotaRepo:    quotaRepo,
 		userSkinRepo: userSkinRepo,
 		userCapeRepo: userCapeRepo,
+		likeRepo:     likeRepo,
+		tagRepo:      tagRepo,
+		profileRepo:  profileRepo,
+		reportRepo:   reportRepo,
+		versionRepo:  versionRepo,
+		campaignRepo: campaignRepo,
+		redeemRepo:   redeemRepo,
+		profileQuota: profileQuota,
+		quotaLogRepo: quotaLogRepo,
+		shareRepo:    shareRepo,
 	}
 }
 
@@ -135,8 +166,8 @@ func (t *LibraryTxnRepo) CreateSkinWithQuota(
 
 		// 5. 创建用户皮肤关联记录（Normal 类型）
 		_, bizErr = t.userSkinRepo.Create(ctx, tx, &entity.UserSkinLibrary{
-			UserID:        *skin.UserID,
-			SkinLibraryID: createdSkin.ID,
+			UserID:         *skin.UserID,
+			SkinLibraryID:  createdSkin.ID,
 			AssignmentType: entityType.AssignmentTypeNormal,
 		})
 		if bizErr != nil {
@@ -144,11 +175,7 @@ func (t *LibraryTxnRepo) CreateSkinWithQuota(
 		}
 
 		// 6. 根据 IsPublic 更新对应配额
-		if skin.IsPublic {
-			bizErr = t.quotaRepo.UpdateSkinsPublicUsed(ctx, tx, quota.ID, quota.SkinsPublicUsed+1)
-		} else {
-			bizErr = t.quotaRepo.UpdateSkinsPrivateUsed(ctx, tx, quota.ID, quota.SkinsPrivateUsed+1)
-		}
+		bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.QuotaBucketFor(entityType.LibraryKindSkin, skin.IsPublic), 1, entityType.LibraryQuotaOpCreate, &createdSkin.ID)
 		if bizErr != nil {
 			return bizErr
 		}
@@ -218,11 +245,11 @@ func (t *LibraryTxnRepo) UpdateSkinWithQuota(
 					bizErr = xError.NewError(ctx, xError.ResourceExhausted, "公开皮肤配额不足", true)
 					return bizErr
 				}
-				bizErr = t.quotaRepo.UpdateSkinsPublicUsed(ctx, tx, quota.ID, quota.SkinsPublicUsed+1)
+				bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketSkinsPublic, 1, entityType.LibraryQuotaOpVisibility, &skinID)
 				if bizErr != nil {
 					return bizErr
 				}
-				bizErr = t.quotaRepo.UpdateSkinsPrivateUsed(ctx, tx, quota.ID, quota.SkinsPrivateUsed-1)
+				bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketSkinsPrivate, -1, entityType.LibraryQuotaOpVisibility, &skinID)
 				if bizErr != nil {
 					return bizErr
 				}
@@ -232,23 +259,51 @@ func (t *LibraryTxnRepo) UpdateSkinWithQuota(
 					bizErr = xError.NewError(ctx, xError.ResourceExhausted, "私有皮肤配额不足", true)
 					return bizErr
 				}
-				bizErr = t.quotaRepo.UpdateSkinsPrivateUsed(ctx, tx, quota.ID, quota.SkinsPrivateUsed+1)
+				bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketSkinsPrivate, 1, entityType.LibraryQuotaOpVisibility, &skinID)
 				if bizErr != nil {
 					return bizErr
 				}
-				bizErr = t.quotaRepo.UpdateSkinsPublicUsed(ctx, tx, quota.ID, quota.SkinsPublicUsed-1)
+				bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketSkinsPublic, -1, entityType.LibraryQuotaOpVisibility, &skinID)
 				if bizErr != nil {
 					return bizErr
 				}
 			}
 		}
 
+		// 公开转私有时卸下收藏者档案上的装备
+		if oldIsPublic && !newIsPublic {
+			bizErr = t.profileRepo.ClearSkinLibraryIDForCollectors(ctx, tx, skinID)
+			if bizErr != nil {
+				return bizErr
+			}
+		}
+
 		// 更新皮肤记录
 		updatedSkin, bizErr = t.skinRepo.UpdateNameAndIsPublic(ctx, tx, skinID, newName, newIsPublic)
 		if bizErr != nil {
 			return bizErr
 		}
 
+		// 转为私有后无需审核，暂存的待审核纹理直接生效
+		if !newIsPublic && updatedSkin.PendingTextureVersion != nil {
+			bizErr = t.promoteSkinPendingTexture(ctx, tx, updatedSkin)
+			if bizErr != nil {
+				return bizErr
+			}
+		}
+
+		// 私有转公开时进入待审核，审核通过前对其他玩家不可见
+		if !oldIsPublic && newIsPublic {
+			bizErr = t.skinRepo.UpdateReviewStatus(ctx, tx, skinID, entityType.ReviewStatusPending, true, nil, nil)
+			if bizErr != nil {
+				return bizErr
+			}
+			updatedSkin.ReviewStatus = entityType.ReviewStatusPending
+			updatedSkin.ReviewReason = nil
+			updatedSkin.ReviewedBy = nil
+			updatedSkin.ReviewedAt = nil
+		}
+
 		return nil
 	})
 	if bizErr != nil {
@@ -310,11 +365,7 @@ func (t *LibraryTxnRepo) DeleteSkinWithQuota(
 				bizErr = xError.NewError(ctx, xError.ResourceNotFound, "用户资源库配额不存在", true)
 				return bizErr
 			}
-			if skinRec.IsPublic {
-				bizErr = t.quotaRepo.UpdateSkinsPublicUsed(ctx, tx, quota.ID, quota.SkinsPublicUsed-1)
-			} else {
-				bizErr = t.quotaRepo.UpdateSkinsPrivateUsed(ctx, tx, quota.ID, quota.SkinsPrivateUsed-1)
-			}
+			bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.QuotaBucketFor(entityType.LibraryKindSkin, skinRec.IsPublic), -1, entityType.LibraryQuotaOpDelete, &skinID)
 			if bizErr != nil {
 				return bizErr
 			}
@@ -325,6 +376,25 @@ func (t *LibraryTxnRepo) DeleteSkinWithQuota(
 				return bizErr
 			}
 
+			// 上传者删除时收藏关联随之失效：卸下收藏者装备 → 删除收藏关联 → 扣减收藏数
+			if skinRec.UserID != nil && *skinRec.UserID == userID {
+				bizErr = t.profileRepo.ClearSkinLibraryIDForCollectors(ctx, tx, skinID)
+				if bizErr != nil {
+					return bizErr
+				}
+				removed, xErr := t.userSkinRepo.DeleteCollectsBySkin(ctx, tx, skinID)
+				if xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+				if removed > 0 {
+					bizErr = t.skinRepo.IncrementCollectCount(ctx, tx, skinID, -removed)
+					if bizErr != nil {
+						return bizErr
+					}
+				}
+			}
+
 			// 4. 检查引用计数，零引用且为当前用户创建的资源才删除 SkinLibrary
 			refCount, xErr := t.userSkinRepo.CountReferences(ctx, tx, skinID)
 			if xErr != nil {
@@ -338,7 +408,27 @@ func (t *LibraryTxnRepo) DeleteSkinWithQuota(
 				}
 			}
 		} else {
-			// 非 Normal 类型（Gift/Admin）：仅删除关联记录，不操作配额
+			// 非 Normal 类型（Gift/Admin/Collect/Share）：仅删除关联记录，不操作配额
+
+			// 收藏关联删除时卸下该用户档案上的装备并扣减收藏数
+			if association.AssignmentType == entityType.AssignmentTypeCollect {
+				bizErr = t.profileRepo.ClearSkinLibraryIDByUser(ctx, tx, userID, skinID)
+				if bizErr != nil {
+					return bizErr
+				}
+				bizErr = t.skinRepo.IncrementCollectCount(ctx, tx, skinID, -1)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
+
+			// 分享关联指向他人的私有资源，删除后同样卸下装备
+			if association.AssignmentType == entityType.AssignmentTypeShare {
+				bizErr = t.profileRepo.ClearSkinLibraryIDByUser(ctx, tx, userID, skinID)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
 
 			// 3. 删除用户皮肤关联记录
 			bizErr = t.userSkinRepo.DeleteByUserAndSkin(ctx, tx, userID, skinID)
@@ -462,8 +552,8 @@ func (t *LibraryTxnRepo) CreateCapeWithQuota(
 
 		// 5. 创建用户披风关联记录（Normal 类型）
 		_, bizErr = t.userCapeRepo.Create(ctx, tx, &entity.UserCapeLibrary{
-			UserID:        *cape.UserID,
-			CapeLibraryID: createdCape.ID,
+			UserID:         *cape.UserID,
+			CapeLibraryID:  createdCape.ID,
 			AssignmentType: entityType.AssignmentTypeNormal,
 		})
 		if bizErr != nil {
@@ -471,11 +561,7 @@ func (t *LibraryTxnRepo) CreateCapeWithQuota(
 		}
 
 		// 6. 根据 IsPublic 更新对应配额
-		if cape.IsPublic {
-			bizErr = t.quotaRepo.UpdateCapesPublicUsed(ctx, tx, quota.ID, quota.CapesPublicUsed+1)
-		} else {
-			bizErr = t.quotaRepo.UpdateCapesPrivateUsed(ctx, tx, quota.ID, quota.CapesPrivateUsed+1)
-		}
+		bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.QuotaBucketFor(entityType.LibraryKindCape, cape.IsPublic), 1, entityType.LibraryQuotaOpCreate, &createdCape.ID)
 		if bizErr != nil {
 			return bizErr
 		}
@@ -545,11 +631,11 @@ func (t *LibraryTxnRepo) UpdateCapeWithQuota(
 					bizErr = xError.NewError(ctx, xError.ResourceExhausted, "公开披风配额不足", true)
 					return bizErr
 				}
-				bizErr = t.quotaRepo.UpdateCapesPublicUsed(ctx, tx, quota.ID, quota.CapesPublicUsed+1)
+				bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketCapesPublic, 1, entityType.LibraryQuotaOpVisibility, &capeID)
 				if bizErr != nil {
 					return bizErr
 				}
-				bizErr = t.quotaRepo.UpdateCapesPrivateUsed(ctx, tx, quota.ID, quota.CapesPrivateUsed-1)
+				bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketCapesPrivate, -1, entityType.LibraryQuotaOpVisibility, &capeID)
 				if bizErr != nil {
 					return bizErr
 				}
@@ -559,23 +645,51 @@ func (t *LibraryTxnRepo) UpdateCapeWithQuota(
 					bizErr = xError.NewError(ctx, xError.ResourceExhausted, "私有披风配额不足", true)
 					return bizErr
 				}
-				bizErr = t.quotaRepo.UpdateCapesPrivateUsed(ctx, tx, quota.ID, quota.CapesPrivateUsed+1)
+				bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketCapesPrivate, 1, entityType.LibraryQuotaOpVisibility, &capeID)
 				if bizErr != nil {
 					return bizErr
 				}
-				bizErr = t.quotaRepo.UpdateCapesPublicUsed(ctx, tx, quota.ID, quota.CapesPublicUsed-1)
+				bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketCapesPublic, -1, entityType.LibraryQuotaOpVisibility, &capeID)
 				if bizErr != nil {
 					return bizErr
 				}
 			}
 		}
 
+		// 公开转私有时卸下收藏者档案上的装备
+		if oldIsPublic && !newIsPublic {
+			bizErr = t.profileRepo.ClearCapeLibraryIDForCollectors(ctx, tx, capeID)
+			if bizErr != nil {
+				return bizErr
+			}
+		}
+
 		// 更新披风记录
 		updatedCape, bizErr = t.capeRepo.UpdateNameAndIsPublic(ctx, tx, capeID, newName, newIsPublic)
 		if bizErr != nil {
 			return bizErr
 		}
 
+		// 转为私有后无需审核，暂存的待审核纹理直接生效
+		if !newIsPublic && updatedCape.PendingTextureVersion != nil {
+			bizErr = t.promoteCapePendingTexture(ctx, tx, updatedCape)
+			if bizErr != nil {
+				return bizErr
+			}
+		}
+
+		// 私有转公开时进入待审核，审核通过前对其他玩家不可见
+		if !oldIsPublic && newIsPublic {
+			bizErr = t.capeRepo.UpdateReviewStatus(ctx, tx, capeID, entityType.ReviewStatusPending, true, nil, nil)
+			if bizErr != nil {
+				return bizErr
+			}
+			updatedCape.ReviewStatus = entityType.ReviewStatusPending
+			updatedCape.ReviewReason = nil
+			updatedCape.ReviewedBy = nil
+			updatedCape.ReviewedAt = nil
+		}
+
 		return nil
 	})
 	if bizErr != nil {
@@ -637,11 +751,7 @@ func (t *LibraryTxnRepo) DeleteCapeWithQuota(
 				bizErr = xError.NewError(ctx, xError.ResourceNotFound, "用户资源库配额不存在", true)
 				return bizErr
 			}
-			if capeRec.IsPublic {
-				bizErr = t.quotaRepo.UpdateCapesPublicUsed(ctx, tx, quota.ID, quota.CapesPublicUsed-1)
-			} else {
-				bizErr = t.quotaRepo.UpdateCapesPrivateUsed(ctx, tx, quota.ID, quota.CapesPrivateUsed-1)
-			}
+			bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.QuotaBucketFor(entityType.LibraryKindCape, capeRec.IsPublic), -1, entityType.LibraryQuotaOpDelete, &capeID)
 			if bizErr != nil {
 				return bizErr
 			}
@@ -652,6 +762,25 @@ func (t *LibraryTxnRepo) DeleteCapeWithQuota(
 				return bizErr
 			}
 
+			// 上传者删除时收藏关联随之失效：卸下收藏者装备 → 删除收藏关联 → 扣减收藏数
+			if capeRec.UserID != nil && *capeRec.UserID == userID {
+				bizErr = t.profileRepo.ClearCapeLibraryIDForCollectors(ctx, tx, capeID)
+				if bizErr != nil {
+					return bizErr
+				}
+				removed, xErr := t.userCapeRepo.DeleteCollectsByCape(ctx, tx, capeID)
+				if xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+				if removed > 0 {
+					bizErr = t.capeRepo.IncrementCollectCount(ctx, tx, capeID, -removed)
+					if bizErr != nil {
+						return bizErr
+					}
+				}
+			}
+
 			// 4. 检查引用计数，零引用且为当前用户创建的资源才删除 CapeLibrary
 			refCount, xErr := t.userCapeRepo.CountReferences(ctx, tx, capeID)
 			if xErr != nil {
@@ -665,7 +794,27 @@ func (t *LibraryTxnRepo) DeleteCapeWithQuota(
 				}
 			}
 		} else {
-			// 非 Normal 类型（Gift/Admin）：仅删除关联记录，不操作配额
+			// 非 Normal 类型（Gift/Admin/Collect/Share）：仅删除关联记录，不操作配额
+
+			// 收藏关联删除时卸下该用户档案上的装备并扣减收藏数
+			if association.AssignmentType == entityType.AssignmentTypeCollect {
+				bizErr = t.profileRepo.ClearCapeLibraryIDByUser(ctx, tx, userID, capeID)
+				if bizErr != nil {
+					return bizErr
+				}
+				bizErr = t.capeRepo.IncrementCollectCount(ctx, tx, capeID, -1)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
+
+			// 分享关联指向他人的私有资源，删除后同样卸下装备
+			if association.AssignmentType == entityType.AssignmentTypeShare {
+				bizErr = t.profileRepo.ClearCapeLibraryIDByUser(ctx, tx, userID, capeID)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
 
 			// 3. 删除用户披风关联记录
 			bizErr = t.userCapeRepo.DeleteByUserAndCape(ctx, tx, userID, capeID)
@@ -708,12 +857,13 @@ func (t *LibraryTxnRepo) DeleteCapeWithQuota(
 // GiftSkinToUser 在事务内完成管理员向用户赠送皮肤。
 //
 // 事务序列：校验 SkinLibrary 存在 → 校验不重复关联 → 创建 UserSkinLibrary(Gift)。
-// 不检查配额，不修改 Used。
+// 不检查配额，不修改 Used。expiresAt 非空时为限时赠送，到期后由定时任务收回。
 func (t *LibraryTxnRepo) GiftSkinToUser(
 	ctx context.Context,
 	targetUserID xSnowflake.SnowflakeID,
 	skinLibraryID xSnowflake.SnowflakeID,
 	assignmentType entityType.AssignmentType,
+	expiresAt *time.Time,
 ) (*entity.UserSkinLibrary, *xError.Error) {
 	t.log.Info(ctx, "GiftSkinToUser - 事务内赠送皮肤")
 
@@ -721,8 +871,8 @@ func (t *LibraryTxnRepo) GiftSkinToUser(
 	var bizErr *xError.Error
 
 	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 1. 校验 SkinLibrary 存在
-		_, found, xErr := t.skinRepo.GetByID(ctx, tx, skinLibraryID)
+		// 1. 校验 SkinLibrary 存在且未停用
+		skinRec, found, xErr := t.skinRepo.GetByID(ctx, tx, skinLibraryID)
 		if xErr != nil {
 			bizErr = xErr
 			return xErr
@@ -731,13 +881,24 @@ func (t *LibraryTxnRepo) GiftSkinToUser(
 			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "皮肤资源不存在", true)
 			return bizErr
 		}
+		if skinRec.IsRetired {
+			bizErr = xError.NewError(ctx, xError.PermissionDenied, "已停用的系统皮肤不能再发放", true)
+			return bizErr
+		}
 
-		// 2. 校验不重复关联
-		exists, xErr := t.userSkinRepo.ExistsByUserAndSkin(ctx, tx, targetUserID, skinLibraryID)
+		// 2. 校验不重复关联（已到期但尚未被定时任务清理的限时关联先行删除）
+		existing, exists, xErr := t.userSkinRepo.GetByUserAndSkin(ctx, tx, targetUserID, skinLibraryID)
 		if xErr != nil {
 			bizErr = xErr
 			return xErr
 		}
+		if exists && existing.ExpiresAt != nil && !existing.ExpiresAt.After(time.Now()) {
+			if _, xErr := t.userSkinRepo.DeleteExpiredByID(ctx, tx, existing.ID, time.Now()); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			exists = false
+		}
 		if exists {
 			bizErr = xError.NewError(ctx, xError.DataConflict, "该用户已拥有此皮肤", true)
 			return bizErr
@@ -748,12 +909,12 @@ func (t *LibraryTxnRepo) GiftSkinToUser(
 			UserID:         targetUserID,
 			SkinLibraryID:  skinLibraryID,
 			AssignmentType: assignmentType,
+			ExpiresAt:      expiresAt,
 		})
 		if bizErr != nil {
 			return bizErr
 		}
 
-
 		return nil
 	})
 	if bizErr != nil {
@@ -841,6 +1002,7 @@ func (t *LibraryTxnRepo) GiftCapeToUser(
 	targetUserID xSnowflake.SnowflakeID,
 	capeLibraryID xSnowflake.SnowflakeID,
 	assignmentType entityType.AssignmentType,
+	expiresAt *time.Time,
 ) (*entity.UserCapeLibrary, *xError.Error) {
 	t.log.Info(ctx, "GiftCapeToUser - 事务内赠送披风")
 
@@ -848,8 +1010,8 @@ func (t *LibraryTxnRepo) GiftCapeToUser(
 	var bizErr *xError.Error
 
 	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 1. 校验 CapeLibrary 存在
-		_, found, xErr := t.capeRepo.GetByID(ctx, tx, capeLibraryID)
+		// 1. 校验 CapeLibrary 存在且未停用
+		capeRec, found, xErr := t.capeRepo.GetByID(ctx, tx, capeLibraryID)
 		if xErr != nil {
 			bizErr = xErr
 			return xErr
@@ -858,13 +1020,24 @@ func (t *LibraryTxnRepo) GiftCapeToUser(
 			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "披风资源不存在", true)
 			return bizErr
 		}
+		if capeRec.IsRetired {
+			bizErr = xError.NewError(ctx, xError.PermissionDenied, "已停用的系统披风不能再发放", true)
+			return bizErr
+		}
 
-		// 2. 校验不重复关联
-		exists, xErr := t.userCapeRepo.ExistsByUserAndCape(ctx, tx, targetUserID, capeLibraryID)
+		// 2. 校验不重复关联（已到期但尚未被定时任务清理的限时关联先行删除）
+		existing, exists, xErr := t.userCapeRepo.GetByUserAndCape(ctx, tx, targetUserID, capeLibraryID)
 		if xErr != nil {
 			bizErr = xErr
 			return xErr
 		}
+		if exists && existing.ExpiresAt != nil && !existing.ExpiresAt.After(time.Now()) {
+			if _, xErr := t.userCapeRepo.DeleteExpiredByID(ctx, tx, existing.ID, time.Now()); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			exists = false
+		}
 		if exists {
 			bizErr = xError.NewError(ctx, xError.DataConflict, "该用户已拥有此披风", true)
 			return bizErr
@@ -875,12 +1048,12 @@ func (t *LibraryTxnRepo) GiftCapeToUser(
 			UserID:         targetUserID,
 			CapeLibraryID:  capeLibraryID,
 			AssignmentType: assignmentType,
+			ExpiresAt:      expiresAt,
 		})
 		if bizErr != nil {
 			return bizErr
 		}
 
-
 		return nil
 	})
 	if bizErr != nil {
@@ -961,10 +1134,12 @@ func (t *LibraryTxnRepo) RevokeCapeFromUser(
 
 // RecalculateQuota 在事务内重新计算用户配额的 Used 字段。
 //
-// 事务序列：锁配额 FOR UPDATE → 用 CountNormalPublicByUser/CountNormalPrivateByUser 重算 4 个 Used → 调用 UpdateAllUsed。
+// 事务序列：锁配额 FOR UPDATE → 用 CountNormalPublicByUser/CountNormalPrivateByUser 重算 4 个 Used → 调用 UpdateAllUsed
+// → 为发生偏差的分项写入配额日志。operatorID 为空表示由系统触发。
 func (t *LibraryTxnRepo) RecalculateQuota(
 	ctx context.Context,
 	userID xSnowflake.SnowflakeID,
+	operatorID *xSnowflake.SnowflakeID,
 ) *xError.Error {
 	t.log.Info(ctx, "RecalculateQuota - 事务内重算配额")
 
@@ -1012,6 +1187,17 @@ func (t *LibraryTxnRepo) RecalculateQuota(
 			return bizErr
 		}
 
+		// 5. 为发生偏差的分项写入配额日志
+		before := *quota
+		quota.SkinsPublicUsed = int32(skinsPub)
+		quota.SkinsPrivateUsed = int32(skinsPri)
+		quota.CapesPublicUsed = int32(capesPub)
+		quota.CapesPrivateUsed = int32(capesPri)
+		bizErr = recordLibraryQuotaDiff(ctx, tx, t.quotaLogRepo, &before, quota, entityType.LibraryQuotaOpRecalculate, libraryQuotaVersion(&before), nil, operatorID, nil)
+		if bizErr != nil {
+			return bizErr
+		}
+
 		return nil
 	})
 	if bizErr != nil {
@@ -1022,3 +1208,656 @@ func (t *LibraryTxnRepo) RecalculateQuota(
 	}
 	return nil
 }
+
+// LikeLibrary 在事务内完成用户对公开皮肤/披风的点赞。
+//
+// 事务序列：行锁校验资源存在且公开 → 校验未重复点赞 → 创建 LibraryLike → 资源 LikeCount +1。
+func (t *LibraryTxnRepo) LikeLibrary(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	kind entityType.LibraryKind,
+	libraryID xSnowflake.SnowflakeID,
+) *xError.Error {
+	t.log.Info(ctx, "LikeLibrary - 事务内点赞资源")
+
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验资源存在且公开
+		bizErr = t.checkPublicLibrary(ctx, tx, kind, libraryID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 2. 校验未重复点赞
+		exists, xErr := t.likeRepo.ExistsByUserAndTarget(ctx, tx, userID, kind, libraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if exists {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "已点赞该资源", true)
+			return bizErr
+		}
+
+		// 3. 创建点赞记录
+		_, bizErr = t.likeRepo.Create(ctx, tx, &entity.LibraryLike{
+			UserID:    userID,
+			Kind:      kind,
+			LibraryID: libraryID,
+		})
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 4. 点赞数 +1
+		bizErr = t.incrementLikeCount(ctx, tx, kind, libraryID, 1)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return bizErr
+	}
+	if err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "点赞失败", true, err)
+	}
+	return nil
+}
+
+// UnlikeLibrary 在事务内取消用户对皮肤/披风的点赞。
+//
+// 事务序列：删除 LibraryLike → 实际删除时资源 LikeCount -1。
+// 不校验资源公开状态，允许对已转为私有的资源取消点赞。
+func (t *LibraryTxnRepo) UnlikeLibrary(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	kind entityType.LibraryKind,
+	libraryID xSnowflake.SnowflakeID,
+) *xError.Error {
+	t.log.Info(ctx, "UnlikeLibrary - 事务内取消点赞")
+
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 删除点赞记录
+		deleted, xErr := t.likeRepo.DeleteByUserAndTarget(ctx, tx, userID, kind, libraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !deleted {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "尚未点赞该资源", true)
+			return bizErr
+		}
+
+		// 2. 点赞数 -1
+		bizErr = t.incrementLikeCount(ctx, tx, kind, libraryID, -1)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return bizErr
+	}
+	if err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "取消点赞失败", true, err)
+	}
+	return nil
+}
+
+// SetLibraryTags 在事务内整体替换皮肤/披风的标签。
+//
+// 事务序列：查询或创建标签 → 替换资源与标签的关联。
+// 调用方需保证 names 已规范化且去重，并已完成资源归属校验。
+func (t *LibraryTxnRepo) SetLibraryTags(
+	ctx context.Context,
+	kind entityType.LibraryKind,
+	libraryID xSnowflake.SnowflakeID,
+	names []string,
+) *xError.Error {
+	t.log.Info(ctx, "SetLibraryTags - 事务内替换资源标签")
+
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 查询或创建标签
+		tags, xErr := t.tagRepo.FindOrCreateByNames(ctx, tx, names)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 2. 替换关联
+		switch kind {
+		case entityType.LibraryKindSkin:
+			bizErr = t.skinRepo.ReplaceTags(ctx, tx, libraryID, tags)
+		case entityType.LibraryKindCape:
+			bizErr = t.capeRepo.ReplaceTags(ctx, tx, libraryID, tags)
+		default:
+			bizErr = xError.NewError(ctx, xError.ParameterError, "无效的资源种类", true)
+		}
+		if bizErr != nil {
+			return bizErr
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return bizErr
+	}
+	if err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "设置标签失败", true, err)
+	}
+	return nil
+}
+
+// CollectSkin 在事务内完成用户收藏公开皮肤。
+//
+// 事务序列：行锁校验皮肤存在且公开 → 校验不重复关联 → 创建 UserSkinLibrary(Collect) → 皮肤 CollectCount +1。
+// 收藏类型不计入配额，不修改 Used。
+func (t *LibraryTxnRepo) CollectSkin(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	skinLibraryID xSnowflake.SnowflakeID,
+) (*entity.UserSkinLibrary, *xError.Error) {
+	t.log.Info(ctx, "CollectSkin - 事务内收藏皮肤")
+
+	var createdAssoc *entity.UserSkinLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验皮肤存在且公开
+		bizErr = t.checkPublicLibrary(ctx, tx, entityType.LibraryKindSkin, skinLibraryID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 2. 校验不重复关联
+		exists, xErr := t.userSkinRepo.ExistsByUserAndSkin(ctx, tx, userID, skinLibraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if exists {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "您已拥有此皮肤", true)
+			return bizErr
+		}
+
+		// 3. 创建 UserSkinLibrary
+		createdAssoc, bizErr = t.userSkinRepo.Create(ctx, tx, &entity.UserSkinLibrary{
+			UserID:         userID,
+			SkinLibraryID:  skinLibraryID,
+			AssignmentType: entityType.AssignmentTypeCollect,
+		})
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 4. 收藏数 +1
+		bizErr = t.skinRepo.IncrementCollectCount(ctx, tx, skinLibraryID, 1)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "收藏皮肤失败", true, err)
+	}
+	return createdAssoc, nil
+}
+
+// UncollectSkin 在事务内取消用户对皮肤的收藏。
+//
+// 事务序列：查找 UserSkinLibrary → 校验 Type = Collect → 卸下该用户档案上的装备 → 删除关联 → 皮肤 CollectCount -1。
+// 不校验皮肤公开状态，允许取消收藏已转为私有的皮肤。
+func (t *LibraryTxnRepo) UncollectSkin(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	skinLibraryID xSnowflake.SnowflakeID,
+) *xError.Error {
+	t.log.Info(ctx, "UncollectSkin - 事务内取消收藏皮肤")
+
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 查找关联记录
+		association, found, xErr := t.userSkinRepo.GetByUserAndSkin(ctx, tx, userID, skinLibraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "尚未收藏该皮肤", true)
+			return bizErr
+		}
+
+		// 2. 校验 Type = Collect
+		if association.AssignmentType != entityType.AssignmentTypeCollect {
+			bizErr = xError.NewError(ctx, xError.PermissionDenied, "该皮肤不是收藏资源，无法取消收藏", true)
+			return bizErr
+		}
+
+		// 3. 卸下该用户档案上的装备
+		bizErr = t.profileRepo.ClearSkinLibraryIDByUser(ctx, tx, userID, skinLibraryID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 4. 删除关联记录
+		bizErr = t.userSkinRepo.DeleteByUserAndSkin(ctx, tx, userID, skinLibraryID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 5. 收藏数 -1
+		bizErr = t.skinRepo.IncrementCollectCount(ctx, tx, skinLibraryID, -1)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return bizErr
+	}
+	if err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "取消收藏皮肤失败", true, err)
+	}
+	return nil
+}
+
+// CollectCape 在事务内完成用户收藏公开披风。
+//
+// 事务序列：行锁校验披风存在且公开 → 校验不重复关联 → 创建 UserCapeLibrary(Collect) → 披风 CollectCount +1。
+// 收藏类型不计入配额，不修改 Used。
+func (t *LibraryTxnRepo) CollectCape(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	capeLibraryID xSnowflake.SnowflakeID,
+) (*entity.UserCapeLibrary, *xError.Error) {
+	t.log.Info(ctx, "CollectCape - 事务内收藏披风")
+
+	var createdAssoc *entity.UserCapeLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验披风存在且公开
+		bizErr = t.checkPublicLibrary(ctx, tx, entityType.LibraryKindCape, capeLibraryID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 2. 校验不重复关联
+		exists, xErr := t.userCapeRepo.ExistsByUserAndCape(ctx, tx, userID, capeLibraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if exists {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "您已拥有此披风", true)
+			return bizErr
+		}
+
+		// 3. 创建 UserCapeLibrary
+		createdAssoc, bizErr = t.userCapeRepo.Create(ctx, tx, &entity.UserCapeLibrary{
+			UserID:         userID,
+			CapeLibraryID:  capeLibraryID,
+			AssignmentType: entityType.AssignmentTypeCollect,
+		})
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 4. 收藏数 +1
+		bizErr = t.capeRepo.IncrementCollectCount(ctx, tx, capeLibraryID, 1)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "收藏披风失败", true, err)
+	}
+	return createdAssoc, nil
+}
+
+// UncollectCape 在事务内取消用户对披风的收藏。
+//
+// 事务序列：查找 UserCapeLibrary → 校验 Type = Collect → 卸下该用户档案上的装备 → 删除关联 → 披风 CollectCount -1。
+// 不校验披风公开状态，允许取消收藏已转为私有的披风。
+func (t *LibraryTxnRepo) UncollectCape(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	capeLibraryID xSnowflake.SnowflakeID,
+) *xError.Error {
+	t.log.Info(ctx, "UncollectCape - 事务内取消收藏披风")
+
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 查找关联记录
+		association, found, xErr := t.userCapeRepo.GetByUserAndCape(ctx, tx, userID, capeLibraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "尚未收藏该披风", true)
+			return bizErr
+		}
+
+		// 2. 校验 Type = Collect
+		if association.AssignmentType != entityType.AssignmentTypeCollect {
+			bizErr = xError.NewError(ctx, xError.PermissionDenied, "该披风不是收藏资源，无法取消收藏", true)
+			return bizErr
+		}
+
+		// 3. 卸下该用户档案上的装备
+		bizErr = t.profileRepo.ClearCapeLibraryIDByUser(ctx, tx, userID, capeLibraryID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 4. 删除关联记录
+		bizErr = t.userCapeRepo.DeleteByUserAndCape(ctx, tx, userID, capeLibraryID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 5. 收藏数 -1
+		bizErr = t.capeRepo.IncrementCollectCount(ctx, tx, capeLibraryID, -1)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return bizErr
+	}
+	if err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "取消收藏披风失败", true, err)
+	}
+	return nil
+}
+
+// ReviewSkins 在事务内批量审核待审核的公开皮肤。
+//
+// 仅处理 IsPublic=true 且处于待审核状态或存在待审核纹理版本的皮肤，其余 ID（不存在、已审核、已转私有）静默跳过，
+// 返回实际完成审核的皮肤记录（审核后状态）。
+//
+// 审核通过时将待审核纹理版本切换为当前纹理；已通过审核的皮肤仅纹理变更被驳回时，
+// 丢弃待审核版本并记录原因，皮肤继续以原纹理公开，不调整配额。
+//
+// 驳回时皮肤退回为私有：若上传者仍以 Normal 关联持有该皮肤，则在同一事务内
+// 将一个公开配额转移为私有配额（公开 -1，私有 +1），保证配额计数与 IsPublic 一致。
+// 驳回为管理员操作，不校验私有配额余量。
+func (t *LibraryTxnRepo) ReviewSkins(
+	ctx context.Context,
+	skinIDs []xSnowflake.SnowflakeID,
+	approve bool,
+	reason *string,
+	reviewerID xSnowflake.SnowflakeID,
+) ([]entity.SkinLibrary, *xError.Error) {
+	t.log.Info(ctx, "ReviewSkins - 事务内批量审核皮肤")
+
+	var reviewed []entity.SkinLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, skinID := range skinIDs {
+			// 1. 校验皮肤处于待审核
+			skinRec, found, xErr := t.skinRepo.GetByID(ctx, tx, skinID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if !found || !skinRec.IsPublic || (skinRec.ReviewStatus != entityType.ReviewStatusPending && skinRec.PendingTextureVersion == nil) {
+				continue
+			}
+
+			// 2. 审核通过：切换待审核纹理并更新审核状态
+			if approve {
+				if skinRec.PendingTextureVersion != nil {
+					bizErr = t.promoteSkinPendingTexture(ctx, tx, skinRec)
+					if bizErr != nil {
+						return bizErr
+					}
+				}
+				bizErr = t.skinRepo.UpdateReviewStatus(ctx, tx, skinID, entityType.ReviewStatusApproved, true, nil, &reviewerID)
+				if bizErr != nil {
+					return bizErr
+				}
+				skinRec.ReviewStatus = entityType.ReviewStatusApproved
+				reviewed = append(reviewed, *skinRec)
+				continue
+			}
+
+			// 3. 驳回纹理变更：丢弃待审核版本，皮肤继续以原纹理公开
+			if skinRec.ReviewStatus == entityType.ReviewStatusApproved {
+				bizErr = t.skinRepo.UpdatePendingTextureVersion(ctx, tx, skinID, nil)
+				if bizErr != nil {
+					return bizErr
+				}
+				bizErr = t.skinRepo.UpdateReviewStatus(ctx, tx, skinID, entityType.ReviewStatusApproved, true, reason, &reviewerID)
+				if bizErr != nil {
+					return bizErr
+				}
+				skinRec.PendingTextureVersion = nil
+				skinRec.ReviewReason = reason
+				reviewed = append(reviewed, *skinRec)
+				continue
+			}
+
+			// 4. 驳回：上传者 Normal 关联的公开配额转为私有配额
+			if skinRec.UserID != nil {
+				association, assocFound, xErr := t.userSkinRepo.GetByUserAndSkin(ctx, tx, *skinRec.UserID, skinID)
+				if xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+				if assocFound && association.AssignmentType.CountsTowardQuota() {
+					quota, quotaFound, xErr := t.quotaRepo.GetByUserID(ctx, tx, *skinRec.UserID, true)
+					if xErr != nil {
+						bizErr = xErr
+						return xErr
+					}
+					if quotaFound {
+						bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketSkinsPublic, -1, entityType.LibraryQuotaOpReviewReject, &skinID)
+						if bizErr != nil {
+							return bizErr
+						}
+						bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketSkinsPrivate, 1, entityType.LibraryQuotaOpReviewReject, &skinID)
+						if bizErr != nil {
+							return bizErr
+						}
+					}
+				}
+			}
+
+			// 5. 驳回：退回私有并记录原因
+			bizErr = t.skinRepo.UpdateReviewStatus(ctx, tx, skinID, entityType.ReviewStatusRejected, false, reason, &reviewerID)
+			if bizErr != nil {
+				return bizErr
+			}
+			skinRec.IsPublic = false
+			skinRec.ReviewStatus = entityType.ReviewStatusRejected
+			skinRec.ReviewReason = reason
+			reviewed = append(reviewed, *skinRec)
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "审核皮肤失败", true, err)
+	}
+	return reviewed, nil
+}
+
+// ReviewCapes 在事务内批量审核待审核的公开披风。
+//
+// 仅处理 IsPublic=true 且处于待审核状态或存在待审核纹理版本的披风，其余 ID（不存在、已审核、已转私有）静默跳过，
+// 返回实际完成审核的披风记录（审核后状态）。
+//
+// 审核通过时将待审核纹理版本切换为当前纹理；已通过审核的披风仅纹理变更被驳回时，
+// 丢弃待审核版本并记录原因，披风继续以原纹理公开，不调整配额。
+//
+// 驳回时披风退回为私有：若上传者仍以 Normal 关联持有该披风，则在同一事务内
+// 将一个公开配额转移为私有配额（公开 -1，私有 +1），保证配额计数与 IsPublic 一致。
+// 驳回为管理员操作，不校验私有配额余量。
+func (t *LibraryTxnRepo) ReviewCapes(
+	ctx context.Context,
+	capeIDs []xSnowflake.SnowflakeID,
+	approve bool,
+	reason *string,
+	reviewerID xSnowflake.SnowflakeID,
+) ([]entity.CapeLibrary, *xError.Error) {
+	t.log.Info(ctx, "ReviewCapes - 事务内批量审核披风")
+
+	var reviewed []entity.CapeLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, capeID := range capeIDs {
+			// 1. 校验披风处于待审核
+			capeRec, found, xErr := t.capeRepo.GetByID(ctx, tx, capeID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if !found || !capeRec.IsPublic || (capeRec.ReviewStatus != entityType.ReviewStatusPending && capeRec.PendingTextureVersion == nil) {
+				continue
+			}
+
+			// 2. 审核通过：切换待审核纹理并更新审核状态
+			if approve {
+				if capeRec.PendingTextureVersion != nil {
+					bizErr = t.promoteCapePendingTexture(ctx, tx, capeRec)
+					if bizErr != nil {
+						return bizErr
+					}
+				}
+				bizErr = t.capeRepo.UpdateReviewStatus(ctx, tx, capeID, entityType.ReviewStatusApproved, true, nil, &reviewerID)
+				if bizErr != nil {
+					return bizErr
+				}
+				capeRec.ReviewStatus = entityType.ReviewStatusApproved
+				reviewed = append(reviewed, *capeRec)
+				continue
+			}
+
+			// 3. 驳回纹理变更：丢弃待审核版本，披风继续以原纹理公开
+			if capeRec.ReviewStatus == entityType.ReviewStatusApproved {
+				bizErr = t.capeRepo.UpdatePendingTextureVersion(ctx, tx, capeID, nil)
+				if bizErr != nil {
+					return bizErr
+				}
+				bizErr = t.capeRepo.UpdateReviewStatus(ctx, tx, capeID, entityType.ReviewStatusApproved, true, reason, &reviewerID)
+				if bizErr != nil {
+					return bizErr
+				}
+				capeRec.PendingTextureVersion = nil
+				capeRec.ReviewReason = reason
+				reviewed = append(reviewed, *capeRec)
+				continue
+			}
+
+			// 4. 驳回：上传者 Normal 关联的公开配额转为私有配额
+			if capeRec.UserID != nil {
+				association, assocFound, xErr := t.userCapeRepo.GetByUserAndCape(ctx, tx, *capeRec.UserID, capeID)
+				if xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+				if assocFound && association.AssignmentType.CountsTowardQuota() {
+					quota, quotaFound, xErr := t.quotaRepo.GetByUserID(ctx, tx, *capeRec.UserID, true)
+					if xErr != nil {
+						bizErr = xErr
+						return xErr
+					}
+					if quotaFound {
+						bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketCapesPublic, -1, entityType.LibraryQuotaOpReviewReject, &capeID)
+						if bizErr != nil {
+							return bizErr
+						}
+						bizErr = t.changeQuotaUsed(ctx, tx, quota, entityType.LibraryQuotaBucketCapesPrivate, 1, entityType.LibraryQuotaOpReviewReject, &capeID)
+						if bizErr != nil {
+							return bizErr
+						}
+					}
+				}
+			}
+
+			// 5. 驳回：退回私有并记录原因
+			bizErr = t.capeRepo.UpdateReviewStatus(ctx, tx, capeID, entityType.ReviewStatusRejected, false, reason, &reviewerID)
+			if bizErr != nil {
+				return bizErr
+			}
+			capeRec.IsPublic = false
+			capeRec.ReviewStatus = entityType.ReviewStatusRejected
+			capeRec.ReviewReason = reason
+			reviewed = append(reviewed, *capeRec)
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "审核披风失败", true, err)
+	}
+	return reviewed, nil
+}
+
+// checkPublicLibrary 行锁查询并校验指定种类的资源存在且处于公开、审核通过、未被隐藏状态。
+//
+// 行锁使同一资源上的并发点赞、收藏串行化，后到的请求在锁释放后能看到先到请求写入的记录，
+// 从而返回 DataConflict 而非唯一索引冲突导致的数据库错误。
+func (t *LibraryTxnRepo) checkPublicLibrary(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) *xError.Error {
+	switch kind {
+	case entityType.LibraryKindSkin:
+		skin, found, xErr := t.skinRepo.GetByIDForUpdate(ctx, tx, libraryID)
+		if xErr != nil {
+			return xErr
+		}
+		if !found || !skin.IsPublic || skin.ReviewStatus != entityType.ReviewStatusApproved || skin.IsHidden {
+			return xError.NewError(ctx, xError.ResourceNotFound, "皮肤不存在或未公开", true)
+		}
+	case entityType.LibraryKindCape:
+		cape, found, xErr := t.capeRepo.GetByIDForUpdate(ctx, tx, libraryID)
+		if xErr != nil {
+			return xErr
+		}
+		if !found || !cape.IsPublic || cape.ReviewStatus != entityType.ReviewStatusApproved || cape.IsHidden {
+			return xError.NewError(ctx, xError.ResourceNotFound, "披风不存在或未公开", true)
+		}
+	default:
+		return xError.NewError(ctx, xError.ParameterError, "无效的资源种类", true)
+	}
+	return nil
+}
+
+// incrementLikeCount 按资源种类分派点赞数增减。
+func (t *LibraryTxnRepo) incrementLikeCount(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, delta int64) *xError.Error {
+	if kind == entityType.LibraryKindCape {
+		return t.capeRepo.IncrementLikeCount(ctx, tx, libraryID, delta)
+	}
+	return t.skinRepo.IncrementLikeCount(ctx, tx, libraryID, delta)
+}
@@ -0,0 +1,162 @@
+package txn
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// GrantCampaignItem 在事务内为单个用户执行一次活动发放。
+//
+// 事务序列：
+//  1. 以 (campaign_id, user_id) 为幂等键写入处理记录，已存在则视为已处理，直接返回
+//  2. 用户已拥有该资源时标记为跳过
+//  3. 否则按活动的关联类型创建用户资源关联，并标记为已发放
+//
+// 返回值为本次处理结果；processed 为 false 表示该用户此前已被本活动处理过。
+func (t *LibraryTxnRepo) GrantCampaignItem(
+	ctx context.Context,
+	campaign *entity.LibraryCampaign,
+	userID xSnowflake.SnowflakeID,
+) (entityType.CampaignGrantStatus, bool, *xError.Error) {
+	t.log.Info(ctx, "GrantCampaignItem - 事务内执行活动发放")
+
+	var status entityType.CampaignGrantStatus
+	var processed bool
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 写入幂等处理记录
+		grant := &entity.LibraryCampaignGrant{
+			CampaignID: campaign.ID,
+			UserID:     userID,
+			Status:     entityType.CampaignGrantStatusGranted,
+		}
+		inserted, xErr := t.campaignRepo.CreateGrantIfAbsent(ctx, tx, grant)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !inserted {
+			return nil
+		}
+		processed = true
+
+		// 2. 已拥有该资源时跳过
+		var exists bool
+		if campaign.Kind == entityType.LibraryKindCape {
+			exists, xErr = t.userCapeRepo.ExistsByUserAndCape(ctx, tx, userID, campaign.LibraryID)
+		} else {
+			exists, xErr = t.userSkinRepo.ExistsByUserAndSkin(ctx, tx, userID, campaign.LibraryID)
+		}
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if exists {
+			status = entityType.CampaignGrantStatusSkipped
+			message := "用户已拥有该资源"
+			bizErr = t.campaignRepo.UpdateGrantStatus(ctx, tx, grant.ID, status, &message)
+			if bizErr != nil {
+				return bizErr
+			}
+			return nil
+		}
+
+		// 3. 创建用户资源关联
+		if campaign.Kind == entityType.LibraryKindCape {
+			_, bizErr = t.userCapeRepo.Create(ctx, tx, &entity.UserCapeLibrary{
+				UserID:         userID,
+				CapeLibraryID:  campaign.LibraryID,
+				AssignmentType: campaign.AssignmentType,
+			})
+		} else {
+			_, bizErr = t.userSkinRepo.Create(ctx, tx, &entity.UserSkinLibrary{
+				UserID:         userID,
+				SkinLibraryID:  campaign.LibraryID,
+				AssignmentType: campaign.AssignmentType,
+			})
+		}
+		if bizErr != nil {
+			return bizErr
+		}
+		status = entityType.CampaignGrantStatusGranted
+		return nil
+	})
+	if bizErr != nil {
+		return 0, false, bizErr
+	}
+	if err != nil {
+		return 0, false, xError.NewError(ctx, xError.DatabaseError, "活动发放事务失败", true, err)
+	}
+	return status, processed, nil
+}
+
+// RevokeCampaignGrant 在事务内收回单条活动发放记录对应的资源。
+//
+// 仅删除关联类型仍与活动一致的关联（用户在活动后自行上传或收藏的同一资源不受影响），
+// 删除后卸下该用户档案上的对应装备，并将处理记录标记为已收回。
+func (t *LibraryTxnRepo) RevokeCampaignGrant(
+	ctx context.Context,
+	campaign *entity.LibraryCampaign,
+	grant *entity.LibraryCampaignGrant,
+) *xError.Error {
+	t.log.Info(ctx, "RevokeCampaignGrant - 事务内收回活动发放")
+
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 删除赠送关联并卸下装备
+		if campaign.Kind == entityType.LibraryKindCape {
+			assoc, found, xErr := t.userCapeRepo.GetByUserAndCape(ctx, tx, grant.UserID, campaign.LibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if found && assoc.AssignmentType == campaign.AssignmentType {
+				if xErr := t.userCapeRepo.DeleteByUserAndCape(ctx, tx, grant.UserID, campaign.LibraryID); xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+				if xErr := t.profileRepo.ClearCapeLibraryIDByUser(ctx, tx, grant.UserID, campaign.LibraryID); xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+			}
+		} else {
+			assoc, found, xErr := t.userSkinRepo.GetByUserAndSkin(ctx, tx, grant.UserID, campaign.LibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if found && assoc.AssignmentType == campaign.AssignmentType {
+				if xErr := t.userSkinRepo.DeleteByUserAndSkin(ctx, tx, grant.UserID, campaign.LibraryID); xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+				if xErr := t.profileRepo.ClearSkinLibraryIDByUser(ctx, tx, grant.UserID, campaign.LibraryID); xErr != nil {
+					bizErr = xErr
+					return xErr
+				}
+			}
+		}
+
+		// 2. 标记处理记录为已收回
+		bizErr = t.campaignRepo.UpdateGrantStatus(ctx, tx, grant.ID, entityType.CampaignGrantStatusRevoked, nil)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return bizErr
+	}
+	if err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "收回活动发放事务失败", true, err)
+	}
+	return nil
+}
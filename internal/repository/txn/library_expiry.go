@@ -0,0 +1,85 @@
+package txn
+
+import (
+	"context"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// RevokeExpiredSkinGift 在事务内收回一条已到期的限时皮肤关联。
+//
+// 事务序列：按到期条件删除关联（期间被续期或已被删除则跳过）→ 卸下该用户游戏档案上的此皮肤。
+// 返回值表示是否实际收回。
+func (t *LibraryTxnRepo) RevokeExpiredSkinGift(ctx context.Context, association *entity.UserSkinLibrary, now time.Time) (bool, *xError.Error) {
+	t.log.Info(ctx, "RevokeExpiredSkinGift - 事务内收回到期皮肤")
+
+	var revoked bool
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 按到期条件删除关联
+		deleted, xErr := t.userSkinRepo.DeleteExpiredByID(ctx, tx, association.ID, now)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !deleted {
+			return nil
+		}
+
+		// 2. 卸下游戏档案上的装备
+		if xErr := t.profileRepo.ClearSkinLibraryIDByUser(ctx, tx, association.UserID, association.SkinLibraryID); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		revoked = true
+		return nil
+	})
+	if bizErr != nil {
+		return false, bizErr
+	}
+	if err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "收回到期皮肤事务失败", true, err)
+	}
+	return revoked, nil
+}
+
+// RevokeExpiredCapeGift 在事务内收回一条已到期的限时披风关联。
+//
+// 同构于 RevokeExpiredSkinGift，Skin → Cape。
+func (t *LibraryTxnRepo) RevokeExpiredCapeGift(ctx context.Context, association *entity.UserCapeLibrary, now time.Time) (bool, *xError.Error) {
+	t.log.Info(ctx, "RevokeExpiredCapeGift - 事务内收回到期披风")
+
+	var revoked bool
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 按到期条件删除关联
+		deleted, xErr := t.userCapeRepo.DeleteExpiredByID(ctx, tx, association.ID, now)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !deleted {
+			return nil
+		}
+
+		// 2. 卸下游戏档案上的装备
+		if xErr := t.profileRepo.ClearCapeLibraryIDByUser(ctx, tx, association.UserID, association.CapeLibraryID); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		revoked = true
+		return nil
+	})
+	if bizErr != nil {
+		return false, bizErr
+	}
+	if err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "收回到期披风事务失败", true, err)
+	}
+	return revoked, nil
+}
@@ -0,0 +1,264 @@
+package txn
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+	"gorm.io/gorm"
+)
+
+// libraryQuotaBuckets 资源库配额的全部分项，按日志写入顺序排列。
+var libraryQuotaBuckets = []entityType.LibraryQuotaBucket{
+	entityType.LibraryQuotaBucketSkinsPublic,
+	entityType.LibraryQuotaBucketSkinsPrivate,
+	entityType.LibraryQuotaBucketCapesPublic,
+	entityType.LibraryQuotaBucketCapesPrivate,
+}
+
+// AdjustLibraryQuotaAdmin 在事务内完成管理员对单个配额分项总额度的调整及日志记录。
+//
+// 该方法执行以下原子操作序列：
+//  1. 行锁查询用户资源库配额
+//  2. 计算新 Total 并校验不小于 0 且不小于已使用额度
+//  3. 更新配额 Total 字段
+//  4. 写入配额变更日志
+//
+// 任一步骤失败将触发整体回滚。
+//
+// requestID 为调用方提供的请求标识，用于生成配额日志幂等键；同一 requestID 重复提交时
+// 不再重复调整，直接返回当前配额。
+func (t *LibraryTxnRepo) AdjustLibraryQuotaAdmin(
+	ctx context.Context,
+	targetUserID xSnowflake.SnowflakeID,
+	bucket entityType.LibraryQuotaBucket,
+	delta int32,
+	operatorID xSnowflake.SnowflakeID,
+	requestID string,
+	remark *string,
+) (*entity.LibraryQuota, *xError.Error) {
+	t.log.Info(ctx, "AdjustLibraryQuotaAdmin - 事务内管理员调整资源库配额")
+
+	var updatedQuota *entity.LibraryQuota
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询配额
+		quota, found, xErr := t.quotaRepo.GetByUserID(ctx, tx, targetUserID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "用户资源库配额不存在", true)
+			return bizErr
+		}
+
+		// 重复提交的请求直接返回当前配额
+		replayed, xErr := t.quotaLogRepo.ExistsByIdempotencyKey(ctx, tx,
+			libraryQuotaLogKey(entityType.LibraryQuotaOpAdminAdjust, targetUserID, bucket, requestID))
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if replayed {
+			updatedQuota = quota
+			return nil
+		}
+
+		// 2. 计算并校验新 Total
+		used, total := libraryQuotaBucketValues(quota, bucket)
+		newTotal := total + delta
+		if newTotal < 0 {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "调整后总额度不能小于 0", true)
+			return bizErr
+		}
+		if newTotal < used {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "调整后总额度不能小于已使用额度", true)
+			return bizErr
+		}
+
+		// 3. 更新 Total
+		before := *quota
+		setLibraryQuotaBucketTotal(quota, bucket, newTotal)
+		bizErr = t.quotaRepo.UpdateAllTotal(ctx, tx, quota.ID,
+			quota.SkinsPublicTotal, quota.SkinsPrivateTotal, quota.CapesPublicTotal, quota.CapesPrivateTotal)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 4. 写入日志
+		bizErr = recordLibraryQuotaDiff(ctx, tx, t.quotaLogRepo, &before, quota, entityType.LibraryQuotaOpAdminAdjust, requestID, nil, &operatorID, remark)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		updatedQuota = quota
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "调整资源库配额失败", true, err)
+	}
+	return updatedQuota, nil
+}
+
+// changeQuotaUsed 调整单个配额分项的已使用额度并写入配额日志。
+//
+// 调用方需已在事务内行锁读取 quota；方法会同步更新 quota 中的已使用额度，
+// 以便同一事务内的后续调整（如公开/私有互转）基于最新值计算。
+func (t *LibraryTxnRepo) changeQuotaUsed(
+	ctx context.Context,
+	tx *gorm.DB,
+	quota *entity.LibraryQuota,
+	bucket entityType.LibraryQuotaBucket,
+	delta int32,
+	opType entityType.LibraryQuotaOpType,
+	refLibraryID *xSnowflake.SnowflakeID,
+) *xError.Error {
+	before := *quota
+	used, _ := libraryQuotaBucketValues(quota, bucket)
+	newUsed := used + delta
+
+	var xErr *xError.Error
+	switch bucket {
+	case entityType.LibraryQuotaBucketSkinsPublic:
+		xErr = t.quotaRepo.UpdateSkinsPublicUsed(ctx, tx, quota.ID, newUsed)
+	case entityType.LibraryQuotaBucketSkinsPrivate:
+		xErr = t.quotaRepo.UpdateSkinsPrivateUsed(ctx, tx, quota.ID, newUsed)
+	case entityType.LibraryQuotaBucketCapesPublic:
+		xErr = t.quotaRepo.UpdateCapesPublicUsed(ctx, tx, quota.ID, newUsed)
+	case entityType.LibraryQuotaBucketCapesPrivate:
+		xErr = t.quotaRepo.UpdateCapesPrivateUsed(ctx, tx, quota.ID, newUsed)
+	default:
+		return xError.NewError(ctx, xError.ServerInternalError, "无效的配额分项", true)
+	}
+	if xErr != nil {
+		return xErr
+	}
+
+	setLibraryQuotaBucketUsed(quota, bucket, newUsed)
+	operationKey := libraryQuotaVersion(&before)
+	if refLibraryID != nil {
+		operationKey = refLibraryID.String() + ":" + operationKey
+	}
+	return recordLibraryQuotaDiff(ctx, tx, t.quotaLogRepo, &before, quota, opType, operationKey, refLibraryID, nil, nil)
+}
+
+// recordLibraryQuotaDiff 对比配额变更前后的快照，为每个发生变化的分项写入一条配额日志。
+//
+// Delta 取已使用额度的变化值；仅总额度变化时取总额度的变化值。未发生变化的分项不写日志。
+// operationKey 标识触发本次变更的业务操作（请求 ID、兑换码、资源 ID 或配额版本等），
+// 与操作类型、用户、分项共同组成幂等键，同一业务操作重复记账时命中唯一索引并使事务回滚。
+func recordLibraryQuotaDiff(
+	ctx context.Context,
+	tx *gorm.DB,
+	logRepo *repository.LibraryQuotaLogRepo,
+	before *entity.LibraryQuota,
+	after *entity.LibraryQuota,
+	opType entityType.LibraryQuotaOpType,
+	operationKey string,
+	refLibraryID *xSnowflake.SnowflakeID,
+	operatorID *xSnowflake.SnowflakeID,
+	remark *string,
+) *xError.Error {
+	for _, bucket := range libraryQuotaBuckets {
+		beforeUsed, beforeTotal := libraryQuotaBucketValues(before, bucket)
+		afterUsed, afterTotal := libraryQuotaBucketValues(after, bucket)
+		if beforeUsed == afterUsed && beforeTotal == afterTotal {
+			continue
+		}
+
+		delta := afterUsed - beforeUsed
+		if delta == 0 {
+			delta = afterTotal - beforeTotal
+		}
+		quotaLog := &entity.LibraryQuotaLog{
+			UserID:         after.UserID,
+			OpType:         opType,
+			Bucket:         bucket,
+			Delta:          delta,
+			BeforeUsed:     beforeUsed,
+			AfterUsed:      afterUsed,
+			BeforeTotal:    beforeTotal,
+			AfterTotal:     afterTotal,
+			IdempotencyKey: libraryQuotaLogKey(opType, after.UserID, bucket, operationKey),
+			RefLibraryID:   refLibraryID,
+			OperatorID:     operatorID,
+			Remark:         remark,
+		}
+		if xErr := logRepo.Create(ctx, tx, quotaLog); xErr != nil {
+			return xErr
+		}
+	}
+	return nil
+}
+
+// libraryQuotaLogKey 生成资源库配额日志的幂等键：操作类型、用户、分项与业务操作标识。
+func libraryQuotaLogKey(
+	opType entityType.LibraryQuotaOpType,
+	userID xSnowflake.SnowflakeID,
+	bucket entityType.LibraryQuotaBucket,
+	operationKey string,
+) string {
+	return fmt.Sprintf("%s:%s:%s:%s", opType.String(), userID.String(), bucket.String(), operationKey)
+}
+
+// libraryQuotaVersion 返回配额记录的版本标识（更新时间的微秒时间戳）。
+//
+// 调用方需已在事务内行锁读取配额，每次变更都会刷新更新时间，因此同一配额的不同变更对应不同版本，
+// 用于为可重复发生的操作（公开状态切换、重算、档位调整等）生成稳定的幂等键。
+func libraryQuotaVersion(quota *entity.LibraryQuota) string {
+	return strconv.FormatInt(quota.UpdatedAt.UnixMicro(), 10)
+}
+
+// libraryQuotaBucketValues 返回配额分项当前的已使用额度与总额度。
+func libraryQuotaBucketValues(quota *entity.LibraryQuota, bucket entityType.LibraryQuotaBucket) (used int32, total int32) {
+	switch bucket {
+	case entityType.LibraryQuotaBucketSkinsPublic:
+		return quota.SkinsPublicUsed, quota.SkinsPublicTotal
+	case entityType.LibraryQuotaBucketSkinsPrivate:
+		return quota.SkinsPrivateUsed, quota.SkinsPrivateTotal
+	case entityType.LibraryQuotaBucketCapesPublic:
+		return quota.CapesPublicUsed, quota.CapesPublicTotal
+	case entityType.LibraryQuotaBucketCapesPrivate:
+		return quota.CapesPrivateUsed, quota.CapesPrivateTotal
+	default:
+		return 0, 0
+	}
+}
+
+// setLibraryQuotaBucketUsed 设置配额分项的已使用额度（仅修改内存值）。
+func setLibraryQuotaBucketUsed(quota *entity.LibraryQuota, bucket entityType.LibraryQuotaBucket, used int32) {
+	switch bucket {
+	case entityType.LibraryQuotaBucketSkinsPublic:
+		quota.SkinsPublicUsed = used
+	case entityType.LibraryQuotaBucketSkinsPrivate:
+		quota.SkinsPrivateUsed = used
+	case entityType.LibraryQuotaBucketCapesPublic:
+		quota.CapesPublicUsed = used
+	case entityType.LibraryQuotaBucketCapesPrivate:
+		quota.CapesPrivateUsed = used
+	}
+}
+
+// setLibraryQuotaBucketTotal 设置配额分项的总额度（仅修改内存值）。
+func setLibraryQuotaBucketTotal(quota *entity.LibraryQuota, bucket entityType.LibraryQuotaBucket, total int32) {
+	switch bucket {
+	case entityType.LibraryQuotaBucketSkinsPublic:
+		quota.SkinsPublicTotal = total
+	case entityType.LibraryQuotaBucketSkinsPrivate:
+		quota.SkinsPrivateTotal = total
+	case entityType.LibraryQuotaBucketCapesPublic:
+		quota.CapesPublicTotal = total
+	case entityType.LibraryQuotaBucketCapesPrivate:
+		quota.CapesPrivateTotal = total
+	}
+}
@@ -0,0 +1,361 @@
+package txn
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// ReportLibrary 在事务内提交一条资源举报并累加资源的待处理举报数。
+//
+// 事务序列：
+//  1. 校验资源存在且处于公开、审核通过状态（已隐藏的资源仍可继续举报）
+//  2. 禁止举报自己上传的资源
+//  3. 同一用户对同一资源仅保留一条待处理举报
+//  4. 创建举报记录
+//  5. 累加待处理举报数，达到 hideThreshold 时自动隐藏
+//
+// 返回值表示资源是否因本次举报由可见变为隐藏。
+func (t *LibraryTxnRepo) ReportLibrary(
+	ctx context.Context,
+	reporterID xSnowflake.SnowflakeID,
+	kind entityType.LibraryKind,
+	libraryID xSnowflake.SnowflakeID,
+	reason entityType.ReportReason,
+	description *string,
+	hideThreshold int64,
+) (bool, *xError.Error) {
+	t.log.Info(ctx, "ReportLibrary - 事务内提交资源举报")
+
+	var becameHidden bool
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验资源处于公开且审核通过状态
+		ownerID, hiddenBefore, xErr := t.getReportTarget(ctx, tx, kind, libraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 2. 禁止举报自己上传的资源
+		if ownerID != nil && *ownerID == reporterID {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "不能举报自己上传的资源", true)
+			return bizErr
+		}
+
+		// 3. 同一用户对同一资源仅保留一条待处理举报
+		exists, xErr := t.reportRepo.ExistsPendingByReporter(ctx, tx, reporterID, kind, libraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if exists {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "您已举报过该资源，请等待管理员处理", true)
+			return bizErr
+		}
+
+		// 4. 创建举报记录
+		_, xErr = t.reportRepo.Create(ctx, tx, &entity.LibraryReport{
+			ReporterID:  reporterID,
+			Kind:        kind,
+			LibraryID:   libraryID,
+			Reason:      reason,
+			Description: description,
+			Status:      entityType.ReportStatusPending,
+		})
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 5. 累加待处理举报数，达到阈值时自动隐藏
+		if kind == entityType.LibraryKindCape {
+			bizErr = t.capeRepo.AddReport(ctx, tx, libraryID, hideThreshold)
+		} else {
+			bizErr = t.skinRepo.AddReport(ctx, tx, libraryID, hideThreshold)
+		}
+		if bizErr != nil {
+			return bizErr
+		}
+
+		_, hiddenAfter, xErr := t.getReportTarget(ctx, tx, kind, libraryID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		becameHidden = !hiddenBefore && hiddenAfter
+		return nil
+	})
+	if bizErr != nil {
+		return false, bizErr
+	}
+	if err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "提交举报失败", true, err)
+	}
+	return becameHidden, nil
+}
+
+// DismissReports 在事务内驳回指定资源的全部待处理举报，并清零举报数、取消隐藏。
+//
+// 返回被驳回的举报条数；资源不存在待处理举报时返回 ResourceNotFound。
+func (t *LibraryTxnRepo) DismissReports(
+	ctx context.Context,
+	kind entityType.LibraryKind,
+	libraryID xSnowflake.SnowflakeID,
+	handlerID xSnowflake.SnowflakeID,
+) (int64, *xError.Error) {
+	t.log.Info(ctx, "DismissReports - 事务内驳回资源举报")
+
+	var dismissed int64
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 将待处理举报标记为已驳回
+		count, xErr := t.reportRepo.ResolvePendingByTarget(ctx, tx, kind, libraryID, entityType.ReportStatusDismissed, handlerID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if count == 0 {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "该资源没有待处理的举报", true)
+			return bizErr
+		}
+		dismissed = count
+
+		// 2. 清零举报数并取消隐藏
+		if kind == entityType.LibraryKindCape {
+			bizErr = t.capeRepo.ClearReports(ctx, tx, libraryID)
+		} else {
+			bizErr = t.skinRepo.ClearReports(ctx, tx, libraryID)
+		}
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return 0, bizErr
+	}
+	if err != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "驳回举报失败", true, err)
+	}
+	return dismissed, nil
+}
+
+// TakedownSkin 在事务内下架皮肤：从所有用户撤销并卸下全部游戏档案上的装备。
+//
+// 事务序列：
+//  1. 查询皮肤记录
+//  2. 卸下所有游戏档案上装备的该皮肤
+//  3. 上传者以 Normal 关联持有时释放对应配额（按权威 IsPublic 区分公开/私有）
+//  4. 删除全部用户关联（Normal/Gift/Admin/Collect）
+//  5. 删除点赞记录
+//  6. 将待处理举报标记为已下架
+//  7. 删除皮肤记录（标签关联随外键级联删除）
+//
+// 返回被删除的皮肤记录（供 Logic 层清理纹理文件）及处理的举报条数。
+func (t *LibraryTxnRepo) TakedownSkin(
+	ctx context.Context,
+	skinID xSnowflake.SnowflakeID,
+	handlerID xSnowflake.SnowflakeID,
+) (*entity.SkinLibrary, int64, *xError.Error) {
+	t.log.Info(ctx, "TakedownSkin - 事务内下架皮肤")
+
+	var skin *entity.SkinLibrary
+	var resolved int64
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 查询皮肤记录
+		skinRec, found, xErr := t.skinRepo.GetByID(ctx, tx, skinID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "皮肤库记录不存在", true)
+			return bizErr
+		}
+		skin = skinRec
+
+		// 2. 卸下所有档案上的装备
+		bizErr = t.profileRepo.ClearSkinLibraryIDAll(ctx, tx, skinID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 3. 上传者 Normal 关联释放配额
+		if skinRec.UserID != nil {
+			association, found, xErr := t.userSkinRepo.GetByUserAndSkin(ctx, tx, *skinRec.UserID, skinID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if found && association.AssignmentType.CountsTowardQuota() {
+				bizErr = t.releaseUploaderQuota(ctx, tx, *skinRec.UserID, entityType.LibraryKindSkin, skinRec.ID, skinRec.IsPublic)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
+		}
+
+		// 4. 删除全部用户关联
+		if _, xErr := t.userSkinRepo.DeleteAllBySkin(ctx, tx, skinID); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 5. 删除点赞记录
+		bizErr = t.likeRepo.DeleteByTarget(ctx, tx, entityType.LibraryKindSkin, skinID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 6. 待处理举报标记为已下架
+		resolved, xErr = t.reportRepo.ResolvePendingByTarget(ctx, tx, entityType.LibraryKindSkin, skinID, entityType.ReportStatusTakenDown, handlerID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 7. 删除皮肤记录
+		bizErr = t.skinRepo.DeleteByID(ctx, tx, skinID)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, 0, bizErr
+	}
+	if err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "下架皮肤失败", true, err)
+	}
+	return skin, resolved, nil
+}
+
+// TakedownCape 在事务内下架披风：从所有用户撤销并卸下全部游戏档案上的装备。
+//
+// 同构于 TakedownSkin，Skin → Cape。
+func (t *LibraryTxnRepo) TakedownCape(
+	ctx context.Context,
+	capeID xSnowflake.SnowflakeID,
+	handlerID xSnowflake.SnowflakeID,
+) (*entity.CapeLibrary, int64, *xError.Error) {
+	t.log.Info(ctx, "TakedownCape - 事务内下架披风")
+
+	var cape *entity.CapeLibrary
+	var resolved int64
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 查询披风记录
+		capeRec, found, xErr := t.capeRepo.GetByID(ctx, tx, capeID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "披风库记录不存在", true)
+			return bizErr
+		}
+		cape = capeRec
+
+		// 2. 卸下所有档案上的装备
+		bizErr = t.profileRepo.ClearCapeLibraryIDAll(ctx, tx, capeID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 3. 上传者 Normal 关联释放配额
+		if capeRec.UserID != nil {
+			association, found, xErr := t.userCapeRepo.GetByUserAndCape(ctx, tx, *capeRec.UserID, capeID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if found && association.AssignmentType.CountsTowardQuota() {
+				bizErr = t.releaseUploaderQuota(ctx, tx, *capeRec.UserID, entityType.LibraryKindCape, capeRec.ID, capeRec.IsPublic)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
+		}
+
+		// 4. 删除全部用户关联
+		if _, xErr := t.userCapeRepo.DeleteAllByCape(ctx, tx, capeID); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 5. 删除点赞记录
+		bizErr = t.likeRepo.DeleteByTarget(ctx, tx, entityType.LibraryKindCape, capeID)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 6. 待处理举报标记为已下架
+		resolved, xErr = t.reportRepo.ResolvePendingByTarget(ctx, tx, entityType.LibraryKindCape, capeID, entityType.ReportStatusTakenDown, handlerID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 7. 删除披风记录
+		bizErr = t.capeRepo.DeleteByID(ctx, tx, capeID)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, 0, bizErr
+	}
+	if err != nil {
+		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "下架披风失败", true, err)
+	}
+	return cape, resolved, nil
+}
+
+// getReportTarget 查询可被举报的资源，返回上传者 ID 与当前隐藏状态。
+func (t *LibraryTxnRepo) getReportTarget(ctx context.Context, tx *gorm.DB, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID) (*xSnowflake.SnowflakeID, bool, *xError.Error) {
+	switch kind {
+	case entityType.LibraryKindSkin:
+		skin, found, xErr := t.skinRepo.GetByID(ctx, tx, libraryID)
+		if xErr != nil {
+			return nil, false, xErr
+		}
+		if !found || !skin.IsPublic || skin.ReviewStatus != entityType.ReviewStatusApproved {
+			return nil, false, xError.NewError(ctx, xError.ResourceNotFound, "皮肤不存在或未公开", true)
+		}
+		return skin.UserID, skin.IsHidden, nil
+	case entityType.LibraryKindCape:
+		cape, found, xErr := t.capeRepo.GetByID(ctx, tx, libraryID)
+		if xErr != nil {
+			return nil, false, xErr
+		}
+		if !found || !cape.IsPublic || cape.ReviewStatus != entityType.ReviewStatusApproved {
+			return nil, false, xError.NewError(ctx, xError.ResourceNotFound, "披风不存在或未公开", true)
+		}
+		return cape.UserID, cape.IsHidden, nil
+	default:
+		return nil, false, xError.NewError(ctx, xError.ParameterError, "无效的资源种类", true)
+	}
+}
+
+// releaseUploaderQuota 行锁读取上传者配额并释放一个公开或私有配额。
+func (t *LibraryTxnRepo) releaseUploaderQuota(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, kind entityType.LibraryKind, libraryID xSnowflake.SnowflakeID, isPublic bool) *xError.Error {
+	quota, found, xErr := t.quotaRepo.GetByUserID(ctx, tx, userID, true)
+	if xErr != nil {
+		return xErr
+	}
+	if !found {
+		return xError.NewError(ctx, xError.ResourceNotFound, "用户资源库配额不存在", true)
+	}
+	return t.changeQuotaUsed(ctx, tx, quota, entityType.QuotaBucketFor(kind, isPublic), -1, entityType.LibraryQuotaOpTakedown, &libraryID)
+}
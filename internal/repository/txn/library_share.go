@@ -0,0 +1,176 @@
+package txn
+
+import (
+	"context"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// ClaimShare 在事务内通过分享令牌将资源添加到领取者的资源库。
+//
+// 事务序列：行锁查询分享 → 校验未撤销、未到期且领取者不是分享者 → 校验资源仍存在 →
+// 校验不重复关联 → 创建 AssignmentTypeShare 关联 → 写入领取记录 → 领取人数 +1。
+// 分享关联不计入配额，不修改 Used。
+func (t *LibraryTxnRepo) ClaimShare(
+	ctx context.Context,
+	token string,
+	userID xSnowflake.SnowflakeID,
+	now time.Time,
+) (*entity.LibraryShare, *xError.Error) {
+	t.log.Info(ctx, "ClaimShare - 事务内领取分享资源")
+
+	var claimedShare *entity.LibraryShare
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询分享并校验状态
+		share, found, xErr := t.shareRepo.GetByToken(ctx, tx, token, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found || !share.IsActive(now) {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "分享链接不存在或已失效", true)
+			return bizErr
+		}
+		if share.OwnerID == userID {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "不能领取自己分享的资源", true)
+			return bizErr
+		}
+
+		// 2. 校验资源存在并创建分享关联
+		if share.Kind == entityType.LibraryKindCape {
+			_, found, xErr = t.capeRepo.GetByID(ctx, tx, share.LibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if !found {
+				bizErr = xError.NewError(ctx, xError.ResourceNotFound, "分享的披风已不存在", true)
+				return bizErr
+			}
+			exists, xErr := t.userCapeRepo.ExistsByUserAndCape(ctx, tx, userID, share.LibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if exists {
+				bizErr = xError.NewError(ctx, xError.DataConflict, "您已拥有此披风", true)
+				return bizErr
+			}
+			_, bizErr = t.userCapeRepo.Create(ctx, tx, &entity.UserCapeLibrary{
+				UserID:         userID,
+				CapeLibraryID:  share.LibraryID,
+				AssignmentType: entityType.AssignmentTypeShare,
+			})
+			if bizErr != nil {
+				return bizErr
+			}
+		} else {
+			_, found, xErr = t.skinRepo.GetByID(ctx, tx, share.LibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if !found {
+				bizErr = xError.NewError(ctx, xError.ResourceNotFound, "分享的皮肤已不存在", true)
+				return bizErr
+			}
+			exists, xErr := t.userSkinRepo.ExistsByUserAndSkin(ctx, tx, userID, share.LibraryID)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			if exists {
+				bizErr = xError.NewError(ctx, xError.DataConflict, "您已拥有此皮肤", true)
+				return bizErr
+			}
+			_, bizErr = t.userSkinRepo.Create(ctx, tx, &entity.UserSkinLibrary{
+				UserID:         userID,
+				SkinLibraryID:  share.LibraryID,
+				AssignmentType: entityType.AssignmentTypeShare,
+			})
+			if bizErr != nil {
+				return bizErr
+			}
+		}
+
+		// 3. 写入领取记录（重复领取同一分享会在上一步因关联已存在而被拦截）
+		_, bizErr = t.shareRepo.CreateClaim(ctx, tx, &entity.LibraryShareClaim{
+			ShareID: share.ID,
+			UserID:  userID,
+		})
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 4. 领取人数 +1
+		bizErr = t.shareRepo.IncrementClaimCount(ctx, tx, share.ID, 1)
+		if bizErr != nil {
+			return bizErr
+		}
+		share.ClaimCount++
+		claimedShare = share
+
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "领取分享资源失败", true, err)
+	}
+	return claimedShare, nil
+}
+
+// RevokeShare 在事务内撤销分享链接，并移除尚未装备该资源的接收者关联。
+//
+// 事务序列：标记分享已撤销 → 删除通过该分享领取、且未在任何游戏档案上装备的分享关联。
+// 已装备的接收者保留关联，之后仍可正常使用。返回被移除的关联数。
+func (t *LibraryTxnRepo) RevokeShare(
+	ctx context.Context,
+	share *entity.LibraryShare,
+	now time.Time,
+) (int64, *xError.Error) {
+	t.log.Info(ctx, "RevokeShare - 事务内撤销分享链接")
+
+	var detached int64
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 标记撤销（并发撤销时仅一方生效）
+		revoked, xErr := t.shareRepo.MarkRevoked(ctx, tx, share.ID, now)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !revoked {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "分享链接已被撤销", true)
+			return bizErr
+		}
+
+		// 2. 移除未装备的接收者关联
+		if share.Kind == entityType.LibraryKindCape {
+			detached, bizErr = t.userCapeRepo.DeleteUnequippedSharesByShare(ctx, tx, share.LibraryID, share.ID)
+		} else {
+			detached, bizErr = t.userSkinRepo.DeleteUnequippedSharesByShare(ctx, tx, share.LibraryID, share.ID)
+		}
+		if bizErr != nil {
+			return bizErr
+		}
+
+		return nil
+	})
+	if bizErr != nil {
+		return 0, bizErr
+	}
+	if err != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "撤销分享链接失败", true, err)
+	}
+	return detached, nil
+}
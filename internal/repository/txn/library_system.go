@@ -0,0 +1,334 @@
+package txn
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	"gorm.io/gorm"
+)
+
+// CreateSystemSkin 在事务内创建系统内置皮肤（user_id 为空，不关联任何用户、不占用配额）。
+//
+// 事务序列：纹理哈希去重 → （设为默认装备时）清除其他皮肤的默认装备标记 → 创建皮肤记录。
+func (t *LibraryTxnRepo) CreateSystemSkin(ctx context.Context, skin *entity.SkinLibrary) (*entity.SkinLibrary, *xError.Error) {
+	t.log.Info(ctx, "CreateSystemSkin - 事务内创建系统皮肤")
+
+	var createdSkin *entity.SkinLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 纹理哈希去重
+		if _, found, xErr := t.skinRepo.GetByTextureHash(ctx, tx, skin.TextureHash); xErr != nil {
+			bizErr = xErr
+			return xErr
+		} else if found {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该皮肤纹理已存在", true)
+			return bizErr
+		}
+
+		// 2. 默认装备皮肤全局唯一
+		if skin.IsDefaultEquip {
+			if xErr := t.skinRepo.ClearDefaultEquip(ctx, tx); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 3. 创建皮肤记录
+		createdSkin, bizErr = t.skinRepo.Create(ctx, tx, skin)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建系统皮肤事务失败", true, err)
+	}
+	return createdSkin, nil
+}
+
+// UpdateSystemSkin 在事务内更新系统皮肤的名称、公开状态与目录标记，空指针表示保持原值。
+//
+// 已停用的皮肤不能再设为公开或默认；设为默认装备时自动设为默认发放，取消默认发放时同时取消默认装备。
+func (t *LibraryTxnRepo) UpdateSystemSkin(
+	ctx context.Context,
+	skinID xSnowflake.SnowflakeID,
+	name *string,
+	isPublic *bool,
+	isDefault *bool,
+	isDefaultEquip *bool,
+) (*entity.SkinLibrary, *xError.Error) {
+	t.log.Info(ctx, "UpdateSystemSkin - 事务内更新系统皮肤")
+
+	var updatedSkin *entity.SkinLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验系统皮肤存在
+		skinRec, xErr := t.getSystemSkin(ctx, tx, skinID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		if isDefault != nil && !*isDefault && isDefaultEquip != nil && *isDefaultEquip {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "默认装备皮肤必须同时为默认发放皮肤", true)
+			return bizErr
+		}
+
+		// 2. 合并更新字段
+		newName := skinRec.Name
+		if name != nil {
+			newName = *name
+		}
+		newPublic := skinRec.IsPublic
+		if isPublic != nil {
+			newPublic = *isPublic
+		}
+		newDefault := skinRec.IsDefault
+		if isDefault != nil {
+			newDefault = *isDefault
+		}
+		newDefaultEquip := skinRec.IsDefaultEquip
+		if isDefaultEquip != nil {
+			newDefaultEquip = *isDefaultEquip
+		}
+		if isDefault != nil && !*isDefault {
+			newDefaultEquip = false
+		}
+		if newDefaultEquip {
+			newDefault = true
+		}
+		if skinRec.IsRetired && (newPublic || newDefault || newDefaultEquip) {
+			bizErr = xError.NewError(ctx, xError.PermissionDenied, "已停用的系统皮肤不能设为公开或默认", true)
+			return bizErr
+		}
+
+		// 3. 默认装备皮肤全局唯一
+		if newDefaultEquip && !skinRec.IsDefaultEquip {
+			if xErr := t.skinRepo.ClearDefaultEquip(ctx, tx); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 4. 写入更新
+		if xErr := t.skinRepo.UpdateCatalog(ctx, tx, skinID, newName, newPublic, newDefault, newDefaultEquip, skinRec.IsRetired); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 5. 重新加载
+		updatedSkin, bizErr = t.getSystemSkin(ctx, tx, skinID)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "更新系统皮肤事务失败", true, err)
+	}
+	return updatedSkin, nil
+}
+
+// RetireSystemSkin 在事务内停用系统皮肤：取消公开与全部默认标记，已持有的用户不受影响。
+//
+// 返回停用前的皮肤记录，供调用方判断是否需要失效公开画廊缓存。
+func (t *LibraryTxnRepo) RetireSystemSkin(ctx context.Context, skinID xSnowflake.SnowflakeID) (*entity.SkinLibrary, *xError.Error) {
+	t.log.Info(ctx, "RetireSystemSkin - 事务内停用系统皮肤")
+
+	var skinRec *entity.SkinLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验系统皮肤存在且未停用
+		skinRec, bizErr = t.getSystemSkin(ctx, tx, skinID)
+		if bizErr != nil {
+			return bizErr
+		}
+		if skinRec.IsRetired {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该系统皮肤已停用", true)
+			return bizErr
+		}
+
+		// 2. 标记停用并清除公开与默认标记
+		if xErr := t.skinRepo.UpdateCatalog(ctx, tx, skinID, skinRec.Name, false, false, false, true); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "停用系统皮肤事务失败", true, err)
+	}
+	return skinRec, nil
+}
+
+// getSystemSkin 查询系统内置皮肤，记录不存在或属于用户上传时返回未找到错误。
+func (t *LibraryTxnRepo) getSystemSkin(ctx context.Context, tx *gorm.DB, skinID xSnowflake.SnowflakeID) (*entity.SkinLibrary, *xError.Error) {
+	skinRec, found, xErr := t.skinRepo.GetByID(ctx, tx, skinID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found || skinRec.UserID != nil {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "系统皮肤不存在", true)
+	}
+	return skinRec, nil
+}
+
+// CreateSystemCape 在事务内创建系统内置披风（user_id 为空，不关联任何用户、不占用配额）。
+//
+// 事务序列：纹理哈希去重 → 创建披风记录。
+func (t *LibraryTxnRepo) CreateSystemCape(ctx context.Context, cape *entity.CapeLibrary) (*entity.CapeLibrary, *xError.Error) {
+	t.log.Info(ctx, "CreateSystemCape - 事务内创建系统披风")
+
+	var createdCape *entity.CapeLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 纹理哈希去重
+		if _, found, xErr := t.capeRepo.GetByTextureHash(ctx, tx, cape.TextureHash); xErr != nil {
+			bizErr = xErr
+			return xErr
+		} else if found {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该披风纹理已存在", true)
+			return bizErr
+		}
+
+		// 2. 创建披风记录
+		createdCape, bizErr = t.capeRepo.Create(ctx, tx, cape)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建系统披风事务失败", true, err)
+	}
+	return createdCape, nil
+}
+
+// UpdateSystemCape 在事务内更新系统披风的名称、公开状态与目录标记，空指针表示保持原值。
+//
+// 已停用的披风不能再设为公开或默认。
+func (t *LibraryTxnRepo) UpdateSystemCape(
+	ctx context.Context,
+	capeID xSnowflake.SnowflakeID,
+	name *string,
+	isPublic *bool,
+	isDefault *bool,
+) (*entity.CapeLibrary, *xError.Error) {
+	t.log.Info(ctx, "UpdateSystemCape - 事务内更新系统披风")
+
+	var updatedCape *entity.CapeLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验系统披风存在
+		capeRec, xErr := t.getSystemCape(ctx, tx, capeID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 2. 合并更新字段
+		newName := capeRec.Name
+		if name != nil {
+			newName = *name
+		}
+		newPublic := capeRec.IsPublic
+		if isPublic != nil {
+			newPublic = *isPublic
+		}
+		newDefault := capeRec.IsDefault
+		if isDefault != nil {
+			newDefault = *isDefault
+		}
+		if capeRec.IsRetired && (newPublic || newDefault) {
+			bizErr = xError.NewError(ctx, xError.PermissionDenied, "已停用的系统披风不能设为公开或默认", true)
+			return bizErr
+		}
+
+		// 3. 写入更新
+		if xErr := t.capeRepo.UpdateCatalog(ctx, tx, capeID, newName, newPublic, newDefault, capeRec.IsRetired); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 4. 重新加载
+		updatedCape, bizErr = t.getSystemCape(ctx, tx, capeID)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "更新系统披风事务失败", true, err)
+	}
+	return updatedCape, nil
+}
+
+// RetireSystemCape 在事务内停用系统披风：取消公开与全部默认标记，已持有的用户不受影响。
+//
+// 返回停用前的披风记录，供调用方判断是否需要失效公开画廊缓存。
+func (t *LibraryTxnRepo) RetireSystemCape(ctx context.Context, capeID xSnowflake.SnowflakeID) (*entity.CapeLibrary, *xError.Error) {
+	t.log.Info(ctx, "RetireSystemCape - 事务内停用系统披风")
+
+	var capeRec *entity.CapeLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验系统披风存在且未停用
+		capeRec, bizErr = t.getSystemCape(ctx, tx, capeID)
+		if bizErr != nil {
+			return bizErr
+		}
+		if capeRec.IsRetired {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该系统披风已停用", true)
+			return bizErr
+		}
+
+		// 2. 标记停用并清除公开与默认标记
+		if xErr := t.capeRepo.UpdateCatalog(ctx, tx, capeID, capeRec.Name, false, false, true); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "停用系统披风事务失败", true, err)
+	}
+	return capeRec, nil
+}
+
+// getSystemCape 查询系统内置披风，记录不存在或属于用户上传时返回未找到错误。
+func (t *LibraryTxnRepo) getSystemCape(ctx context.Context, tx *gorm.DB, capeID xSnowflake.SnowflakeID) (*entity.CapeLibrary, *xError.Error) {
+	capeRec, found, xErr := t.capeRepo.GetByID(ctx, tx, capeID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found || capeRec.UserID != nil {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "系统披风不存在", true)
+	}
+	return capeRec, nil
+}
@@ -0,0 +1,649 @@
+package txn
+
+import (
+	"context"
+	"fmt"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// ReplaceSkinTexture 在事务内替换皮肤纹理并记录版本历史，皮肤 ID、点赞、收藏与装备关系保持不变。
+//
+// 事务序列：
+//  1. 行锁查询皮肤记录并校验当前用户为上传者且以 Normal 关联持有
+//  2. 纹理哈希去重：与当前纹理相同或已被其他皮肤使用时拒绝
+//  3. 历史皮肤首次替换时补录当前纹理为初始版本
+//  4. 新哈希命中自身历史版本时直接切换到该版本，否则追加新版本
+//  5. 已通过审核的公开皮肤暂存为待审核版本，审核通过前继续使用原纹理；
+//     其余皮肤直接更新当前纹理，公开皮肤重新进入待审核
+//  6. 超出 maxVersions 的最旧版本被裁剪（当前版本与待审核版本始终保留）
+//
+// 返回更新后的皮肤记录、被裁剪的版本记录（供 Logic 层按哈希引用释放文件），
+// 以及本次上传的文件是否被新版本采用（未采用时由调用方放弃该缓存态文件）。
+func (t *LibraryTxnRepo) ReplaceSkinTexture(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	skinID xSnowflake.SnowflakeID,
+	texture int64,
+	textureHash string,
+	perceptualHash *int64,
+	maxVersions int,
+) (*entity.SkinLibrary, []entity.LibraryTextureVersion, bool, *xError.Error) {
+	t.log.Info(ctx, "ReplaceSkinTexture - 事务内替换皮肤纹理")
+
+	var updatedSkin *entity.SkinLibrary
+	var pruned []entity.LibraryTextureVersion
+	var adopted bool
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验上传者身份
+		skinRec, xErr := t.getOwnedSkinForTexture(ctx, tx, userID, skinID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 2. 纹理哈希去重
+		if skinRec.TextureHash == textureHash {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "新纹理与当前纹理相同", true)
+			return bizErr
+		}
+		if _, found, xErr := t.skinRepo.GetByTextureHash(ctx, tx, textureHash); xErr != nil {
+			bizErr = xErr
+			return xErr
+		} else if found {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该皮肤纹理已存在", true)
+			return bizErr
+		}
+
+		// 3. 补录初始版本
+		maxVersion, xErr := t.ensureInitialTextureVersion(ctx, tx, entityType.LibraryKindSkin, skinRec.ID, skinRec.TextureVersion, skinRec.Texture, skinRec.TextureHash, skinRec.PerceptualHash, userID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 4. 命中历史版本则切换，否则追加新版本
+		target, found, xErr := t.versionRepo.GetByTargetAndHash(ctx, tx, entityType.LibraryKindSkin, skinID, textureHash)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			target, bizErr = t.versionRepo.Create(ctx, tx, &entity.LibraryTextureVersion{
+				Kind:           entityType.LibraryKindSkin,
+				LibraryID:      skinID,
+				Version:        maxVersion + 1,
+				Texture:        texture,
+				TextureHash:    textureHash,
+				PerceptualHash: perceptualHash,
+				CreatedBy:      userID,
+			})
+			if bizErr != nil {
+				return bizErr
+			}
+			adopted = true
+		}
+
+		// 5. 已通过审核的公开皮肤暂存新版本，其余皮肤直接更新当前纹理
+		currentVersion := target.Version
+		var pendingVersion *int32
+		if skinRec.IsPublic && skinRec.ReviewStatus == entityType.ReviewStatusApproved {
+			if skinRec.PendingTextureVersion != nil && *skinRec.PendingTextureVersion == target.Version {
+				bizErr = xError.NewError(ctx, xError.ParameterError, "该纹理已在等待审核", true)
+				return bizErr
+			}
+			currentVersion = skinRec.TextureVersion
+			pendingVersion = &target.Version
+			bizErr = t.skinRepo.UpdatePendingTextureVersion(ctx, tx, skinID, pendingVersion)
+			if bizErr != nil {
+				return bizErr
+			}
+		} else {
+			bizErr = t.skinRepo.UpdateTexture(ctx, tx, skinID, target.Texture, target.TextureHash, perceptualHash, target.Version)
+			if bizErr != nil {
+				return bizErr
+			}
+			if skinRec.IsPublic {
+				bizErr = t.skinRepo.UpdateReviewStatus(ctx, tx, skinID, entityType.ReviewStatusPending, true, nil, nil)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
+		}
+
+		// 6. 裁剪超出保留数量的旧版本
+		pruned, xErr = t.pruneTextureVersions(ctx, tx, entityType.LibraryKindSkin, skinID, currentVersion, pendingVersion, maxVersions)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		updatedSkin, _, bizErr = t.skinRepo.GetByID(ctx, tx, skinID)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, nil, false, bizErr
+	}
+	if err != nil {
+		return nil, nil, false, xError.NewError(ctx, xError.DatabaseError, "替换皮肤纹理失败", true, err)
+	}
+	return updatedSkin, pruned, adopted, nil
+}
+
+// RollbackSkinTexture 在事务内将皮肤纹理回滚到指定历史版本。
+//
+// 回滚仅切换当前版本号，不删除较新的版本。已通过审核的公开皮肤与替换一致暂存为待审核版本，
+// 审核通过前继续使用原纹理；回滚到当前版本视为撤销待审核的纹理变更。其余公开皮肤回滚后重新进入待审核。
+func (t *LibraryTxnRepo) RollbackSkinTexture(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	skinID xSnowflake.SnowflakeID,
+	version int32,
+) (*entity.SkinLibrary, *xError.Error) {
+	t.log.Info(ctx, "RollbackSkinTexture - 事务内回滚皮肤纹理")
+
+	var updatedSkin *entity.SkinLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验上传者身份
+		skinRec, xErr := t.getOwnedSkinForTexture(ctx, tx, userID, skinID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if skinRec.TextureVersion == version {
+			if skinRec.PendingTextureVersion == nil {
+				bizErr = xError.NewError(ctx, xError.ParameterError, "已是当前纹理版本", true)
+				return bizErr
+			}
+			// 撤销待审核的纹理变更，继续使用当前纹理
+			bizErr = t.skinRepo.UpdatePendingTextureVersion(ctx, tx, skinID, nil)
+			if bizErr != nil {
+				return bizErr
+			}
+			updatedSkin, _, bizErr = t.skinRepo.GetByID(ctx, tx, skinID)
+			return bizErr
+		}
+		if skinRec.PendingTextureVersion != nil && *skinRec.PendingTextureVersion == version {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "该纹理版本已在等待审核", true)
+			return bizErr
+		}
+
+		// 2. 查询目标版本
+		target, found, xErr := t.versionRepo.GetByTargetAndVersion(ctx, tx, entityType.LibraryKindSkin, skinID, version)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "纹理版本不存在", true)
+			return bizErr
+		}
+
+		// 3. 目标纹理已被其他皮肤使用时拒绝（纹理哈希全局唯一）
+		existing, found, xErr := t.skinRepo.GetByTextureHash(ctx, tx, target.TextureHash)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if found && existing.ID != skinID {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该版本纹理已被其他皮肤使用", true)
+			return bizErr
+		}
+
+		// 4. 已通过审核的公开皮肤暂存目标版本，其余皮肤直接切换当前纹理
+		if skinRec.IsPublic && skinRec.ReviewStatus == entityType.ReviewStatusApproved {
+			bizErr = t.skinRepo.UpdatePendingTextureVersion(ctx, tx, skinID, &target.Version)
+			if bizErr != nil {
+				return bizErr
+			}
+		} else {
+			bizErr = t.skinRepo.UpdateTexture(ctx, tx, skinID, target.Texture, target.TextureHash, target.PerceptualHash, target.Version)
+			if bizErr != nil {
+				return bizErr
+			}
+			if skinRec.IsPublic {
+				bizErr = t.skinRepo.UpdateReviewStatus(ctx, tx, skinID, entityType.ReviewStatusPending, true, nil, nil)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
+		}
+
+		updatedSkin, _, bizErr = t.skinRepo.GetByID(ctx, tx, skinID)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "回滚皮肤纹理失败", true, err)
+	}
+	return updatedSkin, nil
+}
+
+// ReplaceCapeTexture 在事务内替换披风纹理并记录版本历史，披风 ID、点赞、收藏与装备关系保持不变。
+//
+// 事务序列：
+//  1. 行锁查询披风记录并校验当前用户为上传者且以 Normal 关联持有
+//  2. 纹理哈希去重：与当前纹理相同或已被其他披风使用时拒绝
+//  3. 历史披风首次替换时补录当前纹理为初始版本
+//  4. 新哈希命中自身历史版本时直接切换到该版本，否则追加新版本
+//  5. 已通过审核的公开披风暂存为待审核版本，审核通过前继续使用原纹理；
+//     其余披风直接更新当前纹理，公开披风重新进入待审核
+//  6. 超出 maxVersions 的最旧版本被裁剪（当前版本与待审核版本始终保留）
+//
+// 返回更新后的披风记录、被裁剪的版本记录（供 Logic 层按哈希引用释放文件），
+// 以及本次上传的文件是否被新版本采用（未采用时由调用方放弃该缓存态文件）。
+func (t *LibraryTxnRepo) ReplaceCapeTexture(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	capeID xSnowflake.SnowflakeID,
+	texture int64,
+	textureHash string,
+	perceptualHash *int64,
+	maxVersions int,
+) (*entity.CapeLibrary, []entity.LibraryTextureVersion, bool, *xError.Error) {
+	t.log.Info(ctx, "ReplaceCapeTexture - 事务内替换披风纹理")
+
+	var updatedCape *entity.CapeLibrary
+	var pruned []entity.LibraryTextureVersion
+	var adopted bool
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验上传者身份
+		capeRec, xErr := t.getOwnedCapeForTexture(ctx, tx, userID, capeID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 2. 纹理哈希去重
+		if capeRec.TextureHash == textureHash {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "新纹理与当前纹理相同", true)
+			return bizErr
+		}
+		if _, found, xErr := t.capeRepo.GetByTextureHash(ctx, tx, textureHash); xErr != nil {
+			bizErr = xErr
+			return xErr
+		} else if found {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该披风纹理已存在", true)
+			return bizErr
+		}
+
+		// 3. 补录初始版本
+		maxVersion, xErr := t.ensureInitialTextureVersion(ctx, tx, entityType.LibraryKindCape, capeRec.ID, capeRec.TextureVersion, capeRec.Texture, capeRec.TextureHash, capeRec.PerceptualHash, userID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		// 4. 命中历史版本则切换，否则追加新版本
+		target, found, xErr := t.versionRepo.GetByTargetAndHash(ctx, tx, entityType.LibraryKindCape, capeID, textureHash)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			target, bizErr = t.versionRepo.Create(ctx, tx, &entity.LibraryTextureVersion{
+				Kind:           entityType.LibraryKindCape,
+				LibraryID:      capeID,
+				Version:        maxVersion + 1,
+				Texture:        texture,
+				TextureHash:    textureHash,
+				PerceptualHash: perceptualHash,
+				CreatedBy:      userID,
+			})
+			if bizErr != nil {
+				return bizErr
+			}
+			adopted = true
+		}
+
+		// 5. 已通过审核的公开披风暂存新版本，其余披风直接更新当前纹理
+		currentVersion := target.Version
+		var pendingVersion *int32
+		if capeRec.IsPublic && capeRec.ReviewStatus == entityType.ReviewStatusApproved {
+			if capeRec.PendingTextureVersion != nil && *capeRec.PendingTextureVersion == target.Version {
+				bizErr = xError.NewError(ctx, xError.ParameterError, "该纹理已在等待审核", true)
+				return bizErr
+			}
+			currentVersion = capeRec.TextureVersion
+			pendingVersion = &target.Version
+			bizErr = t.capeRepo.UpdatePendingTextureVersion(ctx, tx, capeID, pendingVersion)
+			if bizErr != nil {
+				return bizErr
+			}
+		} else {
+			bizErr = t.capeRepo.UpdateTexture(ctx, tx, capeID, target.Texture, target.TextureHash, perceptualHash, target.Version)
+			if bizErr != nil {
+				return bizErr
+			}
+			if capeRec.IsPublic {
+				bizErr = t.capeRepo.UpdateReviewStatus(ctx, tx, capeID, entityType.ReviewStatusPending, true, nil, nil)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
+		}
+
+		// 6. 裁剪超出保留数量的旧版本
+		pruned, xErr = t.pruneTextureVersions(ctx, tx, entityType.LibraryKindCape, capeID, currentVersion, pendingVersion, maxVersions)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+
+		updatedCape, _, bizErr = t.capeRepo.GetByID(ctx, tx, capeID)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, nil, false, bizErr
+	}
+	if err != nil {
+		return nil, nil, false, xError.NewError(ctx, xError.DatabaseError, "替换披风纹理失败", true, err)
+	}
+	return updatedCape, pruned, adopted, nil
+}
+
+// RollbackCapeTexture 在事务内将披风纹理回滚到指定历史版本。
+//
+// 回滚仅切换当前版本号，不删除较新的版本。已通过审核的公开披风与替换一致暂存为待审核版本，
+// 审核通过前继续使用原纹理；回滚到当前版本视为撤销待审核的纹理变更。其余公开披风回滚后重新进入待审核。
+func (t *LibraryTxnRepo) RollbackCapeTexture(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	capeID xSnowflake.SnowflakeID,
+	version int32,
+) (*entity.CapeLibrary, *xError.Error) {
+	t.log.Info(ctx, "RollbackCapeTexture - 事务内回滚披风纹理")
+
+	var updatedCape *entity.CapeLibrary
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 校验上传者身份
+		capeRec, xErr := t.getOwnedCapeForTexture(ctx, tx, userID, capeID)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if capeRec.TextureVersion == version {
+			if capeRec.PendingTextureVersion == nil {
+				bizErr = xError.NewError(ctx, xError.ParameterError, "已是当前纹理版本", true)
+				return bizErr
+			}
+			// 撤销待审核的纹理变更，继续使用当前纹理
+			bizErr = t.capeRepo.UpdatePendingTextureVersion(ctx, tx, capeID, nil)
+			if bizErr != nil {
+				return bizErr
+			}
+			updatedCape, _, bizErr = t.capeRepo.GetByID(ctx, tx, capeID)
+			return bizErr
+		}
+		if capeRec.PendingTextureVersion != nil && *capeRec.PendingTextureVersion == version {
+			bizErr = xError.NewError(ctx, xError.ParameterError, "该纹理版本已在等待审核", true)
+			return bizErr
+		}
+
+		// 2. 查询目标版本
+		target, found, xErr := t.versionRepo.GetByTargetAndVersion(ctx, tx, entityType.LibraryKindCape, capeID, version)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "纹理版本不存在", true)
+			return bizErr
+		}
+
+		// 3. 目标纹理已被其他披风使用时拒绝（纹理哈希全局唯一）
+		existing, found, xErr := t.capeRepo.GetByTextureHash(ctx, tx, target.TextureHash)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if found && existing.ID != capeID {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "该版本纹理已被其他披风使用", true)
+			return bizErr
+		}
+
+		// 4. 已通过审核的公开披风暂存目标版本，其余披风直接切换当前纹理
+		if capeRec.IsPublic && capeRec.ReviewStatus == entityType.ReviewStatusApproved {
+			bizErr = t.capeRepo.UpdatePendingTextureVersion(ctx, tx, capeID, &target.Version)
+			if bizErr != nil {
+				return bizErr
+			}
+		} else {
+			bizErr = t.capeRepo.UpdateTexture(ctx, tx, capeID, target.Texture, target.TextureHash, target.PerceptualHash, target.Version)
+			if bizErr != nil {
+				return bizErr
+			}
+			if capeRec.IsPublic {
+				bizErr = t.capeRepo.UpdateReviewStatus(ctx, tx, capeID, entityType.ReviewStatusPending, true, nil, nil)
+				if bizErr != nil {
+					return bizErr
+				}
+			}
+		}
+
+		updatedCape, _, bizErr = t.capeRepo.GetByID(ctx, tx, capeID)
+		if bizErr != nil {
+			return bizErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "回滚披风纹理失败", true, err)
+	}
+	return updatedCape, nil
+}
+
+// getOwnedSkinForTexture 行锁查询皮肤并校验当前用户为上传者且以 Normal 关联持有。
+func (t *LibraryTxnRepo) getOwnedSkinForTexture(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, skinID xSnowflake.SnowflakeID) (*entity.SkinLibrary, *xError.Error) {
+	skinRec, found, xErr := t.skinRepo.GetByIDAndUserID(ctx, tx, skinID, userID, true)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "皮肤不存在或不是由当前用户上传", true)
+	}
+
+	association, found, xErr := t.userSkinRepo.GetByUserAndSkin(ctx, tx, userID, skinID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found || association.AssignmentType != entityType.AssignmentTypeNormal {
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "仅上传者可修改皮肤纹理", true)
+	}
+	return skinRec, nil
+}
+
+// getOwnedCapeForTexture 行锁查询披风并校验当前用户为上传者且以 Normal 关联持有。
+func (t *LibraryTxnRepo) getOwnedCapeForTexture(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, capeID xSnowflake.SnowflakeID) (*entity.CapeLibrary, *xError.Error) {
+	capeRec, found, xErr := t.capeRepo.GetByIDAndUserID(ctx, tx, capeID, userID, true)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found {
+		return nil, xError.NewError(ctx, xError.ResourceNotFound, "披风不存在或不是由当前用户上传", true)
+	}
+
+	association, found, xErr := t.userCapeRepo.GetByUserAndCape(ctx, tx, userID, capeID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if !found || association.AssignmentType != entityType.AssignmentTypeNormal {
+		return nil, xError.NewError(ctx, xError.PermissionDenied, "仅上传者可修改披风纹理", true)
+	}
+	return capeRec, nil
+}
+
+// ensureInitialTextureVersion 资源尚无版本记录时将当前纹理补录为初始版本，返回当前最大版本号。
+func (t *LibraryTxnRepo) ensureInitialTextureVersion(
+	ctx context.Context,
+	tx *gorm.DB,
+	kind entityType.LibraryKind,
+	libraryID xSnowflake.SnowflakeID,
+	currentVersion int32,
+	texture int64,
+	textureHash string,
+	perceptualHash *int64,
+	userID xSnowflake.SnowflakeID,
+) (int32, *xError.Error) {
+	maxVersion, xErr := t.versionRepo.GetMaxVersion(ctx, tx, kind, libraryID)
+	if xErr != nil {
+		return 0, xErr
+	}
+	if maxVersion > 0 {
+		return maxVersion, nil
+	}
+
+	_, xErr = t.versionRepo.Create(ctx, tx, &entity.LibraryTextureVersion{
+		Kind:           kind,
+		LibraryID:      libraryID,
+		Version:        currentVersion,
+		Texture:        texture,
+		TextureHash:    textureHash,
+		PerceptualHash: perceptualHash,
+		CreatedBy:      userID,
+	})
+	if xErr != nil {
+		return 0, xErr
+	}
+	return currentVersion, nil
+}
+
+// pruneTextureVersions 删除超出保留数量的最旧版本（当前版本与待审核版本始终保留），返回被删除的版本记录。
+func (t *LibraryTxnRepo) pruneTextureVersions(
+	ctx context.Context,
+	tx *gorm.DB,
+	kind entityType.LibraryKind,
+	libraryID xSnowflake.SnowflakeID,
+	currentVersion int32,
+	pendingVersion *int32,
+	maxVersions int,
+) ([]entity.LibraryTextureVersion, *xError.Error) {
+	versions, xErr := t.versionRepo.ListByTarget(ctx, tx, kind, libraryID)
+	if xErr != nil {
+		return nil, xErr
+	}
+	if maxVersions <= 0 || len(versions) <= maxVersions {
+		return nil, nil
+	}
+
+	// versions 按版本号倒序，保留最新的 maxVersions 个（当前版本与待审核版本计入保留数量且不被裁剪）
+	keep := maxVersions - 1
+	if pendingVersion != nil && *pendingVersion != currentVersion {
+		keep--
+	}
+	var pruned []entity.LibraryTextureVersion
+	var prunedIDs []xSnowflake.SnowflakeID
+	kept := 0
+	for _, version := range versions {
+		if version.Version == currentVersion || (pendingVersion != nil && version.Version == *pendingVersion) {
+			continue
+		}
+		if kept < keep {
+			kept++
+			continue
+		}
+		pruned = append(pruned, version)
+		prunedIDs = append(prunedIDs, version.ID)
+	}
+
+	if xErr := t.versionRepo.DeleteByIDs(ctx, tx, prunedIDs); xErr != nil {
+		return nil, xErr
+	}
+	return pruned, nil
+}
+
+// promoteSkinPendingTexture 将皮肤的待审核纹理版本切换为当前纹理，并同步更新 skinRec。
+//
+// 纹理哈希全局唯一，暂存期间目标纹理已被其他皮肤使用时拒绝；待审核版本已不存在时仅清空暂存标记。
+func (t *LibraryTxnRepo) promoteSkinPendingTexture(ctx context.Context, tx *gorm.DB, skinRec *entity.SkinLibrary) *xError.Error {
+	target, found, xErr := t.versionRepo.GetByTargetAndVersion(ctx, tx, entityType.LibraryKindSkin, skinRec.ID, *skinRec.PendingTextureVersion)
+	if xErr != nil {
+		return xErr
+	}
+	if !found {
+		if xErr := t.skinRepo.UpdatePendingTextureVersion(ctx, tx, skinRec.ID, nil); xErr != nil {
+			return xErr
+		}
+		skinRec.PendingTextureVersion = nil
+		return nil
+	}
+
+	existing, found, xErr := t.skinRepo.GetByTextureHash(ctx, tx, target.TextureHash)
+	if xErr != nil {
+		return xErr
+	}
+	if found && existing.ID != skinRec.ID {
+		return xError.NewError(ctx, xError.DataConflict, xError.ErrMessage(fmt.Sprintf("皮肤「%s」的待审核纹理已被其他皮肤使用", skinRec.Name)), true)
+	}
+
+	if xErr := t.skinRepo.UpdateTexture(ctx, tx, skinRec.ID, target.Texture, target.TextureHash, target.PerceptualHash, target.Version); xErr != nil {
+		return xErr
+	}
+	skinRec.Texture = target.Texture
+	skinRec.TextureHash = target.TextureHash
+	skinRec.PerceptualHash = target.PerceptualHash
+	skinRec.TextureVersion = target.Version
+	skinRec.PendingTextureVersion = nil
+	return nil
+}
+
+// promoteCapePendingTexture 将披风的待审核纹理版本切换为当前纹理，并同步更新 capeRec。
+//
+// 同构于 promoteSkinPendingTexture，Skin → Cape。
+func (t *LibraryTxnRepo) promoteCapePendingTexture(ctx context.Context, tx *gorm.DB, capeRec *entity.CapeLibrary) *xError.Error {
+	target, found, xErr := t.versionRepo.GetByTargetAndVersion(ctx, tx, entityType.LibraryKindCape, capeRec.ID, *capeRec.PendingTextureVersion)
+	if xErr != nil {
+		return xErr
+	}
+	if !found {
+		if xErr := t.capeRepo.UpdatePendingTextureVersion(ctx, tx, capeRec.ID, nil); xErr != nil {
+			return xErr
+		}
+		capeRec.PendingTextureVersion = nil
+		return nil
+	}
+
+	existing, found, xErr := t.capeRepo.GetByTextureHash(ctx, tx, target.TextureHash)
+	if xErr != nil {
+		return xErr
+	}
+	if found && existing.ID != capeRec.ID {
+		return xError.NewError(ctx, xError.DataConflict, xError.ErrMessage(fmt.Sprintf("披风「%s」的待审核纹理已被其他披风使用", capeRec.Name)), true)
+	}
+
+	if xErr := t.capeRepo.UpdateTexture(ctx, tx, capeRec.ID, target.Texture, target.TextureHash, target.PerceptualHash, target.Version); xErr != nil {
+		return xErr
+	}
+	capeRec.Texture = target.Texture
+	capeRec.TextureHash = target.TextureHash
+	capeRec.PerceptualHash = target.PerceptualHash
+	capeRec.TextureVersion = target.Version
+	capeRec.PendingTextureVersion = nil
+	return nil
+}
@@ -0,0 +1,160 @@
+package txn
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+	"gorm.io/gorm"
+)
+
+// QuotaTierTxnRepo 角色配额档位事务协调仓储。
+//
+// 在单个事务内同时调整用户的游戏档案配额与资源库配额总额度，
+// 并为两类配额的变更分别写入配额日志。
+type QuotaTierTxnRepo struct {
+	db              *gorm.DB                         // GORM 数据库实例（用于开启事务）
+	log             *xLog.LogNamedLogger             // 日志实例
+	profileQuota    *repository.GameProfileQuotaRepo // 游戏档案配额仓储
+	libraryQuota    *repository.LibraryQuotaRepo     // 资源库配额仓储
+	libraryQuotaLog *repository.LibraryQuotaLogRepo  // 资源库配额日志仓储
+}
+
+// NewQuotaTierTxnRepo 初始化并返回 QuotaTierTxnRepo 实例。
+func NewQuotaTierTxnRepo(
+	db *gorm.DB,
+	profileQuota *repository.GameProfileQuotaRepo,
+	libraryQuota *repository.LibraryQuotaRepo,
+	libraryQuotaLog *repository.LibraryQuotaLogRepo,
+) *QuotaTierTxnRepo {
+	return &QuotaTierTxnRepo{
+		db:              db,
+		log:             xLog.WithName(xLog.NamedREPO, "QuotaTierTxnRepo"),
+		profileQuota:    profileQuota,
+		libraryQuota:    libraryQuota,
+		libraryQuotaLog: libraryQuotaLog,
+	}
+}
+
+// ApplyTier 在事务内将角色配额档位应用到指定用户。
+//
+// raiseOnly 为 true 时仅把低于档位的总额度提升到档位值，不会降低管理员手动调整或兑换获得的额度；
+// 为 false 时直接将总额度设为档位值（用于新用户初始化）。任何情况下总额度都不会低于已使用额度。
+//
+// 返回值中的 bool 表示是否有任一总额度发生变化。
+func (t *QuotaTierTxnRepo) ApplyTier(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	tier *entity.RoleQuotaTier,
+	raiseOnly bool,
+) (bool, *xError.Error) {
+	t.log.Info(ctx, "ApplyTier - 事务内应用角色配额档位")
+
+	var changed bool
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 游戏档案配额
+		profileQuota, _, xErr := t.profileQuota.GetByUserID(ctx, tx, userID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		newProfileTotal := tierTotal(profileQuota.Total, profileQuota.Used, tier.ProfileTotal, raiseOnly)
+		if newProfileTotal != profileQuota.Total {
+			profileVersion := gameProfileQuotaVersion(profileQuota)
+			if xErr := t.profileQuota.UpdateTotal(ctx, tx, profileQuota.ID, newProfileTotal); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+
+			// 按总额度变化方向区分增减类型，Delta 记录绝对值
+			opType := entityType.ObTypeRoleQuotaTier
+			delta := newProfileTotal - profileQuota.Total
+			if delta < 0 {
+				opType = entityType.ObTypeRoleQuotaTierLower
+				delta = -delta
+			}
+			remark := "应用角色配额档位 " + tier.RoleName.String()
+			quotaLog := &entity.GameProfileQuotaLog{
+				UserID:         userID,
+				OpType:         opType,
+				Delta:          delta,
+				BeforeUsed:     profileQuota.Used,
+				AfterUsed:      profileQuota.Used,
+				BeforeTotal:    profileQuota.Total,
+				AfterTotal:     newProfileTotal,
+				IdempotencyKey: fmt.Sprintf("%s:%s:%s:%s", opType.String(), tier.RoleName.String(), userID.String(), profileVersion),
+				Remark:         &remark,
+			}
+			if createErr := tx.Create(quotaLog).Error; createErr != nil {
+				bizErr = xError.NewError(ctx, xError.DatabaseError, "创建配额变更日志失败", true, createErr)
+				return bizErr
+			}
+			changed = true
+		}
+
+		// 2. 资源库配额
+		libraryQuota, _, xErr := t.libraryQuota.GetByUserID(ctx, tx, userID, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		skinsPublic := tierTotal(libraryQuota.SkinsPublicTotal, libraryQuota.SkinsPublicUsed, tier.SkinsPublicTotal, raiseOnly)
+		skinsPrivate := tierTotal(libraryQuota.SkinsPrivateTotal, libraryQuota.SkinsPrivateUsed, tier.SkinsPrivateTotal, raiseOnly)
+		capesPublic := tierTotal(libraryQuota.CapesPublicTotal, libraryQuota.CapesPublicUsed, tier.CapesPublicTotal, raiseOnly)
+		capesPrivate := tierTotal(libraryQuota.CapesPrivateTotal, libraryQuota.CapesPrivateUsed, tier.CapesPrivateTotal, raiseOnly)
+		if skinsPublic != libraryQuota.SkinsPublicTotal || skinsPrivate != libraryQuota.SkinsPrivateTotal ||
+			capesPublic != libraryQuota.CapesPublicTotal || capesPrivate != libraryQuota.CapesPrivateTotal {
+			if xErr := t.libraryQuota.UpdateAllTotal(ctx, tx, libraryQuota.ID, skinsPublic, skinsPrivate, capesPublic, capesPrivate); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+
+			before := *libraryQuota
+			libraryQuota.SkinsPublicTotal = skinsPublic
+			libraryQuota.SkinsPrivateTotal = skinsPrivate
+			libraryQuota.CapesPublicTotal = capesPublic
+			libraryQuota.CapesPrivateTotal = capesPrivate
+			remark := "应用角色配额档位 " + tier.RoleName.String()
+			if xErr := recordLibraryQuotaDiff(ctx, tx, t.libraryQuotaLog, &before, libraryQuota, entityType.LibraryQuotaOpRoleTier, tier.RoleName.String()+":"+libraryQuotaVersion(&before), nil, nil, &remark); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			changed = true
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return false, bizErr
+	}
+	if err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "应用角色配额档位失败", true, err)
+	}
+	return changed, nil
+}
+
+// tierTotal 计算应用档位后的总额度：raiseOnly 时取当前值与档位值的较大者，且结果不低于已使用额度。
+func tierTotal(current int32, used int32, tierValue int32, raiseOnly bool) int32 {
+	target := tierValue
+	if raiseOnly && current > target {
+		target = current
+	}
+	if target < used {
+		target = used
+	}
+	return target
+}
+
+// gameProfileQuotaVersion 返回游戏档案配额记录的版本标识（更新时间的微秒时间戳）。
+//
+// 与 libraryQuotaVersion 一致：同一档位针对同一配额版本的重放生成相同的幂等键，由唯一索引拒绝重复记账。
+func gameProfileQuotaVersion(quota *entity.GameProfileQuota) string {
+	return strconv.FormatInt(quota.UpdatedAt.UnixMicro(), 10)
+}
@@ -0,0 +1,31 @@
+package txn
+
+import "testing"
+
+// TestTierTotal 验证角色配额档位总额度计算：raiseOnly 时不降低现有额度，任何情况下不低于已使用额度。
+func TestTierTotal(t *testing.T) {
+	cases := []struct {
+		name      string
+		current   int32
+		used      int32
+		tierValue int32
+		raiseOnly bool
+		want      int32
+	}{
+		{name: "初始化直接取档位值", current: 0, used: 0, tierValue: 5, raiseOnly: false, want: 5},
+		{name: "初始化可降低总额度", current: 10, used: 2, tierValue: 5, raiseOnly: false, want: 5},
+		{name: "初始化不低于已使用额度", current: 10, used: 8, tierValue: 5, raiseOnly: false, want: 8},
+		{name: "仅提升时提升到档位值", current: 3, used: 1, tierValue: 5, raiseOnly: true, want: 5},
+		{name: "仅提升时保留更高的现有额度", current: 10, used: 1, tierValue: 5, raiseOnly: true, want: 10},
+		{name: "仅提升时不低于已使用额度", current: 3, used: 7, tierValue: 5, raiseOnly: true, want: 7},
+		{name: "档位值为零", current: 4, used: 0, tierValue: 0, raiseOnly: false, want: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tierTotal(tc.current, tc.used, tc.tierValue, tc.raiseOnly); got != tc.want {
+				t.Errorf("tierTotal(%d, %d, %d, %v) = %d, 期望 %d", tc.current, tc.used, tc.tierValue, tc.raiseOnly, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package txn
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"gorm.io/gorm"
+)
+
+// CreateRedeemCodeBatch 在事务内创建兑换码生成批次及其全部兑换码。
+//
+// 兑换码字符串冲突（唯一索引）时整批回滚，由调用方决定是否重新生成。
+func (t *LibraryTxnRepo) CreateRedeemCodeBatch(ctx context.Context, batch *entity.RedeemCodeBatch, codes []entity.RedeemCode) *xError.Error {
+	t.log.Info(ctx, "CreateRedeemCodeBatch - 事务内批量创建兑换码")
+
+	var bizErr *xError.Error
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if xErr := t.redeemRepo.CreateBatch(ctx, tx, batch, codes); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return bizErr
+	}
+	if err != nil {
+		return xError.NewError(ctx, xError.DatabaseError, "批量创建兑换码失败", true, err)
+	}
+	return nil
+}
+
+// RedeemCode 在事务内完成一次兑换码兑换。
+//
+// 事务序列：
+//  1. 行锁查询兑换码，校验未停用、未过期、未领完，且用户角色满足限制
+//  2. 写入 (兑换码, 用户) 兑换记录，已存在则拒绝重复兑换
+//  3. 发放皮肤/披风（Gift 关联，用户已拥有时拒绝兑换）
+//  4. 增加资源库配额总额度
+//  5. 增加游戏档案配额总额度，并以 REDEEM_CODE:{兑换码ID}:{用户ID} 为幂等键写入配额日志
+//  6. 兑换次数 +1
+//
+// 任一步骤失败整体回滚，兑换码次数不会被消耗。
+func (t *LibraryTxnRepo) RedeemCode(
+	ctx context.Context,
+	userID xSnowflake.SnowflakeID,
+	roleName *string,
+	code string,
+) (*entity.RedeemCode, *xError.Error) {
+	t.log.Info(ctx, "RedeemCode - 事务内兑换兑换码")
+
+	var redeemed *entity.RedeemCode
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 行锁查询并校验兑换码
+		redeemCode, found, xErr := t.redeemRepo.GetByCode(ctx, tx, code, true)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !found || redeemCode.IsDisabled {
+			bizErr = xError.NewError(ctx, xError.ResourceNotFound, "兑换码无效", true)
+			return bizErr
+		}
+		if redeemCode.ExpiresAt != nil && !redeemCode.ExpiresAt.After(time.Now()) {
+			bizErr = xError.NewError(ctx, xError.PermissionDenied, "兑换码已过期", true)
+			return bizErr
+		}
+		if redeemCode.UsedCount >= redeemCode.MaxUses {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "兑换码已被领完", true)
+			return bizErr
+		}
+		if !roleAllowed(redeemCode.AllowedRoles, roleName) {
+			bizErr = xError.NewError(ctx, xError.PermissionDenied, "当前账号不满足该兑换码的角色限制", true)
+			return bizErr
+		}
+
+		// 2. 写入兑换记录（每个用户对同一兑换码仅能兑换一次）
+		inserted, xErr := t.redeemRepo.CreateRedemptionIfAbsent(ctx, tx, &entity.RedeemCodeRedemption{
+			CodeID: redeemCode.ID,
+			UserID: userID,
+		})
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		if !inserted {
+			bizErr = xError.NewError(ctx, xError.DataConflict, "您已兑换过该兑换码", true)
+			return bizErr
+		}
+
+		// 3. 发放皮肤/披风
+		if redeemCode.SkinLibraryID != nil {
+			if xErr := t.grantRedeemSkin(ctx, tx, userID, *redeemCode.SkinLibraryID); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+		if redeemCode.CapeLibraryID != nil {
+			if xErr := t.grantRedeemCape(ctx, tx, userID, *redeemCode.CapeLibraryID); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 4. 增加资源库配额总额度并写入配额日志
+		if redeemCode.SkinsPublicDelta > 0 || redeemCode.SkinsPrivateDelta > 0 || redeemCode.CapesPublicDelta > 0 || redeemCode.CapesPrivateDelta > 0 {
+			quota, _, xErr := t.quotaRepo.GetByUserID(ctx, tx, userID, true)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			before := *quota
+			quota.SkinsPublicTotal += redeemCode.SkinsPublicDelta
+			quota.SkinsPrivateTotal += redeemCode.SkinsPrivateDelta
+			quota.CapesPublicTotal += redeemCode.CapesPublicDelta
+			quota.CapesPrivateTotal += redeemCode.CapesPrivateDelta
+			if xErr := t.quotaRepo.UpdateAllTotal(ctx, tx, quota.ID,
+				quota.SkinsPublicTotal, quota.SkinsPrivateTotal, quota.CapesPublicTotal, quota.CapesPrivateTotal,
+			); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			remark := "兑换码 " + redeemCode.Code
+			if xErr := recordLibraryQuotaDiff(ctx, tx, t.quotaLogRepo, &before, quota, entityType.LibraryQuotaOpRedeemCode, redeemCode.ID.String(), nil, nil, &remark); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 5. 增加游戏档案配额总额度并写入配额日志
+		if redeemCode.ProfileQuotaDelta > 0 {
+			quota, _, xErr := t.profileQuota.GetByUserID(ctx, tx, userID, true)
+			if xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			newTotal := quota.Total + redeemCode.ProfileQuotaDelta
+			if xErr := t.profileQuota.UpdateTotal(ctx, tx, quota.ID, newTotal); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+			remark := "兑换码 " + redeemCode.Code
+			quotaLog := &entity.GameProfileQuotaLog{
+				UserID:         userID,
+				OpType:         entityType.ObTypeRedeemCode,
+				Delta:          redeemCode.ProfileQuotaDelta,
+				BeforeUsed:     quota.Used,
+				AfterUsed:      quota.Used,
+				BeforeTotal:    quota.Total,
+				AfterTotal:     newTotal,
+				IdempotencyKey: fmt.Sprintf("%s:%s:%s", entityType.ObTypeRedeemCode.String(), redeemCode.ID.String(), userID.String()),
+				Remark:         &remark,
+			}
+			if createErr := tx.Create(quotaLog).Error; createErr != nil {
+				bizErr = xError.NewError(ctx, xError.DatabaseError, "创建配额变更日志失败", true, createErr)
+				return bizErr
+			}
+		}
+
+		// 6. 兑换次数 +1
+		if xErr := t.redeemRepo.IncrementUsed(ctx, tx, redeemCode.ID); xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		redeemCode.UsedCount++
+		redeemed = redeemCode
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "兑换兑换码失败", true, err)
+	}
+	return redeemed, nil
+}
+
+// grantRedeemSkin 在兑换事务内以 Gift 关联发放皮肤，用户已拥有（未到期）时拒绝。
+func (t *LibraryTxnRepo) grantRedeemSkin(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, skinLibraryID xSnowflake.SnowflakeID) *xError.Error {
+	skinRec, found, xErr := t.skinRepo.GetByID(ctx, tx, skinLibraryID)
+	if xErr != nil {
+		return xErr
+	}
+	if !found || skinRec.IsRetired {
+		return xError.NewError(ctx, xError.ResourceNotFound, "兑换码对应的皮肤已下架", true)
+	}
+
+	existing, exists, xErr := t.userSkinRepo.GetByUserAndSkin(ctx, tx, userID, skinLibraryID)
+	if xErr != nil {
+		return xErr
+	}
+	if exists && existing.ExpiresAt != nil && !existing.ExpiresAt.After(time.Now()) {
+		if _, xErr := t.userSkinRepo.DeleteExpiredByID(ctx, tx, existing.ID, time.Now()); xErr != nil {
+			return xErr
+		}
+		exists = false
+	}
+	if exists {
+		return xError.NewError(ctx, xError.DataConflict, "您已拥有该兑换码对应的皮肤", true)
+	}
+
+	_, xErr = t.userSkinRepo.Create(ctx, tx, &entity.UserSkinLibrary{
+		UserID:         userID,
+		SkinLibraryID:  skinLibraryID,
+		AssignmentType: entityType.AssignmentTypeGift,
+	})
+	return xErr
+}
+
+// grantRedeemCape 在兑换事务内以 Gift 关联发放披风，用户已拥有（未到期）时拒绝。
+func (t *LibraryTxnRepo) grantRedeemCape(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, capeLibraryID xSnowflake.SnowflakeID) *xError.Error {
+	capeRec, found, xErr := t.capeRepo.GetByID(ctx, tx, capeLibraryID)
+	if xErr != nil {
+		return xErr
+	}
+	if !found || capeRec.IsRetired {
+		return xError.NewError(ctx, xError.ResourceNotFound, "兑换码对应的披风已下架", true)
+	}
+
+	existing, exists, xErr := t.userCapeRepo.GetByUserAndCape(ctx, tx, userID, capeLibraryID)
+	if xErr != nil {
+		return xErr
+	}
+	if exists && existing.ExpiresAt != nil && !existing.ExpiresAt.After(time.Now()) {
+		if _, xErr := t.userCapeRepo.DeleteExpiredByID(ctx, tx, existing.ID, time.Now()); xErr != nil {
+			return xErr
+		}
+		exists = false
+	}
+	if exists {
+		return xError.NewError(ctx, xError.DataConflict, "您已拥有该兑换码对应的披风", true)
+	}
+
+	_, xErr = t.userCapeRepo.Create(ctx, tx, &entity.UserCapeLibrary{
+		UserID:         userID,
+		CapeLibraryID:  capeLibraryID,
+		AssignmentType: entityType.AssignmentTypeGift,
+	})
+	return xErr
+}
+
+// roleAllowed 判断用户角色是否满足兑换码的角色限制，allowedRoles 为空表示不限。
+func roleAllowed(allowedRoles []string, roleName *string) bool {
+	if len(allowedRoles) == 0 {
+		return true
+	}
+	if roleName == nil {
+		return false
+	}
+	for _, allowed := range allowedRoles {
+		if allowed == *roleName {
+			return true
+		}
+	}
+	return false
+}
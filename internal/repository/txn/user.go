@@ -0,0 +1,107 @@
+package txn
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
+	entityType "github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity/type"
+	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository"
+	"gorm.io/gorm"
+)
+
+// UserTxnRepo 用户事务协调仓储。
+//
+// 在单个事务内创建新用户并发放系统目录中的默认皮肤与披风，
+// 保证新用户不会处于“已创建但缺少默认资源”的中间状态。
+type UserTxnRepo struct {
+	db           *gorm.DB                        // GORM 数据库实例（用于开启事务）
+	log          *xLog.LogNamedLogger            // 日志实例
+	userRepo     *repository.UserRepo            // 用户仓储
+	skinRepo     *repository.SkinLibraryRepo     // 皮肤库仓储
+	capeRepo     *repository.CapeLibraryRepo     // 披风库仓储
+	userSkinRepo *repository.UserSkinLibraryRepo // 用户皮肤关联仓储
+	userCapeRepo *repository.UserCapeLibraryRepo // 用户披风关联仓储
+}
+
+// NewUserTxnRepo 初始化并返回 UserTxnRepo 实例。
+func NewUserTxnRepo(
+	db *gorm.DB,
+	userRepo *repository.UserRepo,
+	skinRepo *repository.SkinLibraryRepo,
+	capeRepo *repository.CapeLibraryRepo,
+	userSkinRepo *repository.UserSkinLibraryRepo,
+	userCapeRepo *repository.UserCapeLibraryRepo,
+) *UserTxnRepo {
+	return &UserTxnRepo{
+		db:           db,
+		log:          xLog.WithName(xLog.NamedREPO, "UserTxnRepo"),
+		userRepo:     userRepo,
+		skinRepo:     skinRepo,
+		capeRepo:     capeRepo,
+		userSkinRepo: userSkinRepo,
+		userCapeRepo: userCapeRepo,
+	}
+}
+
+// CreateUserWithDefaultLibrary 在事务内创建用户并以 Admin 关联发放所有未停用的默认系统皮肤与披风。
+//
+// 事务序列：创建用户记录 → 查询默认系统皮肤并逐条创建关联 → 查询默认系统披风并逐条创建关联。
+// 任一步骤失败整体回滚，用户记录不会落库，下次登录时重新走创建流程。
+func (t *UserTxnRepo) CreateUserWithDefaultLibrary(ctx context.Context, user *entity.User) (*entity.User, *xError.Error) {
+	t.log.Info(ctx, "CreateUserWithDefaultLibrary - 事务内创建用户并发放默认资源")
+
+	var createdUser *entity.User
+	var bizErr *xError.Error
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 创建用户记录
+		createdUser, bizErr = t.userRepo.Create(ctx, tx, user)
+		if bizErr != nil {
+			return bizErr
+		}
+
+		// 2. 发放默认系统皮肤
+		skins, xErr := t.skinRepo.ListDefaults(ctx, tx)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		for _, skin := range skins {
+			if _, xErr := t.userSkinRepo.Create(ctx, tx, &entity.UserSkinLibrary{
+				UserID:         user.ID,
+				SkinLibraryID:  skin.ID,
+				AssignmentType: entityType.AssignmentTypeAdmin,
+			}); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+
+		// 3. 发放默认系统披风
+		capes, xErr := t.capeRepo.ListDefaults(ctx, tx)
+		if xErr != nil {
+			bizErr = xErr
+			return xErr
+		}
+		for _, cape := range capes {
+			if _, xErr := t.userCapeRepo.Create(ctx, tx, &entity.UserCapeLibrary{
+				UserID:         user.ID,
+				CapeLibraryID:  cape.ID,
+				AssignmentType: entityType.AssignmentTypeAdmin,
+			}); xErr != nil {
+				bizErr = xErr
+				return xErr
+			}
+		}
+		return nil
+	})
+	if bizErr != nil {
+		return nil, bizErr
+	}
+	if err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建用户事务失败", true, err)
+	}
+	return createdUser, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/entity"
 	"github.com/frontleaves-mc/frontleaves-yggleaf/internal/repository/cache"
 	"github.com/redis/go-redis/v9"
@@ -116,6 +117,19 @@ func (r *UserRepo) Set(ctx context.Context, user *entity.User) (*entity.User, *x
 	return user, nil
 }
 
+// Create 创建用户记录。
+//
+// 支持事务参数，当 tx 非空时使用事务连接执行写入。该方法不写入缓存，
+// 以免事务回滚后缓存中残留未落库的用户；后续 Get 未命中缓存时会回源数据库并回填。
+func (r *UserRepo) Create(ctx context.Context, tx *gorm.DB, user *entity.User) (*entity.User, *xError.Error) {
+	r.log.Info(ctx, "Create - 创建用户记录")
+
+	if err := r.pickDB(ctx, tx).Create(user).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "创建用户失败", true, err)
+	}
+	return user, nil
+}
+
 // GetByEmail 根据邮箱查询用户。
 //
 // 该方法通过邮箱地址查询用户实体，用于 Yggdrasil 认证流程中按邮箱查找用户。
@@ -231,6 +245,66 @@ func (r *UserRepo) List(ctx context.Context, page, pageSize int, filter AdminUse
 	return users, total, nil
 }
 
+// CampaignUserFilter 资源发放活动的目标用户筛选条件，各条件之间为“且”关系。
+type CampaignUserFilter struct {
+	RoleName         *string
+	RegisteredAfter  *time.Time
+	RegisteredBefore *time.Time
+	HasProfile       *bool
+	UserIDs          []xSnowflake.SnowflakeID
+}
+
+// campaignQuery 根据发放活动筛选条件构建用户查询。
+func (r *UserRepo) campaignQuery(ctx context.Context, filter CampaignUserFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&entity.User{})
+	if filter.RoleName != nil && *filter.RoleName != "" {
+		query = query.Where("role_name = ?", *filter.RoleName)
+	}
+	if filter.RegisteredAfter != nil {
+		query = query.Where("created_at >= ?", *filter.RegisteredAfter)
+	}
+	if filter.RegisteredBefore != nil {
+		query = query.Where("created_at <= ?", *filter.RegisteredBefore)
+	}
+	if filter.HasProfile != nil {
+		exists := "EXISTS (SELECT 1 FROM fyl_game_profile WHERE fyl_game_profile.user_id = fyl_user.id)"
+		if !*filter.HasProfile {
+			exists = "NOT " + exists
+		}
+		query = query.Where(exists)
+	}
+	if len(filter.UserIDs) > 0 {
+		query = query.Where("id IN ?", filter.UserIDs)
+	}
+	return query
+}
+
+// CountForCampaign 统计符合发放活动筛选条件的用户数。
+func (r *UserRepo) CountForCampaign(ctx context.Context, filter CampaignUserFilter) (int64, *xError.Error) {
+	r.log.Info(ctx, "CountForCampaign - 统计发放活动目标用户数")
+
+	var total int64
+	if err := r.campaignQuery(ctx, filter).Count(&total).Error; err != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "统计发放活动目标用户数失败", true, err)
+	}
+	return total, nil
+}
+
+// ListIDsForCampaign 按用户 ID 升序查询 afterID 之后的一批符合发放活动筛选条件的用户 ID。
+func (r *UserRepo) ListIDsForCampaign(ctx context.Context, filter CampaignUserFilter, afterID xSnowflake.SnowflakeID, limit int) ([]xSnowflake.SnowflakeID, *xError.Error) {
+	r.log.Info(ctx, "ListIDsForCampaign - 查询发放活动目标用户")
+
+	var ids []xSnowflake.SnowflakeID
+	if err := r.campaignQuery(ctx, filter).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询发放活动目标用户失败", true, err)
+	}
+	return ids, nil
+}
+
 // AdminUserDetailAggregates 用户详情聚合数据（不含纹理 URL 解析）。
 type AdminUserDetailAggregates struct {
 	User          *entity.User
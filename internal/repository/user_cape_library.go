@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
@@ -77,7 +78,7 @@ func (r *UserCapeLibraryRepo) ListByUserID(ctx context.Context, tx *gorm.DB, use
 	r.log.Info(ctx, "ListByUserID - 根据用户 ID 查询披风关联列表")
 
 	var total int64
-	query := r.pickDB(ctx, tx).Model(&entity.UserCapeLibrary{}).Where("user_id = ?", userID)
+	query := r.pickDB(ctx, tx).Model(&entity.UserCapeLibrary{}).Where("user_id = ?", userID).Scopes(notExpired(""))
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询用户披风关联总数失败", true, err)
 	}
@@ -100,6 +101,7 @@ func (r *UserCapeLibraryRepo) ListAllByUserID(ctx context.Context, tx *gorm.DB,
 	if err := r.pickDB(ctx, tx).
 		Model(&entity.UserCapeLibrary{}).
 		Where("user_id = ?", userID).
+		Scopes(notExpired("")).
 		Preload("CapeLibrary").
 		Order("created_at DESC").
 		Find(&associations).Error; err != nil {
@@ -151,6 +153,95 @@ func (r *UserCapeLibraryRepo) CountNormalPrivateByUser(ctx context.Context, tx *
 	return count, nil
 }
 
+// ExistsEquippableByUserAndCape 判断用户是否拥有可装备的披风关联。
+//
+// 收藏（Collect）类型的关联仅在披风仍处于公开且审核通过状态时可装备，其余类型只要关联存在且未到期即可装备。
+func (r *UserCapeLibraryRepo) ExistsEquippableByUserAndCape(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, capeLibraryID xSnowflake.SnowflakeID) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsEquippableByUserAndCape - 判断用户是否拥有可装备的披风")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.UserCapeLibrary{}).
+		Joins("JOIN fyl_cape_library ON fyl_cape_library.id = fyl_user_cape_library.cape_library_id").
+		Where("fyl_user_cape_library.user_id = ? AND fyl_user_cape_library.cape_library_id = ?", userID, capeLibraryID).
+		Scopes(notExpired("fyl_user_cape_library")).
+		Where("(fyl_user_cape_library.assignment_type <> ? OR (fyl_cape_library.is_public = ? AND fyl_cape_library.review_status = ?))", entityType.AssignmentTypeCollect, true, entityType.ReviewStatusApproved).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询用户披风关联记录失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// DeleteCollectsByCape 删除指定披风的全部收藏关联，返回删除条数。
+func (r *UserCapeLibraryRepo) DeleteCollectsByCape(ctx context.Context, tx *gorm.DB, capeLibraryID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "DeleteCollectsByCape - 删除披风的全部收藏关联")
+
+	result := r.pickDB(ctx, tx).
+		Where("cape_library_id = ? AND assignment_type = ?", capeLibraryID, entityType.AssignmentTypeCollect).
+		Delete(&entity.UserCapeLibrary{})
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "删除披风收藏关联失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteUnequippedSharesByShare 删除通过指定分享链接领取、且领取者未在任何游戏档案上装备该披风的分享关联，返回删除条数。
+func (r *UserCapeLibraryRepo) DeleteUnequippedSharesByShare(ctx context.Context, tx *gorm.DB, capeLibraryID xSnowflake.SnowflakeID, shareID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "DeleteUnequippedSharesByShare - 删除未装备的披风分享关联")
+
+	result := r.pickDB(ctx, tx).
+		Where("cape_library_id = ? AND assignment_type = ?", capeLibraryID, entityType.AssignmentTypeShare).
+		Where("user_id IN (SELECT user_id FROM fyl_library_share_claim WHERE share_id = ?)", shareID).
+		Where("user_id NOT IN (SELECT user_id FROM fyl_game_profile WHERE cape_library_id = ?)", capeLibraryID).
+		Delete(&entity.UserCapeLibrary{})
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "删除披风分享关联失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteAllByCape 删除指定披风的全部用户关联（不区分关联类型），返回删除条数。
+func (r *UserCapeLibraryRepo) DeleteAllByCape(ctx context.Context, tx *gorm.DB, capeLibraryID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "DeleteAllByCape - 删除披风的全部用户关联")
+
+	result := r.pickDB(ctx, tx).
+		Where("cape_library_id = ?", capeLibraryID).
+		Delete(&entity.UserCapeLibrary{})
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "删除披风用户关联失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ListExpired 查询到期时间早于 now 的一批限时关联（按到期时间升序）。
+func (r *UserCapeLibraryRepo) ListExpired(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.UserCapeLibrary, *xError.Error) {
+	r.log.Info(ctx, "ListExpired - 查询已到期的披风关联")
+
+	var associations []entity.UserCapeLibrary
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.UserCapeLibrary{}).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&associations).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询已到期的披风关联失败", true, err)
+	}
+	return associations, nil
+}
+
+// DeleteExpiredByID 删除指定的已到期关联，返回是否实际删除（到期时间被延长或已被删除时返回 false）。
+func (r *UserCapeLibraryRepo) DeleteExpiredByID(ctx context.Context, tx *gorm.DB, associationID xSnowflake.SnowflakeID, now time.Time) (bool, *xError.Error) {
+	r.log.Info(ctx, "DeleteExpiredByID - 删除已到期的披风关联")
+
+	result := r.pickDB(ctx, tx).
+		Where("id = ? AND expires_at IS NOT NULL AND expires_at <= ?", associationID, now).
+		Delete(&entity.UserCapeLibrary{})
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "删除已到期的披风关联失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *UserCapeLibraryRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
 	if tx != nil {
 		return tx.WithContext(ctx)
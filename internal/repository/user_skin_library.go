@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
@@ -77,7 +78,7 @@ func (r *UserSkinLibraryRepo) ListByUserID(ctx context.Context, tx *gorm.DB, use
 	r.log.Info(ctx, "ListByUserID - 根据用户 ID 查询皮肤关联列表")
 
 	var total int64
-	query := r.pickDB(ctx, tx).Model(&entity.UserSkinLibrary{}).Where("user_id = ?", userID)
+	query := r.pickDB(ctx, tx).Model(&entity.UserSkinLibrary{}).Where("user_id = ?", userID).Scopes(notExpired(""))
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, xError.NewError(ctx, xError.DatabaseError, "查询用户皮肤关联总数失败", true, err)
 	}
@@ -100,6 +101,7 @@ func (r *UserSkinLibraryRepo) ListAllByUserID(ctx context.Context, tx *gorm.DB,
 	if err := r.pickDB(ctx, tx).
 		Model(&entity.UserSkinLibrary{}).
 		Where("user_id = ?", userID).
+		Scopes(notExpired("")).
 		Preload("SkinLibrary").
 		Order("created_at DESC").
 		Find(&associations).Error; err != nil {
@@ -151,9 +153,109 @@ func (r *UserSkinLibraryRepo) CountNormalPrivateByUser(ctx context.Context, tx *
 	return count, nil
 }
 
+// ExistsEquippableByUserAndSkin 判断用户是否拥有可装备的皮肤关联。
+//
+// 收藏（Collect）类型的关联仅在皮肤仍处于公开且审核通过状态时可装备，其余类型只要关联存在且未到期即可装备。
+func (r *UserSkinLibraryRepo) ExistsEquippableByUserAndSkin(ctx context.Context, tx *gorm.DB, userID xSnowflake.SnowflakeID, skinLibraryID xSnowflake.SnowflakeID) (bool, *xError.Error) {
+	r.log.Info(ctx, "ExistsEquippableByUserAndSkin - 判断用户是否拥有可装备的皮肤")
+
+	var count int64
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.UserSkinLibrary{}).
+		Joins("JOIN fyl_skin_library ON fyl_skin_library.id = fyl_user_skin_library.skin_library_id").
+		Where("fyl_user_skin_library.user_id = ? AND fyl_user_skin_library.skin_library_id = ?", userID, skinLibraryID).
+		Scopes(notExpired("fyl_user_skin_library")).
+		Where("(fyl_user_skin_library.assignment_type <> ? OR (fyl_skin_library.is_public = ? AND fyl_skin_library.review_status = ?))", entityType.AssignmentTypeCollect, true, entityType.ReviewStatusApproved).
+		Count(&count).Error; err != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "查询用户皮肤关联记录失败", true, err)
+	}
+	return count > 0, nil
+}
+
+// DeleteCollectsBySkin 删除指定皮肤的全部收藏关联，返回删除条数。
+func (r *UserSkinLibraryRepo) DeleteCollectsBySkin(ctx context.Context, tx *gorm.DB, skinLibraryID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "DeleteCollectsBySkin - 删除皮肤的全部收藏关联")
+
+	result := r.pickDB(ctx, tx).
+		Where("skin_library_id = ? AND assignment_type = ?", skinLibraryID, entityType.AssignmentTypeCollect).
+		Delete(&entity.UserSkinLibrary{})
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "删除皮肤收藏关联失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteUnequippedSharesByShare 删除通过指定分享链接领取、且领取者未在任何游戏档案上装备该皮肤的分享关联，返回删除条数。
+func (r *UserSkinLibraryRepo) DeleteUnequippedSharesByShare(ctx context.Context, tx *gorm.DB, skinLibraryID xSnowflake.SnowflakeID, shareID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "DeleteUnequippedSharesByShare - 删除未装备的皮肤分享关联")
+
+	result := r.pickDB(ctx, tx).
+		Where("skin_library_id = ? AND assignment_type = ?", skinLibraryID, entityType.AssignmentTypeShare).
+		Where("user_id IN (SELECT user_id FROM fyl_library_share_claim WHERE share_id = ?)", shareID).
+		Where("user_id NOT IN (SELECT user_id FROM fyl_game_profile WHERE skin_library_id = ?)", skinLibraryID).
+		Delete(&entity.UserSkinLibrary{})
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "删除皮肤分享关联失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteAllBySkin 删除指定皮肤的全部用户关联（不区分关联类型），返回删除条数。
+func (r *UserSkinLibraryRepo) DeleteAllBySkin(ctx context.Context, tx *gorm.DB, skinLibraryID xSnowflake.SnowflakeID) (int64, *xError.Error) {
+	r.log.Info(ctx, "DeleteAllBySkin - 删除皮肤的全部用户关联")
+
+	result := r.pickDB(ctx, tx).
+		Where("skin_library_id = ?", skinLibraryID).
+		Delete(&entity.UserSkinLibrary{})
+	if result.Error != nil {
+		return 0, xError.NewError(ctx, xError.DatabaseError, "删除皮肤用户关联失败", true, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ListExpired 查询到期时间早于 now 的一批限时关联（按到期时间升序）。
+func (r *UserSkinLibraryRepo) ListExpired(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.UserSkinLibrary, *xError.Error) {
+	r.log.Info(ctx, "ListExpired - 查询已到期的皮肤关联")
+
+	var associations []entity.UserSkinLibrary
+	if err := r.pickDB(ctx, tx).
+		Model(&entity.UserSkinLibrary{}).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&associations).Error; err != nil {
+		return nil, xError.NewError(ctx, xError.DatabaseError, "查询已到期的皮肤关联失败", true, err)
+	}
+	return associations, nil
+}
+
+// DeleteExpiredByID 删除指定的已到期关联，返回是否实际删除（到期时间被延长或已被删除时返回 false）。
+func (r *UserSkinLibraryRepo) DeleteExpiredByID(ctx context.Context, tx *gorm.DB, associationID xSnowflake.SnowflakeID, now time.Time) (bool, *xError.Error) {
+	r.log.Info(ctx, "DeleteExpiredByID - 删除已到期的皮肤关联")
+
+	result := r.pickDB(ctx, tx).
+		Where("id = ? AND expires_at IS NOT NULL AND expires_at <= ?", associationID, now).
+		Delete(&entity.UserSkinLibrary{})
+	if result.Error != nil {
+		return false, xError.NewError(ctx, xError.DatabaseError, "删除已到期的皮肤关联失败", true, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *UserSkinLibraryRepo) pickDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
 	if tx != nil {
 		return tx.WithContext(ctx)
 	}
 	return r.db.WithContext(ctx)
 }
+
+// notExpired 返回排除已到期限时关联的查询作用域，table 非空时为 expires_at 加上表名前缀（JOIN 查询使用）。
+func notExpired(table string) func(*gorm.DB) *gorm.DB {
+	column := "expires_at"
+	if table != "" {
+		column = table + ".expires_at"
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("("+column+" IS NULL OR "+column+" > ?)", time.Now())
+	}
+}
@@ -0,0 +1,41 @@
+package context
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
+	bConst "github.com/frontleaves-mc/frontleaves-yggleaf/internal/constant"
+	bStorage "github.com/frontleaves-mc/frontleaves-yggleaf/pkg/storage"
+)
+
+// MustGetStorage 从上下文中获取文件存储后端实例
+//
+// 该函数从传入的 `ctx` 中提取与 `bConst.CtxStorageKey` 关联的 `bStorage.Storage` 值。
+// 如果上下文中不存在该键或类型断言失败，将触发 panic。
+//
+// 参数说明:
+//   - ctx: 包含文件存储后端的上下文对象
+//
+// 返回值:
+//   - bStorage.Storage: 文件存储后端实例（bucket 或 local）
+//
+// 注意: 此函数为 Must 风格，调用前需确保上下文已正确注入文件存储后端，否则会导致程序崩溃。
+func MustGetStorage(ctx context.Context) bStorage.Storage {
+	return xCtxUtil.MustGet[bStorage.Storage](ctx, bConst.CtxStorageKey)
+}
+
+// GetStorage 从上下文中获取文件存储后端实例
+//
+// 该函数用于从给定的 `context.Context` 中提取预存的文件存储后端实例。
+// 它通过 `bConst.CtxStorageKey` 作为键来检索上下文中的值。
+//
+// 参数说明:
+//   - ctx: 包含文件存储后端的上下文对象。
+//
+// 返回值:
+//   - bStorage.Storage: 成功时返回文件存储后端实例。
+//   - *xError.Error: 当上下文中不存在该键或类型断言失败时返回错误。
+func GetStorage(ctx context.Context) (bStorage.Storage, *xError.Error) {
+	return xCtxUtil.Get[bStorage.Storage](ctx, bConst.CtxStorageKey)
+}
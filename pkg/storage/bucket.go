@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	bBucket "github.com/phalanx-labs/beacon-bucket-sdk"
+	bBucketApi "github.com/phalanx-labs/beacon-bucket-sdk/api"
+)
+
+// BucketTarget 业务分类对应的存储桶与存储路径。
+type BucketTarget struct {
+	BucketID string // 存储桶 ID
+	PathID   string // 存储路径 ID
+}
+
+// bucketReadTimeout 读取文件内容的 HTTP 超时时间。
+const bucketReadTimeout = 30 * time.Second
+
+// BucketStorage 基于 beacon-bucket 对象存储服务的 Storage 实现。
+type BucketStorage struct {
+	client     *bBucket.BucketClient
+	targets    map[Category]BucketTarget
+	httpClient *http.Client // 读取文件内容（beacon-bucket 仅通过对象链接提供文件内容）
+}
+
+// NewBucketStorage 创建 beacon-bucket 存储实现，targets 为各业务分类的上传目标。
+func NewBucketStorage(client *bBucket.BucketClient, targets map[Category]BucketTarget) *BucketStorage {
+	return &BucketStorage{
+		client:     client,
+		targets:    targets,
+		httpClient: &http.Client{Timeout: bucketReadTimeout},
+	}
+}
+
+// Upload 上传文件到业务分类对应的存储桶。
+func (s *BucketStorage) Upload(ctx context.Context, category Category, contentBase64 string) (*FileInfo, error) {
+	target, ok := s.targets[category]
+	if !ok || target.BucketID == "" || target.PathID == "" {
+		return nil, fmt.Errorf("storage: bucket target for %q is not configured", category)
+	}
+
+	resp, err := s.client.Normal.Upload(ctx, &bBucketApi.UploadRequest{
+		BucketId:      target.BucketID,
+		PathId:        target.PathID,
+		ContentBase64: contentBase64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{
+		FileID:  resp.GetFileId(),
+		Size:    resp.GetSize(),
+		Sha256:  resp.GetEtag().GetSha256(),
+		IsCache: resp.GetIsCache(),
+		Link:    resp.GetObj().GetLink(),
+	}, nil
+}
+
+// Get 获取单个文件的元数据与下载链接。
+func (s *BucketStorage) Get(ctx context.Context, fileID string) (*FileInfo, error) {
+	resp, err := s.client.Normal.Get(ctx, &bBucketApi.GetRequest{
+		FileId: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{
+		FileID:  resp.GetFileId(),
+		Size:    resp.GetSize(),
+		Sha256:  resp.GetEtag().GetSha256(),
+		IsCache: resp.GetIsCache(),
+		Link:    resp.GetObj().GetLink(),
+	}, nil
+}
+
+// GetByList 批量获取文件元数据。
+func (s *BucketStorage) GetByList(ctx context.Context, fileIDs []string) ([]*FileInfo, error) {
+	resp, err := s.client.Normal.GetByList(ctx, &bBucketApi.GetByListRequest{
+		FileIdList: fileIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*FileInfo, 0, len(resp.GetFileInfoList()))
+	for _, info := range resp.GetFileInfoList() {
+		infos = append(infos, &FileInfo{
+			FileID:  info.GetFileId(),
+			Size:    info.GetSize(),
+			Sha256:  info.GetEtag().GetSha256(),
+			IsCache: info.GetIsCache(),
+			Link:    info.GetObj().GetLink(),
+		})
+	}
+	return infos, nil
+}
+
+// Read 通过文件对象链接读取文件内容。
+//
+// beacon-bucket 未提供内容读取 RPC，链接由 Get 解析，读取逻辑收敛在存储实现内，业务层无需感知链接。
+func (s *BucketStorage) Read(ctx context.Context, fileID string, maxSize int64) ([]byte, error) {
+	info, err := s.Get(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if info.Link == "" {
+		return nil, fmt.Errorf("storage: bucket file %s has no link", fileID)
+	}
+	if info.Size > maxSize {
+		return nil, ErrTooLarge
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, info.Link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("storage: build bucket read request: %w", err)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage: read bucket file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("storage: read bucket file: unexpected status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("storage: read bucket file: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
+
+// CacheVerify 将缓存态文件确认为永久态。
+func (s *BucketStorage) CacheVerify(ctx context.Context, fileID string) error {
+	_, err := s.client.Normal.CacheVerify(ctx, &bBucketApi.CacheVerifyRequest{
+		FileId: fileID,
+	})
+	return err
+}
+
+// Delete 删除文件。
+func (s *BucketStorage) Delete(ctx context.Context, fileID string) error {
+	_, err := s.client.Normal.Delete(ctx, &bBucketApi.DeleteRequest{
+		FileId: fileID,
+	})
+	return err
+}
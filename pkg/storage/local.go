@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocalFileRoutePrefix 本地存储文件下载路由前缀，由服务自身提供下载。
+const LocalFileRoutePrefix = "/api/v1/storage/files/"
+
+// localFileMeta 本地存储文件的元数据，以 JSON 形式保存在文件旁。
+type localFileMeta struct {
+	Category   Category  `json:"category"`    // 业务分类
+	Size       int64     `json:"size"`        // 文件大小
+	Sha256     string    `json:"sha256"`      // 文件内容 SHA256
+	MimeType   string    `json:"mime_type"`   // 文件 MIME 类型
+	IsCache    bool      `json:"is_cache"`    // 是否仍为缓存态
+	UploadedAt time.Time `json:"uploaded_at"` // 上传时间
+}
+
+// LocalStorage 基于本地文件系统的 Storage 实现，适用于开发与 CI 环境。
+//
+// 文件按 ID 平铺保存在 root 目录下，元数据保存在同名 .json 文件中；
+// 下载链接指向服务自身的 LocalFileRoutePrefix 路由。缓存态仅作标记，
+// 未确认的文件不会被自动清理（可由存储对账任务发现）。多实例部署需共享 root 目录。
+type LocalStorage struct {
+	root      string
+	publicURL string
+
+	mu     sync.Mutex
+	lastID int64
+}
+
+// NewLocalStorage 创建本地存储实现，root 不存在时自动创建。
+//
+// publicURL 为服务对外访问地址（如 http://127.0.0.1:8080），用于拼接下载链接。
+func NewLocalStorage(root string, publicURL string) (*LocalStorage, error) {
+	if root == "" {
+		return nil, errors.New("storage: local root is empty")
+	}
+	if publicURL == "" {
+		return nil, errors.New("storage: local public url is empty")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: create local root: %w", err)
+	}
+	return &LocalStorage{
+		root:      root,
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}, nil
+}
+
+// Upload 解码并写入文件，新文件处于缓存态。
+func (s *LocalStorage) Upload(_ context.Context, category Category, contentBase64 string) (*FileInfo, error) {
+	data, err := decodeBase64Content(contentBase64)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	meta := localFileMeta{
+		Category:   category,
+		Size:       int64(len(data)),
+		Sha256:     hex.EncodeToString(sum[:]),
+		MimeType:   http.DetectContentType(data),
+		IsCache:    true,
+		UploadedAt: time.Now(),
+	}
+
+	for {
+		fileID := s.nextID()
+		file, err := os.OpenFile(s.dataPath(fileID), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("storage: create local file: %w", err)
+		}
+		if _, err := file.Write(data); err != nil {
+			_ = file.Close()
+			_ = os.Remove(s.dataPath(fileID))
+			return nil, fmt.Errorf("storage: write local file: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			_ = os.Remove(s.dataPath(fileID))
+			return nil, fmt.Errorf("storage: write local file: %w", err)
+		}
+		if err := s.writeMeta(fileID, &meta); err != nil {
+			_ = os.Remove(s.dataPath(fileID))
+			return nil, err
+		}
+		return s.fileInfo(fileID, &meta), nil
+	}
+}
+
+// Get 获取单个文件的元数据与下载链接。
+func (s *LocalStorage) Get(_ context.Context, fileID string) (*FileInfo, error) {
+	id, err := parseLocalFileID(fileID)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := s.readMeta(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.fileInfo(id, meta), nil
+}
+
+// GetByList 按请求顺序批量获取文件元数据，不存在的文件会被跳过。
+func (s *LocalStorage) GetByList(ctx context.Context, fileIDs []string) ([]*FileInfo, error) {
+	infos := make([]*FileInfo, 0, len(fileIDs))
+	for _, fileID := range fileIDs {
+		info, err := s.Get(ctx, fileID)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Read 从本地文件系统读取文件内容。
+func (s *LocalStorage) Read(_ context.Context, fileID string, maxSize int64) ([]byte, error) {
+	file, _, _, err := s.Open(fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("storage: read local file: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
+
+// CacheVerify 将缓存态文件确认为永久态。
+func (s *LocalStorage) CacheVerify(_ context.Context, fileID string) error {
+	id, err := parseLocalFileID(fileID)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	meta, err := s.readMeta(id)
+	if err != nil {
+		return err
+	}
+	if !meta.IsCache {
+		return nil
+	}
+	meta.IsCache = false
+	return s.writeMeta(id, meta)
+}
+
+// Delete 删除文件及其元数据。
+func (s *LocalStorage) Delete(_ context.Context, fileID string) error {
+	id, err := parseLocalFileID(fileID)
+	if err != nil {
+		return err
+	}
+
+	dataErr := os.Remove(s.dataPath(id))
+	metaErr := os.Remove(s.metaPath(id))
+	if errors.Is(dataErr, fs.ErrNotExist) && errors.Is(metaErr, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	if dataErr != nil && !errors.Is(dataErr, fs.ErrNotExist) {
+		return fmt.Errorf("storage: delete local file: %w", dataErr)
+	}
+	if metaErr != nil && !errors.Is(metaErr, fs.ErrNotExist) {
+		return fmt.Errorf("storage: delete local file meta: %w", metaErr)
+	}
+	return nil
+}
+
+// Open 打开文件用于下载，调用方负责关闭返回的文件。
+func (s *LocalStorage) Open(fileID string) (*os.File, string, time.Time, error) {
+	id, err := parseLocalFileID(fileID)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	meta, err := s.readMeta(id)
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	file, err := os.Open(s.dataPath(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", time.Time{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, "", time.Time{}, fmt.Errorf("storage: open local file: %w", err)
+	}
+	return file, meta.MimeType, meta.UploadedAt, nil
+}
+
+// nextID 生成单调递增的文件 ID（微秒时间戳，同一微秒内顺延）。
+func (s *LocalStorage) nextID() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := time.Now().UnixMicro()
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return id
+}
+
+func (s *LocalStorage) fileInfo(id int64, meta *localFileMeta) *FileInfo {
+	fileID := strconv.FormatInt(id, 10)
+	return &FileInfo{
+		FileID:  fileID,
+		Size:    meta.Size,
+		Sha256:  meta.Sha256,
+		IsCache: meta.IsCache,
+		Link:    s.publicURL + LocalFileRoutePrefix + fileID,
+	}
+}
+
+func (s *LocalStorage) readMeta(id int64) (*localFileMeta, error) {
+	raw, err := os.ReadFile(s.metaPath(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage: read local file meta: %w", err)
+	}
+	var meta localFileMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, fmt.Errorf("storage: decode local file meta: %w", err)
+	}
+	return &meta, nil
+}
+
+// writeMeta 先写临时文件再重命名，避免读取到写了一半的元数据。
+func (s *LocalStorage) writeMeta(id int64, meta *localFileMeta) error {
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("storage: encode local file meta: %w", err)
+	}
+	tmpPath := s.metaPath(id) + ".tmp"
+	if err := os.WriteFile(tmpPath, raw, 0o644); err != nil {
+		return fmt.Errorf("storage: write local file meta: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.metaPath(id)); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("storage: write local file meta: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) dataPath(id int64) string {
+	return filepath.Join(s.root, strconv.FormatInt(id, 10))
+}
+
+func (s *LocalStorage) metaPath(id int64) string {
+	return filepath.Join(s.root, strconv.FormatInt(id, 10)+".json")
+}
+
+// parseLocalFileID 校验文件 ID 为正整数，防止路径穿越。
+func parseLocalFileID(fileID string) (int64, error) {
+	id, err := strconv.ParseInt(fileID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrNotFound
+	}
+	return id, nil
+}
+
+// decodeBase64Content 解码 Base64 内容，兼容 data URI 前缀。
+func decodeBase64Content(content string) ([]byte, error) {
+	if strings.HasPrefix(content, "data:") {
+		idx := strings.Index(content, "base64,")
+		if idx == -1 {
+			return nil, ErrInvalidContent
+		}
+		content = content[idx+len("base64,"):]
+	}
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil || len(data) == 0 {
+		return nil, ErrInvalidContent
+	}
+	return data, nil
+}
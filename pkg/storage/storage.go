@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"errors"
+)
+
+// Backend 存储后端类型，通过环境变量 STORAGE_BACKEND 选择。
+type Backend string
+
+const (
+	BackendBucket Backend = "bucket" // beacon-bucket 对象存储服务（默认）
+	BackendLocal  Backend = "local"  // 本地文件系统，由服务自身提供下载
+)
+
+// Category 文件业务分类，决定文件写入的存储桶（或本地子目录）。
+type Category string
+
+const (
+	CategorySkin  Category = "skin"  // 皮肤纹理
+	CategoryCape  Category = "cape"  // 披风纹理
+	CategoryIssue Category = "issue" // 问题反馈附件
+)
+
+var (
+	// ErrNotFound 文件不存在。
+	ErrNotFound = errors.New("storage: file not found")
+	// ErrInvalidContent 上传内容不是合法的 Base64 数据。
+	ErrInvalidContent = errors.New("storage: invalid base64 content")
+	// ErrTooLarge 文件内容超过读取上限。
+	ErrTooLarge = errors.New("storage: file too large")
+)
+
+// FileInfo 存储文件元数据。
+type FileInfo struct {
+	FileID  string // 文件 ID（十进制 int64 字符串，与数据库中的 Texture / FileID 对应）
+	Size    int64  // 文件大小（字节）
+	Sha256  string // 文件内容 SHA256（后端未提供时为空）
+	IsCache bool   // 是否仍为缓存态（未确认的上传）
+	Link    string // 下载链接
+}
+
+// Storage 纹理与附件文件存储接口。
+//
+// 业务层只通过该接口读写文件，不直接依赖具体的存储服务 SDK。
+// 上传后的文件处于缓存态，业务数据落库成功后需调用 CacheVerify 确认；
+// 各实现返回的错误可通过 errors.Is 判断 ErrNotFound / ErrInvalidContent / ErrTooLarge，
+// bucket 实现另会透传 beacon-bucket SDK 的 connect 错误。
+type Storage interface {
+	// Upload 上传 Base64 编码的文件内容（可带 data URI 前缀），返回新文件的元数据与下载链接。
+	Upload(ctx context.Context, category Category, contentBase64 string) (*FileInfo, error)
+	// Get 获取单个文件的元数据与下载链接。
+	Get(ctx context.Context, fileID string) (*FileInfo, error)
+	// GetByList 按请求顺序批量获取文件元数据，不存在的文件不会出现在结果中。
+	GetByList(ctx context.Context, fileIDs []string) ([]*FileInfo, error)
+	// Read 读取文件内容，内容超过 maxSize 字节时返回 ErrTooLarge。
+	Read(ctx context.Context, fileID string, maxSize int64) ([]byte, error)
+	// CacheVerify 将缓存态文件确认为永久态。
+	CacheVerify(ctx context.Context, fileID string) error
+	// Delete 删除文件。
+	Delete(ctx context.Context, fileID string) error
+}